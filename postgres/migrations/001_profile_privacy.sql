@@ -0,0 +1,14 @@
+--
+-- Per-user privacy settings, enforced by user service
+--
+
+ALTER TABLE public.users
+    ADD COLUMN IF NOT EXISTS private_profile boolean DEFAULT false NOT NULL,
+    ADD COLUMN IF NOT EXISTS hide_real_name boolean DEFAULT false NOT NULL,
+    ADD COLUMN IF NOT EXISTS hide_followers boolean DEFAULT false NOT NULL,
+    ADD COLUMN IF NOT EXISTS searchable boolean DEFAULT true NOT NULL;
+
+COMMENT ON COLUMN public.users.private_profile IS 'Only followers can see profile details';
+COMMENT ON COLUMN public.users.hide_real_name IS 'First and last name are shown only to user themselves';
+COMMENT ON COLUMN public.users.hide_followers IS 'Followers and followed lists are shown only to user themselves';
+COMMENT ON COLUMN public.users.searchable IS 'User can be found through profile search';
@@ -0,0 +1,22 @@
+--
+-- Personal data export jobs, processed by user service
+--
+
+CREATE TABLE IF NOT EXISTS public.data_exports (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    status character varying(20) DEFAULT 'pending' NOT NULL,
+    archive_path text DEFAULT '' NOT NULL,
+    download_token character varying(40) DEFAULT '' NOT NULL,
+    error_message text DEFAULT '' NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    finished_at timestamp with time zone,
+    expires_at timestamp with time zone
+);
+
+COMMENT ON TABLE public.data_exports IS 'Archives with all data tied to user, requested by users themselves';
+
+CREATE INDEX IF NOT EXISTS data_exports_user_id_idx ON public.data_exports USING btree (user_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS data_exports_download_token_idx ON public.data_exports USING btree (download_token)
+WHERE NOT download_token = '';
@@ -0,0 +1,23 @@
+--
+-- Blocked and muted users, managed by user service
+--
+
+CREATE TABLE IF NOT EXISTS public.user_blocks (
+    blocker_id bigint NOT NULL,
+    blocked_id bigint NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT user_blocks_pk PRIMARY KEY (blocker_id, blocked_id)
+);
+
+COMMENT ON TABLE public.user_blocks IS 'Blocked users can not see blocker and can not interact with them';
+
+CREATE INDEX IF NOT EXISTS user_blocks_blocked_id_idx ON public.user_blocks USING btree (blocked_id);
+
+CREATE TABLE IF NOT EXISTS public.user_mutes (
+    muter_id bigint NOT NULL,
+    muted_id bigint NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT user_mutes_pk PRIMARY KEY (muter_id, muted_id)
+);
+
+COMMENT ON TABLE public.user_mutes IS 'Muted users content is hidden from muter, muted users are not notified';
@@ -0,0 +1,44 @@
+--
+-- Shops and their products, managed by shopProduct service
+--
+
+CREATE TABLE IF NOT EXISTS public.shops (
+    id bigserial PRIMARY KEY,
+    title character varying(100) NOT NULL,
+    description text DEFAULT '' NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE public.shops IS 'Shops that sell products';
+
+CREATE TABLE IF NOT EXISTS public.shop_managers (
+    shop_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    CONSTRAINT shop_managers_pk PRIMARY KEY (shop_id, user_id),
+    CONSTRAINT shop_managers_shop_fk FOREIGN KEY (shop_id) REFERENCES public.shops(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.shop_managers IS 'Users who can manage shop and its products';
+
+CREATE INDEX IF NOT EXISTS shop_managers_user_id_idx ON public.shop_managers USING btree (user_id);
+
+CREATE TABLE IF NOT EXISTS public.products (
+    id bigserial PRIMARY KEY,
+    shop_id bigint NOT NULL,
+    title character varying(100) NOT NULL,
+    description text DEFAULT '' NOT NULL,
+    price bigint DEFAULT 0 NOT NULL,
+    availability boolean DEFAULT false NOT NULL,
+    assembly_time bigint DEFAULT 0 NOT NULL,
+    parts_amount bigint DEFAULT 0 NOT NULL,
+    rating real DEFAULT 0 NOT NULL,
+    size character varying(50) DEFAULT '' NOT NULL,
+    category character varying(100) DEFAULT '' NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT products_shop_fk FOREIGN KEY (shop_id) REFERENCES public.shops(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.products IS 'Products that shops sell';
+COMMENT ON COLUMN public.products.assembly_time IS 'Measured in minutes';
+
+CREATE INDEX IF NOT EXISTS products_shop_id_idx ON public.products USING btree (shop_id);
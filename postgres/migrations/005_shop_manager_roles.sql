@@ -0,0 +1,39 @@
+--
+-- Shop managers' roles and invitations to become one
+--
+
+-- Managers of existing shops were added by their creators, so all of them are treated as owners.
+-- This is done only when role is added, so that rerunning migration does not promote managers
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = 'public' AND table_name = 'shop_managers'
+                                                                 AND column_name = 'role') THEN
+        ALTER TABLE public.shop_managers
+            ADD COLUMN role character varying(20) DEFAULT 'manager' NOT NULL;
+
+        UPDATE public.shop_managers SET role = 'owner';
+    END IF;
+END
+$$;
+
+ALTER TABLE public.shop_managers DROP CONSTRAINT IF EXISTS shop_managers_role_check;
+ALTER TABLE public.shop_managers
+    ADD CONSTRAINT shop_managers_role_check CHECK (role IN ('owner', 'manager'));
+
+COMMENT ON COLUMN public.shop_managers.role IS 'Owners can also add and remove managers';
+
+CREATE TABLE IF NOT EXISTS public.shop_invitations (
+    id bigserial PRIMARY KEY,
+    shop_id bigint NOT NULL,
+    invited_user_id bigint NOT NULL,
+    inviter_id bigint NOT NULL,
+    role character varying(20) DEFAULT 'manager' NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT shop_invitations_unique UNIQUE (shop_id, invited_user_id),
+    CONSTRAINT shop_invitations_role_check CHECK (role IN ('owner', 'manager')),
+    CONSTRAINT shop_invitations_shop_fk FOREIGN KEY (shop_id) REFERENCES public.shops(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.shop_invitations IS 'Pending invitations to become shop manager';
+
+CREATE INDEX IF NOT EXISTS shop_invitations_invited_user_id_idx ON public.shop_invitations USING btree (invited_user_id);
@@ -0,0 +1,25 @@
+--
+-- Product images and their renditions, files are stored in shopProduct service's media directory
+--
+
+CREATE TABLE IF NOT EXISTS public.product_images (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL,
+    "position" integer DEFAULT 0 NOT NULL,
+    is_primary boolean DEFAULT false NOT NULL,
+    thumbnail_link character varying(100) NOT NULL,
+    medium_link character varying(100) NOT NULL,
+    large_link character varying(100) NOT NULL,
+    imageheight integer DEFAULT 0 NOT NULL,
+    imagewidth integer DEFAULT 0 NOT NULL,
+    imageavgcolor character(6) DEFAULT 'FFFFFF'::bpchar NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT product_images_product_fk FOREIGN KEY (product_id) REFERENCES public.products(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.product_images IS 'Product gallery, primary image is shown first';
+COMMENT ON COLUMN public.product_images.imageheight IS 'Height of uploaded image, renditions are scaled down proportionally';
+COMMENT ON COLUMN public.product_images.imagewidth IS 'Width of uploaded image, renditions are scaled down proportionally';
+
+CREATE INDEX IF NOT EXISTS product_images_product_id_idx ON public.product_images USING btree (product_id, "position");
+CREATE UNIQUE INDEX IF NOT EXISTS product_images_primary_idx ON public.product_images USING btree (product_id) WHERE is_primary;
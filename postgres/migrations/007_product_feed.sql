@@ -0,0 +1,46 @@
+--
+-- Signals for personalised product feed and feed snapshots used for its pagination
+--
+
+CREATE TABLE IF NOT EXISTS public.shop_followers (
+    shop_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT shop_followers_pk PRIMARY KEY (shop_id, user_id),
+    CONSTRAINT shop_followers_shop_fk FOREIGN KEY (shop_id) REFERENCES public.shops(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.shop_followers IS 'Users who follow shops, their products are preferred in feed';
+
+CREATE INDEX IF NOT EXISTS shop_followers_user_id_idx ON public.shop_followers USING btree (user_id);
+
+CREATE TABLE IF NOT EXISTS public.product_views (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL,
+    user_id bigint,
+    viewed_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT product_views_product_fk FOREIGN KEY (product_id) REFERENCES public.products(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.product_views IS 'Product page views, user_id is NULL for anonymous views';
+
+CREATE INDEX IF NOT EXISTS product_views_product_id_idx ON public.product_views USING btree (product_id, viewed_at);
+CREATE INDEX IF NOT EXISTS product_views_user_id_idx ON public.product_views USING btree (user_id, viewed_at);
+
+CREATE TABLE IF NOT EXISTS public.product_feeds (
+    id bigserial PRIMARY KEY,
+    user_id bigint,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE public.product_feeds IS 'Feed snapshots, so that feed order does not change while user scrolls it';
+
+CREATE INDEX IF NOT EXISTS product_feeds_created_at_idx ON public.product_feeds USING btree (created_at);
+
+CREATE TABLE IF NOT EXISTS public.product_feed_items (
+    feed_id bigint NOT NULL,
+    "position" integer NOT NULL,
+    product_id bigint NOT NULL,
+    CONSTRAINT product_feed_items_pk PRIMARY KEY (feed_id, "position"),
+    CONSTRAINT product_feed_items_feed_fk FOREIGN KEY (feed_id) REFERENCES public.product_feeds(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
@@ -0,0 +1,32 @@
+--
+-- Product reviews. Products keep aggregated ratings, which are updated together with reviews
+--
+
+CREATE TABLE IF NOT EXISTS public.product_reviews (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    title character varying(100) NOT NULL,
+    text text DEFAULT '' NOT NULL,
+    rating smallint NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT product_reviews_rating_check CHECK (rating BETWEEN 1 AND 5),
+    CONSTRAINT product_reviews_product_user_key UNIQUE (product_id, user_id),
+    CONSTRAINT product_reviews_product_fk FOREIGN KEY (product_id) REFERENCES public.products(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.product_reviews IS 'Product reviews, every user can review product only once';
+
+CREATE INDEX IF NOT EXISTS product_reviews_rating_idx ON public.product_reviews USING btree (product_id, rating, id);
+CREATE INDEX IF NOT EXISTS product_reviews_user_id_idx ON public.product_reviews USING btree (user_id);
+
+ALTER TABLE public.products ADD COLUMN IF NOT EXISTS reviews_count bigint DEFAULT 0 NOT NULL;
+ALTER TABLE public.products ADD COLUMN IF NOT EXISTS rating_sum bigint DEFAULT 0 NOT NULL;
+ALTER TABLE public.products ADD COLUMN IF NOT EXISTS rating_histogram bigint[] DEFAULT '{0,0,0,0,0}'::bigint[] NOT NULL;
+
+COMMENT ON COLUMN public.products.rating IS 'Average rating of reviews, equals rating_sum / reviews_count';
+COMMENT ON COLUMN public.products.rating_histogram IS 'Amounts of reviews with ratings from 1 to 5';
+
+-- Ratings used to be set by shop managers, they are not backed by any reviews
+UPDATE public.products SET rating = 0 WHERE reviews_count = 0;
@@ -0,0 +1,12 @@
+--
+-- Full-text search over products. Russian configuration stems Russian words with Russian stemmer
+-- and words in Latin script with English stemmer, so one vector serves both languages
+--
+
+ALTER TABLE public.products ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (setweight(to_tsvector('russian', title), 'A') ||
+                         setweight(to_tsvector('russian', description), 'B')) STORED;
+
+CREATE INDEX IF NOT EXISTS products_search_vector_idx ON public.products USING gin (search_vector);
+CREATE INDEX IF NOT EXISTS products_category_idx ON public.products USING btree (category);
+CREATE INDEX IF NOT EXISTS products_price_idx ON public.products USING btree (price);
@@ -0,0 +1,74 @@
+--
+-- Category tree which replaces free-text product categories, and administrators who can edit it
+--
+
+CREATE TABLE IF NOT EXISTS public.admins (
+    user_id bigint PRIMARY KEY,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+COMMENT ON TABLE public.admins IS 'Users who can edit catalog-wide data, such as category tree. Are added manually';
+
+CREATE TABLE IF NOT EXISTS public.categories (
+    id bigserial PRIMARY KEY,
+    parent_id bigint,
+    slug character varying(100) NOT NULL,
+    "position" integer DEFAULT 0 NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT categories_slug_key UNIQUE (slug),
+    CONSTRAINT categories_parent_fk FOREIGN KEY (parent_id) REFERENCES public.categories(id) ON UPDATE CASCADE
+);
+
+COMMENT ON TABLE public.categories IS 'Product categories tree, root categories have no parent';
+
+CREATE INDEX IF NOT EXISTS categories_parent_id_idx ON public.categories USING btree (parent_id);
+
+CREATE TABLE IF NOT EXISTS public.category_names (
+    category_id bigint NOT NULL,
+    locale character varying(10) NOT NULL,
+    name character varying(100) NOT NULL,
+    CONSTRAINT category_names_pk PRIMARY KEY (category_id, locale),
+    CONSTRAINT category_names_category_fk FOREIGN KEY (category_id) REFERENCES public.categories(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.category_names IS 'Localized category names';
+
+ALTER TABLE public.products ADD COLUMN IF NOT EXISTS category_id bigint;
+ALTER TABLE public.products DROP CONSTRAINT IF EXISTS products_category_fk;
+ALTER TABLE public.products ADD CONSTRAINT products_category_fk FOREIGN KEY (category_id) REFERENCES public.categories(id) ON UPDATE CASCADE;
+
+CREATE INDEX IF NOT EXISTS products_category_id_idx ON public.products USING btree (category_id);
+
+-- Free-text categories which differ only in case, spaces and punctuation become one root category,
+-- named after the spelling of its oldest product. Other duplicates, such as "Robots" and "Robotics",
+-- should be merged by administrators by deleting one category and moving its products to another one
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = 'public' AND table_name = 'products'
+                                                                 AND column_name = 'category') THEN
+        CREATE TEMPORARY TABLE category_slugs ON COMMIT DROP AS
+        SELECT id AS product_id, trim(category) AS name,
+               trim(BOTH '-' FROM regexp_replace(lower(trim(category)), '[^[:alnum:]]+', '-', 'g')) AS slug
+        FROM public.products;
+
+        INSERT INTO public.categories (slug)
+        SELECT DISTINCT slug FROM category_slugs WHERE slug <> ''
+        ON CONFLICT (slug) DO NOTHING;
+
+        INSERT INTO public.category_names (category_id, locale, name)
+        SELECT DISTINCT ON (categories.id) categories.id, 'ru', category_slugs.name
+        FROM category_slugs
+        INNER JOIN public.categories ON categories.slug = category_slugs.slug
+        ORDER BY categories.id, category_slugs.product_id
+        ON CONFLICT DO NOTHING;
+
+        UPDATE public.products
+        SET category_id = categories.id
+        FROM category_slugs
+        INNER JOIN public.categories ON categories.slug = category_slugs.slug
+        WHERE products.id = category_slugs.product_id;
+
+        ALTER TABLE public.products DROP COLUMN category;
+    END IF;
+END
+$$;
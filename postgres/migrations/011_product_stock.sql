@@ -0,0 +1,73 @@
+--
+-- Stock quantities with reservations, availability becomes derived from stock
+--
+
+ALTER TABLE public.products ADD COLUMN IF NOT EXISTS stock bigint DEFAULT 0 NOT NULL;
+ALTER TABLE public.products ADD COLUMN IF NOT EXISTS reserved bigint DEFAULT 0 NOT NULL;
+ALTER TABLE public.products DROP CONSTRAINT IF EXISTS products_stock_check;
+ALTER TABLE public.products ADD CONSTRAINT products_stock_check CHECK (reserved >= 0 AND reserved <= stock);
+
+COMMENT ON COLUMN public.products.stock IS 'Units on hand, including reserved ones';
+COMMENT ON COLUMN public.products.reserved IS 'Units held by active reservations';
+
+CREATE TABLE IF NOT EXISTS public.stock_reservations (
+    id bigserial PRIMARY KEY,
+    status character varying(16) DEFAULT 'active' NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT stock_reservations_status_check CHECK (status IN ('active', 'committed', 'released', 'expired'))
+);
+
+COMMENT ON TABLE public.stock_reservations IS 'Stock held for checkout, active reservations are released when they expire';
+
+CREATE INDEX IF NOT EXISTS stock_reservations_active_expires_at_idx ON public.stock_reservations USING btree (expires_at)
+    WHERE status = 'active';
+
+CREATE TABLE IF NOT EXISTS public.reservation_items (
+    reservation_id bigint NOT NULL,
+    product_id bigint NOT NULL,
+    quantity bigint NOT NULL,
+    CONSTRAINT reservation_items_pk PRIMARY KEY (reservation_id, product_id),
+    CONSTRAINT reservation_items_quantity_check CHECK (quantity > 0),
+    CONSTRAINT reservation_items_reservation_fk FOREIGN KEY (reservation_id) REFERENCES public.stock_reservations(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT reservation_items_product_fk FOREIGN KEY (product_id) REFERENCES public.products(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS public.inventory_movements (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL,
+    kind character varying(16) NOT NULL,
+    stock_delta bigint NOT NULL,
+    reserved_delta bigint NOT NULL,
+    stock_after bigint NOT NULL,
+    reserved_after bigint NOT NULL,
+    reservation_id bigint,
+    user_id bigint,
+    comment text DEFAULT '' NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT inventory_movements_product_fk FOREIGN KEY (product_id) REFERENCES public.products(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT inventory_movements_reservation_fk FOREIGN KEY (reservation_id) REFERENCES public.stock_reservations(id) ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+COMMENT ON TABLE public.inventory_movements IS 'Ledger of every change of products stock and reserved quantities';
+COMMENT ON COLUMN public.inventory_movements.user_id IS 'Manager who adjusted stock, NULL for reservation movements';
+
+CREATE INDEX IF NOT EXISTS inventory_movements_product_id_idx ON public.inventory_movements USING btree (product_id, id);
+
+-- Products which were marked available get one unit, so that they stay available until managers set real stock
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_schema = 'public' AND table_name = 'products'
+                                                                 AND column_name = 'availability' AND is_generated = 'NEVER') THEN
+        UPDATE public.products SET stock = 1 WHERE availability;
+
+        INSERT INTO public.inventory_movements (product_id, kind, stock_delta, reserved_delta, stock_after, reserved_after, comment)
+        SELECT id, 'adjustment', 1, 0, 1, 0, 'Migrated from availability flag'
+        FROM public.products
+        WHERE stock > 0;
+
+        ALTER TABLE public.products DROP COLUMN availability;
+        ALTER TABLE public.products ADD COLUMN availability boolean GENERATED ALWAYS AS (stock > reserved) STORED;
+    END IF;
+END
+$$;
@@ -0,0 +1,32 @@
+--
+-- Shopping carts of users and anonymous visitors
+--
+
+CREATE TABLE IF NOT EXISTS public.carts (
+    id bigserial PRIMARY KEY,
+    user_id bigint,
+    token character varying(64),
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT carts_user_id_key UNIQUE (user_id),
+    CONSTRAINT carts_token_key UNIQUE (token),
+    CONSTRAINT carts_owner_check CHECK (user_id IS NOT NULL OR token IS NOT NULL)
+);
+
+COMMENT ON TABLE public.carts IS 'Every user has at most one cart, anonymous carts are identified by token and merged into user''s cart on login';
+
+CREATE INDEX IF NOT EXISTS carts_anonymous_updated_at_idx ON public.carts USING btree (updated_at) WHERE user_id IS NULL;
+
+CREATE TABLE IF NOT EXISTS public.cart_items (
+    cart_id bigint NOT NULL,
+    product_id bigint NOT NULL,
+    quantity bigint NOT NULL,
+    added_price bigint NOT NULL,
+    added_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT cart_items_pk PRIMARY KEY (cart_id, product_id),
+    CONSTRAINT cart_items_quantity_check CHECK (quantity > 0),
+    CONSTRAINT cart_items_cart_fk FOREIGN KEY (cart_id) REFERENCES public.carts(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT cart_items_product_fk FOREIGN KEY (product_id) REFERENCES public.products(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN public.cart_items.added_price IS 'Product price when it was added, so that price changes can be shown';
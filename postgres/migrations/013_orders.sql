@@ -0,0 +1,64 @@
+--
+-- Orders placed at checkout, one order per shop, with history of status changes
+--
+
+CREATE TABLE IF NOT EXISTS public.orders (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    shop_id bigint NOT NULL,
+    shop_title text NOT NULL,
+    status character varying(20) DEFAULT 'pending_payment' NOT NULL,
+    total bigint NOT NULL,
+    assembly_time bigint NOT NULL,
+    reservation_id bigint NOT NULL,
+    payment_deadline timestamp with time zone NOT NULL,
+    estimated_ready_at timestamp with time zone NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT orders_status_check CHECK (status IN ('pending_payment', 'paid', 'assembling', 'shipped', 'delivered', 'cancelled', 'refunded')),
+    CONSTRAINT orders_shop_fk FOREIGN KEY (shop_id) REFERENCES public.shops(id) ON UPDATE CASCADE ON DELETE RESTRICT
+);
+
+COMMENT ON COLUMN public.orders.shop_title IS 'Shop title at checkout';
+COMMENT ON COLUMN public.orders.assembly_time IS 'Total assembly time of all items, measured in minutes';
+COMMENT ON COLUMN public.orders.reservation_id IS 'Stock reservation, which is committed when order is paid and released when it is cancelled';
+
+CREATE INDEX IF NOT EXISTS orders_user_id_idx ON public.orders USING btree (user_id, id);
+CREATE INDEX IF NOT EXISTS orders_shop_id_idx ON public.orders USING btree (shop_id, id);
+CREATE INDEX IF NOT EXISTS orders_pending_payment_deadline_idx ON public.orders USING btree (payment_deadline)
+    WHERE status = 'pending_payment';
+
+CREATE TABLE IF NOT EXISTS public.order_items (
+    id bigserial PRIMARY KEY,
+    order_id bigint NOT NULL,
+    product_id bigint,
+    title text NOT NULL,
+    price bigint NOT NULL,
+    quantity bigint NOT NULL,
+    assembly_time bigint NOT NULL,
+    CONSTRAINT order_items_quantity_check CHECK (quantity > 0),
+    CONSTRAINT order_items_order_fk FOREIGN KEY (order_id) REFERENCES public.orders(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT order_items_product_fk FOREIGN KEY (product_id) REFERENCES public.products(id) ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+COMMENT ON TABLE public.order_items IS 'Title, price and assembly time are taken at checkout, so that orders do not change with products';
+
+CREATE INDEX IF NOT EXISTS order_items_order_id_idx ON public.order_items USING btree (order_id);
+
+CREATE TABLE IF NOT EXISTS public.order_status_changes (
+    id bigserial PRIMARY KEY,
+    order_id bigint NOT NULL,
+    from_status character varying(20),
+    to_status character varying(20) NOT NULL,
+    actor_id bigint,
+    actor_role character varying(10) NOT NULL,
+    comment text DEFAULT '' NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT order_status_changes_role_check CHECK (actor_role IN ('buyer', 'manager', 'system')),
+    CONSTRAINT order_status_changes_order_fk FOREIGN KEY (order_id) REFERENCES public.orders(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.order_status_changes IS 'Every transition of order''s state machine, first row is checkout';
+COMMENT ON COLUMN public.order_status_changes.actor_id IS 'User who changed status, NULL for system transitions';
+
+CREATE INDEX IF NOT EXISTS order_status_changes_order_id_idx ON public.order_status_changes USING btree (order_id, id);
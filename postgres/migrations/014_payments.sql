@@ -0,0 +1,37 @@
+--
+-- Payments of orders and webhooks of payment providers
+--
+
+CREATE TABLE IF NOT EXISTS public.payments (
+    id bigserial PRIMARY KEY,
+    order_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    provider character varying(32) NOT NULL,
+    provider_payment_id character varying(128) NOT NULL,
+    status character varying(16) DEFAULT 'pending' NOT NULL,
+    amount bigint NOT NULL,
+    confirmation_url text NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT payments_provider_payment_key UNIQUE (provider, provider_payment_id),
+    CONSTRAINT payments_status_check CHECK (status IN ('pending', 'succeeded', 'failed', 'refunded')),
+    CONSTRAINT payments_order_fk FOREIGN KEY (order_id) REFERENCES public.orders(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN public.payments.confirmation_url IS 'Provider page where buyer pays';
+
+CREATE UNIQUE INDEX IF NOT EXISTS payments_pending_order_id_idx ON public.payments USING btree (order_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS payments_order_id_idx ON public.payments USING btree (order_id, id);
+CREATE INDEX IF NOT EXISTS payments_pending_created_at_idx ON public.payments USING btree (created_at) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS public.payment_events (
+    provider character varying(32) NOT NULL,
+    event_id character varying(128) NOT NULL,
+    payment_id bigint NOT NULL,
+    status character varying(16) NOT NULL,
+    received_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT payment_events_pk PRIMARY KEY (provider, event_id),
+    CONSTRAINT payment_events_payment_fk FOREIGN KEY (payment_id) REFERENCES public.payments(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.payment_events IS 'Processed webhooks, so that redelivered ones are skipped';
@@ -0,0 +1,38 @@
+--
+-- Wishlists of saved products
+--
+
+CREATE TABLE IF NOT EXISTS public.wishlists (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    title character varying(100) NOT NULL,
+    is_default boolean DEFAULT false NOT NULL,
+    is_public boolean DEFAULT false NOT NULL,
+    share_token character varying(64) NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT wishlists_share_token_key UNIQUE (share_token)
+);
+
+COMMENT ON TABLE public.wishlists IS 'Every user has one default wishlist, which is created when first product is saved';
+COMMENT ON COLUMN public.wishlists.share_token IS 'Public wishlists can be opened by link with this token';
+
+CREATE UNIQUE INDEX IF NOT EXISTS wishlists_default_user_id_idx ON public.wishlists USING btree (user_id) WHERE is_default;
+CREATE INDEX IF NOT EXISTS wishlists_user_id_idx ON public.wishlists USING btree (user_id, id);
+
+CREATE TABLE IF NOT EXISTS public.wishlist_items (
+    wishlist_id bigint NOT NULL,
+    product_id bigint NOT NULL,
+    added_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT wishlist_items_pk PRIMARY KEY (wishlist_id, product_id),
+    CONSTRAINT wishlist_items_wishlist_fk FOREIGN KEY (wishlist_id) REFERENCES public.wishlists(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT wishlist_items_product_fk FOREIGN KEY (product_id) REFERENCES public.products(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS wishlist_items_product_id_idx ON public.wishlist_items USING btree (product_id);
+
+ALTER TABLE public.products ADD COLUMN IF NOT EXISTS saves_count bigint DEFAULT 0 NOT NULL;
+
+COMMENT ON COLUMN public.products.saves_count IS 'Amount of users who saved product to any of their wishlists';
+
+CREATE INDEX IF NOT EXISTS products_saves_count_idx ON public.products USING btree (saves_count DESC, id DESC);
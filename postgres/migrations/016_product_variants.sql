@@ -0,0 +1,77 @@
+--
+-- Product options and variants with their own SKU, price, stock and images
+--
+
+CREATE TABLE IF NOT EXISTS public.product_options (
+    product_id bigint NOT NULL,
+    "position" integer NOT NULL,
+    name character varying(50) NOT NULL,
+    option_values character varying(50)[] NOT NULL,
+    CONSTRAINT product_options_pk PRIMARY KEY (product_id, "position"),
+    CONSTRAINT product_options_name_key UNIQUE (product_id, name),
+    CONSTRAINT product_options_product_fk FOREIGN KEY (product_id) REFERENCES public.products(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.product_options IS 'Option types, such as size or colour, with values which product''s variants can have';
+
+CREATE TABLE IF NOT EXISTS public.product_variants (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL,
+    sku character varying(64) NOT NULL,
+    options jsonb NOT NULL,
+    price bigint,
+    stock bigint DEFAULT 0 NOT NULL,
+    reserved bigint DEFAULT 0 NOT NULL,
+    availability boolean GENERATED ALWAYS AS (stock > reserved) STORED,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT product_variants_sku_key UNIQUE (product_id, sku),
+    CONSTRAINT product_variants_options_key UNIQUE (product_id, options),
+    CONSTRAINT product_variants_stock_check CHECK (reserved >= 0 AND reserved <= stock),
+    CONSTRAINT product_variants_product_fk FOREIGN KEY (product_id) REFERENCES public.products(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.product_variants IS 'Stock of products with variants is sum of their variants'' stock';
+COMMENT ON COLUMN public.product_variants.options IS 'Object which maps every option name of product to value of variant';
+COMMENT ON COLUMN public.product_variants.price IS 'Price override, NULL if variant costs the same as product';
+
+CREATE TABLE IF NOT EXISTS public.product_variant_images (
+    variant_id bigint NOT NULL,
+    image_id bigint NOT NULL,
+    CONSTRAINT product_variant_images_pk PRIMARY KEY (variant_id, image_id),
+    CONSTRAINT product_variant_images_variant_fk FOREIGN KEY (variant_id) REFERENCES public.product_variants(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT product_variant_images_image_fk FOREIGN KEY (image_id) REFERENCES public.product_images(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.product_variant_images IS 'Subset of product''s gallery shown for variant, variant without images shows whole gallery';
+
+CREATE INDEX IF NOT EXISTS product_variant_images_image_id_idx ON public.product_variant_images USING btree (image_id);
+
+ALTER TABLE public.cart_items ADD COLUMN IF NOT EXISTS variant_id bigint;
+ALTER TABLE public.cart_items DROP CONSTRAINT IF EXISTS cart_items_pk;
+ALTER TABLE public.cart_items DROP CONSTRAINT IF EXISTS cart_items_variant_fk;
+ALTER TABLE public.cart_items ADD CONSTRAINT cart_items_variant_fk FOREIGN KEY (variant_id) REFERENCES public.product_variants(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS cart_items_variant_idx ON public.cart_items USING btree (cart_id, product_id, COALESCE(variant_id, 0));
+
+ALTER TABLE public.reservation_items ADD COLUMN IF NOT EXISTS variant_id bigint;
+ALTER TABLE public.reservation_items DROP CONSTRAINT IF EXISTS reservation_items_pk;
+ALTER TABLE public.reservation_items DROP CONSTRAINT IF EXISTS reservation_items_variant_fk;
+ALTER TABLE public.reservation_items ADD CONSTRAINT reservation_items_variant_fk FOREIGN KEY (variant_id) REFERENCES public.product_variants(id) ON UPDATE CASCADE ON DELETE CASCADE;
+
+COMMENT ON COLUMN public.reservation_items.variant_id IS 'Variants with reserved stock can not be deleted, so only items of finished reservations are cascaded';
+
+CREATE UNIQUE INDEX IF NOT EXISTS reservation_items_variant_idx ON public.reservation_items USING btree (reservation_id, product_id, COALESCE(variant_id, 0));
+
+ALTER TABLE public.inventory_movements ADD COLUMN IF NOT EXISTS variant_id bigint;
+ALTER TABLE public.inventory_movements DROP CONSTRAINT IF EXISTS inventory_movements_variant_fk;
+ALTER TABLE public.inventory_movements ADD CONSTRAINT inventory_movements_variant_fk FOREIGN KEY (variant_id) REFERENCES public.product_variants(id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+COMMENT ON COLUMN public.inventory_movements.variant_id IS 'Variant whose quantities were changed, NULL for products without variants';
+
+ALTER TABLE public.order_items ADD COLUMN IF NOT EXISTS variant_id bigint;
+ALTER TABLE public.order_items ADD COLUMN IF NOT EXISTS sku character varying(64) DEFAULT '' NOT NULL;
+ALTER TABLE public.order_items ADD COLUMN IF NOT EXISTS variant_title text DEFAULT '' NOT NULL;
+ALTER TABLE public.order_items DROP CONSTRAINT IF EXISTS order_items_variant_fk;
+ALTER TABLE public.order_items ADD CONSTRAINT order_items_variant_fk FOREIGN KEY (variant_id) REFERENCES public.product_variants(id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+COMMENT ON COLUMN public.order_items.variant_title IS 'Option values of variant at checkout, such as "L / Red"';
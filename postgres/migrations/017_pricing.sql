@@ -0,0 +1,124 @@
+--
+-- Scheduled sales, shop discounts, promo codes and history of price changes
+--
+
+CREATE TABLE IF NOT EXISTS public.product_sales (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL,
+    variant_id bigint,
+    sale_price bigint NOT NULL,
+    starts_at timestamp with time zone NOT NULL,
+    ends_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT product_sales_price_check CHECK (sale_price > 0),
+    CONSTRAINT product_sales_period_check CHECK (ends_at IS NULL OR ends_at > starts_at),
+    CONSTRAINT product_sales_product_fk FOREIGN KEY (product_id) REFERENCES public.products(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT product_sales_variant_fk FOREIGN KEY (variant_id) REFERENCES public.product_variants(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN public.product_sales.variant_id IS 'NULL if sale applies to product and its variants without price override';
+COMMENT ON COLUMN public.product_sales.ends_at IS 'NULL for sales without end';
+
+CREATE INDEX IF NOT EXISTS product_sales_product_id_idx ON public.product_sales USING btree (product_id, starts_at);
+
+CREATE TABLE IF NOT EXISTS public.shop_discounts (
+    id bigserial PRIMARY KEY,
+    shop_id bigint NOT NULL,
+    percent integer NOT NULL,
+    starts_at timestamp with time zone NOT NULL,
+    ends_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT shop_discounts_percent_check CHECK (percent BETWEEN 1 AND 90),
+    CONSTRAINT shop_discounts_period_check CHECK (ends_at IS NULL OR ends_at > starts_at),
+    CONSTRAINT shop_discounts_shop_fk FOREIGN KEY (shop_id) REFERENCES public.shops(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.shop_discounts IS 'Percentage discounts of all shop''s products';
+
+CREATE INDEX IF NOT EXISTS shop_discounts_shop_id_idx ON public.shop_discounts USING btree (shop_id, starts_at);
+
+CREATE TABLE IF NOT EXISTS public.promo_codes (
+    id bigserial PRIMARY KEY,
+    shop_id bigint NOT NULL,
+    code character varying(32) NOT NULL,
+    kind character varying(16) NOT NULL,
+    value bigint NOT NULL,
+    min_order bigint DEFAULT 0 NOT NULL,
+    max_uses bigint DEFAULT 0 NOT NULL,
+    max_uses_per_user bigint DEFAULT 0 NOT NULL,
+    used_count bigint DEFAULT 0 NOT NULL,
+    expires_at timestamp with time zone,
+    is_active boolean DEFAULT true NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT promo_codes_kind_check CHECK (kind IN ('fixed', 'percent')),
+    CONSTRAINT promo_codes_value_check CHECK (value > 0 AND (kind = 'fixed' OR value <= 100)),
+    CONSTRAINT promo_codes_uses_check CHECK (used_count >= 0 AND (max_uses = 0 OR used_count <= max_uses)),
+    CONSTRAINT promo_codes_shop_fk FOREIGN KEY (shop_id) REFERENCES public.shops(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN public.promo_codes.code IS 'Upper case, codes are matched case-insensitively';
+COMMENT ON COLUMN public.promo_codes.max_uses IS '0 means no limit, same as for max_uses_per_user';
+COMMENT ON COLUMN public.promo_codes.is_active IS 'Deleted codes are deactivated, so that redemptions of existing orders are kept';
+
+CREATE UNIQUE INDEX IF NOT EXISTS promo_codes_active_code_idx ON public.promo_codes USING btree (shop_id, code) WHERE is_active;
+
+CREATE TABLE IF NOT EXISTS public.promo_redemptions (
+    id bigserial PRIMARY KEY,
+    promo_code_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    discount bigint NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    released_at timestamp with time zone,
+    CONSTRAINT promo_redemptions_promo_code_fk FOREIGN KEY (promo_code_id) REFERENCES public.promo_codes(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN public.promo_redemptions.released_at IS 'Set when order is cancelled before payment, released redemptions do not count towards limits';
+
+CREATE INDEX IF NOT EXISTS promo_redemptions_user_id_idx ON public.promo_redemptions USING btree (promo_code_id, user_id) WHERE released_at IS NULL;
+
+CREATE TABLE IF NOT EXISTS public.price_history (
+    id bigserial PRIMARY KEY,
+    product_id bigint,
+    variant_id bigint,
+    shop_id bigint NOT NULL,
+    kind character varying(32) NOT NULL,
+    old_price bigint,
+    new_price bigint,
+    percent integer,
+    starts_at timestamp with time zone,
+    ends_at timestamp with time zone,
+    user_id bigint,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT price_history_kind_check CHECK (kind IN ('list', 'sale', 'sale_cancelled', 'discount', 'discount_cancelled')),
+    CONSTRAINT price_history_product_fk FOREIGN KEY (product_id) REFERENCES public.products(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT price_history_variant_fk FOREIGN KEY (variant_id) REFERENCES public.product_variants(id) ON UPDATE CASCADE ON DELETE SET NULL,
+    CONSTRAINT price_history_shop_fk FOREIGN KEY (shop_id) REFERENCES public.shops(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.price_history IS 'Append-only log of list price changes, sales and shop discounts';
+COMMENT ON COLUMN public.price_history.user_id IS 'Manager who made change, NULL for changes made before history was kept';
+COMMENT ON COLUMN public.price_history.product_id IS 'NULL for shop discounts, which apply to all shop''s products';
+
+CREATE INDEX IF NOT EXISTS price_history_product_id_idx ON public.price_history USING btree (product_id, id);
+CREATE INDEX IF NOT EXISTS price_history_shop_discounts_idx ON public.price_history USING btree (shop_id, id) WHERE product_id IS NULL;
+
+INSERT INTO public.price_history (product_id, shop_id, kind, new_price)
+SELECT id, shop_id, 'list', price
+FROM public.products
+WHERE NOT EXISTS (SELECT 1 FROM public.price_history WHERE price_history.product_id = products.id);
+
+ALTER TABLE public.orders ADD COLUMN IF NOT EXISTS subtotal bigint;
+UPDATE public.orders SET subtotal = total WHERE subtotal IS NULL;
+ALTER TABLE public.orders ALTER COLUMN subtotal SET NOT NULL;
+ALTER TABLE public.orders ADD COLUMN IF NOT EXISTS discount bigint DEFAULT 0 NOT NULL;
+ALTER TABLE public.orders ADD COLUMN IF NOT EXISTS promo_code character varying(32) DEFAULT '' NOT NULL;
+ALTER TABLE public.orders ADD COLUMN IF NOT EXISTS promo_redemption_id bigint;
+
+COMMENT ON COLUMN public.orders.total IS 'Subtotal minus discount of promo code';
+COMMENT ON COLUMN public.orders.promo_redemption_id IS 'Redemption in shopProduct service, it is released if order is cancelled before payment';
+
+ALTER TABLE public.order_items ADD COLUMN IF NOT EXISTS original_price bigint;
+UPDATE public.order_items SET original_price = price WHERE original_price IS NULL;
+ALTER TABLE public.order_items ALTER COLUMN original_price SET NOT NULL;
+
+COMMENT ON COLUMN public.order_items.original_price IS 'List price at checkout, price is lower if product was on sale';
@@ -0,0 +1,66 @@
+--
+-- Currencies of money amounts, base currencies of shops and exchange rates
+--
+
+ALTER TABLE public.shops ADD COLUMN IF NOT EXISTS currency character(3) DEFAULT 'RUB' NOT NULL;
+
+COMMENT ON COLUMN public.shops.currency IS 'ISO 4217 code of shop''s base currency, it can be changed only while shop has no products';
+
+ALTER TABLE public.products ADD COLUMN IF NOT EXISTS currency character(3) DEFAULT 'RUB' NOT NULL;
+
+COMMENT ON COLUMN public.products.price IS 'Amount in minor units of currency, such as kopecks';
+COMMENT ON COLUMN public.products.currency IS 'Currency of product''s shop, prices of variants and sales are in it';
+
+ALTER TABLE public.product_sales ADD COLUMN IF NOT EXISTS currency character(3) DEFAULT 'RUB' NOT NULL;
+ALTER TABLE public.promo_codes ADD COLUMN IF NOT EXISTS currency character(3) DEFAULT 'RUB' NOT NULL;
+ALTER TABLE public.promo_redemptions ADD COLUMN IF NOT EXISTS currency character(3) DEFAULT 'RUB' NOT NULL;
+ALTER TABLE public.price_history ADD COLUMN IF NOT EXISTS currency character(3) DEFAULT 'RUB' NOT NULL;
+
+COMMENT ON COLUMN public.promo_codes.currency IS 'Currency of fixed value and of minimum order';
+
+CREATE TABLE IF NOT EXISTS public.exchange_rates (
+    currency character(3) PRIMARY KEY,
+    rate numeric NOT NULL,
+    minor_units smallint NOT NULL,
+    rounding character varying(16) DEFAULT 'half_up' NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT exchange_rates_rate_check CHECK (rate > 0),
+    CONSTRAINT exchange_rates_rounding_check CHECK (rounding IN ('half_up', 'half_even', 'up', 'down'))
+);
+
+COMMENT ON TABLE public.exchange_rates IS 'Rates are replaced as a whole from file or by administrators';
+COMMENT ON COLUMN public.exchange_rates.rate IS 'Amount of currency which equals one unit of base currency RUB';
+COMMENT ON COLUMN public.exchange_rates.rounding IS 'Rounding of amounts converted into currency, it is applied to minor units';
+
+INSERT INTO public.exchange_rates (currency, rate, minor_units)
+VALUES ('RUB', 1, 2)
+ON CONFLICT (currency) DO NOTHING;
+
+-- convert_amount converts amount in minor units between currencies by the same rules as shopProduct service does,
+-- it returns NULL if one of currencies has no exchange rate
+CREATE OR REPLACE FUNCTION public.convert_amount(amount bigint, from_currency character(3), to_currency character(3))
+RETURNS bigint AS $$
+    SELECT CASE
+        WHEN from_currency = to_currency THEN amount
+        ELSE (SELECT CASE target.rounding
+                         WHEN 'up' THEN ceil(converted.value)
+                         WHEN 'down' THEN floor(converted.value)
+                         WHEN 'half_even' THEN CASE
+                             WHEN converted.value - floor(converted.value) = 0.5
+                             THEN floor(converted.value) + mod(floor(converted.value), 2)
+                             ELSE round(converted.value)
+                         END
+                         ELSE round(converted.value)
+                     END::bigint
+              FROM public.exchange_rates AS source
+              JOIN public.exchange_rates AS target ON target.currency = to_currency
+              CROSS JOIN LATERAL (SELECT amount * target.rate / source.rate
+                                         * power(10::numeric, target.minor_units - source.minor_units) AS value) AS converted
+              WHERE source.currency = from_currency)
+    END
+$$ LANGUAGE SQL STABLE;
+
+ALTER TABLE public.orders ADD COLUMN IF NOT EXISTS currency character(3) DEFAULT 'RUB' NOT NULL;
+ALTER TABLE public.payments ADD COLUMN IF NOT EXISTS currency character(3) DEFAULT 'RUB' NOT NULL;
+
+COMMENT ON COLUMN public.orders.currency IS 'Currency of shop at checkout, all amounts of order and its items are in it';
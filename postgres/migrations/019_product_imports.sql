@@ -0,0 +1,35 @@
+--
+-- Shop-scoped SKUs of products and catalog import jobs, processed by shopProduct service
+--
+
+ALTER TABLE public.products ADD COLUMN IF NOT EXISTS sku character varying(64) DEFAULT '' NOT NULL;
+
+COMMENT ON COLUMN public.products.sku IS 'Unique within shop, empty for products created without SKU. Catalog imports match products by it';
+
+CREATE UNIQUE INDEX IF NOT EXISTS products_shop_id_sku_idx ON public.products USING btree (shop_id, sku)
+WHERE NOT sku = '';
+
+CREATE TABLE IF NOT EXISTS public.product_imports (
+    id bigserial PRIMARY KEY,
+    shop_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    format character varying(8) NOT NULL,
+    dry_run boolean DEFAULT false NOT NULL,
+    status character varying(20) DEFAULT 'pending' NOT NULL,
+    total_rows integer DEFAULT 0 NOT NULL,
+    processed_rows integer DEFAULT 0 NOT NULL,
+    created_count integer DEFAULT 0 NOT NULL,
+    updated_count integer DEFAULT 0 NOT NULL,
+    failed_count integer DEFAULT 0 NOT NULL,
+    row_errors jsonb DEFAULT '[]' NOT NULL,
+    error_message text DEFAULT '' NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    finished_at timestamp with time zone,
+    CONSTRAINT product_imports_format_check CHECK (format IN ('csv', 'json')),
+    CONSTRAINT product_imports_shop_fk FOREIGN KEY (shop_id) REFERENCES public.shops(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.product_imports IS 'Catalog files uploaded by shop managers, dry run imports only validate rows';
+COMMENT ON COLUMN public.product_imports.row_errors IS 'Array of objects with row, sku, column and message of rows which were not imported';
+
+CREATE INDEX IF NOT EXISTS product_imports_shop_id_idx ON public.product_imports USING btree (shop_id);
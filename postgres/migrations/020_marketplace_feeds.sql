@@ -0,0 +1,98 @@
+--
+-- Yandex Market YML and Google Merchant feeds, generated by shopProduct service. Rendered offers are kept
+-- per product and rendered again only after product changes, triggers below record when that happens
+--
+
+ALTER TABLE public.products ADD COLUMN IF NOT EXISTS feed_changed_at timestamp with time zone DEFAULT now() NOT NULL;
+ALTER TABLE public.shops ADD COLUMN IF NOT EXISTS feed_changed_at timestamp with time zone DEFAULT now() NOT NULL;
+
+COMMENT ON COLUMN public.products.feed_changed_at IS 'Last change of product, its images, variants or sales which is shown in marketplace feeds';
+COMMENT ON COLUMN public.shops.feed_changed_at IS 'Last change of shop or its discounts which is shown in offers of its products';
+
+CREATE OR REPLACE FUNCTION public.touch_feed_changed_at() RETURNS trigger AS $$
+BEGIN
+    NEW.feed_changed_at := now();
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+-- touch_product_feed marks product of changed image, variant or sale
+CREATE OR REPLACE FUNCTION public.touch_product_feed() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        UPDATE public.products SET feed_changed_at = now() WHERE id = OLD.product_id;
+        RETURN OLD;
+    END IF;
+
+    UPDATE public.products SET feed_changed_at = now() WHERE id = NEW.product_id;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+-- touch_shop_feed marks shop of changed discount
+CREATE OR REPLACE FUNCTION public.touch_shop_feed() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        UPDATE public.shops SET feed_changed_at = now() WHERE id = OLD.shop_id;
+        RETURN OLD;
+    END IF;
+
+    UPDATE public.shops SET feed_changed_at = now() WHERE id = NEW.shop_id;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+-- availability is generated from stock and reserved, so it is never set by updates itself
+DROP TRIGGER IF EXISTS products_feed_changed_trigger ON public.products;
+CREATE TRIGGER products_feed_changed_trigger
+    BEFORE UPDATE OF title, description, sku, price, currency, stock, reserved, size, assembly_time, parts_amount, category_id, shop_id
+    ON public.products FOR EACH ROW EXECUTE FUNCTION public.touch_feed_changed_at();
+
+DROP TRIGGER IF EXISTS shops_feed_changed_trigger ON public.shops;
+CREATE TRIGGER shops_feed_changed_trigger
+    BEFORE UPDATE OF title, description, currency
+    ON public.shops FOR EACH ROW EXECUTE FUNCTION public.touch_feed_changed_at();
+
+DROP TRIGGER IF EXISTS product_images_feed_trigger ON public.product_images;
+CREATE TRIGGER product_images_feed_trigger
+    AFTER INSERT OR UPDATE OR DELETE ON public.product_images
+    FOR EACH ROW EXECUTE FUNCTION public.touch_product_feed();
+
+DROP TRIGGER IF EXISTS product_variants_feed_trigger ON public.product_variants;
+CREATE TRIGGER product_variants_feed_trigger
+    AFTER INSERT OR UPDATE OR DELETE ON public.product_variants
+    FOR EACH ROW EXECUTE FUNCTION public.touch_product_feed();
+
+DROP TRIGGER IF EXISTS product_sales_feed_trigger ON public.product_sales;
+CREATE TRIGGER product_sales_feed_trigger
+    AFTER INSERT OR UPDATE OR DELETE ON public.product_sales
+    FOR EACH ROW EXECUTE FUNCTION public.touch_product_feed();
+
+DROP TRIGGER IF EXISTS shop_discounts_feed_trigger ON public.shop_discounts;
+CREATE TRIGGER shop_discounts_feed_trigger
+    AFTER INSERT OR UPDATE OR DELETE ON public.shop_discounts
+    FOR EACH ROW EXECUTE FUNCTION public.touch_shop_feed();
+
+CREATE TABLE IF NOT EXISTS public.marketplace_feed_offers (
+    product_id bigint NOT NULL,
+    format character varying(16) NOT NULL,
+    fragment text NOT NULL,
+    rendered_at timestamp with time zone NOT NULL,
+    CONSTRAINT marketplace_feed_offers_pk PRIMARY KEY (product_id, format),
+    CONSTRAINT marketplace_feed_offers_product_fk FOREIGN KEY (product_id) REFERENCES public.products(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.marketplace_feed_offers IS 'Rendered XML of products in feed formats, it is reused while product does not change';
+
+CREATE TABLE IF NOT EXISTS public.marketplace_feeds (
+    shop_id bigint DEFAULT 0 NOT NULL,
+    format character varying(16) NOT NULL,
+    document bytea NOT NULL,
+    offers_count integer NOT NULL,
+    generated_at timestamp with time zone NOT NULL,
+    CONSTRAINT marketplace_feeds_pk PRIMARY KEY (shop_id, format),
+    CONSTRAINT marketplace_feeds_format_check CHECK (format IN ('yml', 'google'))
+);
+
+COMMENT ON TABLE public.marketplace_feeds IS 'Cached feed documents, they are served until products in them change';
+COMMENT ON COLUMN public.marketplace_feeds.shop_id IS '0 for feed of the whole catalog, so there is no foreign key to shops';
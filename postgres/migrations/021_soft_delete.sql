@@ -0,0 +1,18 @@
+--
+-- Soft deletion of shops and products. Deleted rows are hidden by shopProduct service, can be restored
+-- during retention period and are purged after it
+--
+
+ALTER TABLE public.products ADD COLUMN IF NOT EXISTS deleted_at timestamp with time zone;
+ALTER TABLE public.shops ADD COLUMN IF NOT EXISTS deleted_at timestamp with time zone;
+
+COMMENT ON COLUMN public.products.deleted_at IS 'NULL for products which were not deleted. Products deleted together with shop have deleted_at of shop';
+COMMENT ON COLUMN public.shops.deleted_at IS 'NULL for shops which were not deleted. Deleted shops with orders are kept after purge, as orders reference them';
+
+CREATE INDEX IF NOT EXISTS products_deleted_at_idx ON public.products USING btree (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS shops_deleted_at_idx ON public.shops USING btree (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Deleted products do not hold their SKUs, so that they can be imported again
+DROP INDEX IF EXISTS public.products_shop_id_sku_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS products_shop_id_sku_idx ON public.products USING btree (shop_id, sku)
+WHERE NOT sku = '' AND deleted_at IS NULL;
@@ -0,0 +1,14 @@
+--
+-- Co-view statistics of related products recommendations. shopProduct service rebuilds them from product views
+--
+
+CREATE TABLE IF NOT EXISTS public.product_coviews (
+    product_id bigint NOT NULL,
+    related_id bigint NOT NULL,
+    viewers_count integer NOT NULL,
+    CONSTRAINT product_coviews_pk PRIMARY KEY (product_id, related_id),
+    CONSTRAINT product_coviews_product_fk FOREIGN KEY (product_id) REFERENCES public.products(id) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT product_coviews_related_fk FOREIGN KEY (related_id) REFERENCES public.products(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.product_coviews IS 'Amounts of users who viewed both products within short time, rare pairs are not stored';
@@ -0,0 +1,71 @@
+--
+-- Shop analytics. Product and shop page views are counted once per view session and aggregated by shopProduct
+-- service into daily rollups, which analytics reports are built from
+--
+
+ALTER TABLE public.product_views ADD COLUMN IF NOT EXISTS session varchar(64) DEFAULT '' NOT NULL;
+ALTER TABLE public.product_views ADD COLUMN IF NOT EXISTS referrer varchar(255) DEFAULT '' NOT NULL;
+
+COMMENT ON COLUMN public.product_views.session IS 'Token of view session from gateway cookie, empty for views made without it';
+COMMENT ON COLUMN public.product_views.referrer IS 'Host of page from which product page was opened, empty for direct visits';
+
+CREATE INDEX IF NOT EXISTS product_views_viewed_at_idx ON public.product_views USING btree (viewed_at);
+
+-- Views are counted once per session, unique indexes keep concurrent requests from counting them twice
+DELETE FROM public.product_views
+WHERE NOT session = ''
+  AND EXISTS (SELECT 1 FROM public.product_views AS earlier
+              WHERE earlier.session = product_views.session AND earlier.product_id = product_views.product_id
+                AND earlier.id < product_views.id);
+DROP INDEX IF EXISTS public.product_views_session_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS product_views_session_key ON public.product_views USING btree (session, product_id) WHERE NOT session = '';
+
+CREATE TABLE IF NOT EXISTS public.shop_views (
+    id bigserial PRIMARY KEY,
+    shop_id bigint NOT NULL,
+    user_id bigint,
+    session varchar(64) NOT NULL,
+    referrer varchar(255) DEFAULT '' NOT NULL,
+    viewed_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT shop_views_shop_fk FOREIGN KEY (shop_id) REFERENCES public.shops(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.shop_views IS 'Shop page views, user_id is NULL for anonymous views';
+
+CREATE INDEX IF NOT EXISTS shop_views_shop_id_idx ON public.shop_views USING btree (shop_id, viewed_at);
+DELETE FROM public.shop_views
+WHERE EXISTS (SELECT 1 FROM public.shop_views AS earlier
+              WHERE earlier.session = shop_views.session AND earlier.shop_id = shop_views.shop_id
+                AND earlier.id < shop_views.id);
+DROP INDEX IF EXISTS public.shop_views_session_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS shop_views_session_key ON public.shop_views USING btree (session, shop_id);
+CREATE INDEX IF NOT EXISTS shop_views_viewed_at_idx ON public.shop_views USING btree (viewed_at);
+
+CREATE TABLE IF NOT EXISTS public.view_rollups (
+    day date NOT NULL,
+    shop_id bigint NOT NULL,
+    product_id bigint NOT NULL,
+    views integer NOT NULL,
+    unique_viewers integer NOT NULL,
+    CONSTRAINT view_rollups_pk PRIMARY KEY (shop_id, day, product_id),
+    CONSTRAINT view_rollups_shop_fk FOREIGN KEY (shop_id) REFERENCES public.shops(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.view_rollups IS 'Daily views of shop pages and products, days are in UTC';
+COMMENT ON COLUMN public.view_rollups.product_id IS '0 for views of shop page. Products are not referenced, so that statistics of purged products are kept';
+
+CREATE INDEX IF NOT EXISTS view_rollups_day_idx ON public.view_rollups USING btree (day);
+
+CREATE TABLE IF NOT EXISTS public.referrer_rollups (
+    day date NOT NULL,
+    shop_id bigint NOT NULL,
+    product_id bigint NOT NULL,
+    referrer varchar(255) NOT NULL,
+    views integer NOT NULL,
+    CONSTRAINT referrer_rollups_pk PRIMARY KEY (shop_id, day, product_id, referrer),
+    CONSTRAINT referrer_rollups_shop_fk FOREIGN KEY (shop_id) REFERENCES public.shops(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.referrer_rollups IS 'Daily views of shop pages and products by referrer, product_id is 0 for shop page';
+
+CREATE INDEX IF NOT EXISTS referrer_rollups_day_idx ON public.referrer_rollups USING btree (day);
@@ -0,0 +1,54 @@
+--
+-- Questions about products. Anyone logged in can ask, managers of product's shop and buyers of product can answer,
+-- managers can mark one answer of question as official
+--
+
+CREATE TABLE IF NOT EXISTS public.product_questions (
+    id bigserial PRIMARY KEY,
+    product_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    text text NOT NULL,
+    votes_count bigint DEFAULT 0 NOT NULL,
+    answers_count bigint DEFAULT 0 NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    search_vector tsvector GENERATED ALWAYS AS (to_tsvector('russian', text)) STORED,
+    CONSTRAINT product_questions_product_fk FOREIGN KEY (product_id) REFERENCES public.products(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.product_questions IS 'Questions about products, votes and answers counts are updated together with votes and answers';
+
+CREATE INDEX IF NOT EXISTS product_questions_product_id_idx ON public.product_questions USING btree (product_id, votes_count, id);
+CREATE INDEX IF NOT EXISTS product_questions_user_id_idx ON public.product_questions USING btree (user_id);
+CREATE INDEX IF NOT EXISTS product_questions_search_vector_idx ON public.product_questions USING gin (search_vector);
+
+CREATE TABLE IF NOT EXISTS public.product_answers (
+    id bigserial PRIMARY KEY,
+    question_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    text text NOT NULL,
+    author_role character varying(10) NOT NULL,
+    is_official boolean DEFAULT false NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    search_vector tsvector GENERATED ALWAYS AS (to_tsvector('russian', text)) STORED,
+    CONSTRAINT product_answers_author_role_check CHECK (author_role IN ('manager', 'buyer')),
+    CONSTRAINT product_answers_question_fk FOREIGN KEY (question_id) REFERENCES public.product_questions(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.product_answers IS 'Answers to product questions, author_role tells whether author answered as shop manager or as buyer';
+
+CREATE INDEX IF NOT EXISTS product_answers_question_id_idx ON public.product_answers USING btree (question_id, created_at);
+CREATE INDEX IF NOT EXISTS product_answers_user_id_idx ON public.product_answers USING btree (user_id);
+CREATE INDEX IF NOT EXISTS product_answers_search_vector_idx ON public.product_answers USING gin (search_vector);
+CREATE UNIQUE INDEX IF NOT EXISTS product_answers_official_idx ON public.product_answers USING btree (question_id) WHERE is_official;
+
+CREATE TABLE IF NOT EXISTS public.question_votes (
+    question_id bigint NOT NULL,
+    user_id bigint NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT question_votes_pk PRIMARY KEY (question_id, user_id),
+    CONSTRAINT question_votes_question_fk FOREIGN KEY (question_id) REFERENCES public.product_questions(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.question_votes IS 'Users who found question useful, every user can vote for question once';
+
+CREATE INDEX IF NOT EXISTS question_votes_user_id_idx ON public.question_votes USING btree (user_id);
@@ -0,0 +1,15 @@
+--
+-- Security-relevant events of user accounts, written by auth and user services and included in data exports
+--
+
+CREATE TABLE IF NOT EXISTS public.user_audit_events (
+    id bigserial PRIMARY KEY,
+    user_id bigint NOT NULL,
+    event character varying(50) NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT user_audit_events_user_fk FOREIGN KEY (user_id) REFERENCES public.users(id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+COMMENT ON TABLE public.user_audit_events IS 'Logins, logouts and changes of credentials, profile and privacy settings';
+
+CREATE INDEX IF NOT EXISTS user_audit_events_user_id_idx ON public.user_audit_events USING btree (user_id, created_at);
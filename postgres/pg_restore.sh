@@ -1 +1,2 @@
-psql -U postgres --dbname=postgres < "backup.sql"
\ No newline at end of file
+psql -U postgres --dbname=postgres < "backup.sql"
+for migration in migrations/*.sql; do psql -U postgres --dbname=postgres < "$migration"; done
\ No newline at end of file
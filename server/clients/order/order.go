@@ -0,0 +1,229 @@
+package order
+
+import (
+	"context"
+	"pinterest/domain"
+	orderdomain "pinterest/services/order/domain"
+	orderproto "pinterest/services/order/proto"
+	shopproductdomain "pinterest/services/shopProduct/domain"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type OrderClientInterface interface {
+	Checkout(ctx context.Context, userID uint64, items []domain.CartItemInput, promoCode string) (orders []domain.Order, err error)
+	GetOrder(ctx context.Context, orderID uint64, userID uint64) (order domain.Order, err error)
+	ListUserOrders(ctx context.Context, userID uint64, status string, page domain.PageInput) (orders []domain.Order, nextCursor string, err error)
+	ListShopOrders(ctx context.Context, shopID uint64, userID uint64, status string, page domain.PageInput) (orders []domain.Order, nextCursor string, err error)
+	ChangeOrderStatus(ctx context.Context, orderID uint64, userID uint64, statusInput domain.OrderStatusInput) (order domain.Order, err error)
+	CreatePayment(ctx context.Context, orderID uint64, userID uint64, returnURL string) (payment domain.Payment, err error)
+	SyncPayment(ctx context.Context, orderID uint64, userID uint64) (payment domain.Payment, err error)
+	HandlePaymentWebhook(ctx context.Context, provider string, body []byte, signature string) (err error)
+	CompleteFakePayment(ctx context.Context, providerPaymentID string, succeeded bool) (returnURL string, err error)
+}
+
+type OrderClient struct {
+	orderClient orderproto.OrderServiceClient
+}
+
+func NewOrderClient(orderClient orderproto.OrderServiceClient) *OrderClient {
+	return &OrderClient{
+		orderClient: orderClient,
+	}
+}
+
+func (client *OrderClient) Checkout(ctx context.Context, userID uint64, items []domain.CartItemInput, promoCode string) (orders []domain.Order, err error) {
+	pbOrders, err := client.orderClient.Checkout(context.Background(),
+		&orderproto.CheckoutRequest{
+			UserId:    userID,
+			Items:     domain.ToPbCheckoutItems(items),
+			PromoCode: promoCode,
+		})
+
+	if err != nil {
+		return nil, parseOrderError(err)
+	}
+
+	return domain.ToOrders(pbOrders.GetOrders()), nil
+}
+
+func (client *OrderClient) GetOrder(ctx context.Context, orderID uint64, userID uint64) (order domain.Order, err error) {
+	pbOrder, err := client.orderClient.GetOrder(context.Background(),
+		&orderproto.OrderRequest{Id: orderID, UserId: userID})
+
+	if err != nil {
+		return domain.Order{}, parseOrderError(err)
+	}
+
+	return domain.ToOrder(pbOrder), nil
+}
+
+func (client *OrderClient) ListUserOrders(ctx context.Context, userID uint64, status string, page domain.PageInput) (orders []domain.Order, nextCursor string, err error) {
+	pbOrders, err := client.orderClient.ListUserOrders(context.Background(),
+		&orderproto.ListOrdersRequest{
+			UserId: userID,
+			Status: status,
+			Limit:  page.Limit,
+			Cursor: page.Cursor,
+			Page:   page.Page,
+		})
+
+	if err != nil {
+		return nil, "", parseOrderError(err)
+	}
+
+	return domain.ToOrders(pbOrders.GetOrders()), pbOrders.GetNextCursor(), nil
+}
+
+func (client *OrderClient) ListShopOrders(ctx context.Context, shopID uint64, userID uint64, status string, page domain.PageInput) (orders []domain.Order, nextCursor string, err error) {
+	pbOrders, err := client.orderClient.ListShopOrders(context.Background(),
+		&orderproto.ListOrdersRequest{
+			UserId: userID,
+			ShopId: shopID,
+			Status: status,
+			Limit:  page.Limit,
+			Cursor: page.Cursor,
+			Page:   page.Page,
+		})
+
+	if err != nil {
+		return nil, "", parseOrderError(err)
+	}
+
+	return domain.ToOrders(pbOrders.GetOrders()), pbOrders.GetNextCursor(), nil
+}
+
+func (client *OrderClient) ChangeOrderStatus(ctx context.Context, orderID uint64, userID uint64, statusInput domain.OrderStatusInput) (order domain.Order, err error) {
+	pbOrder, err := client.orderClient.ChangeOrderStatus(context.Background(),
+		&orderproto.ChangeStatusRequest{
+			Id:      orderID,
+			UserId:  userID,
+			Status:  statusInput.Status,
+			Comment: statusInput.Comment,
+		})
+
+	if err != nil {
+		return domain.Order{}, parseOrderError(err)
+	}
+
+	return domain.ToOrder(pbOrder), nil
+}
+
+func (client *OrderClient) CreatePayment(ctx context.Context, orderID uint64, userID uint64, returnURL string) (payment domain.Payment, err error) {
+	pbPayment, err := client.orderClient.CreatePayment(context.Background(),
+		&orderproto.CreatePaymentRequest{
+			OrderId:   orderID,
+			UserId:    userID,
+			ReturnUrl: returnURL,
+		})
+
+	if err != nil {
+		return domain.Payment{}, parseOrderError(err)
+	}
+
+	return domain.ToPayment(pbPayment), nil
+}
+
+func (client *OrderClient) SyncPayment(ctx context.Context, orderID uint64, userID uint64) (payment domain.Payment, err error) {
+	pbPayment, err := client.orderClient.SyncPayment(context.Background(),
+		&orderproto.OrderRequest{Id: orderID, UserId: userID})
+
+	if err != nil {
+		return domain.Payment{}, parseOrderError(err)
+	}
+
+	return domain.ToPayment(pbPayment), nil
+}
+
+func (client *OrderClient) HandlePaymentWebhook(ctx context.Context, provider string, body []byte, signature string) (err error) {
+	_, err = client.orderClient.HandlePaymentWebhook(context.Background(),
+		&orderproto.PaymentWebhookRequest{
+			Provider:  provider,
+			Body:      body,
+			Signature: signature,
+		})
+
+	if err != nil {
+		return parseOrderError(err)
+	}
+
+	return nil
+}
+
+func (client *OrderClient) CompleteFakePayment(ctx context.Context, providerPaymentID string, succeeded bool) (returnURL string, err error) {
+	response, err := client.orderClient.CompleteFakePayment(context.Background(),
+		&orderproto.FakePaymentRequest{
+			ProviderPaymentId: providerPaymentID,
+			Succeeded:         succeeded,
+		})
+
+	if err != nil {
+		return "", parseOrderError(err)
+	}
+
+	return response.GetReturnUrl(), nil
+}
+
+// parseOrderError converts errors of order service, together with errors of shopProduct service which it calls
+func parseOrderError(err error) error {
+	switch {
+	case strings.Contains(err.Error(), orderdomain.OrderNotFoundError.Error()):
+		return domain.ErrOrderNotFound
+	case strings.Contains(err.Error(), orderdomain.EmptyCheckoutError.Error()):
+		return domain.ErrEmptyCheckout
+	case strings.Contains(err.Error(), orderdomain.TooManyCheckoutItemsError.Error()):
+		return domain.ErrTooManyCheckoutItems
+	case strings.Contains(err.Error(), orderdomain.InvalidQuantityError.Error()),
+		strings.Contains(err.Error(), shopproductdomain.InvalidQuantityError.Error()):
+		return domain.ErrInvalidQuantity
+	case strings.Contains(err.Error(), orderdomain.ProductNotFoundError.Error()):
+		return domain.ErrProductNotFound
+	case strings.Contains(err.Error(), orderdomain.VariantNotFoundError.Error()),
+		strings.Contains(err.Error(), shopproductdomain.VariantNotFoundError.Error()):
+		return domain.ErrVariantNotFound
+	case strings.Contains(err.Error(), orderdomain.VariantRequiredError.Error()),
+		strings.Contains(err.Error(), shopproductdomain.VariantRequiredError.Error()):
+		return domain.ErrVariantRequired
+	case strings.Contains(err.Error(), shopproductdomain.ShopNotFoundError.Error()):
+		return domain.ErrShopNotFound
+	case strings.Contains(err.Error(), shopproductdomain.OutOfStockError.Error()):
+		return domain.ErrOutOfStock
+	case strings.Contains(err.Error(), shopproductdomain.ReservationNotActiveError.Error()):
+		return domain.ErrReservationNotActive
+	case strings.Contains(err.Error(), orderdomain.PromoCodeNotFoundError.Error()),
+		strings.Contains(err.Error(), shopproductdomain.PromoCodeNotFoundError.Error()):
+		return domain.ErrPromoCodeNotFound
+	case strings.Contains(err.Error(), shopproductdomain.PromoCodeExpiredError.Error()):
+		return domain.ErrPromoCodeExpired
+	case strings.Contains(err.Error(), shopproductdomain.PromoCodeMinOrderError.Error()):
+		return domain.ErrPromoCodeMinOrder
+	case strings.Contains(err.Error(), shopproductdomain.PromoCodeUsedUpError.Error()):
+		return domain.ErrPromoCodeUsedUp
+	case strings.Contains(err.Error(), orderdomain.NotShopManagerError.Error()):
+		return domain.ErrNotShopManager
+	case strings.Contains(err.Error(), orderdomain.InvalidStatusError.Error()):
+		return domain.ErrInvalidOrderStatus
+	case strings.Contains(err.Error(), orderdomain.InvalidTransitionError.Error()):
+		return domain.ErrInvalidTransition
+	case strings.Contains(err.Error(), orderdomain.TransitionForbiddenError.Error()):
+		return domain.ErrTransitionForbidden
+	case strings.Contains(err.Error(), orderdomain.StatusChangedError.Error()):
+		return domain.ErrOrderStatusChanged
+	case strings.Contains(err.Error(), orderdomain.CommentTooLongError.Error()):
+		return domain.ErrOrderCommentTooLong
+	case strings.Contains(err.Error(), orderdomain.InvalidCursorError.Error()):
+		return domain.ErrInvalidCursor
+	case strings.Contains(err.Error(), orderdomain.OrderNotPayableError.Error()):
+		return domain.ErrOrderNotPayable
+	case strings.Contains(err.Error(), orderdomain.PaymentNotFoundError.Error()):
+		return domain.ErrPaymentNotFound
+	case strings.Contains(err.Error(), orderdomain.UnknownProviderError.Error()):
+		return domain.ErrUnknownProvider
+	case strings.Contains(err.Error(), orderdomain.InvalidSignatureError.Error()),
+		strings.Contains(err.Error(), orderdomain.InvalidWebhookError.Error()):
+		return domain.ErrInvalidWebhook
+	default:
+		return errors.Wrap(err, "order client error: ")
+	}
+}
@@ -0,0 +1,1380 @@
+package shopProduct
+
+import (
+	"context"
+	"io"
+	"pinterest/domain"
+	shopproductdomain "pinterest/services/shopProduct/domain"
+	shopproductproto "pinterest/services/shopProduct/proto"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type ShopProductClientInterface interface {
+	CreateShop(ctx context.Context, shop domain.Shop, userID uint64) (shopID uint64, err error)
+	EditShop(ctx context.Context, shop domain.Shop, userID uint64) (err error)
+	GetShop(ctx context.Context, shopID uint64, view domain.PageView) (shop domain.Shop, err error)
+	CreateProduct(ctx context.Context, product domain.Product, userID uint64) (productID uint64, err error)
+	EditProduct(ctx context.Context, product domain.Product, userID uint64) (err error)
+	GetProduct(ctx context.Context, productID uint64, variantID uint64, view domain.PageView, currency string) (product domain.Product, err error)
+	DeleteProduct(ctx context.Context, productID uint64, userID uint64) (err error)
+	PurgeProduct(ctx context.Context, productID uint64, userID uint64) (err error)
+	RestoreProduct(ctx context.Context, productID uint64, userID uint64) (err error)
+	ListDeletedProducts(ctx context.Context, shopID uint64, userID uint64) (products []domain.Product, err error)
+	DeleteShop(ctx context.Context, shopID uint64, userID uint64) (err error)
+	RestoreShop(ctx context.Context, shopID uint64, userID uint64) (err error)
+	GetRelatedProducts(ctx context.Context, productID uint64, limit uint64, currency string) (products []domain.Product, err error)
+	GetShopAnalytics(ctx context.Context, shopID uint64, userID uint64, from string, to string) (analytics domain.ShopAnalytics, err error)
+	AskQuestion(ctx context.Context, productID uint64, userID uint64, text string) (questionID uint64, err error)
+	AnswerQuestion(ctx context.Context, questionID uint64, userID uint64, text string) (answerID uint64, err error)
+	SetOfficialAnswer(ctx context.Context, answerID uint64, userID uint64, isOfficial bool) (err error)
+	VoteQuestion(ctx context.Context, questionID uint64, userID uint64) (err error)
+	UnvoteQuestion(ctx context.Context, questionID uint64, userID uint64) (err error)
+	ListQuestions(ctx context.Context, productID uint64, viewerID uint64, page domain.PageInput, query string) (questions []domain.ProductQuestion, nextCursor string, err error)
+	CreateReview(ctx context.Context, review domain.ProductReview, userID uint64) (reviewID uint64, err error)
+	EditReview(ctx context.Context, review domain.ProductReview, userID uint64) (err error)
+	ListReviews(ctx context.Context, productID uint64, page domain.PageInput) (reviews []domain.ProductReview, nextCursor string, err error)
+	ListProductsByShop(ctx context.Context, shopID uint64, page domain.PageInput, currency string) (products []domain.Product, nextCursor string, err error)
+	GetCategories(ctx context.Context) (categories []domain.Category, err error)
+	CreateCategory(ctx context.Context, category domain.Category, userID uint64) (categoryID uint64, err error)
+	EditCategory(ctx context.Context, category domain.Category, userID uint64) (err error)
+	DeleteCategory(ctx context.Context, categoryID uint64, replacementID uint64, userID uint64) (err error)
+	ListProductsByCategory(ctx context.Context, categoryID uint64, page domain.PageInput, currency string) (products []domain.Product, nextCursor string, err error)
+	AdjustStock(ctx context.Context, productID uint64, userID uint64, adjustment domain.StockAdjustmentInput) (movement domain.InventoryMovement, err error)
+	ListInventoryMovements(ctx context.Context, productID uint64, userID uint64, page domain.PageInput) (movements []domain.InventoryMovement, nextCursor string, err error)
+	GetCart(ctx context.Context, userID uint64, cartToken string, currency string) (cart domain.Cart, err error)
+	AddToCart(ctx context.Context, userID uint64, cartToken string, item domain.CartItemInput, currency string) (cart domain.Cart, err error)
+	UpdateCartItem(ctx context.Context, userID uint64, cartToken string, item domain.CartItemInput, currency string) (cart domain.Cart, err error)
+	RemoveFromCart(ctx context.Context, userID uint64, cartToken string, productID uint64, variantID uint64, currency string) (cart domain.Cart, err error)
+	MergeCarts(ctx context.Context, cartToken string, userID uint64) (err error)
+	SearchProducts(ctx context.Context, search domain.SearchInput) (result domain.SearchResponse, err error)
+	GetFeed(ctx context.Context, userID uint64, page domain.PageInput, currency string) (products []domain.Product, nextCursor string, err error)
+	FollowShop(ctx context.Context, shopID uint64, userID uint64) (err error)
+	UnfollowShop(ctx context.Context, shopID uint64, userID uint64) (err error)
+	InviteShopManager(ctx context.Context, shopID uint64, userID uint64, invitedUserID uint64, role string) (invitationID uint64, err error)
+	RemoveShopManager(ctx context.Context, shopID uint64, userID uint64, managerID uint64) (err error)
+	GetShopInvitations(ctx context.Context, userID uint64) (invitations []domain.ShopInvitation, err error)
+	AcceptShopInvitation(ctx context.Context, invitationID uint64, userID uint64) (err error)
+	DeclineShopInvitation(ctx context.Context, invitationID uint64, userID uint64) (err error)
+	UploadProductImage(ctx context.Context, productID uint64, userID uint64, image io.Reader) (productImage domain.ProductImage, err error)
+	GetProductImages(ctx context.Context, productID uint64) (images []domain.ProductImage, err error)
+	ReorderProductImages(ctx context.Context, productID uint64, userID uint64, imageIDs []uint64) (err error)
+	SetPrimaryProductImage(ctx context.Context, productID uint64, imageID uint64, userID uint64) (err error)
+	DeleteProductImage(ctx context.Context, productID uint64, imageID uint64, userID uint64) (err error)
+	SetProductOptions(ctx context.Context, productID uint64, userID uint64, options []domain.ProductOption) (err error)
+	CreateVariant(ctx context.Context, productID uint64, userID uint64, variant domain.ProductVariant) (variantID uint64, err error)
+	EditVariant(ctx context.Context, productID uint64, userID uint64, variant domain.ProductVariant) (err error)
+	DeleteVariant(ctx context.Context, productID uint64, variantID uint64, userID uint64) (err error)
+	CreateProductSale(ctx context.Context, productID uint64, userID uint64, sale domain.ProductSale) (saleID uint64, err error)
+	ListProductSales(ctx context.Context, productID uint64, userID uint64) (sales []domain.ProductSale, err error)
+	DeleteProductSale(ctx context.Context, productID uint64, saleID uint64, userID uint64) (err error)
+	CreateShopDiscount(ctx context.Context, shopID uint64, userID uint64, discount domain.ShopDiscount) (discountID uint64, err error)
+	ListShopDiscounts(ctx context.Context, shopID uint64, userID uint64) (discounts []domain.ShopDiscount, err error)
+	DeleteShopDiscount(ctx context.Context, shopID uint64, discountID uint64, userID uint64) (err error)
+	CreatePromoCode(ctx context.Context, shopID uint64, userID uint64, promoCode domain.PromoCode) (promoCodeID uint64, err error)
+	ListPromoCodes(ctx context.Context, shopID uint64, userID uint64) (promoCodes []domain.PromoCode, err error)
+	DeletePromoCode(ctx context.Context, shopID uint64, promoCodeID uint64, userID uint64) (err error)
+	ListPriceHistory(ctx context.Context, productID uint64, page domain.PageInput) (changes []domain.PriceChange, nextCursor string, err error)
+	ListWishlists(ctx context.Context, ownerID uint64, viewerID uint64) (wishlists []domain.Wishlist, err error)
+	GetWishlist(ctx context.Context, wishlistID uint64, viewerID uint64, currency string) (wishlist domain.Wishlist, err error)
+	GetSharedWishlist(ctx context.Context, token string, viewerID uint64, currency string) (wishlist domain.Wishlist, err error)
+	CreateWishlist(ctx context.Context, userID uint64, wishlist domain.WishlistInput) (wishlistID uint64, err error)
+	EditWishlist(ctx context.Context, wishlistID uint64, userID uint64, wishlist domain.WishlistInput) (err error)
+	DeleteWishlist(ctx context.Context, wishlistID uint64, userID uint64) (err error)
+	SaveProduct(ctx context.Context, productID uint64, userID uint64, wishlistID uint64) (savedTo uint64, err error)
+	UnsaveProduct(ctx context.Context, productID uint64, userID uint64, wishlistID uint64) (err error)
+	GetExchangeRates(ctx context.Context) (rates domain.ExchangeRates, err error)
+	UpdateExchangeRates(ctx context.Context, rates domain.ExchangeRates, userID uint64) (err error)
+	ImportProducts(ctx context.Context, shopID uint64, userID uint64, format string, dryRun bool, catalog io.Reader) (productImport domain.ProductImport, err error)
+	GetProductImport(ctx context.Context, shopID uint64, importID uint64, userID uint64) (productImport domain.ProductImport, err error)
+	ExportProducts(ctx context.Context, shopID uint64, userID uint64, format string) (catalog io.Reader, err error)
+	GetMarketplaceFeed(ctx context.Context, shopID uint64, format string) (feed io.Reader, err error)
+}
+
+type ShopProductClient struct {
+	shopProductClient shopproductproto.ShopProductClient
+}
+
+func NewShopProductClient(shopProductClient shopproductproto.ShopProductClient) *ShopProductClient {
+	return &ShopProductClient{
+		shopProductClient: shopProductClient,
+	}
+}
+
+func (client *ShopProductClient) CreateShop(ctx context.Context, shop domain.Shop, userID uint64) (shopID uint64, err error) {
+	pbShopID, err := client.shopProductClient.CreateShop(context.Background(),
+		domain.ToPbCreateShopRequest(shop, userID))
+
+	if err != nil {
+		return 0, parseShopProductError(err)
+	}
+
+	return pbShopID.GetId(), nil
+}
+
+func (client *ShopProductClient) EditShop(ctx context.Context, shop domain.Shop, userID uint64) (err error) {
+	_, err = client.shopProductClient.EditShop(context.Background(),
+		domain.ToPbEditShopRequest(shop, userID))
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) GetShop(ctx context.Context, shopID uint64, view domain.PageView) (shop domain.Shop, err error) {
+	pbShop, err := client.shopProductClient.GetShop(context.Background(),
+		&shopproductproto.GetShopRequest{Id: shopID, UserId: view.UserID, Session: view.Session, Referrer: view.Referrer})
+
+	if err != nil {
+		return domain.Shop{}, parseShopProductError(err)
+	}
+
+	return domain.ToShop(pbShop), nil
+}
+
+func (client *ShopProductClient) CreateProduct(ctx context.Context, product domain.Product, userID uint64) (productID uint64, err error) {
+	pbProductID, err := client.shopProductClient.CreateProduct(context.Background(),
+		domain.ToPbCreateProductRequest(product, userID))
+
+	if err != nil {
+		return 0, parseShopProductError(err)
+	}
+
+	return pbProductID.GetId(), nil
+}
+
+func (client *ShopProductClient) EditProduct(ctx context.Context, product domain.Product, userID uint64) (err error) {
+	_, err = client.shopProductClient.EditProduct(context.Background(),
+		domain.ToPbEditProductRequest(product, userID))
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+// GetProduct returns product with price, stock and images of variant if variantID is not 0.
+// Prices are also converted into currency if it is not empty
+func (client *ShopProductClient) GetProduct(ctx context.Context, productID uint64, variantID uint64, view domain.PageView, currency string) (product domain.Product, err error) {
+	pbProduct, err := client.shopProductClient.GetProduct(context.Background(),
+		&shopproductproto.GetProductRequest{
+			Id:        productID,
+			VariantId: variantID,
+			UserId:    view.UserID,
+			Currency:  currency,
+			Session:   view.Session,
+			Referrer:  view.Referrer,
+		})
+
+	if err != nil {
+		return domain.Product{}, parseShopProductError(err)
+	}
+
+	return domain.ToProduct(pbProduct), nil
+}
+
+func (client *ShopProductClient) ListProductsByShop(ctx context.Context, shopID uint64, page domain.PageInput, currency string) (products []domain.Product, nextCursor string, err error) {
+	pbProducts, err := client.shopProductClient.ListProductsByShop(context.Background(),
+		&shopproductproto.ListProductsRequest{
+			ShopId:   shopID,
+			Sorting:  page.Sorting,
+			Limit:    page.Limit,
+			Cursor:   page.Cursor,
+			Page:     page.Page,
+			Currency: currency,
+		})
+
+	if err != nil {
+		return nil, "", parseShopProductError(err)
+	}
+
+	return domain.ToProducts(pbProducts.GetProducts()), pbProducts.GetNextCursor(), nil
+}
+
+func (client *ShopProductClient) GetCategories(ctx context.Context) (categories []domain.Category, err error) {
+	pbCategories, err := client.shopProductClient.GetCategories(context.Background(),
+		&shopproductproto.CategoriesRequest{})
+
+	if err != nil {
+		return nil, parseShopProductError(err)
+	}
+
+	return domain.ToCategories(pbCategories.GetCategories()), nil
+}
+
+func (client *ShopProductClient) CreateCategory(ctx context.Context, category domain.Category, userID uint64) (categoryID uint64, err error) {
+	pbCategory, err := client.shopProductClient.CreateCategory(context.Background(),
+		&shopproductproto.CategoryRequest{
+			Category: domain.ToPbCategory(category),
+			UserId:   userID,
+		})
+
+	if err != nil {
+		return 0, parseShopProductError(err)
+	}
+
+	return pbCategory.GetId(), nil
+}
+
+func (client *ShopProductClient) EditCategory(ctx context.Context, category domain.Category, userID uint64) (err error) {
+	_, err = client.shopProductClient.EditCategory(context.Background(),
+		&shopproductproto.CategoryRequest{
+			Category: domain.ToPbCategory(category),
+			UserId:   userID,
+		})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) DeleteCategory(ctx context.Context, categoryID uint64, replacementID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.DeleteCategory(context.Background(),
+		&shopproductproto.DeleteCategoryRequest{
+			Id:            categoryID,
+			UserId:        userID,
+			ReplacementId: replacementID,
+		})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) ListProductsByCategory(ctx context.Context, categoryID uint64, page domain.PageInput, currency string) (products []domain.Product, nextCursor string, err error) {
+	pbProducts, err := client.shopProductClient.ListProductsByCategory(context.Background(),
+		&shopproductproto.ListCategoryProductsRequest{
+			CategoryId: categoryID,
+			Sorting:    page.Sorting,
+			Limit:      page.Limit,
+			Cursor:     page.Cursor,
+			Page:       page.Page,
+			Currency:   currency,
+		})
+
+	if err != nil {
+		return nil, "", parseShopProductError(err)
+	}
+
+	return domain.ToProducts(pbProducts.GetProducts()), pbProducts.GetNextCursor(), nil
+}
+
+func (client *ShopProductClient) AdjustStock(ctx context.Context, productID uint64, userID uint64, adjustment domain.StockAdjustmentInput) (movement domain.InventoryMovement, err error) {
+	pbMovement, err := client.shopProductClient.AdjustStock(context.Background(),
+		&shopproductproto.AdjustStockRequest{
+			ProductId: productID,
+			VariantId: adjustment.VariantID,
+			UserId:    userID,
+			Delta:     adjustment.Delta,
+			Comment:   adjustment.Comment,
+		})
+
+	if err != nil {
+		return domain.InventoryMovement{}, parseShopProductError(err)
+	}
+
+	return domain.ToInventoryMovement(pbMovement), nil
+}
+
+func (client *ShopProductClient) ListInventoryMovements(ctx context.Context, productID uint64, userID uint64, page domain.PageInput) (movements []domain.InventoryMovement, nextCursor string, err error) {
+	pbMovements, err := client.shopProductClient.ListInventoryMovements(context.Background(),
+		&shopproductproto.ListMovementsRequest{
+			ProductId: productID,
+			UserId:    userID,
+			Limit:     page.Limit,
+			Cursor:    page.Cursor,
+			Page:      page.Page,
+		})
+
+	if err != nil {
+		return nil, "", parseShopProductError(err)
+	}
+
+	return domain.ToInventoryMovements(pbMovements.GetMovements()), pbMovements.GetNextCursor(), nil
+}
+
+// GetCart returns cart of user, or anonymous cart with cartToken if userID is 0.
+// Prices are also converted into currency if it is not empty
+func (client *ShopProductClient) GetCart(ctx context.Context, userID uint64, cartToken string, currency string) (cart domain.Cart, err error) {
+	pbCart, err := client.shopProductClient.GetCart(context.Background(),
+		&shopproductproto.CartRequest{
+			Owner:    &shopproductproto.CartOwner{UserId: userID, Token: cartToken},
+			Currency: currency,
+		})
+
+	if err != nil {
+		return domain.Cart{}, parseShopProductError(err)
+	}
+
+	return domain.ToCart(pbCart), nil
+}
+
+func (client *ShopProductClient) AddToCart(ctx context.Context, userID uint64, cartToken string, item domain.CartItemInput, currency string) (cart domain.Cart, err error) {
+	pbCart, err := client.shopProductClient.AddToCart(context.Background(),
+		&shopproductproto.CartItemRequest{
+			Owner:     &shopproductproto.CartOwner{UserId: userID, Token: cartToken},
+			ProductId: item.ProductID,
+			Quantity:  item.Quantity,
+			Currency:  currency,
+		})
+
+	if err != nil {
+		return domain.Cart{}, parseShopProductError(err)
+	}
+
+	return domain.ToCart(pbCart), nil
+}
+
+func (client *ShopProductClient) UpdateCartItem(ctx context.Context, userID uint64, cartToken string, item domain.CartItemInput, currency string) (cart domain.Cart, err error) {
+	pbCart, err := client.shopProductClient.UpdateCartItem(context.Background(),
+		&shopproductproto.CartItemRequest{
+			Owner:     &shopproductproto.CartOwner{UserId: userID, Token: cartToken},
+			ProductId: item.ProductID,
+			Quantity:  item.Quantity,
+			Currency:  currency,
+		})
+
+	if err != nil {
+		return domain.Cart{}, parseShopProductError(err)
+	}
+
+	return domain.ToCart(pbCart), nil
+}
+
+func (client *ShopProductClient) RemoveFromCart(ctx context.Context, userID uint64, cartToken string, productID uint64, variantID uint64, currency string) (cart domain.Cart, err error) {
+	pbCart, err := client.shopProductClient.RemoveFromCart(context.Background(),
+		&shopproductproto.CartItemRequest{
+			Owner:     &shopproductproto.CartOwner{UserId: userID, Token: cartToken},
+			ProductId: productID,
+			VariantId: variantID,
+			Currency:  currency,
+		})
+
+	if err != nil {
+		return domain.Cart{}, parseShopProductError(err)
+	}
+
+	return domain.ToCart(pbCart), nil
+}
+
+func (client *ShopProductClient) MergeCarts(ctx context.Context, cartToken string, userID uint64) (err error) {
+	_, err = client.shopProductClient.MergeCarts(context.Background(),
+		&shopproductproto.MergeCartsRequest{
+			Token:  cartToken,
+			UserId: userID,
+		})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) SearchProducts(ctx context.Context, search domain.SearchInput) (result domain.SearchResponse, err error) {
+	pbResult, err := client.shopProductClient.SearchProducts(context.Background(),
+		domain.ToPbSearchProductsRequest(search))
+
+	if err != nil {
+		return domain.SearchResponse{}, parseShopProductError(err)
+	}
+
+	return domain.ToSearchResponse(pbResult), nil
+}
+
+func (client *ShopProductClient) GetFeed(ctx context.Context, userID uint64, page domain.PageInput, currency string) (products []domain.Product, nextCursor string, err error) {
+	pbProducts, err := client.shopProductClient.GetFeed(context.Background(),
+		&shopproductproto.FeedRequest{
+			UserId:   userID,
+			Limit:    page.Limit,
+			Cursor:   page.Cursor,
+			Currency: currency,
+		})
+
+	if err != nil {
+		return nil, "", parseShopProductError(err)
+	}
+
+	return domain.ToProducts(pbProducts.GetProducts()), pbProducts.GetNextCursor(), nil
+}
+
+func (client *ShopProductClient) FollowShop(ctx context.Context, shopID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.FollowShop(context.Background(),
+		&shopproductproto.ShopFollowRequest{ShopId: shopID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) UnfollowShop(ctx context.Context, shopID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.UnfollowShop(context.Background(),
+		&shopproductproto.ShopFollowRequest{ShopId: shopID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) DeleteProduct(ctx context.Context, productID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.DeleteProduct(context.Background(),
+		&shopproductproto.DeleteProductRequest{Id: productID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) PurgeProduct(ctx context.Context, productID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.PurgeProduct(context.Background(),
+		&shopproductproto.DeleteProductRequest{Id: productID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) RestoreProduct(ctx context.Context, productID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.RestoreProduct(context.Background(),
+		&shopproductproto.RestoreProductRequest{Id: productID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) ListDeletedProducts(ctx context.Context, shopID uint64, userID uint64) (products []domain.Product, err error) {
+	pbProducts, err := client.shopProductClient.ListDeletedProducts(context.Background(),
+		&shopproductproto.ShopRequest{ShopId: shopID, UserId: userID})
+
+	if err != nil {
+		return nil, parseShopProductError(err)
+	}
+
+	return domain.ToProducts(pbProducts.GetProducts()), nil
+}
+
+func (client *ShopProductClient) DeleteShop(ctx context.Context, shopID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.DeleteShop(context.Background(),
+		&shopproductproto.ShopRequest{ShopId: shopID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) RestoreShop(ctx context.Context, shopID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.RestoreShop(context.Background(),
+		&shopproductproto.ShopRequest{ShopId: shopID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) GetRelatedProducts(ctx context.Context, productID uint64, limit uint64, currency string) (products []domain.Product, err error) {
+	pbProducts, err := client.shopProductClient.GetRelatedProducts(context.Background(),
+		&shopproductproto.RelatedProductsRequest{ProductId: productID, Limit: limit, Currency: currency})
+
+	if err != nil {
+		return nil, parseShopProductError(err)
+	}
+
+	return domain.ToProducts(pbProducts.GetProducts()), nil
+}
+
+func (client *ShopProductClient) GetShopAnalytics(ctx context.Context, shopID uint64, userID uint64, from string, to string) (analytics domain.ShopAnalytics, err error) {
+	pbAnalytics, err := client.shopProductClient.GetShopAnalytics(context.Background(),
+		&shopproductproto.ShopAnalyticsRequest{ShopId: shopID, UserId: userID, From: from, To: to})
+
+	if err != nil {
+		return domain.ShopAnalytics{}, parseShopProductError(err)
+	}
+
+	return domain.ToShopAnalytics(pbAnalytics), nil
+}
+
+func (client *ShopProductClient) CreateReview(ctx context.Context, review domain.ProductReview, userID uint64) (reviewID uint64, err error) {
+	pbReviewID, err := client.shopProductClient.CreateReview(context.Background(),
+		&shopproductproto.CreateReviewRequest{
+			ProductId: review.ProductID,
+			UserId:    userID,
+			Title:     review.Title,
+			Text:      review.Text,
+			Rating:    review.Rating,
+		})
+
+	if err != nil {
+		return 0, parseShopProductError(err)
+	}
+
+	return pbReviewID.GetId(), nil
+}
+
+func (client *ShopProductClient) EditReview(ctx context.Context, review domain.ProductReview, userID uint64) (err error) {
+	_, err = client.shopProductClient.EditReview(context.Background(),
+		&shopproductproto.EditReviewRequest{
+			Id:     review.ReviewID,
+			UserId: userID,
+			Title:  review.Title,
+			Text:   review.Text,
+			Rating: review.Rating,
+		})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) ListReviews(ctx context.Context, productID uint64, page domain.PageInput) (reviews []domain.ProductReview, nextCursor string, err error) {
+	pbReviews, err := client.shopProductClient.ListReviews(context.Background(),
+		&shopproductproto.ListReviewsRequest{
+			ProductId: productID,
+			Sorting:   page.Sorting,
+			Limit:     page.Limit,
+			Cursor:    page.Cursor,
+			Page:      page.Page,
+		})
+
+	if err != nil {
+		return nil, "", parseShopProductError(err)
+	}
+
+	return domain.ToProductReviews(pbReviews.GetReviews()), pbReviews.GetNextCursor(), nil
+}
+
+func (client *ShopProductClient) AskQuestion(ctx context.Context, productID uint64, userID uint64, text string) (questionID uint64, err error) {
+	pbQuestionID, err := client.shopProductClient.AskQuestion(context.Background(),
+		&shopproductproto.AskQuestionRequest{ProductId: productID, UserId: userID, Text: text})
+
+	if err != nil {
+		return 0, parseShopProductError(err)
+	}
+
+	return pbQuestionID.GetId(), nil
+}
+
+func (client *ShopProductClient) AnswerQuestion(ctx context.Context, questionID uint64, userID uint64, text string) (answerID uint64, err error) {
+	pbAnswerID, err := client.shopProductClient.AnswerQuestion(context.Background(),
+		&shopproductproto.AnswerQuestionRequest{QuestionId: questionID, UserId: userID, Text: text})
+
+	if err != nil {
+		return 0, parseShopProductError(err)
+	}
+
+	return pbAnswerID.GetId(), nil
+}
+
+func (client *ShopProductClient) SetOfficialAnswer(ctx context.Context, answerID uint64, userID uint64, isOfficial bool) (err error) {
+	_, err = client.shopProductClient.SetOfficialAnswer(context.Background(),
+		&shopproductproto.OfficialAnswerRequest{AnswerId: answerID, UserId: userID, IsOfficial: isOfficial})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) VoteQuestion(ctx context.Context, questionID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.VoteQuestion(context.Background(),
+		&shopproductproto.QuestionVoteRequest{QuestionId: questionID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) UnvoteQuestion(ctx context.Context, questionID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.UnvoteQuestion(context.Background(),
+		&shopproductproto.QuestionVoteRequest{QuestionId: questionID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) ListQuestions(ctx context.Context, productID uint64, viewerID uint64, page domain.PageInput, query string) (questions []domain.ProductQuestion, nextCursor string, err error) {
+	pbQuestions, err := client.shopProductClient.ListQuestions(context.Background(),
+		&shopproductproto.ListQuestionsRequest{
+			ProductId: productID,
+			UserId:    viewerID,
+			Sorting:   page.Sorting,
+			Limit:     page.Limit,
+			Cursor:    page.Cursor,
+			Page:      page.Page,
+			Query:     query,
+		})
+
+	if err != nil {
+		return nil, "", parseShopProductError(err)
+	}
+
+	return domain.ToProductQuestions(pbQuestions.GetQuestions()), pbQuestions.GetNextCursor(), nil
+}
+
+func (client *ShopProductClient) InviteShopManager(ctx context.Context, shopID uint64, userID uint64, invitedUserID uint64, role string) (invitationID uint64, err error) {
+	pbInvitationID, err := client.shopProductClient.InviteShopManager(context.Background(),
+		&shopproductproto.InviteShopManagerRequest{
+			ShopId:        shopID,
+			UserId:        userID,
+			InvitedUserId: invitedUserID,
+			Role:          role,
+		})
+
+	if err != nil {
+		return 0, parseShopProductError(err)
+	}
+
+	return pbInvitationID.GetId(), nil
+}
+
+func (client *ShopProductClient) RemoveShopManager(ctx context.Context, shopID uint64, userID uint64, managerID uint64) (err error) {
+	_, err = client.shopProductClient.RemoveShopManager(context.Background(),
+		&shopproductproto.RemoveShopManagerRequest{ShopId: shopID, UserId: userID, ManagerId: managerID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) GetShopInvitations(ctx context.Context, userID uint64) (invitations []domain.ShopInvitation, err error) {
+	pbInvitations, err := client.shopProductClient.GetShopInvitations(context.Background(),
+		&shopproductproto.UserRequest{UserId: userID})
+
+	if err != nil {
+		return nil, parseShopProductError(err)
+	}
+
+	return domain.ToShopInvitations(pbInvitations), nil
+}
+
+func (client *ShopProductClient) AcceptShopInvitation(ctx context.Context, invitationID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.AcceptShopInvitation(context.Background(),
+		&shopproductproto.InvitationRequest{Id: invitationID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) DeclineShopInvitation(ctx context.Context, invitationID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.DeclineShopInvitation(context.Background(),
+		&shopproductproto.InvitationRequest{Id: invitationID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+// UploadProductImage streams image to shopProduct service in chunks of ImageChunkSize
+func (client *ShopProductClient) UploadProductImage(ctx context.Context, productID uint64, userID uint64, image io.Reader) (productImage domain.ProductImage, err error) {
+	stream, err := client.shopProductClient.UploadProductImage(context.Background())
+	if err != nil {
+		return domain.ProductImage{}, parseShopProductError(err)
+	}
+
+	err = stream.Send(&shopproductproto.UploadImageRequest{
+		Data: &shopproductproto.UploadImageRequest_Info{
+			Info: &shopproductproto.ImageInfo{ProductId: productID, UserId: userID},
+		},
+	})
+	if err != nil {
+		return domain.ProductImage{}, parseShopProductError(err)
+	}
+
+	buffer := make([]byte, domain.ImageChunkSize)
+	for {
+		n, readErr := image.Read(buffer)
+		if n > 0 {
+			err = stream.Send(&shopproductproto.UploadImageRequest{
+				Data: &shopproductproto.UploadImageRequest_ChunkData{ChunkData: buffer[:n]},
+			})
+			if err != nil {
+				break // Actual error is returned by CloseAndRecv
+			}
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return domain.ProductImage{}, errors.Wrap(readErr, "shopProduct client error: ")
+		}
+	}
+
+	pbImage, err := stream.CloseAndRecv()
+	if err != nil {
+		return domain.ProductImage{}, parseShopProductError(err)
+	}
+
+	return domain.ToProductImage(pbImage), nil
+}
+
+func (client *ShopProductClient) GetProductImages(ctx context.Context, productID uint64) (images []domain.ProductImage, err error) {
+	pbImages, err := client.shopProductClient.GetProductImages(context.Background(),
+		&shopproductproto.GetProductRequest{Id: productID})
+
+	if err != nil {
+		return nil, parseShopProductError(err)
+	}
+
+	return domain.ToProductImages(pbImages.GetImages()), nil
+}
+
+func (client *ShopProductClient) ReorderProductImages(ctx context.Context, productID uint64, userID uint64, imageIDs []uint64) (err error) {
+	_, err = client.shopProductClient.ReorderProductImages(context.Background(),
+		&shopproductproto.ReorderImagesRequest{ProductId: productID, UserId: userID, ImageIds: imageIDs})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) SetPrimaryProductImage(ctx context.Context, productID uint64, imageID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.SetPrimaryProductImage(context.Background(),
+		&shopproductproto.ProductImageRequest{ProductId: productID, ImageId: imageID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) DeleteProductImage(ctx context.Context, productID uint64, imageID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.DeleteProductImage(context.Background(),
+		&shopproductproto.ProductImageRequest{ProductId: productID, ImageId: imageID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) SetProductOptions(ctx context.Context, productID uint64, userID uint64, options []domain.ProductOption) (err error) {
+	_, err = client.shopProductClient.SetProductOptions(context.Background(),
+		&shopproductproto.SetProductOptionsRequest{
+			ProductId: productID,
+			UserId:    userID,
+			Options:   domain.ToPbProductOptions(options),
+		})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) CreateVariant(ctx context.Context, productID uint64, userID uint64, variant domain.ProductVariant) (variantID uint64, err error) {
+	variant.VariantID = 0
+	pbVariant, err := client.shopProductClient.CreateVariant(context.Background(),
+		domain.ToPbEditVariantRequest(productID, variant, userID))
+
+	if err != nil {
+		return 0, parseShopProductError(err)
+	}
+
+	return pbVariant.GetId(), nil
+}
+
+func (client *ShopProductClient) EditVariant(ctx context.Context, productID uint64, userID uint64, variant domain.ProductVariant) (err error) {
+	_, err = client.shopProductClient.EditVariant(context.Background(),
+		domain.ToPbEditVariantRequest(productID, variant, userID))
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) DeleteVariant(ctx context.Context, productID uint64, variantID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.DeleteVariant(context.Background(),
+		&shopproductproto.VariantRequest{ProductId: productID, VariantId: variantID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) CreateProductSale(ctx context.Context, productID uint64, userID uint64, sale domain.ProductSale) (saleID uint64, err error) {
+	sale.ProductID = productID
+	response, err := client.shopProductClient.CreateProductSale(context.Background(),
+		&shopproductproto.CreateSaleRequest{UserId: userID, Sale: domain.ToPbProductSale(sale)})
+
+	if err != nil {
+		return 0, parseShopProductError(err)
+	}
+
+	return response.GetId(), nil
+}
+
+func (client *ShopProductClient) ListProductSales(ctx context.Context, productID uint64, userID uint64) (sales []domain.ProductSale, err error) {
+	pbSales, err := client.shopProductClient.ListProductSales(context.Background(),
+		&shopproductproto.SaleRequest{ProductId: productID, UserId: userID})
+
+	if err != nil {
+		return nil, parseShopProductError(err)
+	}
+
+	return domain.ToProductSales(pbSales.GetSales()), nil
+}
+
+func (client *ShopProductClient) DeleteProductSale(ctx context.Context, productID uint64, saleID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.DeleteProductSale(context.Background(),
+		&shopproductproto.SaleRequest{ProductId: productID, SaleId: saleID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) CreateShopDiscount(ctx context.Context, shopID uint64, userID uint64, discount domain.ShopDiscount) (discountID uint64, err error) {
+	discount.ShopID = shopID
+	response, err := client.shopProductClient.CreateShopDiscount(context.Background(),
+		&shopproductproto.CreateShopDiscountRequest{UserId: userID, Discount: domain.ToPbShopDiscount(discount)})
+
+	if err != nil {
+		return 0, parseShopProductError(err)
+	}
+
+	return response.GetId(), nil
+}
+
+func (client *ShopProductClient) ListShopDiscounts(ctx context.Context, shopID uint64, userID uint64) (discounts []domain.ShopDiscount, err error) {
+	pbDiscounts, err := client.shopProductClient.ListShopDiscounts(context.Background(),
+		&shopproductproto.ShopPricingRequest{ShopId: shopID, UserId: userID})
+
+	if err != nil {
+		return nil, parseShopProductError(err)
+	}
+
+	return domain.ToShopDiscounts(pbDiscounts.GetDiscounts()), nil
+}
+
+func (client *ShopProductClient) DeleteShopDiscount(ctx context.Context, shopID uint64, discountID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.DeleteShopDiscount(context.Background(),
+		&shopproductproto.ShopDiscountRequest{ShopId: shopID, DiscountId: discountID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) CreatePromoCode(ctx context.Context, shopID uint64, userID uint64, promoCode domain.PromoCode) (promoCodeID uint64, err error) {
+	promoCode.ShopID = shopID
+	response, err := client.shopProductClient.CreatePromoCode(context.Background(),
+		&shopproductproto.CreatePromoCodeRequest{UserId: userID, PromoCode: domain.ToPbPromoCode(promoCode)})
+
+	if err != nil {
+		return 0, parseShopProductError(err)
+	}
+
+	return response.GetId(), nil
+}
+
+func (client *ShopProductClient) ListPromoCodes(ctx context.Context, shopID uint64, userID uint64) (promoCodes []domain.PromoCode, err error) {
+	pbPromoCodes, err := client.shopProductClient.ListPromoCodes(context.Background(),
+		&shopproductproto.ShopPricingRequest{ShopId: shopID, UserId: userID})
+
+	if err != nil {
+		return nil, parseShopProductError(err)
+	}
+
+	return domain.ToPromoCodes(pbPromoCodes.GetPromoCodes()), nil
+}
+
+func (client *ShopProductClient) DeletePromoCode(ctx context.Context, shopID uint64, promoCodeID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.DeletePromoCode(context.Background(),
+		&shopproductproto.PromoCodeRequest{ShopId: shopID, PromoCodeId: promoCodeID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) ListPriceHistory(ctx context.Context, productID uint64, page domain.PageInput) (changes []domain.PriceChange, nextCursor string, err error) {
+	pbHistory, err := client.shopProductClient.ListPriceHistory(context.Background(),
+		&shopproductproto.ListPriceHistoryRequest{
+			ProductId: productID,
+			Limit:     page.Limit,
+			Cursor:    page.Cursor,
+			Page:      page.Page,
+		})
+
+	if err != nil {
+		return nil, "", parseShopProductError(err)
+	}
+
+	return domain.ToPriceChanges(pbHistory.GetChanges()), pbHistory.GetNextCursor(), nil
+}
+
+func (client *ShopProductClient) ListWishlists(ctx context.Context, ownerID uint64, viewerID uint64) (wishlists []domain.Wishlist, err error) {
+	pbWishlists, err := client.shopProductClient.ListWishlists(context.Background(),
+		&shopproductproto.ListWishlistsRequest{OwnerId: ownerID, UserId: viewerID})
+
+	if err != nil {
+		return nil, parseShopProductError(err)
+	}
+
+	return domain.ToWishlists(pbWishlists.GetWishlists()), nil
+}
+
+func (client *ShopProductClient) GetWishlist(ctx context.Context, wishlistID uint64, viewerID uint64, currency string) (wishlist domain.Wishlist, err error) {
+	pbWishlist, err := client.shopProductClient.GetWishlist(context.Background(),
+		&shopproductproto.WishlistRequest{Id: wishlistID, UserId: viewerID, Currency: currency})
+
+	if err != nil {
+		return domain.Wishlist{}, parseShopProductError(err)
+	}
+
+	return domain.ToWishlist(pbWishlist), nil
+}
+
+func (client *ShopProductClient) GetSharedWishlist(ctx context.Context, token string, viewerID uint64, currency string) (wishlist domain.Wishlist, err error) {
+	pbWishlist, err := client.shopProductClient.GetWishlist(context.Background(),
+		&shopproductproto.WishlistRequest{Token: token, UserId: viewerID, Currency: currency})
+
+	if err != nil {
+		return domain.Wishlist{}, parseShopProductError(err)
+	}
+
+	return domain.ToWishlist(pbWishlist), nil
+}
+
+func (client *ShopProductClient) CreateWishlist(ctx context.Context, userID uint64, wishlist domain.WishlistInput) (wishlistID uint64, err error) {
+	response, err := client.shopProductClient.CreateWishlist(context.Background(),
+		&shopproductproto.EditWishlistRequest{
+			UserId:   userID,
+			Title:    wishlist.Title,
+			IsPublic: wishlist.IsPublic,
+		})
+
+	if err != nil {
+		return 0, parseShopProductError(err)
+	}
+
+	return response.GetId(), nil
+}
+
+func (client *ShopProductClient) EditWishlist(ctx context.Context, wishlistID uint64, userID uint64, wishlist domain.WishlistInput) (err error) {
+	_, err = client.shopProductClient.EditWishlist(context.Background(),
+		&shopproductproto.EditWishlistRequest{
+			Id:       wishlistID,
+			UserId:   userID,
+			Title:    wishlist.Title,
+			IsPublic: wishlist.IsPublic,
+		})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) DeleteWishlist(ctx context.Context, wishlistID uint64, userID uint64) (err error) {
+	_, err = client.shopProductClient.DeleteWishlist(context.Background(),
+		&shopproductproto.WishlistRequest{Id: wishlistID, UserId: userID})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+// SaveProduct saves product to user's wishlist, or to default one if wishlistID is 0
+func (client *ShopProductClient) SaveProduct(ctx context.Context, productID uint64, userID uint64, wishlistID uint64) (savedTo uint64, err error) {
+	response, err := client.shopProductClient.SaveProduct(context.Background(),
+		&shopproductproto.SaveProductRequest{
+			ProductId:  productID,
+			UserId:     userID,
+			WishlistId: wishlistID,
+		})
+
+	if err != nil {
+		return 0, parseShopProductError(err)
+	}
+
+	return response.GetId(), nil
+}
+
+// UnsaveProduct removes product from user's wishlist, or from all of them if wishlistID is 0
+func (client *ShopProductClient) UnsaveProduct(ctx context.Context, productID uint64, userID uint64, wishlistID uint64) (err error) {
+	_, err = client.shopProductClient.UnsaveProduct(context.Background(),
+		&shopproductproto.SaveProductRequest{
+			ProductId:  productID,
+			UserId:     userID,
+			WishlistId: wishlistID,
+		})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+func (client *ShopProductClient) GetExchangeRates(ctx context.Context) (rates domain.ExchangeRates, err error) {
+	pbRates, err := client.shopProductClient.GetExchangeRates(context.Background(),
+		&shopproductproto.ExchangeRatesRequest{})
+
+	if err != nil {
+		return domain.ExchangeRates{}, parseShopProductError(err)
+	}
+
+	return domain.ToExchangeRates(pbRates), nil
+}
+
+func (client *ShopProductClient) UpdateExchangeRates(ctx context.Context, rates domain.ExchangeRates, userID uint64) (err error) {
+	_, err = client.shopProductClient.UpdateExchangeRates(context.Background(),
+		&shopproductproto.UpdateExchangeRatesRequest{
+			UserId: userID,
+			Rates:  domain.ToPbExchangeRates(rates),
+		})
+
+	if err != nil {
+		return parseShopProductError(err)
+	}
+
+	return nil
+}
+
+// ImportProducts streams catalog file to shopProduct service in chunks of CatalogChunkSize
+func (client *ShopProductClient) ImportProducts(ctx context.Context, shopID uint64, userID uint64, format string, dryRun bool, catalog io.Reader) (productImport domain.ProductImport, err error) {
+	stream, err := client.shopProductClient.ImportProducts(context.Background())
+	if err != nil {
+		return domain.ProductImport{}, parseShopProductError(err)
+	}
+
+	err = stream.Send(&shopproductproto.ImportProductsRequest{
+		Data: &shopproductproto.ImportProductsRequest_Info{
+			Info: &shopproductproto.ImportInfo{ShopId: shopID, UserId: userID, Format: format, DryRun: dryRun},
+		},
+	})
+	if err != nil {
+		return domain.ProductImport{}, parseShopProductError(err)
+	}
+
+	buffer := make([]byte, domain.CatalogChunkSize)
+	for {
+		n, readErr := catalog.Read(buffer)
+		if n > 0 {
+			err = stream.Send(&shopproductproto.ImportProductsRequest{
+				Data: &shopproductproto.ImportProductsRequest_ChunkData{ChunkData: buffer[:n]},
+			})
+			if err != nil {
+				break // Actual error is returned by CloseAndRecv
+			}
+		}
+
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return domain.ProductImport{}, errors.Wrap(readErr, "shopProduct client error: ")
+		}
+	}
+
+	pbImport, err := stream.CloseAndRecv()
+	if err != nil {
+		return domain.ProductImport{}, parseShopProductError(err)
+	}
+
+	return domain.ToProductImport(pbImport), nil
+}
+
+func (client *ShopProductClient) GetProductImport(ctx context.Context, shopID uint64, importID uint64, userID uint64) (productImport domain.ProductImport, err error) {
+	pbImport, err := client.shopProductClient.GetProductImport(context.Background(),
+		&shopproductproto.ProductImportRequest{
+			ShopId:   shopID,
+			ImportId: importID,
+			UserId:   userID,
+		})
+
+	if err != nil {
+		return domain.ProductImport{}, parseShopProductError(err)
+	}
+
+	return domain.ToProductImport(pbImport), nil
+}
+
+// ExportProducts returns reader of catalog file which is streamed from shopProduct service.
+// First chunk is received here, so that errors are returned before anything is written to response
+func (client *ShopProductClient) ExportProducts(ctx context.Context, shopID uint64, userID uint64, format string) (catalog io.Reader, err error) {
+	stream, err := client.shopProductClient.ExportProducts(ctx, &shopproductproto.ExportProductsRequest{
+		ShopId: shopID,
+		UserId: userID,
+		Format: format,
+	})
+	if err != nil {
+		return nil, parseShopProductError(err)
+	}
+
+	firstChunk, err := stream.Recv()
+	if err != nil {
+		return nil, parseShopProductError(err)
+	}
+
+	return &catalogReader{stream: stream, buffer: firstChunk.GetChunkData()}, nil
+}
+
+// GetMarketplaceFeed returns reader of feed which is streamed from shopProduct service, shopID 0 means feed
+// of the whole catalog. First chunk is received here, same as in ExportProducts
+func (client *ShopProductClient) GetMarketplaceFeed(ctx context.Context, shopID uint64, format string) (feed io.Reader, err error) {
+	stream, err := client.shopProductClient.GetMarketplaceFeed(ctx, &shopproductproto.MarketplaceFeedRequest{
+		ShopId: shopID,
+		Format: format,
+	})
+	if err != nil {
+		return nil, parseShopProductError(err)
+	}
+
+	firstChunk, err := stream.Recv()
+	if err != nil {
+		return nil, parseShopProductError(err)
+	}
+
+	return &catalogReader{stream: stream, buffer: firstChunk.GetChunkData()}, nil
+}
+
+// catalogReader reads exported catalog or feed chunk by chunk from grpc stream
+type catalogReader struct {
+	stream interface {
+		Recv() (*shopproductproto.CatalogChunk, error)
+	}
+	buffer []byte
+}
+
+func (reader *catalogReader) Read(p []byte) (n int, err error) {
+	for len(reader.buffer) == 0 {
+		chunk, err := reader.stream.Recv()
+		if err != nil {
+			return 0, err // Includes io.EOF
+		}
+		reader.buffer = chunk.GetChunkData()
+	}
+
+	n = copy(p, reader.buffer)
+	reader.buffer = reader.buffer[n:]
+	return n, nil
+}
+
+// parseShopProductError converts errors returned by shopProduct service to gateway's errors
+func parseShopProductError(err error) error {
+	switch {
+	// Missing cart item is checked before missing product, as its message contains message about missing product
+	case strings.Contains(err.Error(), shopproductdomain.CartItemNotFoundError.Error()):
+		return domain.ErrCartItemNotFound
+	case strings.Contains(err.Error(), shopproductdomain.ShopNotFoundError.Error()):
+		return domain.ErrShopNotFound
+	case strings.Contains(err.Error(), shopproductdomain.ProductNotFoundError.Error()):
+		return domain.ErrProductNotFound
+	case strings.Contains(err.Error(), shopproductdomain.InvalidSaleError.Error()):
+		return domain.ErrInvalidSale
+	case strings.Contains(err.Error(), shopproductdomain.SaleNotFoundError.Error()):
+		return domain.ErrSaleNotFound
+	case strings.Contains(err.Error(), shopproductdomain.InvalidDiscountError.Error()):
+		return domain.ErrInvalidDiscount
+	case strings.Contains(err.Error(), shopproductdomain.DiscountNotFoundError.Error()):
+		return domain.ErrDiscountNotFound
+	case strings.Contains(err.Error(), shopproductdomain.InvalidPromoCodeError.Error()):
+		return domain.ErrInvalidPromoCode
+	case strings.Contains(err.Error(), shopproductdomain.PromoCodeExistsError.Error()):
+		return domain.ErrPromoCodeExists
+	case strings.Contains(err.Error(), shopproductdomain.PromoCodeNotFoundError.Error()):
+		return domain.ErrPromoCodeNotFound
+	case strings.Contains(err.Error(), shopproductdomain.EmptyTitleError.Error()):
+		return domain.ErrEmptyTitle
+	case strings.Contains(err.Error(), shopproductdomain.NotShopManagerError.Error()):
+		return domain.ErrNotShopManager
+	case strings.Contains(err.Error(), shopproductdomain.NotShopOwnerError.Error()):
+		return domain.ErrNotShopOwner
+	case strings.Contains(err.Error(), shopproductdomain.AlreadyManagerError.Error()):
+		return domain.ErrAlreadyManager
+	case strings.Contains(err.Error(), shopproductdomain.LastOwnerError.Error()):
+		return domain.ErrLastOwner
+	case strings.Contains(err.Error(), shopproductdomain.InvalidRoleError.Error()):
+		return domain.ErrInvalidRole
+	case strings.Contains(err.Error(), shopproductdomain.InvitationNotFoundError.Error()):
+		return domain.ErrInvitationNotFound
+	case strings.Contains(err.Error(), shopproductdomain.InvalidImageError.Error()):
+		return domain.ErrInvalidImage
+	case strings.Contains(err.Error(), shopproductdomain.ImageTooLargeError.Error()):
+		return domain.ErrImageTooLarge
+	case strings.Contains(err.Error(), shopproductdomain.TooManyImagesError.Error()):
+		return domain.ErrTooManyImages
+	case strings.Contains(err.Error(), shopproductdomain.ImageNotFoundError.Error()):
+		return domain.ErrImageNotFound
+	case strings.Contains(err.Error(), shopproductdomain.InvalidImageOrderError.Error()):
+		return domain.ErrInvalidImageOrder
+	case strings.Contains(err.Error(), shopproductdomain.InvalidSortingError.Error()):
+		return domain.ErrInvalidSorting
+	case strings.Contains(err.Error(), shopproductdomain.InvalidCursorError.Error()):
+		return domain.ErrInvalidCursor
+	case strings.Contains(err.Error(), shopproductdomain.ShopFollowNotFoundError.Error()):
+		return domain.ErrShopFollowNotFound
+	case strings.Contains(err.Error(), shopproductdomain.InvalidRatingError.Error()):
+		return domain.ErrInvalidRating
+	case strings.Contains(err.Error(), shopproductdomain.ReviewTitleTooLongError.Error()):
+		return domain.ErrReviewTitleTooLong
+	case strings.Contains(err.Error(), shopproductdomain.ReviewExistsError.Error()):
+		return domain.ErrReviewExists
+	case strings.Contains(err.Error(), shopproductdomain.ReviewNotFoundError.Error()):
+		return domain.ErrReviewNotFound
+	case strings.Contains(err.Error(), shopproductdomain.NotReviewAuthorError.Error()):
+		return domain.ErrNotReviewAuthor
+	case strings.Contains(err.Error(), shopproductdomain.OwnProductReviewError.Error()):
+		return domain.ErrOwnProductReview
+	case strings.Contains(err.Error(), shopproductdomain.InvalidSearchRangeError.Error()):
+		return domain.ErrInvalidSearchRange
+	case strings.Contains(err.Error(), shopproductdomain.NotAdminError.Error()):
+		return domain.ErrNotAdmin
+	case strings.Contains(err.Error(), shopproductdomain.CategoryNotFoundError.Error()):
+		return domain.ErrCategoryNotFound
+	case strings.Contains(err.Error(), shopproductdomain.InvalidSlugError.Error()):
+		return domain.ErrInvalidSlug
+	case strings.Contains(err.Error(), shopproductdomain.SlugExistsError.Error()):
+		return domain.ErrSlugExists
+	case strings.Contains(err.Error(), shopproductdomain.InvalidCategoryNameError.Error()):
+		return domain.ErrInvalidCategoryName
+	case strings.Contains(err.Error(), shopproductdomain.CategoryCycleError.Error()):
+		return domain.ErrCategoryCycle
+	case strings.Contains(err.Error(), shopproductdomain.CategoryNotEmptyError.Error()):
+		return domain.ErrCategoryNotEmpty
+	case strings.Contains(err.Error(), shopproductdomain.OutOfStockError.Error()):
+		return domain.ErrOutOfStock
+	case strings.Contains(err.Error(), shopproductdomain.InvalidQuantityError.Error()):
+		return domain.ErrInvalidQuantity
+	case strings.Contains(err.Error(), shopproductdomain.StockBelowReservedError.Error()):
+		return domain.ErrStockBelowReserved
+	case strings.Contains(err.Error(), shopproductdomain.StockCommentTooLongError.Error()):
+		return domain.ErrStockCommentTooLong
+	case strings.Contains(err.Error(), shopproductdomain.ReservationNotFoundError.Error()):
+		return domain.ErrReservationNotFound
+	case strings.Contains(err.Error(), shopproductdomain.ReservationNotActiveError.Error()):
+		return domain.ErrReservationNotActive
+	case strings.Contains(err.Error(), shopproductdomain.CartQuantityTooLargeError.Error()):
+		return domain.ErrCartQuantityTooLarge
+	case strings.Contains(err.Error(), shopproductdomain.WishlistNotFoundError.Error()):
+		return domain.ErrWishlistNotFound
+	case strings.Contains(err.Error(), shopproductdomain.InvalidWishlistTitleError.Error()):
+		return domain.ErrInvalidWishlistTitle
+	case strings.Contains(err.Error(), shopproductdomain.TooManyWishlistsError.Error()):
+		return domain.ErrTooManyWishlists
+	case strings.Contains(err.Error(), shopproductdomain.DefaultWishlistError.Error()):
+		return domain.ErrDefaultWishlist
+	case strings.Contains(err.Error(), shopproductdomain.WishlistItemNotFoundError.Error()):
+		return domain.ErrWishlistItemNotFound
+	case strings.Contains(err.Error(), shopproductdomain.InvalidOptionsError.Error()):
+		return domain.ErrInvalidOptions
+	case strings.Contains(err.Error(), shopproductdomain.OptionsInUseError.Error()):
+		return domain.ErrOptionsInUse
+	case strings.Contains(err.Error(), shopproductdomain.InvalidVariantOptionsError.Error()):
+		return domain.ErrInvalidVariantOption
+	case strings.Contains(err.Error(), shopproductdomain.InvalidSKUError.Error()):
+		return domain.ErrInvalidSKU
+	case strings.Contains(err.Error(), shopproductdomain.DuplicateVariantError.Error()):
+		return domain.ErrDuplicateVariant
+	case strings.Contains(err.Error(), shopproductdomain.TooManyVariantsError.Error()):
+		return domain.ErrTooManyVariants
+	case strings.Contains(err.Error(), shopproductdomain.VariantNotFoundError.Error()):
+		return domain.ErrVariantNotFound
+	case strings.Contains(err.Error(), shopproductdomain.VariantRequiredError.Error()):
+		return domain.ErrVariantRequired
+	case strings.Contains(err.Error(), shopproductdomain.VariantReservedError.Error()):
+		return domain.ErrVariantReserved
+	case strings.Contains(err.Error(), shopproductdomain.InvalidCurrencyError.Error()):
+		return domain.ErrInvalidCurrency
+	case strings.Contains(err.Error(), shopproductdomain.CurrencyMismatchError.Error()):
+		return domain.ErrCurrencyMismatch
+	case strings.Contains(err.Error(), shopproductdomain.CurrencyInUseError.Error()):
+		return domain.ErrCurrencyInUse
+	case strings.Contains(err.Error(), shopproductdomain.ExchangeRateNotFoundError.Error()):
+		return domain.ErrExchangeRateNotFound
+	case strings.Contains(err.Error(), shopproductdomain.InvalidExchangeRatesError.Error()):
+		return domain.ErrInvalidExchangeRates
+	case strings.Contains(err.Error(), shopproductdomain.DuplicateProductSKUError.Error()):
+		return domain.ErrDuplicateProductSKU
+	case strings.Contains(err.Error(), shopproductdomain.InvalidCatalogFormatError.Error()):
+		return domain.ErrInvalidCatalogFormat
+	case strings.Contains(err.Error(), shopproductdomain.InvalidImportFileError.Error()):
+		return domain.ErrInvalidImportFile
+	case strings.Contains(err.Error(), shopproductdomain.ImportFileTooLargeError.Error()):
+		return domain.ErrImportFileTooLarge
+	case strings.Contains(err.Error(), shopproductdomain.TooManyImportRowsError.Error()):
+		return domain.ErrTooManyImportRows
+	case strings.Contains(err.Error(), shopproductdomain.ImportNotFoundError.Error()):
+		return domain.ErrImportNotFound
+	case strings.Contains(err.Error(), shopproductdomain.InvalidFeedFormatError.Error()):
+		return domain.ErrInvalidFeedFormat
+	case strings.Contains(err.Error(), shopproductdomain.RestoreExpiredError.Error()):
+		return domain.ErrRestoreExpired
+	case strings.Contains(err.Error(), shopproductdomain.ShopDeletedError.Error()):
+		return domain.ErrShopDeleted
+	case strings.Contains(err.Error(), shopproductdomain.InvalidDateRangeError.Error()):
+		return domain.ErrInvalidDateRange
+	case strings.Contains(err.Error(), shopproductdomain.EmptyQuestionTextError.Error()):
+		return domain.ErrEmptyQuestionText
+	case strings.Contains(err.Error(), shopproductdomain.QuestionTextTooLongError.Error()):
+		return domain.ErrQuestionTextTooLong
+	case strings.Contains(err.Error(), shopproductdomain.QuestionNotFoundError.Error()):
+		return domain.ErrQuestionNotFound
+	case strings.Contains(err.Error(), shopproductdomain.AnswerNotFoundError.Error()):
+		return domain.ErrAnswerNotFound
+	case strings.Contains(err.Error(), shopproductdomain.NotAllowedToAnswerError.Error()):
+		return domain.ErrNotAllowedToAnswer
+	case strings.Contains(err.Error(), shopproductdomain.BlockedUserError.Error()):
+		return domain.ErrBlockedUser
+	default:
+		return errors.Wrap(err, "shopProduct client error: ")
+	}
+}
@@ -1,7 +1,9 @@
 package user
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"pinterest/domain"
 	userdomain "pinterest/services/user/domain"
 	userproto "pinterest/services/user/proto"
@@ -13,9 +15,24 @@ import (
 type UserClientInterface interface {
 	CreateUser(ctx context.Context, user domain.User) (userID uint64, err error)
 	EditUser(ctx context.Context, user domain.User) (err error)
-	GetUserByID(ctx context.Context, userID uint64) (user domain.User, err error)
-	GetUserByUsername(ctx context.Context, username string) (user domain.User, err error)
-	GetUsers(ctx context.Context) (users []domain.User, err error)
+	GetUserByID(ctx context.Context, userID uint64, viewerID uint64) (user domain.User, err error)
+	GetUserByUsername(ctx context.Context, username string, viewerID uint64) (user domain.User, err error)
+	GetUsers(ctx context.Context, viewerID uint64) (users []domain.User, err error)
+	SearchUsers(ctx context.Context, keyWords string, viewerID uint64) (users []domain.User, err error)
+	GetFollowers(ctx context.Context, userID uint64, viewerID uint64) (users []domain.User, err error)
+	GetFollowed(ctx context.Context, userID uint64, viewerID uint64) (users []domain.User, err error)
+	GetPrivacySettings(ctx context.Context, userID uint64) (settings domain.PrivacySettings, err error)
+	EditPrivacySettings(ctx context.Context, userID uint64, settings domain.PrivacySettings) (err error)
+	RequestDataExport(ctx context.Context, userID uint64) (export domain.DataExport, err error)
+	GetDataExport(ctx context.Context, userID uint64, exportID uint64) (export domain.DataExport, err error)
+	DownloadDataExport(ctx context.Context, token string) (archive io.Reader, err error)
+	BlockUser(ctx context.Context, userID uint64, targetID uint64) (err error)
+	UnblockUser(ctx context.Context, userID uint64, targetID uint64) (err error)
+	MuteUser(ctx context.Context, userID uint64, targetID uint64) (err error)
+	UnmuteUser(ctx context.Context, userID uint64, targetID uint64) (err error)
+	GetBlockedUsers(ctx context.Context, userID uint64) (users []domain.User, err error)
+	GetMutedUsers(ctx context.Context, userID uint64) (users []domain.User, err error)
+	IsBlocked(ctx context.Context, pairs []domain.UserPair) (blocked []bool, muted []bool, err error)
 }
 
 type UserClient struct {
@@ -52,9 +69,9 @@ func (client *UserClient) EditUser(ctx context.Context, user domain.User) (err e
 	return nil
 }
 
-func (client *UserClient) GetUserByID(ctx context.Context, userID uint64) (user domain.User, err error) {
+func (client *UserClient) GetUserByID(ctx context.Context, userID uint64, viewerID uint64) (user domain.User, err error) {
 	pbUser, err := client.userClient.GetUserByID(context.Background(),
-		&userproto.UserID{Uid: userID})
+		&userproto.UserViewInput{UserID: userID, ViewerID: viewerID})
 
 	if err != nil {
 		if strings.Contains(err.Error(), userdomain.UserNotFoundError.Error()) {
@@ -66,9 +83,9 @@ func (client *UserClient) GetUserByID(ctx context.Context, userID uint64) (user
 	return *domain.ToUser(pbUser), nil
 }
 
-func (client *UserClient) GetUserByUsername(ctx context.Context, username string) (user domain.User, err error) {
+func (client *UserClient) GetUserByUsername(ctx context.Context, username string, viewerID uint64) (user domain.User, err error) {
 	pbUser, err := client.userClient.GetUserByUsername(context.Background(),
-		&userproto.Username{Username: username})
+		&userproto.UsernameViewInput{Username: username, ViewerID: viewerID})
 
 	if err != nil {
 		if strings.Contains(err.Error(), userdomain.UserNotFoundError.Error()) {
@@ -80,17 +97,258 @@ func (client *UserClient) GetUserByUsername(ctx context.Context, username string
 	return *domain.ToUser(pbUser), nil
 }
 
-func (client *UserClient) GetUsers(ctx context.Context) (users []domain.User, err error) {
-	pbUsers, err := client.userClient.GetUsers(context.Background(), &userproto.Empty{})
+func (client *UserClient) GetUsers(ctx context.Context, viewerID uint64) (users []domain.User, err error) {
+	pbUsers, err := client.userClient.GetUsers(context.Background(), &userproto.ViewerInput{ViewerID: viewerID})
 
 	if err != nil {
 		return nil, errors.Wrap(err, "user client error: ")
 	}
 
-	users = make([]domain.User, 0, len(pbUsers.GetUsers()))
+	return toUsers(pbUsers), nil
+}
+
+func (client *UserClient) SearchUsers(ctx context.Context, keyWords string, viewerID uint64) (users []domain.User, err error) {
+	pbUsers, err := client.userClient.SearchUsers(context.Background(),
+		&userproto.SearchInput{KeyWords: keyWords, ViewerID: viewerID})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "user client error: ")
+	}
+
+	return toUsers(pbUsers), nil
+}
+
+func (client *UserClient) GetFollowers(ctx context.Context, userID uint64, viewerID uint64) (users []domain.User, err error) {
+	pbUsers, err := client.userClient.GetFollowers(context.Background(),
+		&userproto.UserViewInput{UserID: userID, ViewerID: viewerID})
+
+	if err != nil {
+		return nil, parseFollowersError(err)
+	}
+
+	return toUsers(pbUsers), nil
+}
+
+func (client *UserClient) GetFollowed(ctx context.Context, userID uint64, viewerID uint64) (users []domain.User, err error) {
+	pbUsers, err := client.userClient.GetFollowed(context.Background(),
+		&userproto.UserViewInput{UserID: userID, ViewerID: viewerID})
+
+	if err != nil {
+		return nil, parseFollowersError(err)
+	}
+
+	return toUsers(pbUsers), nil
+}
+
+func parseFollowersError(err error) error {
+	switch {
+	case strings.Contains(err.Error(), userdomain.UserNotFoundError.Error()):
+		return domain.ErrUserNotFound
+	case strings.Contains(err.Error(), userdomain.FollowersHiddenError.Error()):
+		return domain.ErrFollowersHidden
+	default:
+		return errors.Wrap(err, "user client error: ")
+	}
+}
+
+func (client *UserClient) GetPrivacySettings(ctx context.Context, userID uint64) (settings domain.PrivacySettings, err error) {
+	pbSettings, err := client.userClient.GetPrivacySettings(context.Background(),
+		&userproto.UserID{Uid: userID})
+
+	if err != nil {
+		if strings.Contains(err.Error(), userdomain.UserNotFoundError.Error()) {
+			return domain.PrivacySettings{}, domain.ErrUserNotFound
+		}
+		return domain.PrivacySettings{}, errors.Wrap(err, "user client error: ")
+	}
+
+	return domain.ToPrivacySettings(pbSettings), nil
+}
+
+func (client *UserClient) EditPrivacySettings(ctx context.Context, userID uint64, settings domain.PrivacySettings) (err error) {
+	_, err = client.userClient.EditPrivacySettings(context.Background(),
+		&userproto.PrivacySettingsInput{UserID: userID, Settings: domain.ToPbPrivacySettings(settings)})
+
+	if err != nil {
+		if strings.Contains(err.Error(), userdomain.UserNotFoundError.Error()) {
+			return domain.ErrUserNotFound
+		}
+		return errors.Wrap(err, "user client error: ")
+	}
+
+	return nil
+}
+
+func (client *UserClient) RequestDataExport(ctx context.Context, userID uint64) (export domain.DataExport, err error) {
+	pbExport, err := client.userClient.RequestDataExport(context.Background(),
+		&userproto.UserID{Uid: userID})
+
+	if err != nil {
+		return domain.DataExport{}, errors.Wrap(err, "user client error: ")
+	}
+
+	return domain.ToDataExport(pbExport), nil
+}
+
+func (client *UserClient) GetDataExport(ctx context.Context, userID uint64, exportID uint64) (export domain.DataExport, err error) {
+	pbExport, err := client.userClient.GetDataExport(context.Background(),
+		&userproto.DataExportInput{UserID: userID, ExportID: exportID})
+
+	if err != nil {
+		return domain.DataExport{}, parseExportError(err)
+	}
+
+	return domain.ToDataExport(pbExport), nil
+}
+
+// DownloadDataExport returns reader of archive which is streamed from user service.
+// First chunk is received immediately, so that errors like expired token are returned before anything is read
+func (client *UserClient) DownloadDataExport(ctx context.Context, token string) (archive io.Reader, err error) {
+	stream, err := client.userClient.DownloadDataExport(ctx, &userproto.DownloadToken{Token: token})
+	if err != nil {
+		return nil, parseExportError(err)
+	}
+
+	firstChunk, err := stream.Recv()
+	if err == io.EOF {
+		return bytes.NewReader(nil), nil
+	}
+	if err != nil {
+		return nil, parseExportError(err)
+	}
+
+	return &exportReader{stream: stream, buffer: firstChunk.GetChunkData()}, nil
+}
+
+// exportReader reads data export archive chunk by chunk from grpc stream
+type exportReader struct {
+	stream userproto.User_DownloadDataExportClient
+	buffer []byte
+}
+
+func (reader *exportReader) Read(p []byte) (n int, err error) {
+	for len(reader.buffer) == 0 {
+		chunk, err := reader.stream.Recv()
+		if err != nil {
+			return 0, err // Includes io.EOF
+		}
+		reader.buffer = chunk.GetChunkData()
+	}
+
+	n = copy(p, reader.buffer)
+	reader.buffer = reader.buffer[n:]
+	return n, nil
+}
+
+func parseExportError(err error) error {
+	switch {
+	case strings.Contains(err.Error(), userdomain.ExportNotFoundError.Error()):
+		return domain.ErrExportNotFound
+	case strings.Contains(err.Error(), userdomain.ExportNotReadyError.Error()):
+		return domain.ErrExportNotReady
+	case strings.Contains(err.Error(), userdomain.ExportExpiredError.Error()):
+		return domain.ErrExportExpired
+	default:
+		return errors.Wrap(err, "user client error: ")
+	}
+}
+
+func (client *UserClient) BlockUser(ctx context.Context, userID uint64, targetID uint64) (err error) {
+	_, err = client.userClient.BlockUser(context.Background(),
+		&userproto.UserPair{UserID: userID, TargetID: targetID})
+
+	if err != nil {
+		return parseRelationError(err)
+	}
+
+	return nil
+}
+
+func (client *UserClient) UnblockUser(ctx context.Context, userID uint64, targetID uint64) (err error) {
+	_, err = client.userClient.UnblockUser(context.Background(),
+		&userproto.UserPair{UserID: userID, TargetID: targetID})
+
+	if err != nil {
+		return parseRelationError(err)
+	}
+
+	return nil
+}
+
+func (client *UserClient) MuteUser(ctx context.Context, userID uint64, targetID uint64) (err error) {
+	_, err = client.userClient.MuteUser(context.Background(),
+		&userproto.UserPair{UserID: userID, TargetID: targetID})
+
+	if err != nil {
+		return parseRelationError(err)
+	}
+
+	return nil
+}
+
+func (client *UserClient) UnmuteUser(ctx context.Context, userID uint64, targetID uint64) (err error) {
+	_, err = client.userClient.UnmuteUser(context.Background(),
+		&userproto.UserPair{UserID: userID, TargetID: targetID})
+
+	if err != nil {
+		return parseRelationError(err)
+	}
+
+	return nil
+}
+
+func parseRelationError(err error) error {
+	switch {
+	case strings.Contains(err.Error(), userdomain.UserNotFoundError.Error()):
+		return domain.ErrUserNotFound
+	case strings.Contains(err.Error(), userdomain.SelfRelationError.Error()):
+		return domain.ErrSelfRelation
+	case strings.Contains(err.Error(), userdomain.BlockNotFoundError.Error()):
+		return domain.ErrBlockNotFound
+	case strings.Contains(err.Error(), userdomain.MuteNotFoundError.Error()):
+		return domain.ErrMuteNotFound
+	default:
+		return errors.Wrap(err, "user client error: ")
+	}
+}
+
+func (client *UserClient) GetBlockedUsers(ctx context.Context, userID uint64) (users []domain.User, err error) {
+	pbUsers, err := client.userClient.GetBlockedUsers(context.Background(), &userproto.UserID{Uid: userID})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "user client error: ")
+	}
+
+	return toUsers(pbUsers), nil
+}
+
+func (client *UserClient) GetMutedUsers(ctx context.Context, userID uint64) (users []domain.User, err error) {
+	pbUsers, err := client.userClient.GetMutedUsers(context.Background(), &userproto.UserID{Uid: userID})
+
+	if err != nil {
+		return nil, errors.Wrap(err, "user client error: ")
+	}
+
+	return toUsers(pbUsers), nil
+}
+
+// IsBlocked checks many pairs of users at once, results are in the same order as pairs
+func (client *UserClient) IsBlocked(ctx context.Context, pairs []domain.UserPair) (blocked []bool, muted []bool, err error) {
+	pbResult, err := client.userClient.IsBlocked(context.Background(),
+		&userproto.BlockCheckInput{Pairs: domain.ToPbUserPairs(pairs)})
+
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "user client error: ")
+	}
+
+	return pbResult.GetBlocked(), pbResult.GetMuted(), nil
+}
+
+func toUsers(pbUsers *userproto.UsersListOutput) []domain.User {
+	users := make([]domain.User, 0, len(pbUsers.GetUsers()))
 	for _, pbUser := range pbUsers.GetUsers() {
 		users = append(users, *domain.ToUser(pbUser))
 	}
 
-	return users, nil
+	return users
 }
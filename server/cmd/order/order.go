@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	orderapp "pinterest/services/order/application"
+	orderdomain "pinterest/services/order/domain"
+	orderrepo "pinterest/services/order/infrastructure"
+	"pinterest/services/order/infrastructure/payment"
+	orderfacade "pinterest/services/order/interfaces"
+	orderproto "pinterest/services/order/proto"
+	shopproductproto "pinterest/services/shopProduct/proto"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+func runService(addr string) {
+	logger, _ := zap.NewDevelopment()
+	defer logger.Sync()
+
+	sugarLogger := logger.Sugar()
+
+	err := godotenv.Load(".env")
+	if err != nil {
+		sugarLogger.Fatal("Could not load .env file", zap.String("error", err.Error()))
+	}
+
+	err = godotenv.Load("passwords.env")
+	if err != nil {
+		sugarLogger.Fatal("Could not load passwords.env file", zap.String("error", err.Error()))
+	}
+
+	// err = godotenv.Load("s3.env")
+	// if err != nil {
+	// 	sugarLogger.Fatal("Could not load s3.env file", zap.String("error", err.Error()))
+	// }
+
+	err = godotenv.Load("docker_vars.env")
+	if err != nil {
+		sugarLogger.Fatal("Could not load docker_vars.env file", zap.String("error", err.Error()))
+	}
+
+	dbPrefix := os.Getenv("DB_PREFIX")
+	if dbPrefix != "AMAZON" && dbPrefix != "LOCAL" {
+		sugarLogger.Fatalf("Wrong prefix: %s , should be AMAZON or LOCAL", dbPrefix)
+	}
+
+	postgresConnectionString := fmt.Sprintf("user=%s password=%s host=%s port=%s dbname=%s",
+		os.Getenv(dbPrefix+"_DB_USER"), os.Getenv(dbPrefix+"_DB_PASSWORD"), os.Getenv(dbPrefix+"_DB_HOST"),
+		os.Getenv(dbPrefix+"_DB_PORT"), os.Getenv(dbPrefix+"_DB_NAME"))
+	postgresConn, err := pgxpool.Connect(context.Background(), postgresConnectionString)
+	if err != nil {
+		sugarLogger.Fatal("Could not connect to postgres database", zap.String("error", err.Error()))
+		return
+	}
+
+	fmt.Println("Successfully connected to postgres database")
+	defer postgresConn.Close()
+
+	dockerStatus := os.Getenv("CONTAINER_PREFIX")
+	if dockerStatus != "DOCKER" && dockerStatus != "LOCALHOST" {
+		sugarLogger.Fatalf("Wrong prefix: %s , should be DOCKER or LOCALHOST", dockerStatus)
+	}
+
+	sessionShopProduct, err := grpc.Dial(os.Getenv(dockerStatus+"_SHOPPRODUCT_PREFIX")+":8083", grpc.WithInsecure())
+	if err != nil {
+		sugarLogger.Fatal("Can not create session for ShopProduct service")
+	}
+	defer sessionShopProduct.Close()
+
+	server := grpc.NewServer()
+
+	paymentProvider := os.Getenv("PAYMENT_PROVIDER")
+	if paymentProvider == "" {
+		paymentProvider = orderdomain.FakeProviderName
+	}
+
+	// Anyone who knows id of fake payment can complete it, so fake provider is registered only if it is configured
+	providers := make([]payment.ProviderInterface, 0)
+	var fakeProvider *payment.FakeProvider
+	if paymentProvider == orderdomain.FakeProviderName {
+		fakePageURL := os.Getenv("FAKE_PAYMENT_URL")
+		if fakePageURL == "" {
+			fakePageURL = "http://localhost:8080/api/payments/fake"
+		}
+		fakeProvider = payment.NewFakeProvider(os.Getenv("FAKE_PAYMENT_SECRET"), fakePageURL)
+		providers = append(providers, fakeProvider)
+	}
+
+	isKnownProvider := false
+	for _, provider := range providers {
+		if provider.Name() == paymentProvider {
+			isKnownProvider = true
+			break
+		}
+	}
+	if !isKnownProvider {
+		sugarLogger.Fatalf("Unknown payment provider: %s", paymentProvider)
+	}
+
+	orderApp := orderapp.NewOrderApp(orderrepo.NewOrderRepo(postgresConn), shopproductproto.NewShopProductClient(sessionShopProduct),
+		providers, paymentProvider)
+	if fakeProvider != nil {
+		fakeProvider.SetWebhookHandler(orderApp.HandlePaymentWebhook)
+	}
+	go cancelExpiredOrders(orderApp, sugarLogger)
+	go reconcilePayments(orderApp, sugarLogger)
+
+	service := orderfacade.NewOrderFacade(orderApp)
+	orderproto.RegisterOrderServiceServer(server, service)
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalln("Listen order error: ", err)
+	}
+
+	fmt.Printf("Starting server at localhost%s\n", addr)
+	err = server.Serve(lis)
+	if err != nil {
+		log.Fatalln("Serve order error: ", err)
+	}
+}
+
+// cancelExpiredOrders periodically cancels orders which were not paid in time
+func cancelExpiredOrders(orderApp orderapp.OrderAppInterface, sugarLogger *zap.SugaredLogger) {
+	for range time.Tick(time.Minute) {
+		err := orderApp.CancelExpiredOrders(context.Background())
+		if err != nil {
+			sugarLogger.Info("Could not cancel expired orders", zap.String("error", err.Error()))
+		}
+	}
+}
+
+// reconcilePayments periodically checks payments whose webhooks did not arrive
+func reconcilePayments(orderApp orderapp.OrderAppInterface, sugarLogger *zap.SugaredLogger) {
+	for range time.Tick(5 * time.Minute) {
+		err := orderApp.ReconcilePayments(context.Background())
+		if err != nil {
+			sugarLogger.Info("Could not reconcile payments", zap.String("error", err.Error()))
+		}
+	}
+}
+
+func main() {
+	runService(":8084")
+}
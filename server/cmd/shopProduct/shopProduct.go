@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	orderproto "pinterest/services/order/proto"
+	shopproductapp "pinterest/services/shopProduct/application"
+	shopproductrepo "pinterest/services/shopProduct/infrastructure"
+	shopproductfacade "pinterest/services/shopProduct/interfaces"
+	shopproductproto "pinterest/services/shopProduct/proto"
+	userproto "pinterest/services/user/proto"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/joho/godotenv"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+func runService(addr string) {
+	logger, _ := zap.NewDevelopment()
+	defer logger.Sync()
+
+	sugarLogger := logger.Sugar()
+
+	err := godotenv.Load(".env")
+	if err != nil {
+		sugarLogger.Fatal("Could not load .env file", zap.String("error", err.Error()))
+	}
+
+	err = godotenv.Load("passwords.env")
+	if err != nil {
+		sugarLogger.Fatal("Could not load passwords.env file", zap.String("error", err.Error()))
+	}
+
+	// err = godotenv.Load("s3.env")
+	// if err != nil {
+	// 	sugarLogger.Fatal("Could not load s3.env file", zap.String("error", err.Error()))
+	// }
+
+	err = godotenv.Load("docker_vars.env")
+	if err != nil {
+		sugarLogger.Fatal("Could not load docker_vars.env file", zap.String("error", err.Error()))
+	}
+
+	dbPrefix := os.Getenv("DB_PREFIX")
+	if dbPrefix != "AMAZON" && dbPrefix != "LOCAL" {
+		sugarLogger.Fatalf("Wrong prefix: %s , should be AMAZON or LOCAL", dbPrefix)
+	}
+
+	postgresConnectionString := fmt.Sprintf("user=%s password=%s host=%s port=%s dbname=%s",
+		os.Getenv(dbPrefix+"_DB_USER"), os.Getenv(dbPrefix+"_DB_PASSWORD"), os.Getenv(dbPrefix+"_DB_HOST"),
+		os.Getenv(dbPrefix+"_DB_PORT"), os.Getenv(dbPrefix+"_DB_NAME"))
+	postgresConn, err := pgxpool.Connect(context.Background(), postgresConnectionString)
+	if err != nil {
+		sugarLogger.Fatal("Could not connect to postgres database", zap.String("error", err.Error()))
+		return
+	}
+
+	fmt.Println("Successfully connected to postgres database")
+	defer postgresConn.Close()
+
+	dockerStatus := os.Getenv("CONTAINER_PREFIX")
+	if dockerStatus != "DOCKER" && dockerStatus != "LOCALHOST" {
+		sugarLogger.Fatalf("Wrong prefix: %s , should be DOCKER or LOCALHOST", dockerStatus)
+	}
+
+	sessionUser, err := grpc.Dial(os.Getenv(dockerStatus+"_USER_PREFIX")+":8082", grpc.WithInsecure())
+	if err != nil {
+		sugarLogger.Fatal("Can not create session for User service")
+	}
+	defer sessionUser.Close()
+
+	sessionOrder, err := grpc.Dial(os.Getenv(dockerStatus+"_ORDER_PREFIX")+":8084", grpc.WithInsecure())
+	if err != nil {
+		sugarLogger.Fatal("Can not create session for Order service")
+	}
+	defer sessionOrder.Close()
+
+	server := grpc.NewServer()
+
+	shopProductApp := shopproductapp.NewShopProductApp(shopproductrepo.NewShopProductRepo(postgresConn),
+		userproto.NewUserClient(sessionUser), orderproto.NewOrderServiceClient(sessionOrder), os.Getenv("MEDIA_DIR"), os.Getenv("SITE_URL"))
+	go purgeExpiredFeeds(shopProductApp, sugarLogger)
+	go expireReservations(shopProductApp, sugarLogger)
+	go purgeAbandonedCarts(shopProductApp, sugarLogger)
+	go refreshMarketplaceFeeds(shopProductApp, sugarLogger)
+	go purgeDeleted(shopProductApp, sugarLogger)
+	go refreshCoViews(shopProductApp, sugarLogger)
+	go rollupViews(shopProductApp, sugarLogger)
+	if ratesFile := os.Getenv("EXCHANGE_RATES_FILE"); ratesFile != "" {
+		go loadExchangeRates(shopProductApp, ratesFile, sugarLogger)
+	}
+
+	service := shopproductfacade.NewShopProductFacade(shopProductApp)
+	shopproductproto.RegisterShopProductServer(server, service)
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		log.Fatalln("Listen shopProduct error: ", err)
+	}
+
+	fmt.Printf("Starting server at localhost%s\n", addr)
+	err = server.Serve(lis)
+	if err != nil {
+		log.Fatalln("Serve shopProduct error: ", err)
+	}
+}
+
+// purgeExpiredFeeds periodically deletes feed snapshots which can no longer be scrolled
+func purgeExpiredFeeds(shopProductApp shopproductapp.ShopProductAppInterface, sugarLogger *zap.SugaredLogger) {
+	for range time.Tick(time.Hour) {
+		err := shopProductApp.PurgeExpiredFeeds(context.Background())
+		if err != nil {
+			sugarLogger.Info("Could not purge expired feeds", zap.String("error", err.Error()))
+		}
+	}
+}
+
+// expireReservations periodically returns stock of reservations which were neither committed nor released in time
+func expireReservations(shopProductApp shopproductapp.ShopProductAppInterface, sugarLogger *zap.SugaredLogger) {
+	for range time.Tick(time.Minute) {
+		err := shopProductApp.ExpireReservations(context.Background())
+		if err != nil {
+			sugarLogger.Info("Could not expire reservations", zap.String("error", err.Error()))
+		}
+	}
+}
+
+// purgeAbandonedCarts periodically deletes anonymous carts which were not used for a long time
+func purgeAbandonedCarts(shopProductApp shopproductapp.ShopProductAppInterface, sugarLogger *zap.SugaredLogger) {
+	for range time.Tick(24 * time.Hour) {
+		err := shopProductApp.PurgeAbandonedCarts(context.Background())
+		if err != nil {
+			sugarLogger.Info("Could not purge abandoned carts", zap.String("error", err.Error()))
+		}
+	}
+}
+
+// refreshMarketplaceFeeds periodically renders changed products into cached marketplace feeds
+func refreshMarketplaceFeeds(shopProductApp shopproductapp.ShopProductAppInterface, sugarLogger *zap.SugaredLogger) {
+	for range time.Tick(10 * time.Minute) {
+		err := shopProductApp.RefreshMarketplaceFeeds(context.Background())
+		if err != nil {
+			sugarLogger.Info("Could not refresh marketplace feeds", zap.String("error", err.Error()))
+		}
+	}
+}
+
+// purgeDeleted periodically deletes shops and products whose restore period has expired
+func purgeDeleted(shopProductApp shopproductapp.ShopProductAppInterface, sugarLogger *zap.SugaredLogger) {
+	for range time.Tick(time.Hour) {
+		err := shopProductApp.PurgeDeleted(context.Background())
+		if err != nil {
+			sugarLogger.Info("Could not purge deleted shops and products", zap.String("error", err.Error()))
+		}
+	}
+}
+
+// refreshCoViews periodically recounts users who viewed the same products, related products are ranked by them
+func refreshCoViews(shopProductApp shopproductapp.ShopProductAppInterface, sugarLogger *zap.SugaredLogger) {
+	for range time.Tick(time.Hour) {
+		err := shopProductApp.RefreshCoViews(context.Background())
+		if err != nil {
+			sugarLogger.Info("Could not refresh co-views of products", zap.String("error", err.Error()))
+		}
+	}
+}
+
+// rollupViews periodically aggregates product and shop views into daily rollups, shop analytics is built from them
+func rollupViews(shopProductApp shopproductapp.ShopProductAppInterface, sugarLogger *zap.SugaredLogger) {
+	for range time.Tick(time.Hour) {
+		err := shopProductApp.RollupViews(context.Background())
+		if err != nil {
+			sugarLogger.Info("Could not roll up views", zap.String("error", err.Error()))
+		}
+	}
+}
+
+// loadExchangeRates replaces exchange rates with ones from file at start and then every hour,
+// so that rates can be updated by replacing the file
+func loadExchangeRates(shopProductApp shopproductapp.ShopProductAppInterface, path string, sugarLogger *zap.SugaredLogger) {
+	load := func() {
+		err := shopProductApp.LoadExchangeRatesFile(context.Background(), path)
+		if err != nil {
+			sugarLogger.Info("Could not load exchange rates", zap.String("error", err.Error()))
+		}
+	}
+
+	load()
+	for range time.Tick(time.Hour) {
+		load()
+	}
+}
+
+func main() {
+	runService(":8083")
+}
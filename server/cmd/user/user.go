@@ -6,6 +6,10 @@ import (
 	"log"
 	"net"
 	"os"
+	"time"
+
+	orderproto "pinterest/services/order/proto"
+	shopproductproto "pinterest/services/shopProduct/proto"
 	userapp "pinterest/services/user/application"
 	userrepo "pinterest/services/user/infrastructure"
 	userfacade "pinterest/services/user/interfaces"
@@ -65,9 +69,25 @@ func runService(addr string) {
 		sugarLogger.Fatalf("Wrong prefix: %s , should be DOCKER or LOCALHOST", dockerStatus)
 	}
 
+	sessionShopProduct, err := grpc.Dial(os.Getenv(dockerStatus+"_SHOPPRODUCT_PREFIX")+":8083", grpc.WithInsecure())
+	if err != nil {
+		sugarLogger.Fatal("Can not create session for ShopProduct service")
+	}
+	defer sessionShopProduct.Close()
+
+	sessionOrder, err := grpc.Dial(os.Getenv(dockerStatus+"_ORDER_PREFIX")+":8084", grpc.WithInsecure())
+	if err != nil {
+		sugarLogger.Fatal("Can not create session for Order service")
+	}
+	defer sessionOrder.Close()
+
 	server := grpc.NewServer()
 
-	service := userfacade.NewUserFacade(userapp.NewUserApp(userrepo.NewUserRepo(postgresConn)))
+	exportApp := userapp.NewExportApp(userrepo.NewExportRepo(postgresConn), shopproductproto.NewShopProductClient(sessionShopProduct),
+		orderproto.NewOrderServiceClient(sessionOrder), os.Getenv("EXPORTS_DIR"), os.Getenv("MEDIA_DIR"))
+	go purgeExpiredExports(exportApp, sugarLogger)
+
+	service := userfacade.NewUserFacade(userapp.NewUserApp(userrepo.NewUserRepo(postgresConn)), exportApp)
 	userproto.RegisterUserServer(server, service)
 
 	lis, err := net.Listen("tcp", addr)
@@ -82,6 +102,22 @@ func runService(addr string) {
 	}
 }
 
+// purgeExpiredExports periodically deletes data export archives whose download links have expired
+// and fails exports which were interrupted
+func purgeExpiredExports(exportApp userapp.ExportAppInterface, sugarLogger *zap.SugaredLogger) {
+	for range time.Tick(time.Hour) {
+		err := exportApp.PurgeExpiredExports(context.Background())
+		if err != nil {
+			sugarLogger.Info("Could not purge expired data exports", zap.String("error", err.Error()))
+		}
+
+		err = exportApp.FailStaleExports(context.Background())
+		if err != nil {
+			sugarLogger.Info("Could not fail interrupted data exports", zap.String("error", err.Error()))
+		}
+	}
+}
+
 func main() {
 	runService(":8082")
 }
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	shopproductpb "pinterest/services/shopProduct/proto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ViewSessionCookieName is name of cookie which holds token of view session, product and shop views are
+	// counted once per session
+	ViewSessionCookieName = "view_session"
+	// ViewSessionLifetime is time without views after which view session ends
+	ViewSessionLifetime = 30 * time.Minute
+	// ViewSessionTokenLength is length of view session tokens
+	ViewSessionTokenLength = 32
+	// AnalyticsContentType is content type of exported shop analytics
+	AnalyticsContentType = "text/csv; charset=utf-8"
+	// DirectReferrer is shown in exported analytics instead of empty referrer of direct visits
+	DirectReferrer = "(direct)"
+)
+
+// PageView describes request for product or shop page. UserID is 0 for anonymous viewers,
+// Referrer is taken from Referer header
+type PageView struct {
+	UserID   uint64
+	Session  string
+	Referrer string
+}
+
+// ReferrerViews is amount of views which came from host of referrer, referrer is empty for direct visits
+type ReferrerViews struct {
+	Referrer string `json:"referrer"`
+	Views    uint64 `json:"views"`
+}
+
+// DailyViews is amount of views of shop page and its products during one day in UTC
+type DailyViews struct {
+	Day           string `json:"day"`
+	Views         uint64 `json:"views"`
+	UniqueViewers uint64 `json:"uniqueViewers"`
+}
+
+// ProductAnalytics contains views of product and reviews written during date range.
+// ViewToReviewRatio is share of views which were followed by review
+type ProductAnalytics struct {
+	ProductID         uint64          `json:"productID"`
+	Title             string          `json:"name"`
+	SKU               string          `json:"sku,omitempty"`
+	Views             uint64          `json:"views"`
+	UniqueViewers     uint64          `json:"uniqueViewers"`
+	ReviewsCount      uint64          `json:"reviewsCount"`
+	ViewToReviewRatio float64         `json:"viewToReviewRatio"`
+	Referrers         []ReferrerViews `json:"referrers"`
+}
+
+// ShopAnalytics is report about views of shop page and shop's products from day From to day To, both are included.
+// Unique viewers are counted for each day and then summed up
+type ShopAnalytics struct {
+	ShopID            uint64             `json:"shopID"`
+	From              string             `json:"from"`
+	To                string             `json:"to"`
+	ShopViews         uint64             `json:"shopViews"`
+	ShopUniqueViewers uint64             `json:"shopUniqueViewers"`
+	ProductViews      uint64             `json:"productViews"`
+	Days              []DailyViews       `json:"days"`
+	Referrers         []ReferrerViews    `json:"referrers"`
+	Products          []ProductAnalytics `json:"products"`
+}
+
+func ToShopAnalytics(pbAnalytics *shopproductpb.ShopAnalytics) ShopAnalytics {
+	days := make([]DailyViews, 0, len(pbAnalytics.GetDays()))
+	for _, pbDay := range pbAnalytics.GetDays() {
+		days = append(days, DailyViews{
+			Day:           pbDay.GetDay(),
+			Views:         pbDay.GetViews(),
+			UniqueViewers: pbDay.GetUniqueViewers(),
+		})
+	}
+
+	products := make([]ProductAnalytics, 0, len(pbAnalytics.GetProducts()))
+	for _, pbProduct := range pbAnalytics.GetProducts() {
+		products = append(products, ProductAnalytics{
+			ProductID:         pbProduct.GetProductId(),
+			Title:             pbProduct.GetTitle(),
+			SKU:               pbProduct.GetSku(),
+			Views:             pbProduct.GetViews(),
+			UniqueViewers:     pbProduct.GetUniqueViewers(),
+			ReviewsCount:      pbProduct.GetReviewsCount(),
+			ViewToReviewRatio: pbProduct.GetViewToReviewRatio(),
+			Referrers:         toReferrerViews(pbProduct.GetReferrers()),
+		})
+	}
+
+	return ShopAnalytics{
+		ShopID:            pbAnalytics.GetShopId(),
+		From:              pbAnalytics.GetFrom(),
+		To:                pbAnalytics.GetTo(),
+		ShopViews:         pbAnalytics.GetShopViews(),
+		ShopUniqueViewers: pbAnalytics.GetShopUniqueViewers(),
+		ProductViews:      pbAnalytics.GetProductViews(),
+		Days:              days,
+		Referrers:         toReferrerViews(pbAnalytics.GetReferrers()),
+		Products:          products,
+	}
+}
+
+func toReferrerViews(pbReferrers []*shopproductpb.ReferrerViews) []ReferrerViews {
+	referrers := make([]ReferrerViews, 0, len(pbReferrers))
+	for _, pbReferrer := range pbReferrers {
+		referrers = append(referrers, ReferrerViews{Referrer: pbReferrer.GetReferrer(), Views: pbReferrer.GetViews()})
+	}
+
+	return referrers
+}
+
+// WriteAnalyticsCSV writes one row per product of report, referrers of product are joined into one column
+// as host:views pairs separated by semicolons
+func WriteAnalyticsCSV(w io.Writer, analytics ShopAnalytics) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write([]string{"product_id", "sku", "name", "views", "unique_viewers", "reviews_count",
+		"view_to_review_ratio", "referrers"})
+	if err != nil {
+		return err
+	}
+
+	for _, product := range analytics.Products {
+		referrers := make([]string, 0, len(product.Referrers))
+		for _, referrer := range product.Referrers {
+			host := referrer.Referrer
+			if host == "" {
+				host = DirectReferrer
+			}
+			referrers = append(referrers, fmt.Sprintf("%s:%d", host, referrer.Views))
+		}
+
+		err = writer.Write([]string{
+			strconv.FormatUint(product.ProductID, 10),
+			product.SKU,
+			product.Title,
+			strconv.FormatUint(product.Views, 10),
+			strconv.FormatUint(product.UniqueViewers, 10),
+			strconv.FormatUint(product.ReviewsCount, 10),
+			strconv.FormatFloat(product.ViewToReviewRatio, 'f', 4, 64),
+			strings.Join(referrers, ";"),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
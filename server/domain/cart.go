@@ -0,0 +1,105 @@
+package domain
+
+import (
+	shopproductpb "pinterest/services/shopProduct/proto"
+	"time"
+)
+
+const (
+	// CartCookieName is name of cookie which holds token of anonymous cart
+	CartCookieName = "cart_token"
+	// CartCookieLifetime matches lifetime of anonymous carts in shopProduct service
+	CartCookieLifetime = 30 * 24 * time.Hour
+	// CartItemAvailable is status of cart item which can be bought in full
+	CartItemAvailable = "available"
+)
+
+// CartItemInput is used when parsing JSON in cart handlers, product is taken from URL when item is updated.
+// VariantID is 0 for products without variants
+type CartItemInput struct {
+	ProductID uint64 `json:"productID"`
+	VariantID uint64 `json:"variantID"`
+	Quantity  uint64 `json:"quantity"`
+}
+
+// CartItem contains current product data. Status is one of available, insufficient_stock and unavailable
+// Product's price, stock and images are variant's ones if variant is in cart
+type CartItem struct {
+	Product  Product         `json:"product"`
+	Variant  *ProductVariant `json:"variant,omitempty"`
+	Quantity uint64          `json:"quantity"`
+	// AddedPrice is product's price when it was added to cart
+	AddedPrice        Money     `json:"addedPrice"`
+	PriceChanged      bool      `json:"priceChanged"`
+	AddedAt           time.Time `json:"addedAt"`
+	Status            string    `json:"status"`
+	AvailableQuantity uint64    `json:"availableQuantity"`
+}
+
+// CartShop groups items of one shop, subtotal includes only items which can be bought in full and is in shop's currency.
+// ConvertedSubtotal is in currency which buyer prefers, it is omitted if buyer prefers none
+type CartShop struct {
+	ShopID            uint64     `json:"shopID"`
+	ShopTitle         string     `json:"shopTitle"`
+	Items             []CartItem `json:"items"`
+	Subtotal          Money      `json:"subtotal"`
+	ConvertedSubtotal *Money     `json:"convertedSubtotal,omitempty"`
+}
+
+// Cart has separate total for every currency of its shops. ConvertedTotal is sum of converted subtotals,
+// it is omitted if buyer prefers no currency or some of subtotals could not be converted
+type Cart struct {
+	Shops          []CartShop `json:"shops"`
+	Totals         []Money    `json:"totals"`
+	ConvertedTotal *Money     `json:"convertedTotal,omitempty"`
+	ItemsCount     uint64     `json:"itemsCount"`
+	// Token identifies anonymous cart, it is sent in cookie instead of response body
+	Token string `json:"-"`
+}
+
+func ToCart(pbCart *shopproductpb.Cart) Cart {
+	shops := make([]CartShop, 0, len(pbCart.GetShops()))
+	for _, pbShop := range pbCart.GetShops() {
+		items := make([]CartItem, 0, len(pbShop.GetItems()))
+		for _, pbItem := range pbShop.GetItems() {
+			product := ToProduct(pbItem.GetProduct())
+			var variant *ProductVariant
+			if pbItem.GetVariant() != nil {
+				cartVariant := ToProductVariant(pbItem.GetVariant())
+				variant = &cartVariant
+			}
+
+			items = append(items, CartItem{
+				Product:           product,
+				Variant:           variant,
+				Quantity:          pbItem.GetQuantity(),
+				AddedPrice:        ToMoney(pbItem.GetAddedPrice()),
+				PriceChanged:      ToMoney(pbItem.GetAddedPrice()) != product.Price,
+				AddedAt:           pbItem.GetAddedAt().AsTime(),
+				Status:            pbItem.GetStatus(),
+				AvailableQuantity: pbItem.GetAvailableQuantity(),
+			})
+		}
+
+		shops = append(shops, CartShop{
+			ShopID:            pbShop.GetShopId(),
+			ShopTitle:         pbShop.GetShopTitle(),
+			Items:             items,
+			Subtotal:          ToMoney(pbShop.GetSubtotal()),
+			ConvertedSubtotal: toOptionalMoney(pbShop.GetConvertedSubtotal()),
+		})
+	}
+
+	totals := make([]Money, 0, len(pbCart.GetTotals()))
+	for _, pbTotal := range pbCart.GetTotals() {
+		totals = append(totals, ToMoney(pbTotal))
+	}
+
+	return Cart{
+		Shops:          shops,
+		Totals:         totals,
+		ConvertedTotal: toOptionalMoney(pbCart.GetConvertedTotal()),
+		ItemsCount:     pbCart.GetItemsCount(),
+		Token:          pbCart.GetToken(),
+	}
+}
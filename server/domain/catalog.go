@@ -0,0 +1,86 @@
+package domain
+
+import (
+	shopproductpb "pinterest/services/shopProduct/proto"
+	"time"
+)
+
+const (
+	// CatalogFileField is multipart field which holds uploaded catalog file
+	CatalogFileField = "file"
+	// CatalogChunkSize is size of chunks in which catalog files are streamed to shopProduct service
+	CatalogChunkSize = 64 * 1024
+	// DefaultCatalogFormat is used when neither format parameter nor file's extension tell format
+	DefaultCatalogFormat = "csv"
+)
+
+const (
+	// FeedContentType is content type of marketplace feeds in all formats
+	FeedContentType = "application/xml; charset=utf-8"
+	// FeedCacheMaxAge is time in seconds for which marketplaces and proxies may keep feed,
+	// feeds themselves are cached by shopProduct service
+	FeedCacheMaxAge = 600
+)
+
+// CatalogContentTypes are content types of exported catalog files keyed by format
+var CatalogContentTypes = map[string]string{
+	"csv":  "text/csv; charset=utf-8",
+	"json": "application/json",
+}
+
+// ImportRowError explains why row of catalog file was not imported, column is omitted if whole row is wrong.
+// Rows are counted from 1, CSV header is not counted
+type ImportRowError struct {
+	Row     uint64 `json:"row"`
+	SKU     string `json:"sku,omitempty"`
+	Column  string `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// ProductImport describes status and progress of catalog import. Counts of dry run imports are counts
+// of products which would be created and updated
+type ProductImport struct {
+	ImportID      uint64           `json:"ID"`
+	ShopID        uint64           `json:"shopID"`
+	Format        string           `json:"format"`
+	DryRun        bool             `json:"dryRun"`
+	Status        string           `json:"status"`
+	TotalRows     uint64           `json:"totalRows"`
+	ProcessedRows uint64           `json:"processedRows"`
+	CreatedCount  uint64           `json:"createdCount"`
+	UpdatedCount  uint64           `json:"updatedCount"`
+	FailedCount   uint64           `json:"failedCount"`
+	Errors        []ImportRowError `json:"errors"`
+	ErrorMessage  string           `json:"errorMessage,omitempty"`
+	CreatedAt     time.Time        `json:"createdAt"`
+	FinishedAt    *time.Time       `json:"finishedAt,omitempty"`
+}
+
+func ToProductImport(pbImport *shopproductpb.ProductImport) ProductImport {
+	rowErrors := make([]ImportRowError, 0, len(pbImport.GetErrors()))
+	for _, pbError := range pbImport.GetErrors() {
+		rowErrors = append(rowErrors, ImportRowError{
+			Row:     pbError.GetRow(),
+			SKU:     pbError.GetSku(),
+			Column:  pbError.GetColumn(),
+			Message: pbError.GetMessage(),
+		})
+	}
+
+	return ProductImport{
+		ImportID:      pbImport.GetId(),
+		ShopID:        pbImport.GetShopId(),
+		Format:        pbImport.GetFormat(),
+		DryRun:        pbImport.GetDryRun(),
+		Status:        pbImport.GetStatus(),
+		TotalRows:     pbImport.GetTotalRows(),
+		ProcessedRows: pbImport.GetProcessedRows(),
+		CreatedCount:  pbImport.GetCreatedCount(),
+		UpdatedCount:  pbImport.GetUpdatedCount(),
+		FailedCount:   pbImport.GetFailedCount(),
+		Errors:        rowErrors,
+		ErrorMessage:  pbImport.GetErrorMessage(),
+		CreatedAt:     pbImport.GetCreatedAt().AsTime(),
+		FinishedAt:    toOptionalTime(pbImport.GetFinishedAt()),
+	}
+}
@@ -0,0 +1,66 @@
+package domain
+
+import (
+	shopproductpb "pinterest/services/shopProduct/proto"
+)
+
+// Category is used both as category creation and editing input and as node of categories tree in responses.
+// Children are ignored in input
+type Category struct {
+	CategoryID uint64 `json:"ID"`
+	// ParentID is 0 for root categories
+	ParentID uint64 `json:"parentID"`
+	Slug     string `json:"slug"`
+	Position uint64 `json:"position"`
+	// Names are keyed by locale, such as "ru" or "en-US"
+	Names    map[string]string `json:"names"`
+	Children []Category        `json:"children,omitempty"`
+}
+
+type CategoryIDResponse struct {
+	CategoryID uint64 `json:"ID"`
+}
+
+type CategoriesResponse struct {
+	Categories []Category `json:"categories"`
+}
+
+func ToCategory(pbCategory *shopproductpb.Category) Category {
+	names := make(map[string]string, len(pbCategory.GetNames()))
+	for _, name := range pbCategory.GetNames() {
+		names[name.GetLocale()] = name.GetName()
+	}
+
+	return Category{
+		CategoryID: pbCategory.GetId(),
+		ParentID:   pbCategory.GetParentId(),
+		Slug:       pbCategory.GetSlug(),
+		Position:   pbCategory.GetPosition(),
+		Names:      names,
+		Children:   ToCategories(pbCategory.GetChildren()),
+	}
+}
+
+func ToCategories(pbCategories []*shopproductpb.Category) []Category {
+	categories := make([]Category, 0, len(pbCategories))
+	for _, pbCategory := range pbCategories {
+		categories = append(categories, ToCategory(pbCategory))
+	}
+
+	return categories
+}
+
+func ToPbCategory(category Category) *shopproductpb.Category {
+	names := make([]*shopproductpb.LocalizedName, 0, len(category.Names))
+	for locale, name := range category.Names {
+		names = append(names, &shopproductpb.LocalizedName{Locale: locale, Name: name})
+	}
+
+	return &shopproductpb.Category{
+		Id:       category.CategoryID,
+		ParentId: category.ParentID,
+		Slug:     category.Slug,
+		Position: category.Position,
+		Names:    names,
+	}
+}
@@ -1,7 +1,41 @@
 package domain
 
 const (
-	CookieInfoKey = "cookie"
-	IDKey         = "id"
-	UsernameKey   = "username"
+	CookieInfoKey    = "cookie"
+	IDKey            = "id"
+	UsernameKey      = "username"
+	SearchKeyKey     = "searchKey"
+	TokenKey         = "token"
+	ManagerIDKey     = "managerID"
+	ImageIDKey       = "imageID"
+	ReplacementIDKey = "replacementID"
+	ProductIDKey     = "productID"
+	VariantIDKey     = "variantID"
+	SaleIDKey        = "saleID"
+	DiscountIDKey    = "discountID"
+	PromoCodeIDKey   = "promoCodeID"
+	ImportIDKey      = "importID"
+
+	ProductAmountKey  = "productAmount"
+	ProductPageKey    = "productPage"
+	SortingCritKey    = "sortingCrit"
+	CursorKey         = "cursor"
+	ReviewAmountKey   = "reviewsAmount"
+	ReviewPageKey     = "reviewsPage"
+	QuestionAmountKey = "questionsAmount"
+	QuestionPageKey   = "questionsPage"
+	MovementAmountKey = "movementsAmount"
+	MovementPageKey   = "movementsPage"
+	PriceAmountKey    = "pricesAmount"
+	PricePageKey      = "pricesPage"
+	OrderAmountKey    = "ordersAmount"
+	OrderPageKey      = "ordersPage"
+	OrderStatusKey    = "status"
+	ProviderKey       = "provider"
+	PaymentIDKey      = "paymentID"
+	PaymentOutcomeKey = "outcome"
+	CatalogFormatKey  = "format"
+	DryRunKey         = "dryRun"
+	DateFromKey       = "from"
+	DateToKey         = "to"
 )
@@ -3,7 +3,110 @@ package domain
 import "errors"
 
 var (
-	ErrIncorrectPassword = errors.New("Incorrect username/password pair")
-	ErrUserNotFound      = errors.New("User not found")
-	ErrCookieNotFound    = errors.New("Cookie not found")
+	ErrIncorrectPassword    = errors.New("Incorrect username/password pair")
+	ErrUserNotFound         = errors.New("User not found")
+	ErrCookieNotFound       = errors.New("Cookie not found")
+	ErrFollowersHidden      = errors.New("Followers are hidden")
+	ErrExportNotFound       = errors.New("Data export not found")
+	ErrExportNotReady       = errors.New("Data export is not ready")
+	ErrExportExpired        = errors.New("Data export has expired")
+	ErrSelfRelation         = errors.New("Users can not block or mute themselves")
+	ErrBlockNotFound        = errors.New("Block not found")
+	ErrMuteNotFound         = errors.New("Mute not found")
+	ErrShopNotFound         = errors.New("Shop not found")
+	ErrProductNotFound      = errors.New("Product not found")
+	ErrEmptyTitle           = errors.New("Title can not be empty")
+	ErrNotShopManager       = errors.New("User is not shop's manager")
+	ErrNotShopOwner         = errors.New("User is not shop's owner")
+	ErrAlreadyManager       = errors.New("User already manages this shop")
+	ErrLastOwner            = errors.New("Shop's last owner can not be removed")
+	ErrInvalidRole          = errors.New("Invalid manager role")
+	ErrInvitationNotFound   = errors.New("Invitation not found")
+	ErrInvalidImage         = errors.New("Invalid image")
+	ErrImageTooLarge        = errors.New("Image is too large")
+	ErrTooManyImages        = errors.New("Product has too many images")
+	ErrImageNotFound        = errors.New("Image not found")
+	ErrInvalidImageOrder    = errors.New("Image order must contain every product image exactly once")
+	ErrInvalidSorting       = errors.New("Unknown sorting criterion")
+	ErrInvalidCursor        = errors.New("Invalid pagination cursor")
+	ErrShopFollowNotFound   = errors.New("User does not follow this shop")
+	ErrInvalidRating        = errors.New("Rating must be from 1 to 5")
+	ErrReviewTitleTooLong   = errors.New("Review title is too long")
+	ErrReviewExists         = errors.New("User has already reviewed this product")
+	ErrReviewNotFound       = errors.New("Review not found")
+	ErrNotReviewAuthor      = errors.New("User is not review's author")
+	ErrOwnProductReview     = errors.New("Shop managers can not review their own products")
+	ErrInvalidSearchRange   = errors.New("Range minimum is greater than its maximum")
+	ErrNotAdmin             = errors.New("User is not administrator")
+	ErrCategoryNotFound     = errors.New("Category not found")
+	ErrInvalidSlug          = errors.New("Slug must consist of lowercase letters and digits separated by dashes")
+	ErrSlugExists           = errors.New("Category with this slug already exists")
+	ErrInvalidCategoryName  = errors.New("Category must have names of at most 100 characters in valid locales")
+	ErrCategoryCycle        = errors.New("Category can not be moved into its own subtree")
+	ErrCategoryNotEmpty     = errors.New("Category has subcategories or products")
+	ErrOutOfStock           = errors.New("Not enough stock")
+	ErrInvalidQuantity      = errors.New("Quantities must be positive and products must not repeat")
+	ErrStockBelowReserved   = errors.New("Stock can not become less than reserved quantity")
+	ErrStockCommentTooLong  = errors.New("Stock change comment is too long")
+	ErrReservationNotFound  = errors.New("Reservation not found")
+	ErrReservationNotActive = errors.New("Reservation is already committed, released or expired")
+	ErrCartQuantityTooLarge = errors.New("Cart can hold at most 999 units of product")
+	ErrCartItemNotFound     = errors.New("Product is not in cart")
+	ErrOrderNotFound        = errors.New("Order not found")
+	ErrEmptyCheckout        = errors.New("Checkout must contain at least one product")
+	ErrTooManyCheckoutItems = errors.New("Checkout contains too many products")
+	ErrInvalidOrderStatus   = errors.New("Unknown order status")
+	ErrInvalidTransition    = errors.New("Order can not move from its current status to requested one")
+	ErrTransitionForbidden  = errors.New("User is not allowed to make this status change")
+	ErrOrderStatusChanged   = errors.New("Order status was changed concurrently")
+	ErrOrderCommentTooLong  = errors.New("Status change comment is too long")
+	ErrOrderNotPayable      = errors.New("Only unpaid orders can be paid")
+	ErrPaymentNotFound      = errors.New("Payment not found")
+	ErrUnknownProvider      = errors.New("Unknown payment provider")
+	ErrInvalidWebhook       = errors.New("Webhook has invalid signature or body")
+	ErrWishlistNotFound     = errors.New("Wishlist not found")
+	ErrInvalidWishlistTitle = errors.New("Wishlist title must have from 1 to 100 characters")
+	ErrTooManyWishlists     = errors.New("User has too many wishlists")
+	ErrDefaultWishlist      = errors.New("Default wishlist can not be deleted")
+	ErrWishlistItemNotFound = errors.New("Product is not in wishlist")
+	ErrInvalidOptions       = errors.New("Product must have at most 3 options with unique names and values of 1 to 50 characters")
+	ErrOptionsInUse         = errors.New("Options do not match product's variants")
+	ErrInvalidVariantOption = errors.New("Variant must have one allowed value of every product option")
+	ErrInvalidSKU           = errors.New("SKU must have from 1 to 64 characters")
+	ErrDuplicateVariant     = errors.New("Product already has variant with this SKU or option values")
+	ErrTooManyVariants      = errors.New("Product has too many variants")
+	ErrVariantNotFound      = errors.New("Variant not found")
+	ErrVariantRequired      = errors.New("Variant of product must be selected")
+	ErrVariantReserved      = errors.New("Variant has reserved stock")
+	ErrInvalidSale          = errors.New("Sale must have positive price and end after it starts")
+	ErrSaleNotFound         = errors.New("Sale not found")
+	ErrInvalidDiscount      = errors.New("Discount must be from 1 to 90 percent and end after it starts")
+	ErrDiscountNotFound     = errors.New("Discount not found")
+	ErrInvalidPromoCode     = errors.New("Promo code must have 3 to 32 letters, digits, dashes or underscores and valid discount")
+	ErrPromoCodeExists      = errors.New("Shop already has promo code with this code")
+	ErrPromoCodeNotFound    = errors.New("Promo code not found")
+	ErrPromoCodeExpired     = errors.New("Promo code has expired")
+	ErrPromoCodeMinOrder    = errors.New("Order is less than minimum of promo code")
+	ErrPromoCodeUsedUp      = errors.New("Promo code has reached its usage limit")
+	ErrInvalidCurrency      = errors.New("Unknown currency code")
+	ErrCurrencyMismatch     = errors.New("Price must be in shop's currency")
+	ErrCurrencyInUse        = errors.New("Currency of shop with products can not be changed")
+	ErrExchangeRateNotFound = errors.New("Currency has no exchange rate")
+	ErrInvalidExchangeRates = errors.New("Exchange rates must have positive rates, known roundings and base currency with rate 1")
+	ErrDuplicateProductSKU  = errors.New("Shop already has product with this SKU")
+	ErrInvalidCatalogFormat = errors.New("Catalog format must be csv or json")
+	ErrInvalidImportFile    = errors.New("Could not read catalog file")
+	ErrImportFileTooLarge   = errors.New("Catalog file is too large")
+	ErrTooManyImportRows    = errors.New("Catalog file has too many rows")
+	ErrImportNotFound       = errors.New("Catalog import not found")
+	ErrInvalidFeedFormat    = errors.New("Feed format must be yml or google")
+	ErrRestoreExpired       = errors.New("Deleted item can no longer be restored")
+	ErrShopDeleted          = errors.New("Products of deleted shop are restored together with shop")
+	ErrInvalidDateRange     = errors.New("Date range must have days in YYYY-MM-DD format, start before end and be at most a year long")
+	ErrEmptyQuestionText    = errors.New("Question and answer must have text")
+	ErrQuestionTextTooLong  = errors.New("Question or answer text is too long")
+	ErrQuestionNotFound     = errors.New("Question not found")
+	ErrAnswerNotFound       = errors.New("Answer not found")
+	ErrNotAllowedToAnswer   = errors.New("Only shop's managers and buyers of product can answer its questions")
+	ErrBlockedUser          = errors.New("Users who have blocked one another can not interact")
 )
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	userpb "pinterest/services/user/proto"
+	"time"
+)
+
+const DataExportDownloadPath = "/api/profile/export/download/"
+
+// DataExport describes status of personal data archive. Download link is only present when archive is ready
+type DataExport struct {
+	ExportID     uint64     `json:"exportID"`
+	Status       string     `json:"status"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	ExpiresAt    *time.Time `json:"expiresAt,omitempty"`
+	DownloadLink string     `json:"downloadLink,omitempty"`
+}
+
+func ToDataExport(pbExport *userpb.DataExport) DataExport {
+	export := DataExport{
+		ExportID:  pbExport.GetExportID(),
+		Status:    pbExport.GetStatus(),
+		CreatedAt: pbExport.GetCreatedAt().AsTime(),
+	}
+
+	if pbExport.GetDownloadToken() != "" {
+		expiresAt := pbExport.GetExpiresAt().AsTime()
+		export.ExpiresAt = &expiresAt
+		export.DownloadLink = DataExportDownloadPath + pbExport.GetDownloadToken()
+	}
+	return export
+}
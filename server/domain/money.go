@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"net/http"
+	shopproductpb "pinterest/services/shopProduct/proto"
+	"strings"
+	"time"
+)
+
+const (
+	// CurrencyKey is query parameter with currency which buyer prefers, it overrides CurrencyCookieName cookie
+	CurrencyKey = "currency"
+	// CurrencyCookieName is name of cookie which holds currency which buyer prefers
+	CurrencyCookieName = "currency"
+)
+
+// Money is amount in minor units of currency, such as kopecks. Currency is ISO 4217 code, it can be omitted
+// in input, then shop's currency is used
+type Money struct {
+	Amount   uint64 `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// ExchangeRate is amount of currency which equals one unit of base currency. Rate is decimal string, so that
+// it is kept exactly. Rounding is one of half_up, half_even, up and down and is applied to converted amounts
+type ExchangeRate struct {
+	Currency   string     `json:"currency"`
+	Rate       string     `json:"rate"`
+	Rounding   string     `json:"rounding"`
+	MinorUnits uint32     `json:"minorUnits"`
+	UpdatedAt  *time.Time `json:"updatedAt,omitempty"`
+}
+
+// ExchangeRates are used both in response and when parsing JSON in exchange rates handler,
+// minorUnits and updatedAt of rates can not be set
+type ExchangeRates struct {
+	Base  string         `json:"base"`
+	Rates []ExchangeRate `json:"rates"`
+}
+
+// PreferredCurrency returns currency in which buyer wants to see prices, it is empty if prices should not be converted
+func PreferredCurrency(r *http.Request) string {
+	if currency := r.URL.Query().Get(CurrencyKey); currency != "" {
+		return strings.ToUpper(currency)
+	}
+
+	currencyCookie, err := r.Cookie(CurrencyCookieName)
+	if err != nil {
+		return ""
+	}
+
+	return strings.ToUpper(currencyCookie.Value)
+}
+
+func ToMoney(pbMoney *shopproductpb.Money) Money {
+	return Money{Amount: pbMoney.GetAmount(), Currency: pbMoney.GetCurrency()}
+}
+
+// toOptionalMoney converts money which is omitted in JSON if it is not set
+func toOptionalMoney(pbMoney *shopproductpb.Money) *Money {
+	if pbMoney == nil {
+		return nil
+	}
+
+	money := ToMoney(pbMoney)
+	return &money
+}
+
+func ToPbMoney(money Money) *shopproductpb.Money {
+	if money.Amount == 0 && money.Currency == "" {
+		return nil
+	}
+
+	return &shopproductpb.Money{Amount: money.Amount, Currency: strings.ToUpper(money.Currency)}
+}
+
+func toPbOptionalMoney(money *Money) *shopproductpb.Money {
+	if money == nil {
+		return nil
+	}
+
+	return ToPbMoney(*money)
+}
+
+func ToExchangeRates(pbRates *shopproductpb.ExchangeRates) ExchangeRates {
+	rates := make([]ExchangeRate, 0, len(pbRates.GetRates()))
+	for _, pbRate := range pbRates.GetRates() {
+		rates = append(rates, ExchangeRate{
+			Currency:   pbRate.GetCurrency(),
+			Rate:       pbRate.GetRate(),
+			Rounding:   pbRate.GetRounding(),
+			MinorUnits: pbRate.GetMinorUnits(),
+			UpdatedAt:  toOptionalTime(pbRate.GetUpdatedAt()),
+		})
+	}
+
+	return ExchangeRates{Base: pbRates.GetBase(), Rates: rates}
+}
+
+func ToPbExchangeRates(rates ExchangeRates) *shopproductpb.ExchangeRates {
+	pbRates := make([]*shopproductpb.ExchangeRate, 0, len(rates.Rates))
+	for _, rate := range rates.Rates {
+		pbRates = append(pbRates, &shopproductpb.ExchangeRate{
+			Currency: strings.ToUpper(rate.Currency),
+			Rate:     rate.Rate,
+			Rounding: rate.Rounding,
+		})
+	}
+
+	return &shopproductpb.ExchangeRates{Base: strings.ToUpper(rates.Base), Rates: pbRates}
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	orderpb "pinterest/services/order/proto"
+	"time"
+)
+
+// CheckoutInput is used when parsing JSON in checkout handler. If items are empty, available items of user's cart are bought.
+// PromoCode is applied to order of shop which issued it
+type CheckoutInput struct {
+	Items     []CartItemInput `json:"items"`
+	PromoCode string          `json:"promoCode"`
+}
+
+// OrderStatusInput is used when parsing JSON in order status handler
+type OrderStatusInput struct {
+	Status  string `json:"status"`
+	Comment string `json:"comment"`
+}
+
+// OrderItem keeps product's title, price and assembly time from checkout. ProductID is omitted if product was deleted,
+// VariantID is omitted if product has no variants or variant was deleted. VariantTitle lists option values, such as "L / Red".
+// Price is lower than OriginalPrice if product was on sale
+type OrderItem struct {
+	ProductID     uint64 `json:"productID,omitempty"`
+	VariantID     uint64 `json:"variantID,omitempty"`
+	Title         string `json:"title"`
+	SKU           string `json:"sku,omitempty"`
+	VariantTitle  string `json:"variantTitle,omitempty"`
+	Price         uint64 `json:"price"`
+	OriginalPrice uint64 `json:"originalPrice"`
+	Quantity      uint64 `json:"quantity"`
+	AssemblyTime  uint64 `json:"assemblyTime"`
+}
+
+// OrderStatusChange is transition of order's state machine, FromStatus is omitted for checkout
+type OrderStatusChange struct {
+	FromStatus string `json:"fromStatus,omitempty"`
+	ToStatus   string `json:"toStatus"`
+	// ActorID is omitted for system changes, ActorRole is one of buyer, manager and system
+	ActorID   uint64    `json:"actorID,omitempty"`
+	ActorRole string    `json:"actorRole"`
+	Comment   string    `json:"comment,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Order is one shop's part of checkout. Status is one of pending_payment, paid, assembling, shipped, delivered,
+// cancelled and refunded, AllowedTransitions are statuses into which viewer can move order.
+// All amounts of order are in its Currency, which is currency of shop at checkout
+type Order struct {
+	OrderID            uint64              `json:"ID"`
+	UserID             uint64              `json:"userID"`
+	ShopID             uint64              `json:"shopID"`
+	ShopTitle          string              `json:"shopTitle"`
+	Status             string              `json:"status"`
+	Items              []OrderItem         `json:"items"`
+	Subtotal           uint64              `json:"subtotal"`
+	Discount           uint64              `json:"discount"`
+	PromoCode          string              `json:"promoCode,omitempty"`
+	Total              uint64              `json:"total"`
+	Currency           string              `json:"currency"`
+	AssemblyTime       uint64              `json:"assemblyTime"`
+	PaymentDeadline    time.Time           `json:"paymentDeadline"`
+	EstimatedReadyAt   time.Time           `json:"estimatedReadyAt"`
+	CreatedAt          time.Time           `json:"createdAt"`
+	UpdatedAt          time.Time           `json:"updatedAt"`
+	History            []OrderStatusChange `json:"history,omitempty"`
+	AllowedTransitions []string            `json:"allowedTransitions"`
+}
+
+type OrdersListResponse struct {
+	Orders []Order `json:"orders"`
+	// NextCursor is passed as cursor to get next page, it is omitted on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+func ToOrder(pbOrder *orderpb.Order) Order {
+	items := make([]OrderItem, 0, len(pbOrder.GetItems()))
+	for _, pbItem := range pbOrder.GetItems() {
+		items = append(items, OrderItem{
+			ProductID:     pbItem.GetProductId(),
+			VariantID:     pbItem.GetVariantId(),
+			Title:         pbItem.GetTitle(),
+			SKU:           pbItem.GetSku(),
+			VariantTitle:  pbItem.GetVariantTitle(),
+			Price:         pbItem.GetPrice(),
+			OriginalPrice: pbItem.GetOriginalPrice(),
+			Quantity:      pbItem.GetQuantity(),
+			AssemblyTime:  pbItem.GetAssemblyTime(),
+		})
+	}
+
+	history := make([]OrderStatusChange, 0, len(pbOrder.GetHistory()))
+	for _, pbChange := range pbOrder.GetHistory() {
+		history = append(history, OrderStatusChange{
+			FromStatus: pbChange.GetFromStatus(),
+			ToStatus:   pbChange.GetToStatus(),
+			ActorID:    pbChange.GetActorId(),
+			ActorRole:  pbChange.GetActorRole(),
+			Comment:    pbChange.GetComment(),
+			CreatedAt:  pbChange.GetCreatedAt().AsTime(),
+		})
+	}
+
+	allowedTransitions := pbOrder.GetAllowedTransitions()
+	if allowedTransitions == nil {
+		allowedTransitions = make([]string, 0)
+	}
+
+	return Order{
+		OrderID:            pbOrder.GetId(),
+		UserID:             pbOrder.GetUserId(),
+		ShopID:             pbOrder.GetShopId(),
+		ShopTitle:          pbOrder.GetShopTitle(),
+		Status:             pbOrder.GetStatus(),
+		Items:              items,
+		Subtotal:           pbOrder.GetSubtotal(),
+		Discount:           pbOrder.GetDiscount(),
+		PromoCode:          pbOrder.GetPromoCode(),
+		Total:              pbOrder.GetTotal(),
+		Currency:           pbOrder.GetCurrency(),
+		AssemblyTime:       pbOrder.GetAssemblyTime(),
+		PaymentDeadline:    pbOrder.GetPaymentDeadline().AsTime(),
+		EstimatedReadyAt:   pbOrder.GetEstimatedReadyAt().AsTime(),
+		CreatedAt:          pbOrder.GetCreatedAt().AsTime(),
+		UpdatedAt:          pbOrder.GetUpdatedAt().AsTime(),
+		History:            history,
+		AllowedTransitions: allowedTransitions,
+	}
+}
+
+func ToOrders(pbOrders []*orderpb.Order) []Order {
+	orders := make([]Order, 0, len(pbOrders))
+	for _, pbOrder := range pbOrders {
+		orders = append(orders, ToOrder(pbOrder))
+	}
+
+	return orders
+}
+
+func ToPbCheckoutItems(items []CartItemInput) []*orderpb.CheckoutItem {
+	pbItems := make([]*orderpb.CheckoutItem, 0, len(items))
+	for _, item := range items {
+		pbItems = append(pbItems, &orderpb.CheckoutItem{
+			ProductId: item.ProductID,
+			VariantId: item.VariantID,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	return pbItems
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// PageInput describes requested page of products or reviews list, as it comes from query parameters
+type PageInput struct {
+	Sorting string
+	Limit   uint64
+	Cursor  string
+	Page    uint64
+}
+
+// ParsePageInput reads sortingCrit and cursor query parameters, together with amount and page parameters,
+// whose names differ between lists
+func ParsePageInput(query url.Values, amountKey string, pageKey string) (page PageInput, err error) {
+	page.Sorting = query.Get(SortingCritKey)
+	page.Cursor = query.Get(CursorKey)
+
+	if amount := query.Get(amountKey); amount != "" {
+		page.Limit, err = strconv.ParseUint(amount, 10, 64)
+		if err != nil {
+			return PageInput{}, err
+		}
+	}
+
+	if pageNumber := query.Get(pageKey); pageNumber != "" {
+		page.Page, err = strconv.ParseUint(pageNumber, 10, 64)
+		if err != nil {
+			return PageInput{}, err
+		}
+	}
+
+	return page, nil
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	orderpb "pinterest/services/order/proto"
+	"time"
+)
+
+const (
+	// PaymentSignatureHeader carries signature of payment provider's webhook
+	PaymentSignatureHeader = "X-Payment-Signature"
+	// FakePaymentProvider is name of built-in provider, its payment page is served only if it is configured provider
+	FakePaymentProvider = "fake"
+	// PaymentReturnPath is where provider returns payer after payment of order, it is relative to SITE_URL
+	PaymentReturnPath = "/api/order/%d/payment/return"
+	// PaymentSucceededOutcome is chosen on fake payment page to pay successfully, any other outcome fails payment
+	PaymentSucceededOutcome = "succeeded"
+)
+
+// Payment is attempt to pay order. Status is one of pending, succeeded, failed and refunded,
+// buyer pays pending payment on page at ConfirmationURL
+type Payment struct {
+	PaymentID       uint64    `json:"ID"`
+	OrderID         uint64    `json:"orderID"`
+	Provider        string    `json:"provider"`
+	Status          string    `json:"status"`
+	Amount          uint64    `json:"amount"`
+	Currency        string    `json:"currency"`
+	ConfirmationURL string    `json:"confirmationURL"`
+	CreatedAt       time.Time `json:"createdAt"`
+	UpdatedAt       time.Time `json:"updatedAt"`
+}
+
+func ToPayment(pbPayment *orderpb.Payment) Payment {
+	return Payment{
+		PaymentID:       pbPayment.GetId(),
+		OrderID:         pbPayment.GetOrderId(),
+		Provider:        pbPayment.GetProvider(),
+		Status:          pbPayment.GetStatus(),
+		Amount:          pbPayment.GetAmount(),
+		Currency:        pbPayment.GetCurrency(),
+		ConfirmationURL: pbPayment.GetConfirmationUrl(),
+		CreatedAt:       pbPayment.GetCreatedAt().AsTime(),
+		UpdatedAt:       pbPayment.GetUpdatedAt().AsTime(),
+	}
+}
@@ -0,0 +1,231 @@
+package domain
+
+import (
+	shopproductpb "pinterest/services/shopProduct/proto"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ProductSale is scheduled sale price of product or of its variant. Sale without startsAt starts immediately,
+// sale without endsAt lasts until it is deleted. Sale price is in currency of product's shop
+type ProductSale struct {
+	SaleID    uint64 `json:"ID"`
+	ProductID uint64 `json:"productID"`
+	// VariantID is omitted for sales of whole product, they apply to variants without price override
+	VariantID uint64     `json:"variantID,omitempty"`
+	SalePrice Money      `json:"salePrice"`
+	StartsAt  *time.Time `json:"startsAt,omitempty"`
+	EndsAt    *time.Time `json:"endsAt,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type ProductSalesListResponse struct {
+	Sales []ProductSale `json:"sales"`
+}
+
+// ShopDiscount is percentage discount of all shop's products, products get the lowest of sale and discounted prices
+type ShopDiscount struct {
+	DiscountID uint64     `json:"ID"`
+	ShopID     uint64     `json:"shopID"`
+	Percent    uint64     `json:"percent"`
+	StartsAt   *time.Time `json:"startsAt,omitempty"`
+	EndsAt     *time.Time `json:"endsAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+type ShopDiscountsListResponse struct {
+	Discounts []ShopDiscount `json:"discounts"`
+}
+
+// PromoCode is code which buyers enter at checkout. Kind is fixed or percent, maxUses and maxUsesPerUser
+// of 0 mean no limit. Fixed value and minOrder are in currency of shop. UsedCount and Currency can not be set by managers
+type PromoCode struct {
+	PromoCodeID    uint64     `json:"ID"`
+	ShopID         uint64     `json:"shopID"`
+	Code           string     `json:"code"`
+	Kind           string     `json:"kind"`
+	Value          uint64     `json:"value"`
+	MinOrder       uint64     `json:"minOrder"`
+	MaxUses        uint64     `json:"maxUses"`
+	MaxUsesPerUser uint64     `json:"maxUsesPerUser"`
+	UsedCount      uint64     `json:"usedCount"`
+	Currency       string     `json:"currency"`
+	ExpiresAt      *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+type PromoCodesListResponse struct {
+	PromoCodes []PromoCode `json:"promoCodes"`
+}
+
+// PricingRuleIDResponse is returned when sale, discount or promo code is created
+type PricingRuleIDResponse struct {
+	ID uint64 `json:"ID"`
+}
+
+// PriceChange is entry of product's price history. Kind is one of list, sale, sale_cancelled, discount
+// and discount_cancelled, fields which do not apply to kind are omitted
+type PriceChange struct {
+	ChangeID  uint64     `json:"ID"`
+	ProductID uint64     `json:"productID,omitempty"`
+	VariantID uint64     `json:"variantID,omitempty"`
+	ShopID    uint64     `json:"shopID"`
+	Kind      string     `json:"kind"`
+	OldPrice  *Money     `json:"oldPrice,omitempty"`
+	NewPrice  *Money     `json:"newPrice,omitempty"`
+	Percent   uint64     `json:"percent,omitempty"`
+	StartsAt  *time.Time `json:"startsAt,omitempty"`
+	EndsAt    *time.Time `json:"endsAt,omitempty"`
+	UserID    uint64     `json:"userID,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type PriceHistoryResponse struct {
+	Changes []PriceChange `json:"changes"`
+	// NextCursor is passed as cursor to get next page, it is omitted on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+// toOptionalTime converts timestamp to time which is omitted in JSON if timestamp is not set
+func toOptionalTime(pbTime *timestamppb.Timestamp) *time.Time {
+	if pbTime == nil {
+		return nil
+	}
+
+	t := pbTime.AsTime()
+	return &t
+}
+
+func toPbOptionalTime(t *time.Time) *timestamppb.Timestamp {
+	if t == nil || t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
+
+func ToProductSale(pbSale *shopproductpb.ProductSale) ProductSale {
+	return ProductSale{
+		SaleID:    pbSale.GetId(),
+		ProductID: pbSale.GetProductId(),
+		VariantID: pbSale.GetVariantId(),
+		SalePrice: ToMoney(pbSale.GetSalePrice()),
+		StartsAt:  toOptionalTime(pbSale.GetStartsAt()),
+		EndsAt:    toOptionalTime(pbSale.GetEndsAt()),
+		CreatedAt: pbSale.GetCreatedAt().AsTime(),
+	}
+}
+
+func ToProductSales(pbSales []*shopproductpb.ProductSale) []ProductSale {
+	sales := make([]ProductSale, 0, len(pbSales))
+	for _, pbSale := range pbSales {
+		sales = append(sales, ToProductSale(pbSale))
+	}
+
+	return sales
+}
+
+func ToPbProductSale(sale ProductSale) *shopproductpb.ProductSale {
+	return &shopproductpb.ProductSale{
+		ProductId: sale.ProductID,
+		VariantId: sale.VariantID,
+		SalePrice: ToPbMoney(sale.SalePrice),
+		StartsAt:  toPbOptionalTime(sale.StartsAt),
+		EndsAt:    toPbOptionalTime(sale.EndsAt),
+	}
+}
+
+func ToShopDiscount(pbDiscount *shopproductpb.ShopDiscount) ShopDiscount {
+	return ShopDiscount{
+		DiscountID: pbDiscount.GetId(),
+		ShopID:     pbDiscount.GetShopId(),
+		Percent:    pbDiscount.GetPercent(),
+		StartsAt:   toOptionalTime(pbDiscount.GetStartsAt()),
+		EndsAt:     toOptionalTime(pbDiscount.GetEndsAt()),
+		CreatedAt:  pbDiscount.GetCreatedAt().AsTime(),
+	}
+}
+
+func ToShopDiscounts(pbDiscounts []*shopproductpb.ShopDiscount) []ShopDiscount {
+	discounts := make([]ShopDiscount, 0, len(pbDiscounts))
+	for _, pbDiscount := range pbDiscounts {
+		discounts = append(discounts, ToShopDiscount(pbDiscount))
+	}
+
+	return discounts
+}
+
+func ToPbShopDiscount(discount ShopDiscount) *shopproductpb.ShopDiscount {
+	return &shopproductpb.ShopDiscount{
+		ShopId:   discount.ShopID,
+		Percent:  discount.Percent,
+		StartsAt: toPbOptionalTime(discount.StartsAt),
+		EndsAt:   toPbOptionalTime(discount.EndsAt),
+	}
+}
+
+func ToPromoCode(pbPromoCode *shopproductpb.PromoCode) PromoCode {
+	return PromoCode{
+		PromoCodeID:    pbPromoCode.GetId(),
+		ShopID:         pbPromoCode.GetShopId(),
+		Code:           pbPromoCode.GetCode(),
+		Kind:           pbPromoCode.GetKind(),
+		Value:          pbPromoCode.GetValue(),
+		MinOrder:       pbPromoCode.GetMinOrder(),
+		MaxUses:        pbPromoCode.GetMaxUses(),
+		MaxUsesPerUser: pbPromoCode.GetMaxUsesPerUser(),
+		UsedCount:      pbPromoCode.GetUsedCount(),
+		Currency:       pbPromoCode.GetCurrency(),
+		ExpiresAt:      toOptionalTime(pbPromoCode.GetExpiresAt()),
+		CreatedAt:      pbPromoCode.GetCreatedAt().AsTime(),
+	}
+}
+
+func ToPromoCodes(pbPromoCodes []*shopproductpb.PromoCode) []PromoCode {
+	promoCodes := make([]PromoCode, 0, len(pbPromoCodes))
+	for _, pbPromoCode := range pbPromoCodes {
+		promoCodes = append(promoCodes, ToPromoCode(pbPromoCode))
+	}
+
+	return promoCodes
+}
+
+func ToPbPromoCode(promoCode PromoCode) *shopproductpb.PromoCode {
+	return &shopproductpb.PromoCode{
+		ShopId:         promoCode.ShopID,
+		Code:           promoCode.Code,
+		Kind:           promoCode.Kind,
+		Value:          promoCode.Value,
+		MinOrder:       promoCode.MinOrder,
+		MaxUses:        promoCode.MaxUses,
+		MaxUsesPerUser: promoCode.MaxUsesPerUser,
+		ExpiresAt:      toPbOptionalTime(promoCode.ExpiresAt),
+	}
+}
+
+func ToPriceChange(pbChange *shopproductpb.PriceChange) PriceChange {
+	return PriceChange{
+		ChangeID:  pbChange.GetId(),
+		ProductID: pbChange.GetProductId(),
+		VariantID: pbChange.GetVariantId(),
+		ShopID:    pbChange.GetShopId(),
+		Kind:      pbChange.GetKind(),
+		OldPrice:  toOptionalMoney(pbChange.GetOldPrice()),
+		NewPrice:  toOptionalMoney(pbChange.GetNewPrice()),
+		Percent:   pbChange.GetPercent(),
+		StartsAt:  toOptionalTime(pbChange.GetStartsAt()),
+		EndsAt:    toOptionalTime(pbChange.GetEndsAt()),
+		UserID:    pbChange.GetUserId(),
+		CreatedAt: pbChange.GetCreatedAt().AsTime(),
+	}
+}
+
+func ToPriceChanges(pbChanges []*shopproductpb.PriceChange) []PriceChange {
+	changes := make([]PriceChange, 0, len(pbChanges))
+	for _, pbChange := range pbChanges {
+		changes = append(changes, ToPriceChange(pbChange))
+	}
+
+	return changes
+}
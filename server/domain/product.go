@@ -0,0 +1,302 @@
+package domain
+
+import (
+	shopproductpb "pinterest/services/shopProduct/proto"
+	"time"
+)
+
+// MediaPath is prefix of uploaded media's URLs, media links from services are relative to it
+const MediaPath = "/api/media/"
+
+// ImageChunkSize is size of chunks in which images are streamed to shopProduct service
+const ImageChunkSize = 64 * 1024
+
+type Product struct {
+	ProductID   uint64 `json:"ID"`
+	ShopID      uint64 `json:"shopID"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// SKU is unique within shop, catalog imports match products by it. It is empty for products created without one
+	SKU string `json:"sku"`
+	// Price is in currency of product's shop
+	Price Money `json:"price"`
+	// DiscountedPrice is price with active sale or shop discount applied, it equals price if there are none.
+	// It can not be set by managers
+	DiscountedPrice Money `json:"discountedPrice"`
+	// ConvertedPrice and ConvertedDiscountedPrice are prices in currency which buyer prefers,
+	// they are omitted if buyer prefers none or currency of product's shop has no exchange rate
+	ConvertedPrice           *Money `json:"convertedPrice,omitempty"`
+	ConvertedDiscountedPrice *Money `json:"convertedDiscountedPrice,omitempty"`
+	// Availability is true if some of stock units are not reserved, it can not be set by managers
+	Availability bool `json:"availability"`
+	// Stock is amount of units on hand including reserved ones, it is set only on creation,
+	// later it is changed by stock adjustments
+	Stock    uint64 `json:"stock"`
+	Reserved uint64 `json:"reserved"`
+	// AssemblyTime is measured in minutes
+	AssemblyTime uint64 `json:"assemblyTime"`
+	PartsAmount  uint64 `json:"partsAmount"`
+	// Rating is average rating of product's reviews, it can not be set by managers
+	Rating       float32 `json:"rating"`
+	ReviewsCount uint64  `json:"reviewsCount"`
+	// RatingHistogram contains amounts of reviews with ratings from 1 to 5
+	RatingHistogram []uint64 `json:"ratingHistogram"`
+	// SavesCount is amount of users who saved product to their wishlists, it can not be set by managers
+	SavesCount uint64 `json:"savesCount"`
+	// IsSaved is true if current user saved product, it is returned only for single product
+	IsSaved    bool           `json:"isSaved,omitempty"`
+	Size       string         `json:"size"`
+	CategoryID uint64         `json:"categoryID"`
+	ImageLinks []string       `json:"imageLinks"`
+	Images     []ProductImage `json:"images,omitempty"`
+	// Options and Variants are empty for products without variants, they can not be set when product is created or edited
+	Options  []ProductOption  `json:"options"`
+	Variants []ProductVariant `json:"variants"`
+	// SelectedVariantID is set if product was requested with variant, then price, stock and images are variant's ones
+	SelectedVariantID uint64 `json:"selectedVariantID,omitempty"`
+	// DeletedAt is set only in list of shop's deleted products, they can be restored for 30 days after deletion
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+// ProductOption is option type, such as size or colour, with values which product's variants can have
+type ProductOption struct {
+	Name   string   `json:"name"`
+	Values []string `json:"values"`
+}
+
+// ProductOptionsInput is used when parsing JSON in product options handler
+type ProductOptionsInput struct {
+	Options []ProductOption `json:"options"`
+}
+
+// ProductVariant is purchasable combination of product's option values. PriceOverride is omitted if variant
+// costs the same as product, ImageIDs are subset of product's images and empty subset means all of them.
+// Price, DiscountedPrice, converted prices, Reserved, Availability and ImageLinks can not be set,
+// Stock is set only on creation
+type ProductVariant struct {
+	VariantID                uint64            `json:"ID"`
+	SKU                      string            `json:"sku"`
+	Options                  map[string]string `json:"options"`
+	Price                    Money             `json:"price"`
+	DiscountedPrice          Money             `json:"discountedPrice"`
+	PriceOverride            *Money            `json:"priceOverride,omitempty"`
+	ConvertedPrice           *Money            `json:"convertedPrice,omitempty"`
+	ConvertedDiscountedPrice *Money            `json:"convertedDiscountedPrice,omitempty"`
+	Stock                    uint64            `json:"stock"`
+	Reserved                 uint64            `json:"reserved"`
+	Availability             bool              `json:"availability"`
+	ImageIDs                 []uint64          `json:"imageIDs"`
+	ImageLinks               []string          `json:"imageLinks"`
+}
+
+type VariantIDResponse struct {
+	VariantID uint64 `json:"ID"`
+}
+
+type ProductImage struct {
+	ImageID       uint64 `json:"ID"`
+	Position      uint64 `json:"position"`
+	IsPrimary     bool   `json:"isPrimary"`
+	ThumbnailLink string `json:"thumbnailLink"`
+	MediumLink    string `json:"mediumLink"`
+	LargeLink     string `json:"largeLink"`
+	Height        uint64 `json:"imageHeight"`
+	Width         uint64 `json:"imageWidth"`
+	AvgColor      string `json:"imageAvgColor"`
+}
+
+// ImageOrderInput is used when parsing JSON in product images order handler
+type ImageOrderInput struct {
+	ImageIDs []uint64 `json:"imageIDs"`
+}
+
+type ProductsListResponse struct {
+	Products []Product `json:"products"`
+	// NextCursor is passed as cursor to get next page, it is omitted on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+type ProductImagesResponse struct {
+	Images []ProductImage `json:"images"`
+}
+
+type ProductIDResponse struct {
+	ProductID uint64 `json:"ID"`
+}
+
+func ToProduct(pbProduct *shopproductpb.Product) Product {
+	imageLinks := make([]string, 0, len(pbProduct.GetImageLinks()))
+	for _, link := range pbProduct.GetImageLinks() {
+		imageLinks = append(imageLinks, MediaPath+link)
+	}
+
+	return Product{
+		ProductID:                pbProduct.GetId(),
+		ShopID:                   pbProduct.GetShopId(),
+		Title:                    pbProduct.GetTitle(),
+		Description:              pbProduct.GetDescription(),
+		SKU:                      pbProduct.GetSku(),
+		Price:                    ToMoney(pbProduct.GetPrice()),
+		DiscountedPrice:          ToMoney(pbProduct.GetDiscountedPrice()),
+		ConvertedPrice:           toOptionalMoney(pbProduct.GetConvertedPrice()),
+		ConvertedDiscountedPrice: toOptionalMoney(pbProduct.GetConvertedDiscountedPrice()),
+		Availability:             pbProduct.GetAvailability(),
+		Stock:                    pbProduct.GetStock(),
+		Reserved:                 pbProduct.GetReserved(),
+		AssemblyTime:             pbProduct.GetAssemblyTime(),
+		PartsAmount:              pbProduct.GetPartsAmount(),
+		Rating:                   pbProduct.GetRating(),
+		ReviewsCount:             pbProduct.GetReviewsCount(),
+		RatingHistogram:          pbProduct.GetRatingHistogram(),
+		SavesCount:               pbProduct.GetSavesCount(),
+		IsSaved:                  pbProduct.GetIsSaved(),
+		Size:                     pbProduct.GetSize(),
+		CategoryID:               pbProduct.GetCategoryId(),
+		ImageLinks:               imageLinks,
+		Images:                   ToProductImages(pbProduct.GetImages()),
+		Options:                  ToProductOptions(pbProduct.GetOptions()),
+		Variants:                 ToProductVariants(pbProduct.GetVariants()),
+		SelectedVariantID:        pbProduct.GetSelectedVariantId(),
+		DeletedAt:                toOptionalTime(pbProduct.GetDeletedAt()),
+	}
+}
+
+func ToProductOptions(pbOptions []*shopproductpb.ProductOption) []ProductOption {
+	options := make([]ProductOption, 0, len(pbOptions))
+	for _, pbOption := range pbOptions {
+		options = append(options, ProductOption{Name: pbOption.GetName(), Values: pbOption.GetValues()})
+	}
+
+	return options
+}
+
+func ToPbProductOptions(options []ProductOption) []*shopproductpb.ProductOption {
+	pbOptions := make([]*shopproductpb.ProductOption, 0, len(options))
+	for _, option := range options {
+		pbOptions = append(pbOptions, &shopproductpb.ProductOption{Name: option.Name, Values: option.Values})
+	}
+
+	return pbOptions
+}
+
+func ToProductVariant(pbVariant *shopproductpb.ProductVariant) ProductVariant {
+	imageLinks := make([]string, 0, len(pbVariant.GetImageLinks()))
+	for _, link := range pbVariant.GetImageLinks() {
+		imageLinks = append(imageLinks, MediaPath+link)
+	}
+
+	options := pbVariant.GetOptions()
+	if options == nil {
+		options = make(map[string]string)
+	}
+
+	imageIDs := pbVariant.GetImageIds()
+	if imageIDs == nil {
+		imageIDs = make([]uint64, 0)
+	}
+
+	return ProductVariant{
+		VariantID:                pbVariant.GetId(),
+		SKU:                      pbVariant.GetSku(),
+		Options:                  options,
+		Price:                    ToMoney(pbVariant.GetPrice()),
+		DiscountedPrice:          ToMoney(pbVariant.GetDiscountedPrice()),
+		PriceOverride:            toOptionalMoney(pbVariant.GetPriceOverride()),
+		ConvertedPrice:           toOptionalMoney(pbVariant.GetConvertedPrice()),
+		ConvertedDiscountedPrice: toOptionalMoney(pbVariant.GetConvertedDiscountedPrice()),
+		Stock:                    pbVariant.GetStock(),
+		Reserved:                 pbVariant.GetReserved(),
+		Availability:             pbVariant.GetAvailability(),
+		ImageIDs:                 imageIDs,
+		ImageLinks:               imageLinks,
+	}
+}
+
+func ToProductVariants(pbVariants []*shopproductpb.ProductVariant) []ProductVariant {
+	variants := make([]ProductVariant, 0, len(pbVariants))
+	for _, pbVariant := range pbVariants {
+		variants = append(variants, ToProductVariant(pbVariant))
+	}
+
+	return variants
+}
+
+func ToPbEditVariantRequest(productID uint64, variant ProductVariant, userID uint64) *shopproductpb.EditVariantRequest {
+	return &shopproductpb.EditVariantRequest{
+		ProductId: productID,
+		UserId:    userID,
+		Variant: &shopproductpb.ProductVariant{
+			Id:            variant.VariantID,
+			ProductId:     productID,
+			Sku:           variant.SKU,
+			Options:       variant.Options,
+			PriceOverride: toPbOptionalMoney(variant.PriceOverride),
+			Stock:         variant.Stock,
+			ImageIds:      variant.ImageIDs,
+		},
+	}
+}
+
+func ToProductImage(pbImage *shopproductpb.ProductImage) ProductImage {
+	return ProductImage{
+		ImageID:       pbImage.GetId(),
+		Position:      pbImage.GetPosition(),
+		IsPrimary:     pbImage.GetIsPrimary(),
+		ThumbnailLink: MediaPath + pbImage.GetThumbnailLink(),
+		MediumLink:    MediaPath + pbImage.GetMediumLink(),
+		LargeLink:     MediaPath + pbImage.GetLargeLink(),
+		Height:        pbImage.GetHeight(),
+		Width:         pbImage.GetWidth(),
+		AvgColor:      pbImage.GetAvgColor(),
+	}
+}
+
+func ToProductImages(pbImages []*shopproductpb.ProductImage) []ProductImage {
+	images := make([]ProductImage, 0, len(pbImages))
+	for _, pbImage := range pbImages {
+		images = append(images, ToProductImage(pbImage))
+	}
+
+	return images
+}
+
+func ToPbCreateProductRequest(product Product, userID uint64) *shopproductpb.CreateProductRequest {
+	return &shopproductpb.CreateProductRequest{
+		Title:        product.Title,
+		Description:  product.Description,
+		Sku:          product.SKU,
+		Price:        ToPbMoney(product.Price),
+		Stock:        product.Stock,
+		AssemblyTime: product.AssemblyTime,
+		PartsAmount:  product.PartsAmount,
+		Size:         product.Size,
+		CategoryId:   product.CategoryID,
+		ShopId:       product.ShopID,
+		UserId:       userID,
+	}
+}
+
+func ToPbEditProductRequest(product Product, userID uint64) *shopproductpb.EditProductRequest {
+	return &shopproductpb.EditProductRequest{
+		Id:           product.ProductID,
+		Title:        product.Title,
+		Description:  product.Description,
+		Sku:          product.SKU,
+		Price:        ToPbMoney(product.Price),
+		AssemblyTime: product.AssemblyTime,
+		PartsAmount:  product.PartsAmount,
+		Size:         product.Size,
+		CategoryId:   product.CategoryID,
+		ShopId:       product.ShopID,
+		UserId:       userID,
+	}
+}
+
+func ToProducts(pbProducts []*shopproductpb.Product) []Product {
+	products := make([]Product, 0, len(pbProducts))
+	for _, pbProduct := range pbProducts {
+		products = append(products, ToProduct(pbProduct))
+	}
+
+	return products
+}
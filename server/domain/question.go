@@ -0,0 +1,83 @@
+package domain
+
+import (
+	shopproductpb "pinterest/services/shopProduct/proto"
+	"time"
+)
+
+// ProductQuestion is question about product, IsVoted shows whether current user voted for it.
+// Official answer goes first among answers, other answers go from oldest to newest
+type ProductQuestion struct {
+	QuestionID   uint64          `json:"ID"`
+	ProductID    uint64          `json:"productID"`
+	UserID       uint64          `json:"userID"`
+	Username     string          `json:"username"`
+	Text         string          `json:"text"`
+	VotesCount   uint64          `json:"votesCount"`
+	AnswersCount uint64          `json:"answersCount"`
+	IsVoted      bool            `json:"isVoted"`
+	Answers      []ProductAnswer `json:"answers"`
+	CreatedAt    time.Time       `json:"createdAt"`
+}
+
+// ProductAnswer is answer to question, AuthorRole is manager for managers of product's shop and buyer for
+// users who have received product
+type ProductAnswer struct {
+	AnswerID   uint64    `json:"ID"`
+	QuestionID uint64    `json:"questionID"`
+	UserID     uint64    `json:"userID"`
+	Username   string    `json:"username"`
+	Text       string    `json:"text"`
+	AuthorRole string    `json:"authorRole"`
+	IsOfficial bool      `json:"isOfficial"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// QuestionInput is used when parsing JSON of questions and answers
+type QuestionInput struct {
+	Text string `json:"text"`
+}
+
+type QuestionIDResponse struct {
+	ID uint64 `json:"ID"`
+}
+
+type QuestionsListResponse struct {
+	Questions []ProductQuestion `json:"questions"`
+	// NextCursor is passed as cursor to get next page, it is omitted on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+func ToProductQuestions(pbQuestions []*shopproductpb.Question) []ProductQuestion {
+	questions := make([]ProductQuestion, 0, len(pbQuestions))
+	for _, pbQuestion := range pbQuestions {
+		answers := make([]ProductAnswer, 0, len(pbQuestion.GetAnswers()))
+		for _, pbAnswer := range pbQuestion.GetAnswers() {
+			answers = append(answers, ProductAnswer{
+				AnswerID:   pbAnswer.GetId(),
+				QuestionID: pbAnswer.GetQuestionId(),
+				UserID:     pbAnswer.GetUserId(),
+				Username:   pbAnswer.GetUsername(),
+				Text:       pbAnswer.GetText(),
+				AuthorRole: pbAnswer.GetAuthorRole(),
+				IsOfficial: pbAnswer.GetIsOfficial(),
+				CreatedAt:  pbAnswer.GetCreatedAt().AsTime(),
+			})
+		}
+
+		questions = append(questions, ProductQuestion{
+			QuestionID:   pbQuestion.GetId(),
+			ProductID:    pbQuestion.GetProductId(),
+			UserID:       pbQuestion.GetUserId(),
+			Username:     pbQuestion.GetUsername(),
+			Text:         pbQuestion.GetText(),
+			VotesCount:   pbQuestion.GetVotesCount(),
+			AnswersCount: pbQuestion.GetAnswersCount(),
+			IsVoted:      pbQuestion.GetIsVoted(),
+			Answers:      answers,
+			CreatedAt:    pbQuestion.GetCreatedAt().AsTime(),
+		})
+	}
+
+	return questions
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	shopproductpb "pinterest/services/shopProduct/proto"
+	"time"
+)
+
+// ProductReview is used both as review creation input and as review in responses
+type ProductReview struct {
+	ReviewID  uint64    `json:"ID"`
+	ProductID uint64    `json:"productID"`
+	UserID    uint64    `json:"userID"`
+	Username  string    `json:"username"`
+	Title     string    `json:"title"`
+	Text      string    `json:"text"`
+	Rating    uint32    `json:"rating"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type ReviewIDResponse struct {
+	ReviewID uint64 `json:"ID"`
+}
+
+type ReviewsListResponse struct {
+	Reviews []ProductReview `json:"reviews"`
+	// NextCursor is passed as cursor to get next page, it is omitted on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+func ToProductReview(pbReview *shopproductpb.Review) ProductReview {
+	return ProductReview{
+		ReviewID:  pbReview.GetId(),
+		ProductID: pbReview.GetProductId(),
+		UserID:    pbReview.GetUserId(),
+		Username:  pbReview.GetUsername(),
+		Title:     pbReview.GetTitle(),
+		Text:      pbReview.GetText(),
+		Rating:    pbReview.GetRating(),
+		CreatedAt: pbReview.GetCreatedAt().AsTime(),
+		UpdatedAt: pbReview.GetUpdatedAt().AsTime(),
+	}
+}
+
+func ToProductReviews(pbReviews []*shopproductpb.Review) []ProductReview {
+	reviews := make([]ProductReview, 0, len(pbReviews))
+	for _, pbReview := range pbReviews {
+		reviews = append(reviews, ToProductReview(pbReview))
+	}
+
+	return reviews
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"net/url"
+	shopproductpb "pinterest/services/shopProduct/proto"
+	"strconv"
+)
+
+// Query parameters of products search, besides pagination ones
+const (
+	PriceMinKey        = "priceMin"
+	PriceMaxKey        = "priceMax"
+	CategoryKey        = "category"
+	SizeKey            = "size"
+	AvailableKey       = "available"
+	AssemblyTimeMinKey = "assemblyTimeMin"
+	AssemblyTimeMaxKey = "assemblyTimeMax"
+	PartsAmountMinKey  = "partsAmountMin"
+	PartsAmountMaxKey  = "partsAmountMax"
+	MinRatingKey       = "minRating"
+)
+
+// SearchInput describes products search, as it comes from query parameters. Category and size
+// parameters can be repeated, products must match any of them. Products of subcategories match their categories
+type SearchInput struct {
+	Query           string
+	PriceMin        uint64
+	PriceMax        uint64
+	CategoryIDs     []uint64
+	Sizes           []string
+	OnlyAvailable   bool
+	AssemblyTimeMin uint64
+	AssemblyTimeMax uint64
+	PartsAmountMin  uint64
+	PartsAmountMax  uint64
+	MinRating       float32
+	// Currency is currency of price filters and facet, it is set from currency which buyer prefers
+	Currency string
+	Page     PageInput
+}
+
+// ParseSearchInput reads search query from searchKey parameter, filters and pagination parameters
+func ParseSearchInput(query url.Values) (search SearchInput, err error) {
+	search.Query = query.Get(SearchKeyKey)
+	search.Sizes = query[SizeKey]
+
+	for _, category := range query[CategoryKey] {
+		categoryID, err := strconv.ParseUint(category, 10, 64)
+		if err != nil {
+			return SearchInput{}, err
+		}
+		search.CategoryIDs = append(search.CategoryIDs, categoryID)
+	}
+
+	uintParams := map[string]*uint64{
+		PriceMinKey:        &search.PriceMin,
+		PriceMaxKey:        &search.PriceMax,
+		AssemblyTimeMinKey: &search.AssemblyTimeMin,
+		AssemblyTimeMaxKey: &search.AssemblyTimeMax,
+		PartsAmountMinKey:  &search.PartsAmountMin,
+		PartsAmountMaxKey:  &search.PartsAmountMax,
+	}
+	for key, value := range uintParams {
+		if param := query.Get(key); param != "" {
+			*value, err = strconv.ParseUint(param, 10, 64)
+			if err != nil {
+				return SearchInput{}, err
+			}
+		}
+	}
+
+	if available := query.Get(AvailableKey); available != "" {
+		search.OnlyAvailable, err = strconv.ParseBool(available)
+		if err != nil {
+			return SearchInput{}, err
+		}
+	}
+
+	if minRating := query.Get(MinRatingKey); minRating != "" {
+		rating, err := strconv.ParseFloat(minRating, 32)
+		if err != nil {
+			return SearchInput{}, err
+		}
+		search.MinRating = float32(rating)
+	}
+
+	search.Page, err = ParsePageInput(query, ProductAmountKey, ProductPageKey)
+	if err != nil {
+		return SearchInput{}, err
+	}
+
+	return search, nil
+}
+
+func ToPbSearchProductsRequest(search SearchInput) *shopproductpb.SearchProductsRequest {
+	return &shopproductpb.SearchProductsRequest{
+		Query:           search.Query,
+		PriceMin:        search.PriceMin,
+		PriceMax:        search.PriceMax,
+		CategoryIds:     search.CategoryIDs,
+		Sizes:           search.Sizes,
+		OnlyAvailable:   search.OnlyAvailable,
+		AssemblyTimeMin: search.AssemblyTimeMin,
+		AssemblyTimeMax: search.AssemblyTimeMax,
+		PartsAmountMin:  search.PartsAmountMin,
+		PartsAmountMax:  search.PartsAmountMax,
+		MinRating:       search.MinRating,
+		Currency:        search.Currency,
+		Sorting:         search.Page.Sorting,
+		Limit:           search.Page.Limit,
+		Cursor:          search.Page.Cursor,
+		Page:            search.Page.Page,
+	}
+}
+
+type FacetValue struct {
+	Value string `json:"value"`
+	Count uint64 `json:"count"`
+}
+
+type RangeFacet struct {
+	Min uint64 `json:"min"`
+	Max uint64 `json:"max"`
+}
+
+// SearchFacets are counted for every filter with all other filters applied
+type SearchFacets struct {
+	// Categories are counted by category ids, subcategories are counted separately
+	Categories   []FacetValue `json:"categories"`
+	Sizes        []FacetValue `json:"sizes"`
+	Availability []FacetValue `json:"availability"`
+	Price        RangeFacet   `json:"price"`
+	// PriceCurrency is currency of price filters and facet, prices of products in other currencies are converted
+	PriceCurrency string     `json:"priceCurrency"`
+	AssemblyTime  RangeFacet `json:"assemblyTime"`
+	PartsAmount   RangeFacet `json:"partsAmount"`
+	// MinRating contains amounts of products whose rating is at least 1, 2, 3 and 4
+	MinRating []FacetValue `json:"minRating"`
+}
+
+type SearchResponse struct {
+	Products []Product    `json:"products"`
+	Total    uint64       `json:"total"`
+	Facets   SearchFacets `json:"facets"`
+	// NextCursor is passed as cursor to get next page, it is omitted on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+func ToFacetValues(pbValues []*shopproductpb.FacetValue) []FacetValue {
+	values := make([]FacetValue, 0, len(pbValues))
+	for _, pbValue := range pbValues {
+		values = append(values, FacetValue{Value: pbValue.GetValue(), Count: pbValue.GetCount()})
+	}
+
+	return values
+}
+
+func ToRangeFacet(pbFacet *shopproductpb.RangeFacet) RangeFacet {
+	return RangeFacet{Min: pbFacet.GetMin(), Max: pbFacet.GetMax()}
+}
+
+func ToSearchResponse(pbResponse *shopproductpb.SearchProductsResponse) SearchResponse {
+	pbFacets := pbResponse.GetFacets()
+	return SearchResponse{
+		Products: ToProducts(pbResponse.GetProducts()),
+		Total:    pbResponse.GetTotal(),
+		Facets: SearchFacets{
+			Categories:    ToFacetValues(pbFacets.GetCategories()),
+			Sizes:         ToFacetValues(pbFacets.GetSizes()),
+			Availability:  ToFacetValues(pbFacets.GetAvailability()),
+			Price:         ToRangeFacet(pbFacets.GetPrice()),
+			AssemblyTime:  ToRangeFacet(pbFacets.GetAssemblyTime()),
+			PartsAmount:   ToRangeFacet(pbFacets.GetPartsAmount()),
+			MinRating:     ToFacetValues(pbFacets.GetMinRating()),
+			PriceCurrency: pbFacets.GetPriceCurrency(),
+		},
+		NextCursor: pbResponse.GetNextCursor(),
+	}
+}
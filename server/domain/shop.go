@@ -0,0 +1,89 @@
+package domain
+
+import (
+	shopproductpb "pinterest/services/shopProduct/proto"
+	"strings"
+)
+
+type Shop struct {
+	ShopID      uint64 `json:"ID"`
+	Title       string `json:"name"`
+	Description string `json:"description"`
+	// Currency is ISO 4217 code of shop's base currency, prices of its products are in it. It is RUB if it is
+	// omitted on creation and can be changed only while shop has no products
+	Currency   string   `json:"currency"`
+	ManagerIDs []uint64 `json:"managerIDs"`
+	OwnerIDs   []uint64 `json:"ownerIDs"`
+}
+
+// ShopInvitation is an offer to become shop's manager, which current user can accept or decline
+type ShopInvitation struct {
+	InvitationID uint64 `json:"ID"`
+	ShopID       uint64 `json:"shopID"`
+	ShopTitle    string `json:"shopName"`
+	InviterID    uint64 `json:"inviterID"`
+	Role         string `json:"role"`
+}
+
+// ManagerInvitationInput is used when parsing JSON in shop managers handler. Role is "manager" if omitted
+type ManagerInvitationInput struct {
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+type ShopInvitationsResponse struct {
+	Invitations []ShopInvitation `json:"invitations"`
+}
+
+type InvitationIDResponse struct {
+	InvitationID uint64 `json:"ID"`
+}
+
+type ShopIDResponse struct {
+	ShopID uint64 `json:"ID"`
+}
+
+func ToShop(pbShop *shopproductpb.Shop) Shop {
+	return Shop{
+		ShopID:      pbShop.GetId(),
+		Title:       pbShop.GetTitle(),
+		Description: pbShop.GetDescription(),
+		Currency:    pbShop.GetCurrency(),
+		ManagerIDs:  pbShop.GetManagerIds(),
+		OwnerIDs:    pbShop.GetOwnerIds(),
+	}
+}
+
+func ToPbCreateShopRequest(shop Shop, userID uint64) *shopproductpb.CreateShopRequest {
+	return &shopproductpb.CreateShopRequest{
+		Title:       shop.Title,
+		Description: shop.Description,
+		Currency:    strings.ToUpper(shop.Currency),
+		UserId:      userID,
+	}
+}
+
+func ToPbEditShopRequest(shop Shop, userID uint64) *shopproductpb.EditShopRequest {
+	return &shopproductpb.EditShopRequest{
+		Id:          shop.ShopID,
+		Title:       shop.Title,
+		Description: shop.Description,
+		Currency:    strings.ToUpper(shop.Currency),
+		UserId:      userID,
+	}
+}
+
+func ToShopInvitations(pbInvitations *shopproductpb.ShopInvitations) []ShopInvitation {
+	invitations := make([]ShopInvitation, 0, len(pbInvitations.GetInvitations()))
+	for _, pbInvitation := range pbInvitations.GetInvitations() {
+		invitations = append(invitations, ShopInvitation{
+			InvitationID: pbInvitation.GetId(),
+			ShopID:       pbInvitation.GetShopId(),
+			ShopTitle:    pbInvitation.GetShopTitle(),
+			InviterID:    pbInvitation.GetInviterId(),
+			Role:         pbInvitation.GetRole(),
+		})
+	}
+
+	return invitations
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	shopproductpb "pinterest/services/shopProduct/proto"
+	"time"
+)
+
+// StockAdjustmentInput is used when parsing JSON in stock adjustment handler.
+// Delta is added to stock, it is negative for write-offs. VariantID must be set for products with variants
+type StockAdjustmentInput struct {
+	VariantID uint64 `json:"variantID"`
+	Delta     int64  `json:"delta"`
+	Comment   string `json:"comment"`
+}
+
+// InventoryMovement is entry of product's inventory ledger
+type InventoryMovement struct {
+	MovementID uint64 `json:"ID"`
+	ProductID  uint64 `json:"productID"`
+	// VariantID is omitted for products without variants and for deleted variants
+	VariantID uint64 `json:"variantID,omitempty"`
+	// Kind is one of adjustment, reserve, commit, release and expire
+	Kind          string `json:"kind"`
+	StockDelta    int64  `json:"stockDelta"`
+	ReservedDelta int64  `json:"reservedDelta"`
+	StockAfter    uint64 `json:"stockAfter"`
+	ReservedAfter uint64 `json:"reservedAfter"`
+	// ReservationID is omitted for adjustments, UserID is omitted for reservation movements
+	ReservationID uint64    `json:"reservationID,omitempty"`
+	UserID        uint64    `json:"userID,omitempty"`
+	Comment       string    `json:"comment"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type MovementsListResponse struct {
+	Movements []InventoryMovement `json:"movements"`
+	// NextCursor is passed as cursor to get next page, it is omitted on the last page
+	NextCursor string `json:"nextCursor,omitempty"`
+}
+
+func ToInventoryMovement(pbMovement *shopproductpb.InventoryMovement) InventoryMovement {
+	return InventoryMovement{
+		MovementID:    pbMovement.GetId(),
+		ProductID:     pbMovement.GetProductId(),
+		VariantID:     pbMovement.GetVariantId(),
+		Kind:          pbMovement.GetKind(),
+		StockDelta:    pbMovement.GetStockDelta(),
+		ReservedDelta: pbMovement.GetReservedDelta(),
+		StockAfter:    pbMovement.GetStockAfter(),
+		ReservedAfter: pbMovement.GetReservedAfter(),
+		ReservationID: pbMovement.GetReservationId(),
+		UserID:        pbMovement.GetUserId(),
+		Comment:       pbMovement.GetComment(),
+		CreatedAt:     pbMovement.GetCreatedAt().AsTime(),
+	}
+}
+
+func ToInventoryMovements(pbMovements []*shopproductpb.InventoryMovement) []InventoryMovement {
+	movements := make([]InventoryMovement, 0, len(pbMovements))
+	for _, pbMovement := range pbMovements {
+		movements = append(movements, ToInventoryMovement(pbMovement))
+	}
+
+	return movements
+}
@@ -9,6 +9,32 @@ type User struct {
 	FirstName string `json:"firstName,omitempty"`
 	LastName  string `json:"lastName,omitempty"`
 	Email     string `json:"email,omitempty"`
+	IsPrivate bool   `json:"isPrivate,omitempty"`
+}
+
+// UserPair describes relation of user with id UserID to user with id TargetID, for example block
+type UserPair struct {
+	UserID   uint64
+	TargetID uint64
+}
+
+type UsersListResponse struct {
+	Users []User `json:"users"`
+}
+
+type PrivacySettings struct {
+	PrivateProfile bool `json:"privateProfile"`
+	HideRealName   bool `json:"hideRealName"`
+	HideFollowers  bool `json:"hideFollowers"`
+	Searchable     bool `json:"searchable"`
+}
+
+// PrivacySettingsInput is used when parsing JSON in profile/privacy handler. Omitted settings are left unchanged
+type PrivacySettingsInput struct {
+	PrivateProfile *bool `json:"privateProfile"`
+	HideRealName   *bool `json:"hideRealName"`
+	HideFollowers  *bool `json:"hideFollowers"`
+	Searchable     *bool `json:"searchable"`
 }
 
 type UserIDResponse struct {
@@ -42,5 +68,52 @@ func ToUser(pbuser *userpb.UserOutput) *User {
 		FirstName: pbuser.GetFirstName(),
 		LastName:  pbuser.GetLastName(),
 		Email:     pbuser.GetEmail(),
+		IsPrivate: pbuser.GetIsPrivate(),
+	}
+}
+
+func ToPrivacySettings(pbSettings *userpb.PrivacySettings) PrivacySettings {
+	return PrivacySettings{
+		PrivateProfile: pbSettings.GetPrivateProfile(),
+		HideRealName:   pbSettings.GetHideRealName(),
+		HideFollowers:  pbSettings.GetHideFollowers(),
+		Searchable:     pbSettings.GetSearchable(),
+	}
+}
+
+func ToPbPrivacySettings(settings PrivacySettings) *userpb.PrivacySettings {
+	return &userpb.PrivacySettings{
+		PrivateProfile: settings.PrivateProfile,
+		HideRealName:   settings.HideRealName,
+		HideFollowers:  settings.HideFollowers,
+		Searchable:     settings.Searchable,
+	}
+}
+
+// Apply changes settings that were specified in input
+func (input PrivacySettingsInput) Apply(settings PrivacySettings) PrivacySettings {
+	if input.PrivateProfile != nil {
+		settings.PrivateProfile = *input.PrivateProfile
+	}
+	if input.HideRealName != nil {
+		settings.HideRealName = *input.HideRealName
+	}
+	if input.HideFollowers != nil {
+		settings.HideFollowers = *input.HideFollowers
+	}
+	if input.Searchable != nil {
+		settings.Searchable = *input.Searchable
+	}
+	return settings
+}
+
+func ToPbUserPairs(pairs []UserPair) []*userpb.UserPair {
+	pbPairs := make([]*userpb.UserPair, 0, len(pairs))
+	for _, pair := range pairs {
+		pbPairs = append(pbPairs, &userpb.UserPair{
+			UserID:   pair.UserID,
+			TargetID: pair.TargetID,
+		})
 	}
+	return pbPairs
 }
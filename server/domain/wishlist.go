@@ -0,0 +1,81 @@
+package domain
+
+import (
+	shopproductpb "pinterest/services/shopProduct/proto"
+	"time"
+)
+
+// WishlistSharePath is path of share links, wishlist's share token is appended to it
+const WishlistSharePath = "/api/wishlists/shared/"
+
+// WishlistInput is used when parsing JSON in wishlist handlers. Empty title is left unchanged when wishlist is edited
+type WishlistInput struct {
+	Title    string `json:"title"`
+	IsPublic bool   `json:"isPublic"`
+}
+
+// SaveProductInput is used when parsing JSON in save product handler, default wishlist is used if WishlistID is 0
+type SaveProductInput struct {
+	WishlistID uint64 `json:"wishlistID"`
+}
+
+// Wishlist is named list of saved products. ShareLink is returned only to wishlist's owner,
+// Products are returned only for single wishlist
+type Wishlist struct {
+	WishlistID    uint64    `json:"ID"`
+	UserID        uint64    `json:"userID"`
+	Title         string    `json:"title"`
+	IsDefault     bool      `json:"isDefault"`
+	IsPublic      bool      `json:"isPublic"`
+	ShareLink     string    `json:"shareLink,omitempty"`
+	ProductsCount uint64    `json:"productsCount"`
+	Products      []Product `json:"products,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+type WishlistsListResponse struct {
+	Wishlists []Wishlist `json:"wishlists"`
+}
+
+// WishlistIDResponse returns id of created wishlist, or of wishlist where product was saved
+type WishlistIDResponse struct {
+	WishlistID uint64 `json:"wishlistID"`
+}
+
+func ToWishlist(pbWishlist *shopproductpb.Wishlist) Wishlist {
+	var products []Product
+	if len(pbWishlist.GetProducts()) != 0 {
+		products = make([]Product, 0, len(pbWishlist.GetProducts()))
+		for _, pbProduct := range pbWishlist.GetProducts() {
+			products = append(products, ToProduct(pbProduct))
+		}
+	}
+
+	shareLink := ""
+	if pbWishlist.GetShareToken() != "" {
+		shareLink = WishlistSharePath + pbWishlist.GetShareToken()
+	}
+
+	return Wishlist{
+		WishlistID:    pbWishlist.GetId(),
+		UserID:        pbWishlist.GetUserId(),
+		Title:         pbWishlist.GetTitle(),
+		IsDefault:     pbWishlist.GetIsDefault(),
+		IsPublic:      pbWishlist.GetIsPublic(),
+		ShareLink:     shareLink,
+		ProductsCount: pbWishlist.GetProductsCount(),
+		Products:      products,
+		CreatedAt:     pbWishlist.GetCreatedAt().AsTime(),
+		UpdatedAt:     pbWishlist.GetUpdatedAt().AsTime(),
+	}
+}
+
+func ToWishlists(pbWishlists []*shopproductpb.Wishlist) []Wishlist {
+	wishlists := make([]Wishlist, 0, len(pbWishlists))
+	for _, pbWishlist := range pbWishlists {
+		wishlists = append(wishlists, ToWishlist(pbWishlist))
+	}
+
+	return wishlists
+}
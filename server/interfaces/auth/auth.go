@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	authclient "pinterest/clients/auth"
+	shopproductclient "pinterest/clients/shopProduct"
 	"pinterest/domain"
 	"pinterest/interfaces/middleware"
 
@@ -14,20 +15,23 @@ import (
 	"go.uber.org/zap"
 )
 
-// AuthFacade calls auth app
+// AuthFacade calls auth app, shopProduct service is called to merge anonymous cart into user's cart on login
 type AuthFacade struct {
-	authClient authclient.AuthClientInterface
-	logger     *zap.Logger
+	authClient        authclient.AuthClientInterface
+	shopProductClient shopproductclient.ShopProductClientInterface
+	logger            *zap.Logger
 }
 
-func NewAuthFacade(authClient authclient.AuthClientInterface, logger *zap.Logger) *AuthFacade {
+func NewAuthFacade(authClient authclient.AuthClientInterface, shopProductClient shopproductclient.ShopProductClientInterface, logger *zap.Logger) *AuthFacade {
 	return &AuthFacade{
-		authClient: authClient,
-		logger:     logger,
+		authClient:        authClient,
+		shopProductClient: shopProductClient,
+		logger:            logger,
 	}
 }
 
-// LoginUser logs user in using provided username and password
+// LoginUser logs user in using provided username and password. Anonymous cart is merged into user's cart,
+// user is logged in even if merge fails
 func (facade *AuthFacade) LoginUser(w http.ResponseWriter, r *http.Request) {
 	userInput := new(domain.UserCredentialsInput)
 	err := json.NewDecoder(r.Body).Decode(userInput)
@@ -54,6 +58,18 @@ func (facade *AuthFacade) LoginUser(w http.ResponseWriter, r *http.Request) {
 
 	fmt.Println(cookieInfo.Cookie)
 
+	cartCookie, err := r.Cookie(domain.CartCookieName)
+	if err == nil {
+		err = facade.shopProductClient.MergeCarts(context.Background(), cartCookie.Value, cookieInfo.UserID)
+		if err != nil {
+			facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		} else {
+			cartCookie.Path = "/"
+			cartCookie.Expires = time.Now().AddDate(0, 0, -1) // Making cookie expire
+			http.SetCookie(w, cartCookie)
+		}
+	}
+
 	http.SetCookie(w, cookieInfo.Cookie)
 	w.WriteHeader(http.StatusNoContent)
 }
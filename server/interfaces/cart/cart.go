@@ -0,0 +1,174 @@
+package cart
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	authclient "pinterest/clients/auth"
+	shopproductclient "pinterest/clients/shopProduct"
+	"pinterest/domain"
+	"pinterest/interfaces/middleware"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// CartFacade calls shopProduct service for cart requests. Logged in users get their own carts,
+// anonymous visitors get carts identified by token in cookie
+type CartFacade struct {
+	shopProductClient shopproductclient.ShopProductClientInterface
+	authClient        authclient.AuthClientInterface
+	logger            *zap.Logger
+}
+
+func NewCartFacade(shopProductClient shopproductclient.ShopProductClientInterface, authClient authclient.AuthClientInterface, logger *zap.Logger) *CartFacade {
+	return &CartFacade{
+		shopProductClient: shopProductClient,
+		authClient:        authClient,
+		logger:            logger,
+	}
+}
+
+// GetCart returns cart grouped by shops, with current prices and stock of its products.
+// Prices are also converted into currency which buyer prefers
+func (facade *CartFacade) GetCart(w http.ResponseWriter, r *http.Request) {
+	userID, cartToken := facade.cartOwner(r)
+	cart, err := facade.shopProductClient.GetCart(context.Background(), userID, cartToken, domain.PreferredCurrency(r))
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeCartError(w, err)
+		return
+	}
+
+	facade.writeCart(w, r, userID, cartToken, cart)
+}
+
+// AddToCart adds quantity of product to cart, cart is created if there is none
+func (facade *CartFacade) AddToCart(w http.ResponseWriter, r *http.Request) {
+	itemInput := new(domain.CartItemInput)
+	err := json.NewDecoder(r.Body).Decode(itemInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userID, cartToken := facade.cartOwner(r)
+	cart, err := facade.shopProductClient.AddToCart(context.Background(), userID, cartToken, *itemInput, domain.PreferredCurrency(r))
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeCartError(w, err)
+		return
+	}
+
+	facade.writeCart(w, r, userID, cartToken, cart)
+}
+
+// UpdateCartItem sets quantity of product in cart, product is removed if quantity is 0
+func (facade *CartFacade) UpdateCartItem(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	itemInput := new(domain.CartItemInput)
+	err := json.NewDecoder(r.Body).Decode(itemInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	itemInput.ProductID = productID
+
+	userID, cartToken := facade.cartOwner(r)
+	cart, err := facade.shopProductClient.UpdateCartItem(context.Background(), userID, cartToken, *itemInput, domain.PreferredCurrency(r))
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeCartError(w, err)
+		return
+	}
+
+	facade.writeCart(w, r, userID, cartToken, cart)
+}
+
+// RemoveFromCart removes product from cart, variantID parameter selects which of product's variants is removed
+func (facade *CartFacade) RemoveFromCart(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	var variantID uint64
+	var err error
+	if variant := r.URL.Query().Get(domain.VariantIDKey); variant != "" {
+		variantID, err = strconv.ParseUint(variant, 10, 64)
+		if err != nil {
+			facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	userID, cartToken := facade.cartOwner(r)
+	cart, err := facade.shopProductClient.RemoveFromCart(context.Background(), userID, cartToken, productID, variantID, domain.PreferredCurrency(r))
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeCartError(w, err)
+		return
+	}
+
+	facade.writeCart(w, r, userID, cartToken, cart)
+}
+
+// cartOwner returns id of user who made request, or token of anonymous cart if they are not logged in
+func (facade *CartFacade) cartOwner(r *http.Request) (userID uint64, cartToken string) {
+	cookie, found := middleware.CheckCookies(r, facade.authClient)
+	if found {
+		return cookie.UserID, ""
+	}
+
+	cartCookie, err := r.Cookie(domain.CartCookieName)
+	if err != nil {
+		return 0, ""
+	}
+	return 0, cartCookie.Value
+}
+
+// writeCart writes cart, cookie of anonymous cart is set when cart gets new token and removed when token is unknown
+func (facade *CartFacade) writeCart(w http.ResponseWriter, r *http.Request, userID uint64, cartToken string, cart domain.Cart) {
+	responseBody, err := json.Marshal(cart)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if userID == 0 && cart.Token != cartToken {
+		cookie := &http.Cookie{
+			Name:     domain.CartCookieName,
+			Value:    cart.Token,
+			Path:     "/",
+			Expires:  time.Now().Add(domain.CartCookieLifetime),
+			HttpOnly: true,
+		}
+		if cart.Token == "" {
+			cookie.Expires = time.Now().AddDate(0, 0, -1) // Making cookie expire
+		}
+		http.SetCookie(w, cookie)
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// writeCartError writes status which corresponds to error returned when cart is changed
+func writeCartError(w http.ResponseWriter, err error) {
+	switch err {
+	case domain.ErrInvalidQuantity, domain.ErrCartQuantityTooLarge, domain.ErrVariantRequired,
+		domain.ErrInvalidCurrency, domain.ErrExchangeRateNotFound:
+		w.WriteHeader(http.StatusBadRequest)
+	case domain.ErrProductNotFound, domain.ErrCartItemNotFound, domain.ErrVariantNotFound:
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -2,9 +2,13 @@ package middleware
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"net/http"
 	authclient "pinterest/clients/auth"
 	"pinterest/domain"
+	"strings"
+	"time"
 
 	"go.uber.org/zap"
 
@@ -69,6 +73,21 @@ func CSRFSettingMid(next http.Handler) http.Handler {
 	})
 }
 
+// CSRFSkipMid disables CSRF check for requests which are not sent by browsers, it must go before CSRF middleware
+func CSRFSkipMid(pathPrefixes ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, prefix := range pathPrefixes {
+				if strings.HasPrefix(r.URL.Path, prefix) {
+					r = csrf.UnsafeSkipCheck(r)
+					break
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
 // CheckCookies returns *CookieInfo and true if cookie is present in sessions slice, nil and false othervise
 func CheckCookies(r *http.Request, authClient authclient.AuthClientInterface) (*domain.CookieInfo, bool) {
 	cookie, err := r.Cookie(string(domain.DefaultCookieName))
@@ -83,3 +102,34 @@ func CheckCookies(r *http.Request, authClient authclient.AuthClientInterface) (*
 
 	return cookieInfo, true
 }
+
+// TrackPageView describes request for product or shop page. View session is taken from cookie, which is issued
+// when it is missing and is prolonged on every view, so that session ends after domain.ViewSessionLifetime without views
+func TrackPageView(w http.ResponseWriter, r *http.Request, authClient authclient.AuthClientInterface) domain.PageView {
+	view := domain.PageView{Referrer: r.Referer()}
+	cookie, found := CheckCookies(r, authClient)
+	if found {
+		view.UserID = cookie.UserID
+	}
+
+	sessionCookie, err := r.Cookie(domain.ViewSessionCookieName)
+	if err == nil && len(sessionCookie.Value) == domain.ViewSessionTokenLength {
+		view.Session = sessionCookie.Value
+	} else {
+		token := make([]byte, domain.ViewSessionTokenLength/2)
+		_, err = rand.Read(token)
+		if err != nil { // View is counted without session
+			return view
+		}
+		view.Session = hex.EncodeToString(token)
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     domain.ViewSessionCookieName,
+		Value:    view.Session,
+		Path:     "/",
+		Expires:  time.Now().Add(domain.ViewSessionLifetime),
+		HttpOnly: true,
+	})
+	return view
+}
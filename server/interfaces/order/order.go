@@ -0,0 +1,239 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	orderclient "pinterest/clients/order"
+	shopproductclient "pinterest/clients/shopProduct"
+	"pinterest/domain"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// OrderFacade calls order service, shopProduct service is called to check out user's cart
+type OrderFacade struct {
+	orderClient       orderclient.OrderClientInterface
+	shopProductClient shopproductclient.ShopProductClientInterface
+	// siteURL is public URL of site without trailing slash, payment providers return payers to its pages
+	siteURL string
+	logger  *zap.Logger
+}
+
+func NewOrderFacade(orderClient orderclient.OrderClientInterface, shopProductClient shopproductclient.ShopProductClientInterface,
+	siteURL string, logger *zap.Logger) *OrderFacade {
+	return &OrderFacade{
+		orderClient:       orderClient,
+		shopProductClient: shopProductClient,
+		siteURL:           strings.TrimSuffix(siteURL, "/"),
+		logger:            logger,
+	}
+}
+
+// Checkout creates order for every shop whose products are bought. If no items are passed, available items
+// of user's cart are bought and then removed from cart
+func (facade *OrderFacade) Checkout(w http.ResponseWriter, r *http.Request) {
+	checkoutInput := new(domain.CheckoutInput)
+	err := json.NewDecoder(r.Body).Decode(checkoutInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+
+	fromCart := len(checkoutInput.Items) == 0
+	if fromCart {
+		checkoutInput.Items, err = facade.availableCartItems(userCookie.UserID)
+		if err != nil {
+			facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	orders, err := facade.orderClient.Checkout(context.Background(), userCookie.UserID, checkoutInput.Items, checkoutInput.PromoCode)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeOrderError(w, err)
+		return
+	}
+
+	if fromCart {
+		for _, item := range checkoutInput.Items {
+			_, err = facade.shopProductClient.RemoveFromCart(context.Background(), userCookie.UserID, "", item.ProductID, item.VariantID, "")
+			if err != nil {
+				facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+			}
+		}
+	}
+
+	responseBody, err := json.Marshal(domain.OrdersListResponse{Orders: orders})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseBody)
+}
+
+// availableCartItems returns items of user's cart which can be bought in full
+func (facade *OrderFacade) availableCartItems(userID uint64) (items []domain.CartItemInput, err error) {
+	cart, err := facade.shopProductClient.GetCart(context.Background(), userID, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	items = make([]domain.CartItemInput, 0, cart.ItemsCount)
+	for _, shop := range cart.Shops {
+		for _, item := range shop.Items {
+			if item.Status == domain.CartItemAvailable {
+				items = append(items, domain.CartItemInput{
+					ProductID: item.Product.ProductID,
+					VariantID: item.Product.SelectedVariantID,
+					Quantity:  item.Quantity,
+				})
+			}
+		}
+	}
+
+	return items, nil
+}
+
+// GetOrder returns order with history of its statuses, only its buyer and managers of its shop can see it
+func (facade *OrderFacade) GetOrder(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orderID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	order, err := facade.orderClient.GetOrder(context.Background(), orderID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeOrderError(w, err)
+		return
+	}
+
+	facade.writeOrder(w, r, order)
+}
+
+// ListUserOrders returns page of orders placed by user, newest orders go first
+func (facade *OrderFacade) ListUserOrders(w http.ResponseWriter, r *http.Request) {
+	page, err := domain.ParsePageInput(r.URL.Query(), domain.OrderAmountKey, domain.OrderPageKey)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	orders, nextCursor, err := facade.orderClient.ListUserOrders(context.Background(), userCookie.UserID,
+		r.URL.Query().Get(domain.OrderStatusKey), page)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeOrderError(w, err)
+		return
+	}
+
+	facade.writeOrders(w, r, orders, nextCursor)
+}
+
+// ListShopOrders returns page of shop's orders, newest orders go first. Only shop's managers can see them
+func (facade *OrderFacade) ListShopOrders(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	page, err := domain.ParsePageInput(r.URL.Query(), domain.OrderAmountKey, domain.OrderPageKey)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	orders, nextCursor, err := facade.orderClient.ListShopOrders(context.Background(), shopID, userCookie.UserID,
+		r.URL.Query().Get(domain.OrderStatusKey), page)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeOrderError(w, err)
+		return
+	}
+
+	facade.writeOrders(w, r, orders, nextCursor)
+}
+
+// ChangeOrderStatus moves order to another status, allowed transitions depend on whether user is buyer or manager
+func (facade *OrderFacade) ChangeOrderStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orderID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	statusInput := new(domain.OrderStatusInput)
+	err := json.NewDecoder(r.Body).Decode(statusInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	order, err := facade.orderClient.ChangeOrderStatus(context.Background(), orderID, userCookie.UserID, *statusInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeOrderError(w, err)
+		return
+	}
+
+	facade.writeOrder(w, r, order)
+}
+
+func (facade *OrderFacade) writeOrder(w http.ResponseWriter, r *http.Request, order domain.Order) {
+	responseBody, err := json.Marshal(order)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+func (facade *OrderFacade) writeOrders(w http.ResponseWriter, r *http.Request, orders []domain.Order, nextCursor string) {
+	responseBody, err := json.Marshal(domain.OrdersListResponse{Orders: orders, NextCursor: nextCursor})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// writeOrderError writes status which corresponds to error returned by order service
+func writeOrderError(w http.ResponseWriter, err error) {
+	switch err {
+	case domain.ErrEmptyCheckout, domain.ErrTooManyCheckoutItems, domain.ErrInvalidQuantity, domain.ErrInvalidOrderStatus,
+		domain.ErrOrderCommentTooLong, domain.ErrInvalidCursor, domain.ErrInvalidWebhook, domain.ErrVariantRequired,
+		domain.ErrPromoCodeExpired, domain.ErrPromoCodeMinOrder:
+		w.WriteHeader(http.StatusBadRequest)
+	case domain.ErrNotShopManager, domain.ErrTransitionForbidden:
+		w.WriteHeader(http.StatusForbidden)
+	case domain.ErrOrderNotFound, domain.ErrProductNotFound, domain.ErrShopNotFound, domain.ErrPaymentNotFound,
+		domain.ErrUnknownProvider, domain.ErrVariantNotFound, domain.ErrPromoCodeNotFound:
+		w.WriteHeader(http.StatusNotFound)
+	case domain.ErrOutOfStock, domain.ErrInvalidTransition, domain.ErrOrderStatusChanged, domain.ErrReservationNotActive,
+		domain.ErrOrderNotPayable, domain.ErrPromoCodeUsedUp:
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
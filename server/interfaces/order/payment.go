@@ -0,0 +1,138 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"net/http"
+	"pinterest/domain"
+	"strconv"
+
+	"github.com/gorilla/csrf"
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// maxWebhookSize limits body of payment provider's webhook
+const maxWebhookSize = 64 << 10
+
+var fakePaymentPage = template.Must(template.New("fakePayment").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Fake payment</title></head>
+<body>
+<h1>Fake payment {{.PaymentID}}</h1>
+<p>Nothing is charged, choose outcome of payment.</p>
+<form method="POST">
+{{.CSRFField}}
+<button type="submit" name="outcome" value="succeeded">Pay</button>
+<button type="submit" name="outcome" value="failed">Decline</button>
+</form>
+</body>
+</html>
+`))
+
+// CreatePayment starts payment of user's order, client redirects buyer to payment's confirmationURL.
+// If order already has pending payment, that payment is returned. Return address is built from configured
+// site URL rather than from request, as request's host is chosen by client
+func (facade *OrderFacade) CreatePayment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orderID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	returnURL := facade.siteURL + fmt.Sprintf(domain.PaymentReturnPath, orderID)
+	payment, err := facade.orderClient.CreatePayment(context.Background(), orderID, userCookie.UserID, returnURL)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeOrderError(w, err)
+		return
+	}
+
+	facade.writePayment(w, r, payment, http.StatusCreated)
+}
+
+// PaymentReturn is where provider redirects buyer after payment. Payment's status is requested from provider,
+// as webhook may not have arrived yet
+func (facade *OrderFacade) PaymentReturn(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	orderID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	payment, err := facade.orderClient.SyncPayment(context.Background(), orderID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeOrderError(w, err)
+		return
+	}
+
+	facade.writePayment(w, r, payment, http.StatusOK)
+}
+
+// PaymentWebhook receives notifications of payment providers. They are not sent by browsers,
+// so they are authenticated with signatures instead of cookies and CSRF tokens
+func (facade *OrderFacade) PaymentWebhook(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	provider := vars[domain.ProviderKey]
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookSize))
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	err = facade.orderClient.HandlePaymentWebhook(context.Background(), provider, body, r.Header.Get(domain.PaymentSignatureHeader))
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeOrderError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// FakePaymentPage imitates provider's payment page, payer chooses whether payment succeeds
+func (facade *OrderFacade) FakePaymentPage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	w.Header().Add("Content-Type", "text/html; charset=utf-8")
+	err := fakePaymentPage.Execute(w, struct {
+		PaymentID string
+		CSRFField template.HTML
+	}{
+		PaymentID: vars[domain.PaymentIDKey],
+		CSRFField: csrf.TemplateField(r),
+	})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+	}
+}
+
+// CompleteFakePayment receives outcome chosen on fake payment page and redirects payer back to shop
+func (facade *OrderFacade) CompleteFakePayment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	succeeded := r.FormValue(domain.PaymentOutcomeKey) == domain.PaymentSucceededOutcome
+	returnURL, err := facade.orderClient.CompleteFakePayment(context.Background(), vars[domain.PaymentIDKey], succeeded)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeOrderError(w, err)
+		return
+	}
+
+	http.Redirect(w, r, returnURL, http.StatusSeeOther)
+}
+
+func (facade *OrderFacade) writePayment(w http.ResponseWriter, r *http.Request, payment domain.Payment, status int) {
+	responseBody, err := json.Marshal(payment)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(responseBody)
+}
@@ -0,0 +1,161 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"pinterest/domain"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// GetCategories returns tree of all categories
+func (facade *ProductFacade) GetCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := facade.shopProductClient.GetCategories(context.Background())
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	responseBody, err := json.Marshal(domain.CategoriesResponse{Categories: categories})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// CreateCategory creates category, only administrators can do it
+func (facade *ProductFacade) CreateCategory(w http.ResponseWriter, r *http.Request) {
+	categoryInput := new(domain.Category)
+	err := json.NewDecoder(r.Body).Decode(categoryInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	categoryID, err := facade.shopProductClient.CreateCategory(context.Background(), *categoryInput, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeCategoryError(w, err)
+		return
+	}
+
+	responseBody, err := json.Marshal(domain.CategoryIDResponse{CategoryID: categoryID})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseBody)
+}
+
+// EditCategory replaces category's slug, parent, position and names with ones specified, only administrators can do it
+func (facade *ProductFacade) EditCategory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	categoryID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	categoryInput := new(domain.Category)
+	err := json.NewDecoder(r.Body).Decode(categoryInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	categoryInput.CategoryID = categoryID
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err = facade.shopProductClient.EditCategory(context.Background(), *categoryInput, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeCategoryError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteCategory deletes category without subcategories, its products are moved to category
+// specified by replacementID parameter. Only administrators can do it
+func (facade *ProductFacade) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	categoryID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	var replacementID uint64
+	var err error
+	if replacement := r.URL.Query().Get(domain.ReplacementIDKey); replacement != "" {
+		replacementID, err = strconv.ParseUint(replacement, 10, 64)
+		if err != nil {
+			facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err = facade.shopProductClient.DeleteCategory(context.Background(), categoryID, replacementID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeCategoryError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListProductsByCategory returns page of products of category and all its subcategories
+func (facade *ProductFacade) ListProductsByCategory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	categoryID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	page, err := domain.ParsePageInput(r.URL.Query(), domain.ProductAmountKey, domain.ProductPageKey)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	products, nextCursor, err := facade.shopProductClient.ListProductsByCategory(context.Background(), categoryID, page, domain.PreferredCurrency(r))
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrInvalidSorting, domain.ErrInvalidCursor, domain.ErrInvalidCurrency, domain.ErrExchangeRateNotFound:
+			w.WriteHeader(http.StatusBadRequest)
+		case domain.ErrCategoryNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	facade.writeProductsList(w, r, products, nextCursor)
+}
+
+// writeCategoryError writes status which corresponds to error returned when category is created, edited or deleted
+func writeCategoryError(w http.ResponseWriter, err error) {
+	switch err {
+	case domain.ErrInvalidSlug, domain.ErrInvalidCategoryName, domain.ErrCategoryCycle:
+		w.WriteHeader(http.StatusBadRequest)
+	case domain.ErrNotAdmin:
+		w.WriteHeader(http.StatusForbidden)
+	case domain.ErrCategoryNotFound:
+		w.WriteHeader(http.StatusNotFound)
+	case domain.ErrSlugExists, domain.ErrCategoryNotEmpty:
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,61 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"pinterest/domain"
+
+	"go.uber.org/zap"
+)
+
+// GetExchangeRates returns exchange rates which prices can be converted with, buyers choose one of their currencies
+func (facade *ProductFacade) GetExchangeRates(w http.ResponseWriter, r *http.Request) {
+	rates, err := facade.shopProductClient.GetExchangeRates(context.Background())
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	responseBody, err := json.Marshal(rates)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// UpdateExchangeRates replaces all exchange rates with ones specified, only administrators can do it
+func (facade *ProductFacade) UpdateExchangeRates(w http.ResponseWriter, r *http.Request) {
+	ratesInput := new(domain.ExchangeRates)
+	err := json.NewDecoder(r.Body).Decode(ratesInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err = facade.shopProductClient.UpdateExchangeRates(context.Background(), *ratesInput, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrInvalidCurrency, domain.ErrInvalidExchangeRates:
+			w.WriteHeader(http.StatusBadRequest)
+		case domain.ErrNotAdmin:
+			w.WriteHeader(http.StatusForbidden)
+		case domain.ErrCurrencyInUse:
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,62 @@
+package product
+
+import (
+	"context"
+	"net/http"
+	"pinterest/domain"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// RestoreProduct restores product which was deleted less than 30 days ago. Only managers of product's shop can do it,
+// products of deleted shop are restored together with shop
+func (facade *ProductFacade) RestoreProduct(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err := facade.shopProductClient.RestoreProduct(context.Background(), productID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrNotShopManager:
+			w.WriteHeader(http.StatusForbidden)
+		case domain.ErrProductNotFound, domain.ErrShopNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		case domain.ErrDuplicateProductSKU, domain.ErrShopDeleted:
+			w.WriteHeader(http.StatusConflict)
+		case domain.ErrRestoreExpired:
+			w.WriteHeader(http.StatusGone)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeletedProducts returns shop's deleted products which can still be restored, only shop's managers can see them
+func (facade *ProductFacade) ListDeletedProducts(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	products, err := facade.shopProductClient.ListDeletedProducts(context.Background(), shopID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrNotShopManager:
+			w.WriteHeader(http.StatusForbidden)
+		case domain.ErrShopNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	facade.writeProductsList(w, r, products, "")
+}
@@ -0,0 +1,172 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"pinterest/domain"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// productImagesField is multipart field which contains uploaded images
+const productImagesField = "productImages"
+
+// UploadProductImages adds images from "productImages" multipart field to product's gallery
+func (facade *ProductFacade) UploadProductImages(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	err := r.ParseMultipartForm(domain.ImageChunkSize)
+	if err != nil || len(r.MultipartForm.File[productImagesField]) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	images, err := facade.uploadImages(r, productID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeImageError(w, err)
+		return
+	}
+
+	facade.writeImages(w, r, http.StatusCreated, images)
+}
+
+func (facade *ProductFacade) GetProductImages(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	images, err := facade.shopProductClient.GetProductImages(context.Background(), productID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	facade.writeImages(w, r, http.StatusOK, images)
+}
+
+// ReorderProductImages changes order of product's gallery, request must list all of product's images
+func (facade *ProductFacade) ReorderProductImages(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	orderInput := new(domain.ImageOrderInput)
+	err := json.NewDecoder(r.Body).Decode(orderInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err = facade.shopProductClient.ReorderProductImages(context.Background(), productID, userCookie.UserID, orderInput.ImageIDs)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeImageError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetPrimaryProductImage makes image the first one in product's gallery
+func (facade *ProductFacade) SetPrimaryProductImage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+	imageID, _ := strconv.ParseUint(vars[domain.ImageIDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err := facade.shopProductClient.SetPrimaryProductImage(context.Background(), productID, imageID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeImageError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (facade *ProductFacade) DeleteProductImage(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+	imageID, _ := strconv.ParseUint(vars[domain.ImageIDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err := facade.shopProductClient.DeleteProductImage(context.Background(), productID, imageID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeImageError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// uploadImages streams all images from request's multipart form to shopProduct service, stopping at first error.
+// Images which were saved before error are deleted, so that either whole batch is uploaded or none of it
+func (facade *ProductFacade) uploadImages(r *http.Request, productID uint64, userID uint64) (images []domain.ProductImage, err error) {
+	images = make([]domain.ProductImage, 0)
+	if r.MultipartForm == nil {
+		return images, nil
+	}
+
+	for _, fileHeader := range r.MultipartForm.File[productImagesField] {
+		image, err := facade.uploadImage(fileHeader, productID, userID)
+		if err != nil {
+			for _, savedImage := range images {
+				facade.shopProductClient.DeleteProductImage(context.Background(), productID, savedImage.ImageID, userID)
+			}
+			return nil, err
+		}
+
+		images = append(images, image)
+	}
+
+	return images, nil
+}
+
+func (facade *ProductFacade) uploadImage(fileHeader *multipart.FileHeader, productID uint64, userID uint64) (image domain.ProductImage, err error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return domain.ProductImage{}, err
+	}
+	defer file.Close()
+
+	return facade.shopProductClient.UploadProductImage(context.Background(), productID, userID, file)
+}
+
+func (facade *ProductFacade) writeImages(w http.ResponseWriter, r *http.Request, status int, images []domain.ProductImage) {
+	responseBody, err := json.Marshal(domain.ProductImagesResponse{Images: images})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(responseBody)
+}
+
+func writeImageError(w http.ResponseWriter, err error) {
+	switch err {
+	case domain.ErrInvalidImage, domain.ErrInvalidImageOrder:
+		w.WriteHeader(http.StatusBadRequest)
+	case domain.ErrNotShopManager:
+		w.WriteHeader(http.StatusForbidden)
+	case domain.ErrProductNotFound, domain.ErrImageNotFound:
+		w.WriteHeader(http.StatusNotFound)
+	case domain.ErrTooManyImages:
+		w.WriteHeader(http.StatusConflict)
+	case domain.ErrImageTooLarge:
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,138 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"pinterest/domain"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// CreateProductSale schedules sale price of product or of its variant. Only managers of product's shop can do it
+func (facade *ProductFacade) CreateProductSale(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	saleInput := new(domain.ProductSale)
+	err := json.NewDecoder(r.Body).Decode(saleInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	saleID, err := facade.shopProductClient.CreateProductSale(context.Background(), productID, userCookie.UserID, *saleInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeSaleError(w, err)
+		return
+	}
+
+	responseBody, err := json.Marshal(domain.PricingRuleIDResponse{ID: saleID})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseBody)
+}
+
+// ListProductSales returns active and scheduled sales of product, only managers of product's shop can see them
+func (facade *ProductFacade) ListProductSales(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	sales, err := facade.shopProductClient.ListProductSales(context.Background(), productID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeSaleError(w, err)
+		return
+	}
+
+	responseBody, err := json.Marshal(domain.ProductSalesListResponse{Sales: sales})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// DeleteProductSale cancels sale of product, cancellation is kept in price history
+func (facade *ProductFacade) DeleteProductSale(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+	saleID, _ := strconv.ParseUint(vars[domain.SaleIDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err := facade.shopProductClient.DeleteProductSale(context.Background(), productID, saleID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeSaleError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListPriceHistory returns page of product's price changes, including discounts of its shop. Newest changes go first
+func (facade *ProductFacade) ListPriceHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	page, err := domain.ParsePageInput(r.URL.Query(), domain.PriceAmountKey, domain.PricePageKey)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	changes, nextCursor, err := facade.shopProductClient.ListPriceHistory(context.Background(), productID, page)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrInvalidCursor:
+			w.WriteHeader(http.StatusBadRequest)
+		case domain.ErrProductNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(domain.PriceHistoryResponse{Changes: changes, NextCursor: nextCursor})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+func writeSaleError(w http.ResponseWriter, err error) {
+	switch err {
+	case domain.ErrInvalidSale, domain.ErrCurrencyMismatch:
+		w.WriteHeader(http.StatusBadRequest)
+	case domain.ErrNotShopManager:
+		w.WriteHeader(http.StatusForbidden)
+	case domain.ErrProductNotFound, domain.ErrVariantNotFound, domain.ErrSaleNotFound:
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
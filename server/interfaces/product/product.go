@@ -0,0 +1,300 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	authclient "pinterest/clients/auth"
+	shopproductclient "pinterest/clients/shopProduct"
+	"pinterest/domain"
+	"pinterest/interfaces/middleware"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// ProductFacade calls shopProduct service for product-related requests
+type ProductFacade struct {
+	shopProductClient shopproductclient.ShopProductClientInterface
+	authClient        authclient.AuthClientInterface
+	logger            *zap.Logger
+}
+
+func NewProductFacade(shopProductClient shopproductclient.ShopProductClientInterface, authClient authclient.AuthClientInterface, logger *zap.Logger) *ProductFacade {
+	return &ProductFacade{
+		shopProductClient: shopProductClient,
+		authClient:        authClient,
+		logger:            logger,
+	}
+}
+
+// CreateProduct creates product using data from "productInfo" multipart field and images from "productImages" field.
+// Only shop's managers can do it. If any image is rejected, product is not created
+func (facade *ProductFacade) CreateProduct(w http.ResponseWriter, r *http.Request) {
+	productInput := new(domain.Product)
+	err := json.Unmarshal([]byte(r.FormValue("productInfo")), productInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	productID, err := facade.shopProductClient.CreateProduct(context.Background(), *productInput, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrEmptyTitle, domain.ErrCurrencyMismatch, domain.ErrInvalidSKU:
+			w.WriteHeader(http.StatusBadRequest)
+		case domain.ErrNotShopManager:
+			w.WriteHeader(http.StatusForbidden)
+		case domain.ErrShopNotFound, domain.ErrCategoryNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		case domain.ErrDuplicateProductSKU:
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	_, err = facade.uploadImages(r, productID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		// Deleted product could be restored, so half-created one is removed completely together with saved images
+		facade.shopProductClient.PurgeProduct(context.Background(), productID, userCookie.UserID)
+		writeImageError(w, err)
+		return
+	}
+
+	responseBody, err := json.Marshal(domain.ProductIDResponse{ProductID: productID})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseBody)
+}
+
+// EditProduct changes product's data to one specified, omitted fields except for availability are left unchanged.
+// Price must be in currency of product's shop, product can be moved only to shop with the same currency
+func (facade *ProductFacade) EditProduct(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	productInput := new(domain.Product)
+	err := json.NewDecoder(r.Body).Decode(productInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	productInput.ProductID = productID
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err = facade.shopProductClient.EditProduct(context.Background(), *productInput, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrCurrencyMismatch, domain.ErrInvalidSKU:
+			w.WriteHeader(http.StatusBadRequest)
+		case domain.ErrNotShopManager:
+			w.WriteHeader(http.StatusForbidden)
+		case domain.ErrProductNotFound, domain.ErrShopNotFound, domain.ErrCategoryNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		case domain.ErrDuplicateProductSKU:
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetProduct returns product, view is counted towards feed ranking of both product and its viewer.
+// If variantID parameter is set, product has price, stock and images of this variant.
+// Prices are also converted into currency which buyer prefers
+func (facade *ProductFacade) GetProduct(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	var variantID uint64
+	var err error
+	if variant := r.URL.Query().Get(domain.VariantIDKey); variant != "" {
+		variantID, err = strconv.ParseUint(variant, 10, 64)
+		if err != nil {
+			facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	view := middleware.TrackPageView(w, r, facade.authClient)
+	product, err := facade.shopProductClient.GetProduct(context.Background(), productID, variantID, view, domain.PreferredCurrency(r))
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrInvalidCurrency, domain.ErrExchangeRateNotFound:
+			w.WriteHeader(http.StatusBadRequest)
+		case domain.ErrProductNotFound, domain.ErrVariantNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(product)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// DeleteProduct deletes product, only managers of product's shop can do it. Product can be restored during 30 days
+func (facade *ProductFacade) DeleteProduct(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err := facade.shopProductClient.DeleteProduct(context.Background(), productID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrNotShopManager:
+			w.WriteHeader(http.StatusForbidden)
+		case domain.ErrProductNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListProductsByShop returns page of shop's products. Both cursor and productPage are supported,
+// cursor should be preferred as pages shift when products are added
+func (facade *ProductFacade) ListProductsByShop(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	page, err := domain.ParsePageInput(r.URL.Query(), domain.ProductAmountKey, domain.ProductPageKey)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	products, nextCursor, err := facade.shopProductClient.ListProductsByShop(context.Background(), shopID, page, domain.PreferredCurrency(r))
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrInvalidSorting, domain.ErrInvalidCursor, domain.ErrInvalidCurrency, domain.ErrExchangeRateNotFound:
+			w.WriteHeader(http.StatusBadRequest)
+		case domain.ErrShopNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	facade.writeProductsList(w, r, products, nextCursor)
+}
+
+// SearchProducts returns page of products found by searchKey and filters, together with total amount
+// of found products and facets of every filter. Price filters and facet are in currency which buyer prefers
+func (facade *ProductFacade) SearchProducts(w http.ResponseWriter, r *http.Request) {
+	search, err := domain.ParseSearchInput(r.URL.Query())
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	search.Currency = domain.PreferredCurrency(r)
+
+	result, err := facade.shopProductClient.SearchProducts(context.Background(), search)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrInvalidSorting, domain.ErrInvalidCursor, domain.ErrInvalidSearchRange, domain.ErrInvalidRating,
+			domain.ErrInvalidCurrency, domain.ErrExchangeRateNotFound:
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(result)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// GetFeed returns page of products feed. Logged in users get products from shops they follow,
+// categories they browse and trending products, anonymous users get most popular products
+func (facade *ProductFacade) GetFeed(w http.ResponseWriter, r *http.Request) {
+	page, err := domain.ParsePageInput(r.URL.Query(), domain.ProductAmountKey, domain.ProductPageKey)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	products, nextCursor, err := facade.shopProductClient.GetFeed(context.Background(), facade.viewerID(r), page, domain.PreferredCurrency(r))
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrInvalidCursor, domain.ErrInvalidCurrency, domain.ErrExchangeRateNotFound:
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	facade.writeProductsList(w, r, products, nextCursor)
+}
+
+func (facade *ProductFacade) writeProductsList(w http.ResponseWriter, r *http.Request, products []domain.Product, nextCursor string) {
+	responseBody, err := json.Marshal(domain.ProductsListResponse{Products: products, NextCursor: nextCursor})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// viewerID returns id of user who made request, 0 if they are not logged in
+func (facade *ProductFacade) viewerID(r *http.Request) uint64 {
+	cookie, found := middleware.CheckCookies(r, facade.authClient)
+	if !found {
+		return 0
+	}
+
+	return cookie.UserID
+}
@@ -0,0 +1,187 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"pinterest/domain"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// AskQuestion creates question about product from URL, anyone who is logged in can ask
+func (facade *ProductFacade) AskQuestion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	questionInput := new(domain.QuestionInput)
+	err := json.NewDecoder(r.Body).Decode(questionInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	questionID, err := facade.shopProductClient.AskQuestion(context.Background(), productID, userCookie.UserID, questionInput.Text)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeQuestionError(w, err)
+		return
+	}
+
+	facade.writeQuestionID(w, r, questionID)
+}
+
+// AnswerQuestion creates answer to question from URL, only managers of product's shop and users who have
+// received product can answer
+func (facade *ProductFacade) AnswerQuestion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	questionID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	answerInput := new(domain.QuestionInput)
+	err := json.NewDecoder(r.Body).Decode(answerInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	answerID, err := facade.shopProductClient.AnswerQuestion(context.Background(), questionID, userCookie.UserID, answerInput.Text)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeQuestionError(w, err)
+		return
+	}
+
+	facade.writeQuestionID(w, r, answerID)
+}
+
+// MarkOfficialAnswer makes answer official answer of its question instead of previous one, only managers
+// of product's shop can do it
+func (facade *ProductFacade) MarkOfficialAnswer(w http.ResponseWriter, r *http.Request) {
+	facade.setOfficialAnswer(w, r, true)
+}
+
+// UnmarkOfficialAnswer removes official mark from answer, only managers of product's shop can do it
+func (facade *ProductFacade) UnmarkOfficialAnswer(w http.ResponseWriter, r *http.Request) {
+	facade.setOfficialAnswer(w, r, false)
+}
+
+func (facade *ProductFacade) setOfficialAnswer(w http.ResponseWriter, r *http.Request, isOfficial bool) {
+	vars := mux.Vars(r)
+	answerID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err := facade.shopProductClient.SetOfficialAnswer(context.Background(), answerID, userCookie.UserID, isOfficial)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeQuestionError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// VoteQuestion adds current user's vote for question, repeated votes are ignored
+func (facade *ProductFacade) VoteQuestion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	questionID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err := facade.shopProductClient.VoteQuestion(context.Background(), questionID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeQuestionError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// UnvoteQuestion removes current user's vote for question
+func (facade *ProductFacade) UnvoteQuestion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	questionID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err := facade.shopProductClient.UnvoteQuestion(context.Background(), questionID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeQuestionError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListQuestions returns page of product's questions with their answers sorted by votes, newest or unanswered first.
+// Questions are searched by searchKey in their text and text of their answers
+func (facade *ProductFacade) ListQuestions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	query := r.URL.Query()
+	page, err := domain.ParsePageInput(query, domain.QuestionAmountKey, domain.QuestionPageKey)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	questions, nextCursor, err := facade.shopProductClient.ListQuestions(context.Background(), productID, facade.viewerID(r),
+		page, query.Get(domain.SearchKeyKey))
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrInvalidSorting, domain.ErrInvalidCursor:
+			w.WriteHeader(http.StatusBadRequest)
+		case domain.ErrProductNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(domain.QuestionsListResponse{Questions: questions, NextCursor: nextCursor})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+func (facade *ProductFacade) writeQuestionID(w http.ResponseWriter, r *http.Request, id uint64) {
+	responseBody, err := json.Marshal(domain.QuestionIDResponse{ID: id})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseBody)
+}
+
+// writeQuestionError writes status which corresponds to error returned when questions and answers are changed
+func writeQuestionError(w http.ResponseWriter, err error) {
+	switch err {
+	case domain.ErrEmptyQuestionText, domain.ErrQuestionTextTooLong:
+		w.WriteHeader(http.StatusBadRequest)
+	case domain.ErrNotAllowedToAnswer, domain.ErrNotShopManager, domain.ErrBlockedUser:
+		w.WriteHeader(http.StatusForbidden)
+	case domain.ErrProductNotFound, domain.ErrQuestionNotFound, domain.ErrAnswerNotFound:
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,45 @@
+package product
+
+import (
+	"context"
+	"net/http"
+	"pinterest/domain"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// GetRelatedProducts returns products similar to product and products which its viewers also viewed,
+// productAmount limits their amount. Prices are converted into currency which buyer prefers
+func (facade *ProductFacade) GetRelatedProducts(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	var limit uint64
+	var err error
+	if amount := r.URL.Query().Get(domain.ProductAmountKey); amount != "" {
+		limit, err = strconv.ParseUint(amount, 10, 64)
+		if err != nil {
+			facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	products, err := facade.shopProductClient.GetRelatedProducts(context.Background(), productID, limit, domain.PreferredCurrency(r))
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrInvalidCurrency, domain.ErrExchangeRateNotFound:
+			w.WriteHeader(http.StatusBadRequest)
+		case domain.ErrProductNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	facade.writeProductsList(w, r, products, "")
+}
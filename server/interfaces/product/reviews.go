@@ -0,0 +1,121 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"pinterest/domain"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// CreateReview creates review of product specified in request body, every user can review product only once
+func (facade *ProductFacade) CreateReview(w http.ResponseWriter, r *http.Request) {
+	reviewInput := new(domain.ProductReview)
+	err := json.NewDecoder(r.Body).Decode(reviewInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	reviewID, err := facade.shopProductClient.CreateReview(context.Background(), *reviewInput, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeReviewError(w, err)
+		return
+	}
+
+	responseBody, err := json.Marshal(domain.ReviewIDResponse{ReviewID: reviewID})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseBody)
+}
+
+// EditReview changes title, text and rating of user's review, omitted fields are left unchanged
+func (facade *ProductFacade) EditReview(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	reviewID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	reviewInput := new(domain.ProductReview)
+	err := json.NewDecoder(r.Body).Decode(reviewInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	reviewInput.ReviewID = reviewID
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err = facade.shopProductClient.EditReview(context.Background(), *reviewInput, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeReviewError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListReviews returns page of product's reviews sorted by newest, highest or lowest rating
+func (facade *ProductFacade) ListReviews(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	page, err := domain.ParsePageInput(r.URL.Query(), domain.ReviewAmountKey, domain.ReviewPageKey)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	reviews, nextCursor, err := facade.shopProductClient.ListReviews(context.Background(), productID, page)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrInvalidSorting, domain.ErrInvalidCursor:
+			w.WriteHeader(http.StatusBadRequest)
+		case domain.ErrProductNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(domain.ReviewsListResponse{Reviews: reviews, NextCursor: nextCursor})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// writeReviewError writes status which corresponds to error returned when review is created or edited
+func writeReviewError(w http.ResponseWriter, err error) {
+	switch err {
+	case domain.ErrInvalidRating, domain.ErrEmptyTitle, domain.ErrReviewTitleTooLong:
+		w.WriteHeader(http.StatusBadRequest)
+	case domain.ErrNotReviewAuthor, domain.ErrOwnProductReview, domain.ErrBlockedUser:
+		w.WriteHeader(http.StatusForbidden)
+	case domain.ErrProductNotFound, domain.ErrReviewNotFound:
+		w.WriteHeader(http.StatusNotFound)
+	case domain.ErrReviewExists:
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,99 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"pinterest/domain"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// AdjustStock adds delta to stock of product or of its variant and returns resulting inventory movement.
+// Only managers of product's shop can do it
+func (facade *ProductFacade) AdjustStock(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	adjustmentInput := new(domain.StockAdjustmentInput)
+	err := json.NewDecoder(r.Body).Decode(adjustmentInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	movement, err := facade.shopProductClient.AdjustStock(context.Background(), productID, userCookie.UserID, *adjustmentInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrInvalidQuantity, domain.ErrStockCommentTooLong, domain.ErrVariantRequired:
+			w.WriteHeader(http.StatusBadRequest)
+		case domain.ErrNotShopManager:
+			w.WriteHeader(http.StatusForbidden)
+		case domain.ErrProductNotFound, domain.ErrVariantNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		case domain.ErrStockBelowReserved:
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(movement)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// ListInventoryMovements returns page of product's inventory ledger, newest movements go first.
+// Only managers of product's shop can audit it
+func (facade *ProductFacade) ListInventoryMovements(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	page, err := domain.ParsePageInput(r.URL.Query(), domain.MovementAmountKey, domain.MovementPageKey)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	movements, nextCursor, err := facade.shopProductClient.ListInventoryMovements(context.Background(), productID, userCookie.UserID, page)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrInvalidCursor:
+			w.WriteHeader(http.StatusBadRequest)
+		case domain.ErrNotShopManager:
+			w.WriteHeader(http.StatusForbidden)
+		case domain.ErrProductNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(domain.MovementsListResponse{Movements: movements, NextCursor: nextCursor})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
@@ -0,0 +1,131 @@
+package product
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"pinterest/domain"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// SetProductOptions replaces option types of product, such as size and colour, with their allowed values.
+// Values which product's variants use can not be removed
+func (facade *ProductFacade) SetProductOptions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	optionsInput := new(domain.ProductOptionsInput)
+	err := json.NewDecoder(r.Body).Decode(optionsInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err = facade.shopProductClient.SetProductOptions(context.Background(), productID, userCookie.UserID, optionsInput.Options)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeVariantError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreateVariant creates variant of product with its initial stock. The first variant takes over product's stock,
+// so product must have no reserved units then
+func (facade *ProductFacade) CreateVariant(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	variantInput := new(domain.ProductVariant)
+	err := json.NewDecoder(r.Body).Decode(variantInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	variantID, err := facade.shopProductClient.CreateVariant(context.Background(), productID, userCookie.UserID, *variantInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeVariantError(w, err)
+		return
+	}
+
+	responseBody, err := json.Marshal(domain.VariantIDResponse{VariantID: variantID})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseBody)
+}
+
+// EditVariant replaces SKU, option values, price override and images of variant, its stock is changed by stock adjustments
+func (facade *ProductFacade) EditVariant(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+	variantID, _ := strconv.ParseUint(vars[domain.VariantIDKey], 10, 64)
+
+	variantInput := new(domain.ProductVariant)
+	err := json.NewDecoder(r.Body).Decode(variantInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	variantInput.VariantID = variantID
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err = facade.shopProductClient.EditVariant(context.Background(), productID, userCookie.UserID, *variantInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeVariantError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteVariant deletes variant which has no reserved units, its remaining stock is written off
+func (facade *ProductFacade) DeleteVariant(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+	variantID, _ := strconv.ParseUint(vars[domain.VariantIDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err := facade.shopProductClient.DeleteVariant(context.Background(), productID, variantID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeVariantError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeVariantError(w http.ResponseWriter, err error) {
+	switch err {
+	case domain.ErrInvalidOptions, domain.ErrInvalidVariantOption, domain.ErrInvalidSKU, domain.ErrInvalidQuantity,
+		domain.ErrCurrencyMismatch:
+		w.WriteHeader(http.StatusBadRequest)
+	case domain.ErrNotShopManager:
+		w.WriteHeader(http.StatusForbidden)
+	case domain.ErrProductNotFound, domain.ErrVariantNotFound, domain.ErrImageNotFound:
+		w.WriteHeader(http.StatusNotFound)
+	case domain.ErrOptionsInUse, domain.ErrDuplicateVariant, domain.ErrTooManyVariants, domain.ErrVariantReserved,
+		domain.ErrStockBelowReserved:
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -3,10 +3,12 @@ package profile
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	authclient "pinterest/clients/auth"
 	userclient "pinterest/clients/user"
 	"pinterest/domain"
+	"pinterest/interfaces/middleware"
 	"strconv"
 
 	"github.com/gorilla/mux"
@@ -106,7 +108,7 @@ func (facade *ProfileFacade) EditUser(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// GetUserByID recieves user data from user service. E-mail gets hidden for personal data protection
+// GetUserByID recieves user data from user service. Data is stripped by user service according to user's privacy settings
 func (facade *ProfileFacade) GetUserByID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	userIDStr, passedID := vars[string(domain.IDKey)]
@@ -120,7 +122,7 @@ func (facade *ProfileFacade) GetUserByID(w http.ResponseWriter, r *http.Request)
 	}
 
 	userID, _ := strconv.ParseUint(userIDStr, 10, 64)
-	user, err := facade.userClient.GetUserByID(context.Background(), userID)
+	user, err := facade.userClient.GetUserByID(context.Background(), userID, facade.viewerID(r))
 	if err != nil {
 		facade.logger.Info(err.Error(),
 			zap.String("url", r.RequestURI),
@@ -133,8 +135,6 @@ func (facade *ProfileFacade) GetUserByID(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	user.Email = ""
-
 	responseBody, err := json.Marshal(user)
 	if err != nil {
 		facade.logger.Info(err.Error(),
@@ -150,7 +150,7 @@ func (facade *ProfileFacade) GetUserByID(w http.ResponseWriter, r *http.Request)
 	return
 }
 
-// GetUserByUsername recieves user data from user service. E-mail gets hidden for personal data protection
+// GetUserByUsername recieves user data from user service. Data is stripped by user service according to user's privacy settings
 func (facade *ProfileFacade) GetUserByUsername(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	username, passedUsername := vars[string(domain.UsernameKey)]
@@ -163,7 +163,7 @@ func (facade *ProfileFacade) GetUserByUsername(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	user, err := facade.userClient.GetUserByUsername(context.Background(), username)
+	user, err := facade.userClient.GetUserByUsername(context.Background(), username, facade.viewerID(r))
 	if err != nil {
 		facade.logger.Info(err.Error(),
 			zap.String("url", r.RequestURI),
@@ -176,8 +176,6 @@ func (facade *ProfileFacade) GetUserByUsername(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	user.Email = ""
-
 	responseBody, err := json.Marshal(user)
 	if err != nil {
 		facade.logger.Info(err.Error(),
@@ -197,7 +195,7 @@ func (facade *ProfileFacade) GetUserByUsername(w http.ResponseWriter, r *http.Re
 func (facade *ProfileFacade) GetCurrentUser(w http.ResponseWriter, r *http.Request) {
 	cookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
 
-	user, err := facade.userClient.GetUserByID(context.Background(), cookie.UserID)
+	user, err := facade.userClient.GetUserByID(context.Background(), cookie.UserID, cookie.UserID)
 	if err != nil {
 		facade.logger.Info(err.Error(),
 			zap.String("url", r.RequestURI),
@@ -224,3 +222,364 @@ func (facade *ProfileFacade) GetCurrentUser(w http.ResponseWriter, r *http.Reque
 	w.Write(responseBody)
 	return
 }
+
+// GetFollowers returns users who follow specified user, if their privacy settings allow it
+func (facade *ProfileFacade) GetFollowers(w http.ResponseWriter, r *http.Request) {
+	facade.getFollowList(w, r, facade.userClient.GetFollowers)
+}
+
+// GetFollowed returns users whom specified user follows, if their privacy settings allow it
+func (facade *ProfileFacade) GetFollowed(w http.ResponseWriter, r *http.Request) {
+	facade.getFollowList(w, r, facade.userClient.GetFollowed)
+}
+
+type followListGetter func(ctx context.Context, userID uint64, viewerID uint64) ([]domain.User, error)
+
+func (facade *ProfileFacade) getFollowList(w http.ResponseWriter, r *http.Request, getList followListGetter) {
+	vars := mux.Vars(r)
+	userIDStr, passedID := vars[string(domain.IDKey)]
+
+	if !passedID {
+		facade.logger.Info("Could not get id from query params",
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	userID, _ := strconv.ParseUint(userIDStr, 10, 64)
+	users, err := getList(context.Background(), userID, facade.viewerID(r))
+	if err != nil {
+		facade.logger.Info(err.Error(),
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		switch err {
+		case domain.ErrUserNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		case domain.ErrFollowersHidden:
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	facade.writeUsersList(w, r, users)
+}
+
+// SearchUsers returns users whose username or name match search key. Users who disabled search are skipped
+func (facade *ProfileFacade) SearchUsers(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	searchKey, passedKey := vars[string(domain.SearchKeyKey)]
+
+	if !passedKey {
+		facade.logger.Info("Could not get search key from query params",
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	users, err := facade.userClient.SearchUsers(context.Background(), searchKey, facade.viewerID(r))
+	if err != nil {
+		facade.logger.Info(err.Error(),
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if len(users) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	facade.writeUsersList(w, r, users)
+}
+
+func (facade *ProfileFacade) writeUsersList(w http.ResponseWriter, r *http.Request, users []domain.User) {
+	responseBody, err := json.Marshal(domain.UsersListResponse{Users: users})
+	if err != nil {
+		facade.logger.Info(err.Error(),
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// GetPrivacySettings returns current user's privacy settings
+func (facade *ProfileFacade) GetPrivacySettings(w http.ResponseWriter, r *http.Request) {
+	cookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+
+	settings, err := facade.userClient.GetPrivacySettings(context.Background(), cookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(),
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		if err == domain.ErrUserNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	responseBody, err := json.Marshal(settings)
+	if err != nil {
+		facade.logger.Info(err.Error(),
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// EditPrivacySettings changes current user's privacy settings. Settings that were not passed stay the same
+func (facade *ProfileFacade) EditPrivacySettings(w http.ResponseWriter, r *http.Request) {
+	settingsInput := new(domain.PrivacySettingsInput)
+	err := json.NewDecoder(r.Body).Decode(settingsInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	cookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+
+	settings, err := facade.userClient.GetPrivacySettings(context.Background(), cookie.UserID)
+	if err == nil {
+		err = facade.userClient.EditPrivacySettings(context.Background(), cookie.UserID, settingsInput.Apply(settings))
+	}
+
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrUserNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RequestDataExport starts collecting all current user's data into downloadable archive
+func (facade *ProfileFacade) RequestDataExport(w http.ResponseWriter, r *http.Request) {
+	cookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+
+	export, err := facade.userClient.RequestDataExport(context.Background(), cookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(),
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	responseBody, err := json.Marshal(export)
+	if err != nil {
+		facade.logger.Info(err.Error(),
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	w.Write(responseBody)
+}
+
+// GetDataExport returns status of current user's data export and, if it is ready, its download link
+func (facade *ProfileFacade) GetDataExport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	exportIDStr, passedID := vars[string(domain.IDKey)]
+
+	if !passedID {
+		facade.logger.Info("Could not get id from query params",
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	cookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+
+	exportID, _ := strconv.ParseUint(exportIDStr, 10, 64)
+	export, err := facade.userClient.GetDataExport(context.Background(), cookie.UserID, exportID)
+	if err != nil {
+		facade.logger.Info(err.Error(),
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		if err == domain.ErrExportNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	responseBody, err := json.Marshal(export)
+	if err != nil {
+		facade.logger.Info(err.Error(),
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// DownloadDataExport sends data export archive. Download token is the only authorization needed
+func (facade *ProfileFacade) DownloadDataExport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	token, passedToken := vars[string(domain.TokenKey)]
+
+	if !passedToken {
+		facade.logger.Info("Could not get token from query params",
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	archive, err := facade.userClient.DownloadDataExport(r.Context(), token)
+	if err != nil {
+		facade.logger.Info(err.Error(),
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		switch err {
+		case domain.ErrExportNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		case domain.ErrExportExpired:
+			w.WriteHeader(http.StatusGone)
+		case domain.ErrExportNotReady:
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/zip")
+	w.Header().Add("Content-Disposition", `attachment; filename="pinterbest_data.zip"`)
+	w.WriteHeader(http.StatusOK)
+	_, err = io.Copy(w, archive)
+	if err != nil { // Headers are already sent, so we can only log error
+		facade.logger.Info(err.Error(),
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+	}
+}
+
+// BlockUser blocks user with specified id for current user
+func (facade *ProfileFacade) BlockUser(w http.ResponseWriter, r *http.Request) {
+	facade.changeRelation(w, r, facade.userClient.BlockUser)
+}
+
+// UnblockUser removes block of user with specified id
+func (facade *ProfileFacade) UnblockUser(w http.ResponseWriter, r *http.Request) {
+	facade.changeRelation(w, r, facade.userClient.UnblockUser)
+}
+
+// MuteUser mutes user with specified id for current user
+func (facade *ProfileFacade) MuteUser(w http.ResponseWriter, r *http.Request) {
+	facade.changeRelation(w, r, facade.userClient.MuteUser)
+}
+
+// UnmuteUser removes mute of user with specified id
+func (facade *ProfileFacade) UnmuteUser(w http.ResponseWriter, r *http.Request) {
+	facade.changeRelation(w, r, facade.userClient.UnmuteUser)
+}
+
+type relationChanger func(ctx context.Context, userID uint64, targetID uint64) error
+
+func (facade *ProfileFacade) changeRelation(w http.ResponseWriter, r *http.Request, changeRelation relationChanger) {
+	vars := mux.Vars(r)
+	targetIDStr, passedID := vars[string(domain.IDKey)]
+
+	if !passedID {
+		facade.logger.Info("Could not get id from query params",
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	cookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+
+	targetID, _ := strconv.ParseUint(targetIDStr, 10, 64)
+	err := changeRelation(context.Background(), cookie.UserID, targetID)
+	if err != nil {
+		facade.logger.Info(err.Error(),
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		switch err {
+		case domain.ErrUserNotFound, domain.ErrBlockNotFound, domain.ErrMuteNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		case domain.ErrSelfRelation:
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetBlockedUsers returns users whom current user has blocked
+func (facade *ProfileFacade) GetBlockedUsers(w http.ResponseWriter, r *http.Request) {
+	cookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+
+	users, err := facade.userClient.GetBlockedUsers(context.Background(), cookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(),
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	facade.writeUsersList(w, r, users)
+}
+
+// GetMutedUsers returns users whom current user has muted
+func (facade *ProfileFacade) GetMutedUsers(w http.ResponseWriter, r *http.Request) {
+	cookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+
+	users, err := facade.userClient.GetMutedUsers(context.Background(), cookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(),
+			zap.String("url", r.RequestURI),
+			zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	facade.writeUsersList(w, r, users)
+}
+
+// viewerID returns id of user who made request, 0 if they are not logged in
+func (facade *ProfileFacade) viewerID(r *http.Request) uint64 {
+	cookie, found := middleware.CheckCookies(r, facade.authClient)
+	if !found {
+		return 0
+	}
+
+	return cookie.UserID
+}
@@ -4,10 +4,16 @@ import (
 	"net/http"
 	"os"
 	authclient "pinterest/clients/auth"
+	"pinterest/domain"
 	authfacade "pinterest/interfaces/auth"
+	cartfacade "pinterest/interfaces/cart"
 	"pinterest/interfaces/metrics"
 	mid "pinterest/interfaces/middleware"
+	orderfacade "pinterest/interfaces/order"
+	productfacade "pinterest/interfaces/product"
 	profilefacade "pinterest/interfaces/profile"
+	shopfacade "pinterest/interfaces/shop"
+	wishlistfacade "pinterest/interfaces/wishlist"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
@@ -15,12 +21,15 @@ import (
 	"github.com/gorilla/mux"
 )
 
-func CreateRouter(authClient authclient.AuthClientInterface, authFacade *authfacade.AuthFacade, profileFacade *profilefacade.ProfileFacade, csrfOn bool) *mux.Router {
+func CreateRouter(authClient authclient.AuthClientInterface, authFacade *authfacade.AuthFacade, profileFacade *profilefacade.ProfileFacade,
+	shopFacade *shopfacade.ShopFacade, productFacade *productfacade.ProductFacade, cartFacade *cartfacade.CartFacade, orderFacade *orderfacade.OrderFacade,
+	wishlistFacade *wishlistfacade.WishlistFacade, csrfOn bool, fakePaymentOn bool) *mux.Router {
 	r := mux.NewRouter()
 
 	r.Use(mid.PanicMid, metrics.PrometheusMiddleware)
 
 	if csrfOn {
+		r.Use(mid.CSRFSkipMid("/api/payments/webhook/"))
 		csrfMid := csrf.Protect(
 			[]byte(os.Getenv("CSRF_KEY")),
 			csrf.Path("/"),
@@ -41,10 +50,123 @@ func CreateRouter(authClient authclient.AuthClientInterface, authFacade *authfac
 	r.HandleFunc("/api/profile/edit", mid.AuthMid(profileFacade.EditUser, authClient)).Methods("PUT")
 	// r.HandleFunc("/api/profile/delete", mid.AuthMid(profileInfo.HandleDeleteProfile, authApp)).Methods("DELETE")
 	r.HandleFunc("/api/profile", mid.AuthMid(profileFacade.GetCurrentUser, authClient)).Methods("GET")
+	r.HandleFunc("/api/profile/privacy", mid.AuthMid(profileFacade.GetPrivacySettings, authClient)).Methods("GET")
+	r.HandleFunc("/api/profile/privacy", mid.AuthMid(profileFacade.EditPrivacySettings, authClient)).Methods("PUT")
+	r.HandleFunc("/api/profile/export", mid.AuthMid(profileFacade.RequestDataExport, authClient)).Methods("POST")
+	r.HandleFunc("/api/profile/export/{id:[0-9]+}", mid.AuthMid(profileFacade.GetDataExport, authClient)).Methods("GET")
+	r.HandleFunc("/api/profile/export/download/{token}", profileFacade.DownloadDataExport).Methods("GET")
+	r.HandleFunc("/api/profile/blocked", mid.AuthMid(profileFacade.GetBlockedUsers, authClient)).Methods("GET")
+	r.HandleFunc("/api/profile/muted", mid.AuthMid(profileFacade.GetMutedUsers, authClient)).Methods("GET")
 	r.HandleFunc("/api/profile/{id:[0-9]+}", profileFacade.GetUserByID).Methods("GET") // Is preferred over next one
 	r.HandleFunc("/api/profile/{username}", profileFacade.GetUserByUsername).Methods("GET")
+	r.HandleFunc("/api/profile/{id:[0-9]+}/followers", profileFacade.GetFollowers).Methods("GET")
+	r.HandleFunc("/api/profile/{id:[0-9]+}/followed", profileFacade.GetFollowed).Methods("GET")
+	r.HandleFunc("/api/profile/{id:[0-9]+}/block", mid.AuthMid(profileFacade.BlockUser, authClient)).Methods("POST")
+	r.HandleFunc("/api/profile/{id:[0-9]+}/block", mid.AuthMid(profileFacade.UnblockUser, authClient)).Methods("DELETE")
+	r.HandleFunc("/api/profile/{id:[0-9]+}/mute", mid.AuthMid(profileFacade.MuteUser, authClient)).Methods("POST")
+	r.HandleFunc("/api/profile/{id:[0-9]+}/mute", mid.AuthMid(profileFacade.UnmuteUser, authClient)).Methods("DELETE")
 	// r.HandleFunc("/api/profile/avatar", mid.AuthMid(profileInfo.HandlePostAvatar, authApp)).Methods("PUT")
-	// r.HandleFunc("/api/profiles/search/{searchKey}", profileInfo.HandleGetProfilesByKeyWords).Methods("GET")
+	r.HandleFunc("/api/profiles/search/{searchKey}", profileFacade.SearchUsers).Methods("GET")
+
+	r.HandleFunc("/api/shop", mid.AuthMid(shopFacade.CreateShop, authClient)).Methods("POST")
+	r.HandleFunc("/api/shop/{id:[0-9]+}", shopFacade.GetShop).Methods("GET")
+	r.HandleFunc("/api/shop/{id:[0-9]+}", mid.AuthMid(shopFacade.EditShop, authClient)).Methods("PUT")
+	r.HandleFunc("/api/shop/{id:[0-9]+}", mid.AuthMid(shopFacade.DeleteShop, authClient)).Methods("DELETE")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/restore", mid.AuthMid(shopFacade.RestoreShop, authClient)).Methods("POST")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/managers", mid.AuthMid(shopFacade.InviteShopManager, authClient)).Methods("POST")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/managers/{managerID:[0-9]+}", mid.AuthMid(shopFacade.RemoveShopManager, authClient)).Methods("DELETE")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/follow", mid.AuthMid(shopFacade.FollowShop, authClient)).Methods("POST")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/follow", mid.AuthMid(shopFacade.UnfollowShop, authClient)).Methods("DELETE")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/discounts", mid.AuthMid(shopFacade.CreateShopDiscount, authClient)).Methods("POST")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/discounts", mid.AuthMid(shopFacade.ListShopDiscounts, authClient)).Methods("GET")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/discount/{discountID:[0-9]+}", mid.AuthMid(shopFacade.DeleteShopDiscount, authClient)).Methods("DELETE")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/promocodes", mid.AuthMid(shopFacade.CreatePromoCode, authClient)).Methods("POST")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/promocodes", mid.AuthMid(shopFacade.ListPromoCodes, authClient)).Methods("GET")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/promocode/{promoCodeID:[0-9]+}", mid.AuthMid(shopFacade.DeletePromoCode, authClient)).Methods("DELETE")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/products/import", mid.AuthMid(shopFacade.ImportProducts, authClient)).Methods("POST")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/products/import/{importID:[0-9]+}", mid.AuthMid(shopFacade.GetProductImport, authClient)).Methods("GET")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/products/export", mid.AuthMid(shopFacade.ExportProducts, authClient)).Methods("GET")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/products/deleted", mid.AuthMid(productFacade.ListDeletedProducts, authClient)).Methods("GET")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/analytics", mid.AuthMid(shopFacade.GetShopAnalytics, authClient)).Methods("GET")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/analytics/export", mid.AuthMid(shopFacade.ExportShopAnalytics, authClient)).Methods("GET")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/marketplace/{format:yml|google}", shopFacade.GetShopMarketplaceFeed).Methods("GET")
+	r.HandleFunc("/api/marketplace/{format:yml|google}", shopFacade.GetCatalogMarketplaceFeed).Methods("GET")
+	r.HandleFunc("/api/shop/invitations", mid.AuthMid(shopFacade.GetShopInvitations, authClient)).Methods("GET")
+	r.HandleFunc("/api/shop/invitations/{id:[0-9]+}/accept", mid.AuthMid(shopFacade.AcceptShopInvitation, authClient)).Methods("POST")
+	r.HandleFunc("/api/shop/invitations/{id:[0-9]+}/decline", mid.AuthMid(shopFacade.DeclineShopInvitation, authClient)).Methods("POST")
+
+	r.HandleFunc("/api/product", mid.AuthMid(productFacade.CreateProduct, authClient)).Methods("POST")
+	r.HandleFunc("/api/product/{id:[0-9]+}", productFacade.GetProduct).Methods("GET")
+	r.HandleFunc("/api/product/{id:[0-9]+}", mid.AuthMid(productFacade.EditProduct, authClient)).Methods("PUT")
+	r.HandleFunc("/api/product/{id:[0-9]+}", mid.AuthMid(productFacade.DeleteProduct, authClient)).Methods("DELETE")
+	r.HandleFunc("/api/product/{id:[0-9]+}/restore", mid.AuthMid(productFacade.RestoreProduct, authClient)).Methods("POST")
+	r.HandleFunc("/api/product/{id:[0-9]+}/related", productFacade.GetRelatedProducts).Methods("GET")
+	r.HandleFunc("/api/product/{id:[0-9]+}/images", productFacade.GetProductImages).Methods("GET")
+	r.HandleFunc("/api/product/{id:[0-9]+}/images", mid.AuthMid(productFacade.UploadProductImages, authClient)).Methods("POST")
+	r.HandleFunc("/api/product/{id:[0-9]+}/images/order", mid.AuthMid(productFacade.ReorderProductImages, authClient)).Methods("PUT")
+	r.HandleFunc("/api/product/{id:[0-9]+}/images/{imageID:[0-9]+}/primary", mid.AuthMid(productFacade.SetPrimaryProductImage, authClient)).Methods("PUT")
+	r.HandleFunc("/api/product/{id:[0-9]+}/images/{imageID:[0-9]+}", mid.AuthMid(productFacade.DeleteProductImage, authClient)).Methods("DELETE")
+	r.HandleFunc("/api/product/{id:[0-9]+}/options", mid.AuthMid(productFacade.SetProductOptions, authClient)).Methods("PUT")
+	r.HandleFunc("/api/product/{id:[0-9]+}/variants", mid.AuthMid(productFacade.CreateVariant, authClient)).Methods("POST")
+	r.HandleFunc("/api/product/{id:[0-9]+}/variant/{variantID:[0-9]+}", mid.AuthMid(productFacade.EditVariant, authClient)).Methods("PUT")
+	r.HandleFunc("/api/product/{id:[0-9]+}/variant/{variantID:[0-9]+}", mid.AuthMid(productFacade.DeleteVariant, authClient)).Methods("DELETE")
+	r.HandleFunc("/api/product/review/", mid.AuthMid(productFacade.CreateReview, authClient)).Methods("POST")
+	r.HandleFunc("/api/product/review/{id:[0-9]+}", mid.AuthMid(productFacade.EditReview, authClient)).Methods("PUT")
+	r.HandleFunc("/api/product/{id:[0-9]+}/reviews/", productFacade.ListReviews).Methods("GET")
+	r.HandleFunc("/api/product/{id:[0-9]+}/questions", mid.AuthMid(productFacade.AskQuestion, authClient)).Methods("POST")
+	r.HandleFunc("/api/product/{id:[0-9]+}/questions", productFacade.ListQuestions).Methods("GET")
+	r.HandleFunc("/api/product/question/{id:[0-9]+}/answers", mid.AuthMid(productFacade.AnswerQuestion, authClient)).Methods("POST")
+	r.HandleFunc("/api/product/question/{id:[0-9]+}/vote", mid.AuthMid(productFacade.VoteQuestion, authClient)).Methods("POST")
+	r.HandleFunc("/api/product/question/{id:[0-9]+}/vote", mid.AuthMid(productFacade.UnvoteQuestion, authClient)).Methods("DELETE")
+	r.HandleFunc("/api/product/answer/{id:[0-9]+}/official", mid.AuthMid(productFacade.MarkOfficialAnswer, authClient)).Methods("POST")
+	r.HandleFunc("/api/product/answer/{id:[0-9]+}/official", mid.AuthMid(productFacade.UnmarkOfficialAnswer, authClient)).Methods("DELETE")
+	r.HandleFunc("/api/product/{id:[0-9]+}/stock", mid.AuthMid(productFacade.AdjustStock, authClient)).Methods("POST")
+	r.HandleFunc("/api/product/{id:[0-9]+}/stock/movements", mid.AuthMid(productFacade.ListInventoryMovements, authClient)).Methods("GET")
+	r.HandleFunc("/api/product/{id:[0-9]+}/sales", mid.AuthMid(productFacade.CreateProductSale, authClient)).Methods("POST")
+	r.HandleFunc("/api/product/{id:[0-9]+}/sales", mid.AuthMid(productFacade.ListProductSales, authClient)).Methods("GET")
+	r.HandleFunc("/api/product/{id:[0-9]+}/sale/{saleID:[0-9]+}", mid.AuthMid(productFacade.DeleteProductSale, authClient)).Methods("DELETE")
+	r.HandleFunc("/api/product/{id:[0-9]+}/prices", productFacade.ListPriceHistory).Methods("GET")
+	r.HandleFunc("/api/products/{id:[0-9]+}", productFacade.ListProductsByShop).Methods("GET")
+	r.HandleFunc("/api/products/feed/", productFacade.GetFeed).Methods("GET")
+	r.HandleFunc("/api/products/search", productFacade.SearchProducts).Methods("GET")
+	r.HandleFunc("/api/categories", productFacade.GetCategories).Methods("GET")
+	r.HandleFunc("/api/category", mid.AuthMid(productFacade.CreateCategory, authClient)).Methods("POST")
+	r.HandleFunc("/api/category/{id:[0-9]+}", mid.AuthMid(productFacade.EditCategory, authClient)).Methods("PUT")
+	r.HandleFunc("/api/category/{id:[0-9]+}", mid.AuthMid(productFacade.DeleteCategory, authClient)).Methods("DELETE")
+	r.HandleFunc("/api/category/{id:[0-9]+}/products", productFacade.ListProductsByCategory).Methods("GET")
+	r.HandleFunc("/api/currencies", productFacade.GetExchangeRates).Methods("GET")
+	r.HandleFunc("/api/currencies", mid.AuthMid(productFacade.UpdateExchangeRates, authClient)).Methods("PUT")
+
+	r.HandleFunc("/api/cart", cartFacade.GetCart).Methods("GET")
+	r.HandleFunc("/api/cart/items", cartFacade.AddToCart).Methods("POST")
+	r.HandleFunc("/api/cart/items/{id:[0-9]+}", cartFacade.UpdateCartItem).Methods("PUT")
+	r.HandleFunc("/api/cart/items/{id:[0-9]+}", cartFacade.RemoveFromCart).Methods("DELETE")
+
+	r.HandleFunc("/api/wishlists", mid.AuthMid(wishlistFacade.GetOwnWishlists, authClient)).Methods("GET")
+	r.HandleFunc("/api/wishlists", mid.AuthMid(wishlistFacade.CreateWishlist, authClient)).Methods("POST")
+	r.HandleFunc("/api/wishlists/shared/{token}", wishlistFacade.GetSharedWishlist).Methods("GET")
+	r.HandleFunc("/api/wishlist/{id:[0-9]+}", wishlistFacade.GetWishlist).Methods("GET")
+	r.HandleFunc("/api/wishlist/{id:[0-9]+}", mid.AuthMid(wishlistFacade.EditWishlist, authClient)).Methods("PUT")
+	r.HandleFunc("/api/wishlist/{id:[0-9]+}", mid.AuthMid(wishlistFacade.DeleteWishlist, authClient)).Methods("DELETE")
+	r.HandleFunc("/api/wishlist/{id:[0-9]+}/products/{productID:[0-9]+}", mid.AuthMid(wishlistFacade.RemoveWishlistItem, authClient)).Methods("DELETE")
+	r.HandleFunc("/api/profile/{id:[0-9]+}/wishlists", wishlistFacade.GetUserWishlists).Methods("GET")
+	r.HandleFunc("/api/product/{id:[0-9]+}/save", mid.AuthMid(wishlistFacade.SaveProduct, authClient)).Methods("POST")
+	r.HandleFunc("/api/product/{id:[0-9]+}/save", mid.AuthMid(wishlistFacade.UnsaveProduct, authClient)).Methods("DELETE")
+
+	r.HandleFunc("/api/orders/checkout", mid.AuthMid(orderFacade.Checkout, authClient)).Methods("POST")
+	r.HandleFunc("/api/orders", mid.AuthMid(orderFacade.ListUserOrders, authClient)).Methods("GET")
+	r.HandleFunc("/api/order/{id:[0-9]+}", mid.AuthMid(orderFacade.GetOrder, authClient)).Methods("GET")
+	r.HandleFunc("/api/order/{id:[0-9]+}/status", mid.AuthMid(orderFacade.ChangeOrderStatus, authClient)).Methods("PUT")
+	r.HandleFunc("/api/shop/{id:[0-9]+}/orders", mid.AuthMid(orderFacade.ListShopOrders, authClient)).Methods("GET")
+	r.HandleFunc("/api/order/{id:[0-9]+}/payment", mid.AuthMid(orderFacade.CreatePayment, authClient)).Methods("POST")
+	r.HandleFunc("/api/order/{id:[0-9]+}/payment/return", mid.AuthMid(orderFacade.PaymentReturn, authClient)).Methods("GET")
+	r.HandleFunc("/api/payments/webhook/{provider}", orderFacade.PaymentWebhook).Methods("POST")
+	if fakePaymentOn { // Fake payments can be completed by anyone, so they are served only if fake provider is configured
+		r.HandleFunc("/api/payments/fake/{paymentID}", orderFacade.FakePaymentPage).Methods("GET")
+		r.HandleFunc("/api/payments/fake/{paymentID}", orderFacade.CompleteFakePayment).Methods("POST")
+	}
+
+	r.PathPrefix(domain.MediaPath).Handler(http.StripPrefix(domain.MediaPath, http.FileServer(http.Dir(os.Getenv("MEDIA_DIR"))))).Methods("GET")
 
 	if csrfOn {
 		r.HandleFunc("/api/csrf", func(w http.ResponseWriter, r *http.Request) { // Is used only for getting csrf key
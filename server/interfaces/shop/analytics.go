@@ -0,0 +1,76 @@
+package shop
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"pinterest/domain"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// GetShopAnalytics returns views of shop page and shop's products during date range, only shop's managers can see them
+func (facade *ShopFacade) GetShopAnalytics(w http.ResponseWriter, r *http.Request) {
+	analytics, err := facade.getShopAnalytics(r)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeAnalyticsError(w, err)
+		return
+	}
+
+	responseBody, err := json.Marshal(analytics)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// ExportShopAnalytics returns CSV file with the same per-product report as GetShopAnalytics
+func (facade *ShopFacade) ExportShopAnalytics(w http.ResponseWriter, r *http.Request) {
+	analytics, err := facade.getShopAnalytics(r)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeAnalyticsError(w, err)
+		return
+	}
+
+	w.Header().Add("Content-Type", domain.AnalyticsContentType)
+	w.Header().Add("Content-Disposition", fmt.Sprintf(`attachment; filename="shop_%d_analytics_%s_%s.csv"`,
+		analytics.ShopID, analytics.From, analytics.To))
+	w.WriteHeader(http.StatusOK)
+	err = domain.WriteAnalyticsCSV(w, analytics)
+	if err != nil { // Headers are already sent, so we can only log error
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+	}
+}
+
+func (facade *ShopFacade) getShopAnalytics(r *http.Request) (analytics domain.ShopAnalytics, err error) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+	query := r.URL.Query()
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	return facade.shopProductClient.GetShopAnalytics(context.Background(), shopID, userCookie.UserID,
+		query.Get(domain.DateFromKey), query.Get(domain.DateToKey))
+}
+
+func writeAnalyticsError(w http.ResponseWriter, err error) {
+	switch err {
+	case domain.ErrInvalidDateRange:
+		w.WriteHeader(http.StatusBadRequest)
+	case domain.ErrNotShopManager:
+		w.WriteHeader(http.StatusForbidden)
+	case domain.ErrShopNotFound:
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
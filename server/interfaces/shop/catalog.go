@@ -0,0 +1,141 @@
+package shop
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"pinterest/domain"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// ImportProducts starts import of shop's products from "file" multipart field. Format is taken from "format"
+// query parameter or from file's extension, import with "dryRun" parameter only validates rows.
+// Import goes on in background, its progress is returned by GetProductImport
+func (facade *ShopFacade) ImportProducts(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	dryRun := false
+	if dryRunParam := r.URL.Query().Get(domain.DryRunKey); dryRunParam != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(dryRunParam)
+		if err != nil {
+			facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+
+	err := r.ParseMultipartForm(domain.CatalogChunkSize)
+	if err != nil || len(r.MultipartForm.File[domain.CatalogFileField]) == 0 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	fileHeader := r.MultipartForm.File[domain.CatalogFileField][0]
+	format := r.URL.Query().Get(domain.CatalogFormatKey)
+	if format == "" {
+		format = strings.TrimPrefix(filepath.Ext(fileHeader.Filename), ".")
+	}
+	if format == "" {
+		format = domain.DefaultCatalogFormat
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	productImport, err := facade.shopProductClient.ImportProducts(context.Background(), shopID, userCookie.UserID,
+		strings.ToLower(format), dryRun, file)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeCatalogError(w, err)
+		return
+	}
+
+	facade.writeProductImport(w, r, http.StatusAccepted, productImport)
+}
+
+// GetProductImport returns status, progress and row errors of shop's import
+func (facade *ShopFacade) GetProductImport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+	importID, _ := strconv.ParseUint(vars[domain.ImportIDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	productImport, err := facade.shopProductClient.GetProductImport(context.Background(), shopID, importID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeCatalogError(w, err)
+		return
+	}
+
+	facade.writeProductImport(w, r, http.StatusOK, productImport)
+}
+
+// ExportProducts returns file with all shop's products in format which ImportProducts accepts, csv by default
+func (facade *ShopFacade) ExportProducts(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	format := strings.ToLower(r.URL.Query().Get(domain.CatalogFormatKey))
+	if format == "" {
+		format = domain.DefaultCatalogFormat
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	catalog, err := facade.shopProductClient.ExportProducts(r.Context(), shopID, userCookie.UserID, format)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeCatalogError(w, err)
+		return
+	}
+
+	w.Header().Add("Content-Type", domain.CatalogContentTypes[format])
+	w.Header().Add("Content-Disposition", fmt.Sprintf(`attachment; filename="shop_%d_products.%s"`, shopID, format))
+	w.WriteHeader(http.StatusOK)
+	_, err = io.Copy(w, catalog)
+	if err != nil { // Headers are already sent, so we can only log error
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+	}
+}
+
+func (facade *ShopFacade) writeProductImport(w http.ResponseWriter, r *http.Request, status int, productImport domain.ProductImport) {
+	responseBody, err := json.Marshal(productImport)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(responseBody)
+}
+
+func writeCatalogError(w http.ResponseWriter, err error) {
+	switch err {
+	case domain.ErrInvalidCatalogFormat, domain.ErrInvalidImportFile, domain.ErrTooManyImportRows:
+		w.WriteHeader(http.StatusBadRequest)
+	case domain.ErrNotShopManager:
+		w.WriteHeader(http.StatusForbidden)
+	case domain.ErrShopNotFound, domain.ErrImportNotFound:
+		w.WriteHeader(http.StatusNotFound)
+	case domain.ErrImportFileTooLarge:
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
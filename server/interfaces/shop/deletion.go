@@ -0,0 +1,63 @@
+package shop
+
+import (
+	"context"
+	"net/http"
+	"pinterest/domain"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// DeleteShop deletes shop together with its products, only shop's owners can do it.
+// Shop can be restored during 30 days, existing orders of shop are kept
+func (facade *ShopFacade) DeleteShop(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err := facade.shopProductClient.DeleteShop(context.Background(), shopID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrNotShopOwner:
+			w.WriteHeader(http.StatusForbidden)
+		case domain.ErrShopNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RestoreShop restores shop deleted less than 30 days ago together with products which were deleted with it.
+// Only shop's owners can do it
+func (facade *ShopFacade) RestoreShop(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err := facade.shopProductClient.RestoreShop(context.Background(), shopID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrNotShopOwner:
+			w.WriteHeader(http.StatusForbidden)
+		case domain.ErrShopNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		case domain.ErrDuplicateProductSKU:
+			w.WriteHeader(http.StatusConflict)
+		case domain.ErrRestoreExpired:
+			w.WriteHeader(http.StatusGone)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,146 @@
+package shop
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"pinterest/domain"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// InviteShopManager invites user with specified username to become shop's manager. Only shop's owners can do it
+func (facade *ShopFacade) InviteShopManager(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	invitationInput := new(domain.ManagerInvitationInput)
+	err := json.NewDecoder(r.Body).Decode(invitationInput)
+	if err != nil || invitationInput.Username == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	invitedUser, err := facade.userClient.GetUserByUsername(context.Background(), invitationInput.Username, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrUserNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	invitationID, err := facade.shopProductClient.InviteShopManager(context.Background(), shopID, userCookie.UserID,
+		invitedUser.UserID, invitationInput.Role)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrInvalidRole:
+			w.WriteHeader(http.StatusBadRequest)
+		case domain.ErrNotShopOwner:
+			w.WriteHeader(http.StatusForbidden)
+		case domain.ErrShopNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		case domain.ErrAlreadyManager:
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(domain.InvitationIDResponse{InvitationID: invitationID})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseBody)
+}
+
+// RemoveShopManager removes manager or owner from shop. Only shop's owners can do it
+func (facade *ShopFacade) RemoveShopManager(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+	managerID, _ := strconv.ParseUint(vars[domain.ManagerIDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err := facade.shopProductClient.RemoveShopManager(context.Background(), shopID, userCookie.UserID, managerID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrNotShopOwner:
+			w.WriteHeader(http.StatusForbidden)
+		case domain.ErrShopNotFound, domain.ErrNotShopManager:
+			w.WriteHeader(http.StatusNotFound)
+		case domain.ErrLastOwner:
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetShopInvitations returns current user's pending invitations to become shop manager
+func (facade *ShopFacade) GetShopInvitations(w http.ResponseWriter, r *http.Request) {
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	invitations, err := facade.shopProductClient.GetShopInvitations(context.Background(), userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	responseBody, err := json.Marshal(domain.ShopInvitationsResponse{Invitations: invitations})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+func (facade *ShopFacade) AcceptShopInvitation(w http.ResponseWriter, r *http.Request) {
+	facade.answerInvitation(w, r, facade.shopProductClient.AcceptShopInvitation)
+}
+
+func (facade *ShopFacade) DeclineShopInvitation(w http.ResponseWriter, r *http.Request) {
+	facade.answerInvitation(w, r, facade.shopProductClient.DeclineShopInvitation)
+}
+
+// answerInvitation calls accept or decline method for invitation from path, on behalf of current user
+func (facade *ShopFacade) answerInvitation(w http.ResponseWriter, r *http.Request,
+	answer func(ctx context.Context, invitationID uint64, userID uint64) error) {
+	vars := mux.Vars(r)
+	invitationID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err := answer(context.Background(), invitationID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrInvitationNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
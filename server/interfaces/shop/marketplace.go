@@ -0,0 +1,52 @@
+package shop
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"pinterest/domain"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// GetShopMarketplaceFeed returns Yandex Market YML or Google Merchant feed of shop's products
+func (facade *ShopFacade) GetShopMarketplaceFeed(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	facade.writeMarketplaceFeed(w, r, shopID)
+}
+
+// GetCatalogMarketplaceFeed returns Yandex Market YML or Google Merchant feed of products of all shops
+func (facade *ShopFacade) GetCatalogMarketplaceFeed(w http.ResponseWriter, r *http.Request) {
+	facade.writeMarketplaceFeed(w, r, 0)
+}
+
+func (facade *ShopFacade) writeMarketplaceFeed(w http.ResponseWriter, r *http.Request, shopID uint64) {
+	vars := mux.Vars(r)
+	format := vars[domain.CatalogFormatKey]
+
+	feed, err := facade.shopProductClient.GetMarketplaceFeed(r.Context(), shopID, format)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrInvalidFeedFormat:
+			w.WriteHeader(http.StatusBadRequest)
+		case domain.ErrShopNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Add("Content-Type", domain.FeedContentType)
+	w.Header().Add("Cache-Control", fmt.Sprintf("public, max-age=%d", domain.FeedCacheMaxAge))
+	w.WriteHeader(http.StatusOK)
+	_, err = io.Copy(w, feed)
+	if err != nil { // Headers are already sent, so we can only log error
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+	}
+}
@@ -0,0 +1,172 @@
+package shop
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"pinterest/domain"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// CreateShopDiscount schedules percentage discount of all shop's products. Only shop's managers can do it
+func (facade *ShopFacade) CreateShopDiscount(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	discountInput := new(domain.ShopDiscount)
+	err := json.NewDecoder(r.Body).Decode(discountInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	discountID, err := facade.shopProductClient.CreateShopDiscount(context.Background(), shopID, userCookie.UserID, *discountInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writePricingError(w, err)
+		return
+	}
+
+	facade.writePricingRuleID(w, r, discountID)
+}
+
+// ListShopDiscounts returns active and scheduled discounts of shop, only shop's managers can see them
+func (facade *ShopFacade) ListShopDiscounts(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	discounts, err := facade.shopProductClient.ListShopDiscounts(context.Background(), shopID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writePricingError(w, err)
+		return
+	}
+
+	responseBody, err := json.Marshal(domain.ShopDiscountsListResponse{Discounts: discounts})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// DeleteShopDiscount cancels discount of shop, cancellation is kept in price history
+func (facade *ShopFacade) DeleteShopDiscount(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+	discountID, _ := strconv.ParseUint(vars[domain.DiscountIDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err := facade.shopProductClient.DeleteShopDiscount(context.Background(), shopID, discountID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writePricingError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CreatePromoCode creates promo code which buyers can enter at checkout. Only shop's managers can do it
+func (facade *ShopFacade) CreatePromoCode(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	promoCodeInput := new(domain.PromoCode)
+	err := json.NewDecoder(r.Body).Decode(promoCodeInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	promoCodeID, err := facade.shopProductClient.CreatePromoCode(context.Background(), shopID, userCookie.UserID, *promoCodeInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writePricingError(w, err)
+		return
+	}
+
+	facade.writePricingRuleID(w, r, promoCodeID)
+}
+
+// ListPromoCodes returns active promo codes of shop with their usage, only shop's managers can see them
+func (facade *ShopFacade) ListPromoCodes(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	promoCodes, err := facade.shopProductClient.ListPromoCodes(context.Background(), shopID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writePricingError(w, err)
+		return
+	}
+
+	responseBody, err := json.Marshal(domain.PromoCodesListResponse{PromoCodes: promoCodes})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+// DeletePromoCode deactivates promo code, orders which already used it keep their discount
+func (facade *ShopFacade) DeletePromoCode(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+	promoCodeID, _ := strconv.ParseUint(vars[domain.PromoCodeIDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err := facade.shopProductClient.DeletePromoCode(context.Background(), shopID, promoCodeID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writePricingError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (facade *ShopFacade) writePricingRuleID(w http.ResponseWriter, r *http.Request, id uint64) {
+	responseBody, err := json.Marshal(domain.PricingRuleIDResponse{ID: id})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseBody)
+}
+
+func writePricingError(w http.ResponseWriter, err error) {
+	switch err {
+	case domain.ErrInvalidDiscount, domain.ErrInvalidPromoCode:
+		w.WriteHeader(http.StatusBadRequest)
+	case domain.ErrNotShopManager:
+		w.WriteHeader(http.StatusForbidden)
+	case domain.ErrShopNotFound, domain.ErrDiscountNotFound, domain.ErrPromoCodeNotFound:
+		w.WriteHeader(http.StatusNotFound)
+	case domain.ErrPromoCodeExists:
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,135 @@
+package shop
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	authclient "pinterest/clients/auth"
+	shopproductclient "pinterest/clients/shopProduct"
+	userclient "pinterest/clients/user"
+	"pinterest/domain"
+	"pinterest/interfaces/middleware"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// ShopFacade calls shopProduct service for shop-related requests
+type ShopFacade struct {
+	shopProductClient shopproductclient.ShopProductClientInterface
+	userClient        userclient.UserClientInterface
+	authClient        authclient.AuthClientInterface
+	logger            *zap.Logger
+}
+
+func NewShopFacade(shopProductClient shopproductclient.ShopProductClientInterface, userClient userclient.UserClientInterface,
+	authClient authclient.AuthClientInterface, logger *zap.Logger) *ShopFacade {
+	return &ShopFacade{
+		shopProductClient: shopProductClient,
+		userClient:        userClient,
+		authClient:        authClient,
+		logger:            logger,
+	}
+}
+
+// CreateShop creates shop using data from "shopInfo" multipart field. Current user becomes shop's owner
+func (facade *ShopFacade) CreateShop(w http.ResponseWriter, r *http.Request) {
+	shopInput := new(domain.Shop)
+	err := json.Unmarshal([]byte(r.FormValue("shopInfo")), shopInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	shopID, err := facade.shopProductClient.CreateShop(context.Background(), *shopInput, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrEmptyTitle, domain.ErrInvalidCurrency:
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(domain.ShopIDResponse{ShopID: shopID})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	w.Write(responseBody)
+}
+
+// EditShop changes shop's data to one specified, omitted fields are left unchanged. Only shop's managers can do it.
+// Currency can be changed only while shop has no products and promo codes
+func (facade *ShopFacade) EditShop(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	shopInput := new(domain.Shop)
+	err := json.NewDecoder(r.Body).Decode(shopInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	shopInput.ShopID = shopID
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err = facade.shopProductClient.EditShop(context.Background(), *shopInput, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrInvalidCurrency:
+			w.WriteHeader(http.StatusBadRequest)
+		case domain.ErrNotShopManager:
+			w.WriteHeader(http.StatusForbidden)
+		case domain.ErrShopNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		case domain.ErrCurrencyInUse:
+			w.WriteHeader(http.StatusConflict)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (facade *ShopFacade) GetShop(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	shopID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	view := middleware.TrackPageView(w, r, facade.authClient)
+	shop, err := facade.shopProductClient.GetShop(context.Background(), shopID, view)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		switch err {
+		case domain.ErrShopNotFound:
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		return
+	}
+
+	responseBody, err := json.Marshal(shop)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
@@ -0,0 +1,268 @@
+package wishlist
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	authclient "pinterest/clients/auth"
+	shopproductclient "pinterest/clients/shopProduct"
+	"pinterest/domain"
+	"pinterest/interfaces/middleware"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+)
+
+// WishlistFacade calls shopProduct service for wishlist requests. Public wishlists can be seen by anyone,
+// private ones only by their owners
+type WishlistFacade struct {
+	shopProductClient shopproductclient.ShopProductClientInterface
+	authClient        authclient.AuthClientInterface
+	logger            *zap.Logger
+}
+
+func NewWishlistFacade(shopProductClient shopproductclient.ShopProductClientInterface, authClient authclient.AuthClientInterface, logger *zap.Logger) *WishlistFacade {
+	return &WishlistFacade{
+		shopProductClient: shopProductClient,
+		authClient:        authClient,
+		logger:            logger,
+	}
+}
+
+// GetOwnWishlists returns all wishlists of current user with their share links
+func (facade *WishlistFacade) GetOwnWishlists(w http.ResponseWriter, r *http.Request) {
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	wishlists, err := facade.shopProductClient.ListWishlists(context.Background(), userCookie.UserID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeWishlistError(w, err)
+		return
+	}
+
+	facade.writeWishlists(w, r, wishlists)
+}
+
+// GetUserWishlists returns public wishlists of user, user who views own profile gets all wishlists
+func (facade *WishlistFacade) GetUserWishlists(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	ownerID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	wishlists, err := facade.shopProductClient.ListWishlists(context.Background(), ownerID, facade.viewerID(r))
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeWishlistError(w, err)
+		return
+	}
+
+	facade.writeWishlists(w, r, wishlists)
+}
+
+func (facade *WishlistFacade) CreateWishlist(w http.ResponseWriter, r *http.Request) {
+	wishlistInput := new(domain.WishlistInput)
+	err := json.NewDecoder(r.Body).Decode(wishlistInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	wishlistID, err := facade.shopProductClient.CreateWishlist(context.Background(), userCookie.UserID, *wishlistInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeWishlistError(w, err)
+		return
+	}
+
+	facade.writeWishlistID(w, r, wishlistID, http.StatusCreated)
+}
+
+// GetWishlist returns wishlist with its products, recently saved products go first
+func (facade *WishlistFacade) GetWishlist(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	wishlistID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	wishlist, err := facade.shopProductClient.GetWishlist(context.Background(), wishlistID, facade.viewerID(r), domain.PreferredCurrency(r))
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeWishlistError(w, err)
+		return
+	}
+
+	facade.writeWishlist(w, r, wishlist)
+}
+
+// GetSharedWishlist returns wishlist opened by share link
+func (facade *WishlistFacade) GetSharedWishlist(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	wishlist, err := facade.shopProductClient.GetSharedWishlist(context.Background(), vars[domain.TokenKey], facade.viewerID(r), domain.PreferredCurrency(r))
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeWishlistError(w, err)
+		return
+	}
+
+	facade.writeWishlist(w, r, wishlist)
+}
+
+// EditWishlist renames wishlist if title is passed and sets its visibility
+func (facade *WishlistFacade) EditWishlist(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	wishlistID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	wishlistInput := new(domain.WishlistInput)
+	err := json.NewDecoder(r.Body).Decode(wishlistInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err = facade.shopProductClient.EditWishlist(context.Background(), wishlistID, userCookie.UserID, *wishlistInput)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeWishlistError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (facade *WishlistFacade) DeleteWishlist(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	wishlistID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err := facade.shopProductClient.DeleteWishlist(context.Background(), wishlistID, userCookie.UserID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeWishlistError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// SaveProduct saves product to wishlist from body, request without body saves product to default wishlist
+func (facade *WishlistFacade) SaveProduct(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	saveInput := new(domain.SaveProductInput)
+	err := json.NewDecoder(r.Body).Decode(saveInput)
+	if err != nil && err != io.EOF {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	wishlistID, err := facade.shopProductClient.SaveProduct(context.Background(), productID, userCookie.UserID, saveInput.WishlistID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeWishlistError(w, err)
+		return
+	}
+
+	facade.writeWishlistID(w, r, wishlistID, http.StatusOK)
+}
+
+// UnsaveProduct removes product from all wishlists of current user
+func (facade *WishlistFacade) UnsaveProduct(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	productID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+
+	facade.unsaveProduct(w, r, productID, 0)
+}
+
+// RemoveWishlistItem removes product from one wishlist of current user
+func (facade *WishlistFacade) RemoveWishlistItem(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	wishlistID, _ := strconv.ParseUint(vars[domain.IDKey], 10, 64)
+	productID, _ := strconv.ParseUint(vars[domain.ProductIDKey], 10, 64)
+
+	facade.unsaveProduct(w, r, productID, wishlistID)
+}
+
+func (facade *WishlistFacade) unsaveProduct(w http.ResponseWriter, r *http.Request, productID uint64, wishlistID uint64) {
+	userCookie := r.Context().Value(domain.CookieInfoKey).(*domain.CookieInfo)
+	err := facade.shopProductClient.UnsaveProduct(context.Background(), productID, userCookie.UserID, wishlistID)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		writeWishlistError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// viewerID returns id of logged in user, 0 for anonymous users
+func (facade *WishlistFacade) viewerID(r *http.Request) uint64 {
+	cookie, found := middleware.CheckCookies(r, facade.authClient)
+	if !found {
+		return 0
+	}
+
+	return cookie.UserID
+}
+
+func (facade *WishlistFacade) writeWishlist(w http.ResponseWriter, r *http.Request, wishlist domain.Wishlist) {
+	if wishlist.Products == nil {
+		wishlist.Products = make([]domain.Product, 0)
+	}
+
+	responseBody, err := json.Marshal(wishlist)
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+func (facade *WishlistFacade) writeWishlists(w http.ResponseWriter, r *http.Request, wishlists []domain.Wishlist) {
+	responseBody, err := json.Marshal(domain.WishlistsListResponse{Wishlists: wishlists})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBody)
+}
+
+func (facade *WishlistFacade) writeWishlistID(w http.ResponseWriter, r *http.Request, wishlistID uint64, status int) {
+	responseBody, err := json.Marshal(domain.WishlistIDResponse{WishlistID: wishlistID})
+	if err != nil {
+		facade.logger.Info(err.Error(), zap.String("url", r.RequestURI), zap.String("method", r.Method))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(responseBody)
+}
+
+// writeWishlistError writes status which corresponds to error returned by shopProduct service
+func writeWishlistError(w http.ResponseWriter, err error) {
+	switch err {
+	case domain.ErrInvalidWishlistTitle, domain.ErrInvalidCurrency, domain.ErrExchangeRateNotFound:
+		w.WriteHeader(http.StatusBadRequest)
+	case domain.ErrWishlistNotFound, domain.ErrWishlistItemNotFound, domain.ErrProductNotFound:
+		w.WriteHeader(http.StatusNotFound)
+	case domain.ErrTooManyWishlists, domain.ErrDefaultWishlist:
+		w.WriteHeader(http.StatusConflict)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
@@ -6,11 +6,21 @@ import (
 	"os"
 
 	authclient "pinterest/clients/auth"
+	orderclient "pinterest/clients/order"
+	shopproductclient "pinterest/clients/shopProduct"
 	userclient "pinterest/clients/user"
+	"pinterest/domain"
 	authfacade "pinterest/interfaces/auth"
+	cartfacade "pinterest/interfaces/cart"
+	orderfacade "pinterest/interfaces/order"
+	productfacade "pinterest/interfaces/product"
 	profilefacade "pinterest/interfaces/profile"
 	"pinterest/interfaces/routing"
+	shopfacade "pinterest/interfaces/shop"
+	wishlistfacade "pinterest/interfaces/wishlist"
 	authproto "pinterest/services/auth/proto"
+	orderproto "pinterest/services/order/proto"
+	shopproductproto "pinterest/services/shopProduct/proto"
 	userproto "pinterest/services/user/proto"
 
 	"go.uber.org/zap"
@@ -52,14 +62,37 @@ func runServer(addr string) {
 	}
 	defer sessionAuth.Close()
 
+	sessionShopProduct, err := grpc.Dial(os.Getenv(dockerStatus+"_SHOPPRODUCT_PREFIX")+":8083", grpc.WithInsecure())
+	if err != nil {
+		sugarLogger.Fatal("Can not create session for ShopProduct service")
+	}
+	defer sessionShopProduct.Close()
+
+	sessionOrder, err := grpc.Dial(os.Getenv(dockerStatus+"_ORDER_PREFIX")+":8084", grpc.WithInsecure())
+	if err != nil {
+		sugarLogger.Fatal("Can not create session for Order service")
+	}
+	defer sessionOrder.Close()
+
 	authClient := authclient.NewAuthClient(authproto.NewAuthClient(sessionAuth), os.Getenv("HTTPS_ON") == "true")
 	userClient := userclient.NewUserClient(userproto.NewUserClient(sessionUser))
+	shopProductClient := shopproductclient.NewShopProductClient(shopproductproto.NewShopProductClient(sessionShopProduct))
+	orderClient := orderclient.NewOrderClient(orderproto.NewOrderServiceClient(sessionOrder))
 
-	authFacade := authfacade.NewAuthFacade(authClient, logger)
+	authFacade := authfacade.NewAuthFacade(authClient, shopProductClient, logger)
 	profilefacade := profilefacade.NewProfileFacade(userClient, authClient, logger)
+	shopFacade := shopfacade.NewShopFacade(shopProductClient, userClient, authClient, logger)
+	productFacade := productfacade.NewProductFacade(shopProductClient, authClient, logger)
+	cartFacade := cartfacade.NewCartFacade(shopProductClient, authClient, logger)
+	orderFacade := orderfacade.NewOrderFacade(orderClient, shopProductClient, os.Getenv("SITE_URL"), logger)
+	wishlistFacade := wishlistfacade.NewWishlistFacade(shopProductClient, authClient, logger)
 	// TODO divide file
 
-	r := routing.CreateRouter(authClient, authFacade, profilefacade, os.Getenv("CSRF_ON") == "true")
+	paymentProvider := os.Getenv("PAYMENT_PROVIDER")
+	fakePaymentOn := paymentProvider == "" || paymentProvider == domain.FakePaymentProvider
+
+	r := routing.CreateRouter(authClient, authFacade, profilefacade, shopFacade, productFacade, cartFacade, orderFacade, wishlistFacade,
+		os.Getenv("CSRF_ON") == "true", fakePaymentOn)
 
 	allowedOrigins := make([]string, 0)
 	switch os.Getenv("HTTPS_ON") {
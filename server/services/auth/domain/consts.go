@@ -4,3 +4,10 @@ const (
 	CookieSessionLength = 30
 	CookieExpiryHours   = 120
 )
+
+// Audit events which are recorded by auth service, they are exported together with user's data
+const (
+	AuditEventLogin              = "login"
+	AuditEventLogout             = "logout"
+	AuditEventCredentialsChanged = "credentials_changed"
+)
@@ -76,6 +76,11 @@ func (repo *AuthRepo) AddCookieInfo(ctx context.Context, cookieInfo domain.Cooki
 		return domain.UserNotFoundError
 	}
 
+	err = addAuditEvent(ctx, tx, cookieInfo.UserID, domain.AuditEventLogin)
+	if err != nil {
+		return err
+	}
+
 	err = tx.Commit(ctx)
 	if err != nil {
 		return domain.TransactionCommitError
@@ -152,15 +157,22 @@ func (repo *AuthRepo) DeleteCookie(ctx context.Context, cookieValue string) erro
 
 	deleteCookieQuery := `UPDATE users
 						  SET cookie_value = '', cookie_expiry = now()
-						  WHERE users.cookie_value = $1`
+						  WHERE users.cookie_value = $1
+						  RETURNING id`
 
-	result, err := tx.Exec(ctx, deleteCookieQuery, cookieValue)
+	var userID uint64
+	err = tx.QueryRow(ctx, deleteCookieQuery, cookieValue).Scan(&userID)
 	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.CookieNotFoundError
+		}
+
 		return err
 	}
 
-	if result.RowsAffected() != 1 {
-		return domain.CookieNotFoundError
+	err = addAuditEvent(ctx, tx, userID, domain.AuditEventLogout)
+	if err != nil {
+		return err
 	}
 
 	err = tx.Commit(ctx)
@@ -219,9 +231,23 @@ func (repo *AuthRepo) UpdateUserCredentials(ctx context.Context, userID uint64,
 		return domain.UserNotFoundError
 	}
 
+	err = addAuditEvent(ctx, tx, userID, domain.AuditEventCredentialsChanged)
+	if err != nil {
+		return err
+	}
+
 	err = tx.Commit(ctx)
 	if err != nil {
 		return domain.TransactionCommitError
 	}
 	return nil
 }
+
+// addAuditEvent records event of user's account in transaction which makes the change
+func addAuditEvent(ctx context.Context, tx pgx.Tx, userID uint64, event string) (err error) {
+	addEventQuery := `INSERT INTO user_audit_events (user_id, event)
+					  VALUES ($1, $2)`
+
+	_, err = tx.Exec(ctx, addEventQuery, userID, event)
+	return err
+}
@@ -0,0 +1,11 @@
+package application
+
+import (
+	"context"
+	"pinterest/services/order/domain"
+)
+
+// ExportUserData collects user's orders and payments for user's data export
+func (app *OrderApp) ExportUserData(ctx context.Context, userID uint64) (sections []domain.ExportSection, err error) {
+	return app.repo.CollectUserData(ctx, userID)
+}
@@ -0,0 +1,386 @@
+package application
+
+import (
+	"context"
+	"pinterest/services/order/domain"
+	repository "pinterest/services/order/infrastructure"
+	"pinterest/services/order/infrastructure/payment"
+	shopproductdomain "pinterest/services/shopProduct/domain"
+	shopproductpb "pinterest/services/shopProduct/proto"
+	"strings"
+	"time"
+)
+
+type OrderAppInterface interface {
+	Checkout(ctx context.Context, userID uint64, items []domain.CheckoutItem, promoCode string) (orders []domain.Order, err error)
+	GetOrder(ctx context.Context, orderID uint64, userID uint64) (order domain.Order, err error)
+	ListUserOrders(ctx context.Context, userID uint64, status string, page domain.OrdersPage) (orders []domain.Order, nextCursor string, err error)
+	ListShopOrders(ctx context.Context, shopID uint64, userID uint64, status string, page domain.OrdersPage) (orders []domain.Order, nextCursor string, err error)
+	ChangeOrderStatus(ctx context.Context, orderID uint64, userID uint64, status string, comment string) (order domain.Order, err error)
+	CancelExpiredOrders(ctx context.Context) (err error)
+	HasReceivedProduct(ctx context.Context, userID uint64, productID uint64) (hasReceived bool, err error)
+	ListShopsWithOrders(ctx context.Context, shopIDs []uint64) (shopsWithOrders []uint64, err error)
+	CreatePayment(ctx context.Context, orderID uint64, userID uint64, returnURL string) (payment domain.Payment, err error)
+	SyncPayment(ctx context.Context, orderID uint64, userID uint64) (payment domain.Payment, err error)
+	HandlePaymentWebhook(ctx context.Context, provider string, body []byte, signature string) (err error)
+	ReconcilePayments(ctx context.Context) (err error)
+	CompleteFakePayment(ctx context.Context, providerPaymentID string, succeeded bool) (returnURL string, err error)
+	ExportUserData(ctx context.Context, userID uint64) (sections []domain.ExportSection, err error)
+}
+
+// OrderApp keeps orders and their payments, products, shops and stock reservations are managed by shopProduct service
+type OrderApp struct {
+	repo              repository.OrderRepoInterface
+	shopProductClient shopproductpb.ShopProductClient
+	providers         map[string]payment.ProviderInterface
+	// defaultProvider is used for new payments
+	defaultProvider string
+}
+
+func NewOrderApp(repo repository.OrderRepoInterface, shopProductClient shopproductpb.ShopProductClient,
+	providers []payment.ProviderInterface, defaultProvider string) *OrderApp {
+	providersByName := make(map[string]payment.ProviderInterface, len(providers))
+	for _, provider := range providers {
+		providersByName[provider.Name()] = provider
+	}
+
+	return &OrderApp{
+		repo:              repo,
+		shopProductClient: shopProductClient,
+		providers:         providersByName,
+		defaultProvider:   defaultProvider,
+	}
+}
+
+// Checkout creates order for every shop whose products are bought and reserves their stock until payment deadline.
+// Titles, discounted prices and assembly times are copied into orders, so later changes of products do not affect them.
+// Promo code is optional, it is redeemed for order of the shop which issued it
+func (app *OrderApp) Checkout(ctx context.Context, userID uint64, items []domain.CheckoutItem, promoCode string) (orders []domain.Order, err error) {
+	err = domain.ValidateCheckoutItems(items)
+	if err != nil {
+		return nil, err
+	}
+
+	productIDs := make([]uint64, 0, len(items))
+	listed := make(map[uint64]bool, len(items))
+	for _, item := range items {
+		if !listed[item.ProductId] {
+			productIDs = append(productIDs, item.ProductId)
+			listed[item.ProductId] = true
+		}
+	}
+
+	pbProducts, err := app.shopProductClient.GetProductsByIds(ctx, &shopproductpb.ProductIdsRequest{Ids: productIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	products := make(map[uint64]domain.ProductSnapshot, len(items))
+	shopTitles := make(map[uint64]string)
+	for _, pbProduct := range pbProducts.GetProducts() {
+		product := domain.ToProductSnapshot(pbProduct)
+		products[product.Id] = product
+
+		if _, found := shopTitles[product.ShopId]; !found {
+			shop, err := app.shopProductClient.GetShop(ctx, &shopproductpb.GetShopRequest{Id: product.ShopId})
+			if err != nil {
+				return nil, err
+			}
+			shopTitles[product.ShopId] = shop.GetTitle()
+		}
+	}
+	if len(products) != len(productIDs) {
+		return nil, domain.ProductNotFoundError
+	}
+
+	orders, err = domain.NewOrders(userID, items, products, shopTitles, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	if promoCode != "" {
+		err = app.redeemPromoCode(ctx, userID, orders, promoCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ttlSeconds := uint64((domain.PaymentTimeout + domain.ReservationMargin) / time.Second)
+	for i := range orders {
+		reservation, err := app.shopProductClient.ReserveStock(ctx, &shopproductpb.ReserveStockRequest{
+			Items:      domain.ToPbStockItems(orders[i].Items),
+			TtlSeconds: ttlSeconds,
+		})
+		if err != nil {
+			app.releaseReservations(ctx, orders[:i])
+			app.releasePromoRedemptions(ctx, orders)
+			return nil, err
+		}
+
+		orders[i].ReservationId = reservation.GetId()
+	}
+
+	createdOrders, err := app.repo.CreateOrders(ctx, orders)
+	if err != nil {
+		app.releaseReservations(ctx, orders)
+		app.releasePromoRedemptions(ctx, orders)
+		return nil, err
+	}
+
+	for i := range createdOrders {
+		createdOrders[i].AllowedTransitions = domain.AllowedTransitions(createdOrders[i].Status, []string{domain.RoleBuyer})
+	}
+	return createdOrders, nil
+}
+
+// releaseReservations is used when checkout fails, errors are ignored as reservations expire anyway
+func (app *OrderApp) releaseReservations(ctx context.Context, orders []domain.Order) {
+	for _, order := range orders {
+		app.shopProductClient.ReleaseReservation(ctx, &shopproductpb.ReservationRequest{Id: order.ReservationId})
+	}
+}
+
+// redeemPromoCode applies promo code to order of the first shop which issued it,
+// PromoCodeNotFoundError is returned if no shop of checkout has such code
+func (app *OrderApp) redeemPromoCode(ctx context.Context, userID uint64, orders []domain.Order, code string) (err error) {
+	for i := range orders {
+		redemption, err := app.shopProductClient.RedeemPromoCode(ctx, &shopproductpb.RedeemPromoCodeRequest{
+			Code:     code,
+			ShopId:   orders[i].ShopId,
+			UserId:   userID,
+			Subtotal: &shopproductpb.Money{Amount: orders[i].Subtotal, Currency: orders[i].Currency},
+		})
+		if err != nil {
+			if strings.Contains(err.Error(), shopproductdomain.PromoCodeNotFoundError.Error()) {
+				continue
+			}
+
+			return err
+		}
+
+		orders[i] = orders[i].WithPromoCode(redemption.GetCode(), redemption.GetId(), redemption.GetDiscount().GetAmount())
+		return nil
+	}
+
+	return domain.PromoCodeNotFoundError
+}
+
+// releasePromoRedemptions is used when checkout fails, errors are ignored as checkout is failed anyway
+func (app *OrderApp) releasePromoRedemptions(ctx context.Context, orders []domain.Order) {
+	for _, order := range orders {
+		if order.PromoRedemptionId != 0 {
+			app.shopProductClient.ReleasePromoRedemption(ctx, &shopproductpb.PromoRedemptionRequest{Id: order.PromoRedemptionId})
+		}
+	}
+}
+
+// GetOrder returns order with its history to its buyer or to managers of its shop
+func (app *OrderApp) GetOrder(ctx context.Context, orderID uint64, userID uint64) (order domain.Order, err error) {
+	order, err = app.repo.GetOrder(ctx, orderID)
+	if err != nil {
+		return domain.Order{}, err
+	}
+
+	roles, err := app.viewerRoles(ctx, order, userID)
+	if err != nil {
+		return domain.Order{}, err
+	}
+
+	order.AllowedTransitions = domain.AllowedTransitions(order.Status, roles)
+	return order, nil
+}
+
+// HasReceivedProduct checks whether user has bought product and received it, such users can answer questions about it
+func (app *OrderApp) HasReceivedProduct(ctx context.Context, userID uint64, productID uint64) (hasReceived bool, err error) {
+	return app.repo.HasReceivedProduct(ctx, userID, productID)
+}
+
+// ListShopsWithOrders returns those of given shops which have orders, such shops can not be purged
+func (app *OrderApp) ListShopsWithOrders(ctx context.Context, shopIDs []uint64) (shopsWithOrders []uint64, err error) {
+	return app.repo.ListShopsWithOrders(ctx, shopIDs)
+}
+
+// ListUserOrders returns page of orders placed by user and cursor of next page, which is empty if this page is the last one
+func (app *OrderApp) ListUserOrders(ctx context.Context, userID uint64, status string, page domain.OrdersPage) (orders []domain.Order, nextCursor string, err error) {
+	return app.listOrders(ctx, domain.OrdersFilter{UserId: userID, Status: status}, page, []string{domain.RoleBuyer})
+}
+
+// ListShopOrders returns page of shop's orders and cursor of next page, only shop's managers can list them
+func (app *OrderApp) ListShopOrders(ctx context.Context, shopID uint64, userID uint64, status string, page domain.OrdersPage) (orders []domain.Order, nextCursor string, err error) {
+	isManager, err := app.isShopManager(ctx, shopID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !isManager {
+		return nil, "", domain.NotShopManagerError
+	}
+
+	return app.listOrders(ctx, domain.OrdersFilter{ShopId: shopID, Status: status}, page, []string{domain.RoleManager})
+}
+
+func (app *OrderApp) listOrders(ctx context.Context, filter domain.OrdersFilter, page domain.OrdersPage, roles []string) (orders []domain.Order, nextCursor string, err error) {
+	if filter.Status != "" && !domain.IsOrderStatus(filter.Status) {
+		return nil, "", domain.InvalidStatusError
+	}
+
+	orders, err = app.repo.ListOrders(ctx, filter, page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if uint64(len(orders)) > page.Limit {
+		orders = orders[:page.Limit]
+		nextCursor = page.NextCursor(orders[len(orders)-1])
+	}
+
+	for i := range orders {
+		orders[i].AllowedTransitions = domain.AllowedTransitions(orders[i].Status, roles)
+	}
+	return orders, nextCursor, nil
+}
+
+// ChangeOrderStatus makes transition on behalf of order's buyer or manager of its shop, user who is both
+// can make transitions allowed to any of these roles
+func (app *OrderApp) ChangeOrderStatus(ctx context.Context, orderID uint64, userID uint64, status string, comment string) (order domain.Order, err error) {
+	order, err = app.repo.GetOrder(ctx, orderID)
+	if err != nil {
+		return domain.Order{}, err
+	}
+
+	roles, err := app.viewerRoles(ctx, order, userID)
+	if err != nil {
+		return domain.Order{}, err
+	}
+
+	role, err := domain.TransitionRole(order.Status, status, roles)
+	if err != nil {
+		return domain.Order{}, err
+	}
+
+	err = app.changeStatus(ctx, order, status, userID, role, comment)
+	if err != nil {
+		return domain.Order{}, err
+	}
+
+	return app.GetOrder(ctx, orderID, userID)
+}
+
+// changeStatus records transition and changes stock reservation. Reservation is committed before order becomes paid,
+// so that paid orders always have their stock, and is released together with promo code after order is cancelled.
+// Payment is refunded before order becomes refunded
+func (app *OrderApp) changeStatus(ctx context.Context, order domain.Order, status string, actorID uint64, role string, comment string) (err error) {
+	now := time.Now()
+	change := domain.StatusChange{
+		OrderId:    order.Id,
+		FromStatus: order.Status,
+		ToStatus:   status,
+		ActorId:    actorID,
+		ActorRole:  role,
+		Comment:    comment,
+		CreatedAt:  now,
+	}
+
+	err = change.Validate()
+	if err != nil {
+		return err
+	}
+
+	estimatedReadyAt := order.EstimatedReadyAt
+	if status == domain.OrderPaid || status == domain.OrderAssembling {
+		estimatedReadyAt = order.EstimateReadyAt(now)
+	}
+
+	if status == domain.OrderRefunded {
+		err = app.refundOrderPayment(ctx, order.Id)
+		if err != nil {
+			return err
+		}
+	}
+
+	if status == domain.OrderPaid {
+		_, err = app.shopProductClient.CommitReservation(ctx, &shopproductpb.ReservationRequest{Id: order.ReservationId})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = app.repo.ChangeOrderStatus(ctx, change, estimatedReadyAt)
+	if err != nil {
+		return err
+	}
+
+	if order.Status == domain.OrderPendingPayment && status == domain.OrderCancelled {
+		_, err = app.shopProductClient.ReleaseReservation(ctx, &shopproductpb.ReservationRequest{Id: order.ReservationId})
+		if err != nil && !isReservationClosed(err) {
+			return err
+		}
+
+		if order.PromoRedemptionId != 0 {
+			_, err = app.shopProductClient.ReleasePromoRedemption(ctx, &shopproductpb.PromoRedemptionRequest{Id: order.PromoRedemptionId})
+			if err != nil && !strings.Contains(err.Error(), shopproductdomain.RedemptionNotFoundError.Error()) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// isReservationClosed recognizes reservations which have already expired, so there is nothing to release
+func isReservationClosed(err error) bool {
+	return strings.Contains(err.Error(), shopproductdomain.ReservationNotActiveError.Error())
+}
+
+// CancelExpiredOrders cancels orders which were not paid in time, so that their stock goes back to sale
+func (app *OrderApp) CancelExpiredOrders(ctx context.Context) (err error) {
+	orders, err := app.repo.ListExpiredOrders(ctx, time.Now(), domain.ExpiredOrdersBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		err = app.changeStatus(ctx, order, domain.OrderCancelled, 0, domain.RoleSystem, "Payment time is over")
+		if err != nil && err != domain.StatusChangedError {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// viewerRoles returns roles of user in order, OrderNotFoundError is returned to users who are not related to it
+func (app *OrderApp) viewerRoles(ctx context.Context, order domain.Order, userID uint64) (roles []string, err error) {
+	roles = make([]string, 0, 2)
+	if order.UserId == userID {
+		roles = append(roles, domain.RoleBuyer)
+	}
+
+	isManager, err := app.isShopManager(ctx, order.ShopId, userID)
+	if err != nil {
+		return nil, err
+	}
+	if isManager {
+		roles = append(roles, domain.RoleManager)
+	}
+
+	if len(roles) == 0 {
+		return nil, domain.OrderNotFoundError
+	}
+	return roles, nil
+}
+
+func (app *OrderApp) isShopManager(ctx context.Context, shopID uint64, userID uint64) (isManager bool, err error) {
+	// Orders of deleted shop still resolve, so that its managers can finish or refund them
+	shop, err := app.shopProductClient.GetShop(ctx, &shopproductpb.GetShopRequest{Id: shopID, IncludeDeleted: true})
+	if err != nil {
+		return false, err
+	}
+
+	for _, managerID := range shop.GetManagerIds() {
+		if managerID == userID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,276 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"pinterest/services/order/domain"
+	"pinterest/services/order/infrastructure/payment"
+	"time"
+)
+
+// CreatePayment creates payment of order's total at default provider, buyer pays on page at payment's ConfirmationURL
+// and then returns to returnURL. If order already has pending payment, that payment is returned
+func (app *OrderApp) CreatePayment(ctx context.Context, orderID uint64, userID uint64, returnURL string) (createdPayment domain.Payment, err error) {
+	order, err := app.repo.GetOrder(ctx, orderID)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	if order.UserId != userID {
+		return domain.Payment{}, domain.OrderNotFoundError
+	}
+	if order.Status != domain.OrderPendingPayment || time.Now().After(order.PaymentDeadline) {
+		return domain.Payment{}, domain.OrderNotPayableError
+	}
+
+	pendingPayment, err := app.repo.GetOrderPayment(ctx, orderID, domain.PaymentPending)
+	switch err {
+	case nil:
+		return pendingPayment, nil
+	case domain.PaymentNotFoundError:
+	default:
+		return domain.Payment{}, err
+	}
+
+	provider, err := app.provider(app.defaultProvider)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	newPayment := domain.Payment{
+		OrderId:  order.Id,
+		UserId:   userID,
+		Provider: provider.Name(),
+		Status:   domain.PaymentPending,
+		Amount:   order.Total,
+		Currency: order.Currency,
+	}
+
+	intent, err := provider.CreatePayment(ctx, newPayment, returnURL)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	newPayment.ProviderPaymentId = intent.ProviderPaymentId
+	newPayment.ConfirmationURL = intent.ConfirmationURL
+	return app.repo.CreatePayment(ctx, newPayment)
+}
+
+// SyncPayment is called when buyer returns from provider's page. Status of order's latest payment is requested
+// from provider, as webhook may not have arrived yet
+func (app *OrderApp) SyncPayment(ctx context.Context, orderID uint64, userID uint64) (orderPayment domain.Payment, err error) {
+	orderPayment, err = app.repo.GetOrderPayment(ctx, orderID, "")
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	if orderPayment.UserId != userID {
+		return domain.Payment{}, domain.PaymentNotFoundError
+	}
+	if orderPayment.Status != domain.PaymentPending {
+		return orderPayment, nil
+	}
+
+	err = app.reconcilePayment(ctx, orderPayment)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	return app.repo.GetPaymentByProviderID(ctx, orderPayment.Provider, orderPayment.ProviderPaymentId)
+}
+
+// HandlePaymentWebhook applies status from provider's signed webhook. Providers deliver webhooks at least once,
+// so events which were already processed are skipped, and applying the same status twice changes nothing
+func (app *OrderApp) HandlePaymentWebhook(ctx context.Context, providerName string, body []byte, signature string) (err error) {
+	provider, err := app.provider(providerName)
+	if err != nil {
+		return err
+	}
+
+	event, err := provider.ParseWebhook(body, signature)
+	if err != nil {
+		return err
+	}
+
+	processed, err := app.repo.IsPaymentEventProcessed(ctx, providerName, event.EventId)
+	if err != nil || processed {
+		return err
+	}
+
+	eventPayment, err := app.repo.GetPaymentByProviderID(ctx, providerName, event.ProviderPaymentId)
+	if err != nil {
+		return err
+	}
+
+	err = app.applyPaymentStatus(ctx, eventPayment, event.Status)
+	if err != nil {
+		return err
+	}
+
+	return app.repo.AddPaymentEvent(ctx, providerName, event, eventPayment.Id)
+}
+
+// ReconcilePayments requests statuses of payments which stayed pending for too long, in case their webhooks were lost
+func (app *OrderApp) ReconcilePayments(ctx context.Context) (err error) {
+	payments, err := app.repo.ListStalePayments(ctx, time.Now().Add(-domain.PaymentReconcileAfter), domain.ReconcileBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, stalePayment := range payments {
+		err = app.reconcilePayment(ctx, stalePayment)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcilePayment applies payment's status at provider. Payments which provider does not know have failed,
+// pending payments of orders which can no longer be paid are abandoned
+func (app *OrderApp) reconcilePayment(ctx context.Context, pendingPayment domain.Payment) (err error) {
+	provider, err := app.provider(pendingPayment.Provider)
+	if err != nil {
+		return err
+	}
+
+	status, err := provider.GetPaymentStatus(ctx, pendingPayment.ProviderPaymentId)
+	switch err {
+	case nil:
+	case domain.PaymentNotFoundError:
+		status = domain.PaymentFailed
+	default:
+		return err
+	}
+
+	if status == domain.PaymentPending {
+		order, err := app.repo.GetOrder(ctx, pendingPayment.OrderId)
+		if err != nil {
+			return err
+		}
+
+		if order.Status == domain.OrderPendingPayment || time.Since(pendingPayment.CreatedAt) < domain.PaymentReconcileAfter {
+			return nil
+		}
+		status = domain.PaymentFailed
+	}
+
+	return app.applyPaymentStatus(ctx, pendingPayment, status)
+}
+
+// applyPaymentStatus moves pending payment to status. Order becomes paid before its payment succeeds, so that
+// succeeded payments always have paid orders. Payments which came after order was cancelled or paid are refunded
+func (app *OrderApp) applyPaymentStatus(ctx context.Context, orderPayment domain.Payment, status string) (err error) {
+	switch {
+	case orderPayment.Status == status:
+		return nil
+	case orderPayment.Status != domain.PaymentPending:
+		return nil
+	case status == domain.PaymentFailed:
+		return app.finishPayment(ctx, orderPayment.Id, domain.PaymentPending, domain.PaymentFailed)
+	case status != domain.PaymentSucceeded:
+		return nil
+	}
+
+	order, err := app.repo.GetOrder(ctx, orderPayment.OrderId)
+	if err != nil {
+		return err
+	}
+
+	if order.Status == domain.OrderPendingPayment {
+		err = app.changeStatus(ctx, order, domain.OrderPaid, 0, domain.RoleSystem, fmt.Sprintf("Paid with payment %d", orderPayment.Id))
+		switch {
+		case err == nil:
+			return app.finishPayment(ctx, orderPayment.Id, domain.PaymentPending, domain.PaymentSucceeded)
+		case err != domain.StatusChangedError && !isReservationClosed(err):
+			return err
+		}
+
+		order, err = app.repo.GetOrder(ctx, orderPayment.OrderId)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Order could have become paid with this payment before its status was saved
+	if order.Status != domain.OrderCancelled && order.Status != domain.OrderPendingPayment {
+		_, err = app.repo.GetOrderPayment(ctx, order.Id, domain.PaymentSucceeded)
+		if err == domain.PaymentNotFoundError {
+			return app.finishPayment(ctx, orderPayment.Id, domain.PaymentPending, domain.PaymentSucceeded)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	provider, err := app.provider(orderPayment.Provider)
+	if err != nil {
+		return err
+	}
+
+	err = provider.Refund(ctx, orderPayment.ProviderPaymentId, orderPayment.Amount)
+	if err != nil {
+		return err
+	}
+
+	return app.finishPayment(ctx, orderPayment.Id, domain.PaymentPending, domain.PaymentRefunded)
+}
+
+// finishPayment changes payment's status, payment which was changed concurrently is left as it is
+func (app *OrderApp) finishPayment(ctx context.Context, paymentID uint64, from string, to string) (err error) {
+	err = app.repo.UpdatePaymentStatus(ctx, paymentID, from, to)
+	if err == domain.PaymentStatusChangedError {
+		return nil
+	}
+
+	return err
+}
+
+// refundOrderPayment refunds order's succeeded payment, orders which were not paid with payments have nothing to refund
+func (app *OrderApp) refundOrderPayment(ctx context.Context, orderID uint64) (err error) {
+	paidPayment, err := app.repo.GetOrderPayment(ctx, orderID, domain.PaymentSucceeded)
+	switch err {
+	case nil:
+	case domain.PaymentNotFoundError:
+		return nil
+	default:
+		return err
+	}
+
+	provider, err := app.provider(paidPayment.Provider)
+	if err != nil {
+		return err
+	}
+
+	err = provider.Refund(ctx, paidPayment.ProviderPaymentId, paidPayment.Amount)
+	if err != nil {
+		return err
+	}
+
+	return app.finishPayment(ctx, paidPayment.Id, domain.PaymentSucceeded, domain.PaymentRefunded)
+}
+
+// CompleteFakePayment is called from fake provider's payment page, it returns address to which payer is redirected
+func (app *OrderApp) CompleteFakePayment(ctx context.Context, providerPaymentID string, succeeded bool) (returnURL string, err error) {
+	provider, err := app.provider(domain.FakeProviderName)
+	if err != nil {
+		return "", err
+	}
+
+	fakeProvider, ok := provider.(*payment.FakeProvider)
+	if !ok {
+		return "", domain.UnknownProviderError
+	}
+
+	return fakeProvider.Complete(providerPaymentID, succeeded)
+}
+
+func (app *OrderApp) provider(name string) (provider payment.ProviderInterface, err error) {
+	provider, found := app.providers[name]
+	if !found {
+		return nil, domain.UnknownProviderError
+	}
+
+	return provider, nil
+}
@@ -0,0 +1,30 @@
+package domain
+
+import "errors"
+
+var (
+	TransactionBeginError     = errors.New("Could not begin transaction")
+	TransactionCommitError    = errors.New("Could not commit transaction")
+	OrderNotFoundError        = errors.New("Could not find order")
+	EmptyCheckoutError        = errors.New("Checkout must contain at least one product")
+	TooManyCheckoutItemsError = errors.New("Checkout contains too many products")
+	InvalidQuantityError      = errors.New("Quantities must be from 1 to 999 and products must not repeat")
+	ProductNotFoundError      = errors.New("Could not find product")
+	VariantNotFoundError      = errors.New("Could not find variant")
+	VariantRequiredError      = errors.New("Variant of product must be selected")
+	NotShopManagerError       = errors.New("User is not shop's manager")
+	InvalidStatusError        = errors.New("Unknown order status")
+	InvalidTransitionError    = errors.New("Order can not move from its current status to requested one")
+	TransitionForbiddenError  = errors.New("User is not allowed to make this status change")
+	StatusChangedError        = errors.New("Order status was changed concurrently")
+	CommentTooLongError       = errors.New("Status change comment is too long")
+	InvalidCursorError        = errors.New("Invalid pagination cursor")
+	OrderNotPayableError      = errors.New("Only unpaid orders can be paid")
+	PaymentNotFoundError      = errors.New("Could not find payment")
+	PaymentStatusChangedError = errors.New("Payment status was changed concurrently")
+	UnknownProviderError      = errors.New("Unknown payment provider")
+	InvalidSignatureError     = errors.New("Invalid webhook signature")
+	InvalidWebhookError       = errors.New("Could not parse webhook")
+	RefundFailedError         = errors.New("Payment can not be refunded")
+	PromoCodeNotFoundError    = errors.New("Could not find promo code")
+)
@@ -0,0 +1,9 @@
+package domain
+
+// ExportSection is one file of user's data export, which is assembled by user service.
+// MediaLinks are links to media files which records refer to, they are added to archive too
+type ExportSection struct {
+	Name       string
+	Records    []map[string]interface{}
+	MediaLinks []string
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"encoding/json"
+	pb "pinterest/services/order/proto"
+	shopproductdomain "pinterest/services/shopProduct/domain"
+	shopproductpb "pinterest/services/shopProduct/proto"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func ToCheckoutItems(pbItems []*pb.CheckoutItem) []CheckoutItem {
+	items := make([]CheckoutItem, 0, len(pbItems))
+	for _, pbItem := range pbItems {
+		items = append(items, CheckoutItem{
+			ProductId: pbItem.GetProductId(),
+			VariantId: pbItem.GetVariantId(),
+			Quantity:  pbItem.GetQuantity(),
+		})
+	}
+
+	return items
+}
+
+func ToProductSnapshot(pbProduct *shopproductpb.Product) ProductSnapshot {
+	options := shopproductdomain.ToOptions(pbProduct.GetOptions())
+	variants := make(map[uint64]VariantSnapshot, len(pbProduct.GetVariants()))
+	for _, pbVariant := range pbProduct.GetVariants() {
+		variant := shopproductdomain.ToVariant(pbVariant)
+		variants[variant.Id] = VariantSnapshot{
+			Id:              variant.Id,
+			SKU:             variant.SKU,
+			Title:           variant.Describe(options),
+			Price:           variant.Price.Amount,
+			DiscountedPrice: variant.DiscountedPrice.Amount,
+		}
+	}
+
+	return ProductSnapshot{
+		Id:              pbProduct.GetId(),
+		ShopId:          pbProduct.GetShopId(),
+		Title:           pbProduct.GetTitle(),
+		Price:           pbProduct.GetPrice().GetAmount(),
+		DiscountedPrice: pbProduct.GetDiscountedPrice().GetAmount(),
+		Currency:        pbProduct.GetPrice().GetCurrency(),
+		AssemblyTime:    pbProduct.GetAssemblyTime(),
+		Variants:        variants,
+	}
+}
+
+// ToPbStockItems returns order's items as they are reserved in shopProduct service
+func ToPbStockItems(items []OrderItem) []*shopproductpb.StockItem {
+	pbItems := make([]*shopproductpb.StockItem, 0, len(items))
+	for _, item := range items {
+		pbItems = append(pbItems, &shopproductpb.StockItem{
+			ProductId: item.ProductId,
+			VariantId: item.VariantId,
+			Quantity:  item.Quantity,
+		})
+	}
+
+	return pbItems
+}
+
+func ToPbOrder(order Order) *pb.Order {
+	pbItems := make([]*pb.OrderItem, 0, len(order.Items))
+	for _, item := range order.Items {
+		pbItems = append(pbItems, &pb.OrderItem{
+			ProductId:     item.ProductId,
+			VariantId:     item.VariantId,
+			Title:         item.Title,
+			Sku:           item.SKU,
+			VariantTitle:  item.VariantTitle,
+			Price:         item.Price,
+			OriginalPrice: item.OriginalPrice,
+			Quantity:      item.Quantity,
+			AssemblyTime:  item.AssemblyTime,
+		})
+	}
+
+	pbHistory := make([]*pb.StatusChange, 0, len(order.History))
+	for _, change := range order.History {
+		pbHistory = append(pbHistory, &pb.StatusChange{
+			FromStatus: change.FromStatus,
+			ToStatus:   change.ToStatus,
+			ActorId:    change.ActorId,
+			ActorRole:  change.ActorRole,
+			Comment:    change.Comment,
+			CreatedAt:  timestamppb.New(change.CreatedAt),
+		})
+	}
+
+	return &pb.Order{
+		Id:                 order.Id,
+		UserId:             order.UserId,
+		ShopId:             order.ShopId,
+		ShopTitle:          order.ShopTitle,
+		Status:             order.Status,
+		Items:              pbItems,
+		Subtotal:           order.Subtotal,
+		Discount:           order.Discount,
+		Total:              order.Total,
+		Currency:           order.Currency,
+		PromoCode:          order.PromoCode,
+		AssemblyTime:       order.AssemblyTime,
+		PaymentDeadline:    timestamppb.New(order.PaymentDeadline),
+		EstimatedReadyAt:   timestamppb.New(order.EstimatedReadyAt),
+		CreatedAt:          timestamppb.New(order.CreatedAt),
+		UpdatedAt:          timestamppb.New(order.UpdatedAt),
+		History:            pbHistory,
+		AllowedTransitions: order.AllowedTransitions,
+	}
+}
+
+func ToPbOrdersList(orders []Order, nextCursor string) *pb.OrdersList {
+	pbOrders := make([]*pb.Order, 0, len(orders))
+	for _, order := range orders {
+		pbOrders = append(pbOrders, ToPbOrder(order))
+	}
+
+	return &pb.OrdersList{Orders: pbOrders, NextCursor: nextCursor}
+}
+
+func ToPbPayment(payment Payment) *pb.Payment {
+	return &pb.Payment{
+		Id:              payment.Id,
+		OrderId:         payment.OrderId,
+		Provider:        payment.Provider,
+		Status:          payment.Status,
+		Amount:          payment.Amount,
+		Currency:        payment.Currency,
+		ConfirmationUrl: payment.ConfirmationURL,
+		CreatedAt:       timestamppb.New(payment.CreatedAt),
+		UpdatedAt:       timestamppb.New(payment.UpdatedAt),
+	}
+}
+
+// ToPbExportSections encodes records of every section as JSON array
+func ToPbExportSections(sections []ExportSection) (*pb.ExportSections, error) {
+	pbSections := make([]*pb.ExportSection, 0, len(sections))
+	for _, section := range sections {
+		data, err := json.Marshal(section.Records)
+		if err != nil {
+			return nil, err
+		}
+
+		pbSections = append(pbSections, &pb.ExportSection{Name: section.Name, Data: data, MediaLinks: section.MediaLinks})
+	}
+
+	return &pb.ExportSections{Sections: pbSections}, nil
+}
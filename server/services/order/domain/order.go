@@ -0,0 +1,375 @@
+package domain
+
+import (
+	"encoding/base64"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// PaymentTimeout is time for which unpaid order holds stock, order is cancelled after it
+	PaymentTimeout = 30 * time.Minute
+	// ReservationMargin is added to payment timeout, so that reservation is not expired while order is still pending
+	ReservationMargin = 5 * time.Minute
+	// MaxCheckoutItems limits amount of different products in one checkout
+	MaxCheckoutItems = 100
+	// MaxItemQuantity matches limit of cart
+	MaxItemQuantity = 999
+	// MaxCommentLength is measured in characters
+	MaxCommentLength = 500
+	// MaxOrdersPageSize is used when limit is not specified or is too big
+	MaxOrdersPageSize = 50
+	// ExpiredOrdersBatchSize limits amount of orders cancelled at once
+	ExpiredOrdersBatchSize = 100
+)
+
+// Order statuses. Paid orders are assembled, shipped and delivered, they can be refunded on any of these steps.
+// Unpaid orders can only be paid or cancelled
+const (
+	OrderPendingPayment = "pending_payment"
+	OrderPaid           = "paid"
+	OrderAssembling     = "assembling"
+	OrderShipped        = "shipped"
+	OrderDelivered      = "delivered"
+	OrderCancelled      = "cancelled"
+	OrderRefunded       = "refunded"
+)
+
+// Roles of those who change order's status, system changes are made by payments and timeouts
+const (
+	RoleBuyer   = "buyer"
+	RoleManager = "manager"
+	RoleSystem  = "system"
+)
+
+type transition struct {
+	from string
+	to   string
+}
+
+// orderTransitions lists roles which can make every allowed transition
+var orderTransitions = map[transition][]string{
+	{OrderPendingPayment, OrderPaid}:      {RoleSystem},
+	{OrderPendingPayment, OrderCancelled}: {RoleBuyer, RoleManager, RoleSystem},
+	{OrderPaid, OrderAssembling}:          {RoleManager},
+	{OrderPaid, OrderRefunded}:            {RoleManager, RoleSystem},
+	{OrderAssembling, OrderShipped}:       {RoleManager},
+	{OrderAssembling, OrderRefunded}:      {RoleManager, RoleSystem},
+	{OrderShipped, OrderDelivered}:        {RoleBuyer, RoleManager},
+	{OrderShipped, OrderRefunded}:         {RoleManager, RoleSystem},
+	{OrderDelivered, OrderRefunded}:       {RoleManager, RoleSystem},
+}
+
+// statusesOrder is used to list allowed transitions in the same order every time
+var statusesOrder = []string{OrderPendingPayment, OrderPaid, OrderAssembling, OrderShipped, OrderDelivered,
+	OrderCancelled, OrderRefunded}
+
+func IsOrderStatus(status string) bool {
+	for _, orderStatus := range statusesOrder {
+		if status == orderStatus {
+			return true
+		}
+	}
+
+	return false
+}
+
+// TransitionRole returns which of actor's roles allows moving order from one status to another
+func TransitionRole(from string, to string, roles []string) (role string, err error) {
+	if !IsOrderStatus(to) {
+		return "", InvalidStatusError
+	}
+
+	allowedRoles, found := orderTransitions[transition{from, to}]
+	if !found {
+		return "", InvalidTransitionError
+	}
+
+	for _, allowedRole := range allowedRoles {
+		for _, role := range roles {
+			if role == allowedRole {
+				return role, nil
+			}
+		}
+	}
+
+	return "", TransitionForbiddenError
+}
+
+// AllowedTransitions returns statuses into which actor with these roles can move order
+func AllowedTransitions(from string, roles []string) []string {
+	statuses := make([]string, 0)
+	for _, to := range statusesOrder {
+		_, err := TransitionRole(from, to, roles)
+		if err == nil {
+			statuses = append(statuses, to)
+		}
+	}
+
+	return statuses
+}
+
+// CheckoutItem is product which buyer wants to order, VariantId is 0 for products without variants
+type CheckoutItem struct {
+	ProductId uint64
+	VariantId uint64
+	Quantity  uint64
+}
+
+// ValidateCheckoutItems checks that there are not too many items, quantities are in range and every variant is listed once
+func ValidateCheckoutItems(items []CheckoutItem) error {
+	switch {
+	case len(items) == 0:
+		return EmptyCheckoutError
+	case len(items) > MaxCheckoutItems:
+		return TooManyCheckoutItemsError
+	}
+
+	variants := make(map[[2]uint64]bool, len(items))
+	for _, item := range items {
+		key := [2]uint64{item.ProductId, item.VariantId}
+		if item.Quantity == 0 || item.Quantity > MaxItemQuantity || variants[key] {
+			return InvalidQuantityError
+		}
+		variants[key] = true
+	}
+
+	return nil
+}
+
+// ProductSnapshot is product's data at checkout. Price is list price, DiscountedPrice includes sales and shop discounts.
+// Prices of product and of its variants are in Currency of product's shop
+type ProductSnapshot struct {
+	Id              uint64
+	ShopId          uint64
+	Title           string
+	Price           uint64
+	DiscountedPrice uint64
+	Currency        string
+	// AssemblyTime is measured in minutes
+	AssemblyTime uint64
+	Variants     map[uint64]VariantSnapshot
+}
+
+// VariantSnapshot is variant's data at checkout, Title lists its option values, such as "L / Red"
+type VariantSnapshot struct {
+	Id              uint64
+	SKU             string
+	Title           string
+	Price           uint64
+	DiscountedPrice uint64
+}
+
+// OrderItem keeps title, price and assembly time from checkout. ProductId is 0 if product was deleted,
+// VariantId is 0 if product has no variants or variant was deleted. Price is paid price of one unit,
+// OriginalPrice is list price, which is higher if product was on sale
+type OrderItem struct {
+	ProductId     uint64
+	VariantId     uint64
+	Title         string
+	SKU           string
+	VariantTitle  string
+	Price         uint64
+	OriginalPrice uint64
+	Quantity      uint64
+	AssemblyTime  uint64
+}
+
+// StatusChange is one transition of order's state machine, FromStatus is empty for checkout
+type StatusChange struct {
+	OrderId    uint64
+	FromStatus string
+	ToStatus   string
+	// ActorId is 0 for system changes
+	ActorId   uint64
+	ActorRole string
+	Comment   string
+	CreatedAt time.Time
+}
+
+func (change StatusChange) Validate() error {
+	if utf8.RuneCountInString(change.Comment) > MaxCommentLength {
+		return CommentTooLongError
+	}
+
+	return nil
+}
+
+type Order struct {
+	Id        uint64
+	UserId    uint64
+	ShopId    uint64
+	ShopTitle string
+	Status    string
+	Items     []OrderItem
+	// Subtotal is sum of items' prices, Total is Subtotal minus Discount of promo code.
+	// All amounts of order and of its items are in Currency of shop
+	Subtotal uint64
+	Discount uint64
+	Total    uint64
+	Currency string
+	// PromoCode is empty and PromoRedemptionId is 0 if no promo code was used
+	PromoCode         string
+	PromoRedemptionId uint64
+	// AssemblyTime is total time of assembling all items, measured in minutes
+	AssemblyTime     uint64
+	ReservationId    uint64
+	PaymentDeadline  time.Time
+	EstimatedReadyAt time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	// History is filled only when single order is requested
+	History []StatusChange
+	// AllowedTransitions depends on who views order
+	AllowedTransitions []string
+}
+
+// WithPromoCode returns order with discount of redeemed promo code, discount can not exceed subtotal
+func (order Order) WithPromoCode(code string, redemptionID uint64, discount uint64) Order {
+	if discount > order.Subtotal {
+		discount = order.Subtotal
+	}
+
+	order.PromoCode = code
+	order.PromoRedemptionId = redemptionID
+	order.Discount = discount
+	order.Total = order.Subtotal - discount
+	return order
+}
+
+// EstimateReadyAt returns when order will be ready if its assembly starts at start
+func (order Order) EstimateReadyAt(start time.Time) time.Time {
+	return start.Add(time.Duration(order.AssemblyTime) * time.Minute)
+}
+
+// NewOrders splits checkout into orders of separate shops, which are sorted by shop.
+// Every item's product must be present in products
+func NewOrders(userID uint64, items []CheckoutItem, products map[uint64]ProductSnapshot, shopTitles map[uint64]string, now time.Time) ([]Order, error) {
+	ordersByShop := make(map[uint64]*Order)
+	shopIDs := make([]uint64, 0)
+
+	for _, item := range items {
+		product := products[item.ProductId]
+		variant, err := product.selectVariant(item.VariantId)
+		if err != nil {
+			return nil, err
+		}
+
+		order, found := ordersByShop[product.ShopId]
+		if !found {
+			order = &Order{
+				UserId:          userID,
+				ShopId:          product.ShopId,
+				ShopTitle:       shopTitles[product.ShopId],
+				Currency:        product.Currency,
+				Status:          OrderPendingPayment,
+				Items:           make([]OrderItem, 0),
+				PaymentDeadline: now.Add(PaymentTimeout),
+				CreatedAt:       now,
+				UpdatedAt:       now,
+			}
+			ordersByShop[product.ShopId] = order
+			shopIDs = append(shopIDs, product.ShopId)
+		}
+
+		order.Items = append(order.Items, OrderItem{
+			ProductId:     product.Id,
+			VariantId:     variant.Id,
+			Title:         product.Title,
+			SKU:           variant.SKU,
+			VariantTitle:  variant.Title,
+			Price:         variant.DiscountedPrice,
+			OriginalPrice: variant.Price,
+			Quantity:      item.Quantity,
+			AssemblyTime:  product.AssemblyTime,
+		})
+		order.Subtotal += variant.DiscountedPrice * item.Quantity
+		order.AssemblyTime += product.AssemblyTime * item.Quantity
+	}
+
+	sort.Slice(shopIDs, func(i, j int) bool { return shopIDs[i] < shopIDs[j] })
+
+	orders := make([]Order, 0, len(shopIDs))
+	for _, shopID := range shopIDs {
+		order := ordersByShop[shopID]
+		order.EstimatedReadyAt = order.EstimateReadyAt(now)
+		order.Total = order.Subtotal
+		orders = append(orders, *order)
+	}
+
+	return orders, nil
+}
+
+// selectVariant returns variant which is bought. Products without variants are bought as variant with id 0
+// and product's prices
+func (product ProductSnapshot) selectVariant(variantID uint64) (VariantSnapshot, error) {
+	if len(product.Variants) == 0 {
+		if variantID != 0 {
+			return VariantSnapshot{}, VariantNotFoundError
+		}
+
+		return VariantSnapshot{Price: product.Price, DiscountedPrice: product.DiscountedPrice}, nil
+	}
+
+	if variantID == 0 {
+		return VariantSnapshot{}, VariantRequiredError
+	}
+
+	variant, found := product.Variants[variantID]
+	if !found {
+		return VariantSnapshot{}, VariantNotFoundError
+	}
+
+	return variant, nil
+}
+
+// OrdersFilter selects orders of buyer or of shop, empty status means orders with any status
+type OrdersFilter struct {
+	UserId uint64
+	ShopId uint64
+	Status string
+}
+
+// OrdersPage describes which orders should be returned, newest orders go first.
+// Cursor takes precedence over offset
+type OrdersPage struct {
+	Limit uint64
+	// Cursor is id of the last order of previous page, it is 0 on the first page
+	Cursor uint64
+	Offset uint64
+}
+
+// NewOrdersPage checks cursor which came from client. Page is used only if cursor is empty and is counted from 0
+func NewOrdersPage(limit uint64, cursor string, page uint64) (ordersPage OrdersPage, err error) {
+	if limit == 0 || limit > MaxOrdersPageSize {
+		limit = MaxOrdersPageSize
+	}
+
+	ordersPage = OrdersPage{
+		Limit:  limit,
+		Offset: page * limit,
+	}
+
+	if cursor != "" {
+		decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+		if err != nil {
+			return OrdersPage{}, InvalidCursorError
+		}
+
+		ordersPage.Cursor, err = strconv.ParseUint(string(decoded), 10, 64)
+		if err != nil || ordersPage.Cursor == 0 {
+			return OrdersPage{}, InvalidCursorError
+		}
+
+		ordersPage.Offset = 0
+	}
+
+	return ordersPage, nil
+}
+
+// NextCursor returns cursor which points after order
+func (page OrdersPage) NextCursor(order Order) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatUint(order.Id, 10)))
+}
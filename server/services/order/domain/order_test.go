@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTransitionRole(t *testing.T) {
+	tests := []struct {
+		name  string
+		from  string
+		to    string
+		roles []string
+		role  string
+		err   error
+	}{
+		{"system pays order", OrderPendingPayment, OrderPaid, []string{RoleSystem}, RoleSystem, nil},
+		{"buyer can not pay order", OrderPendingPayment, OrderPaid, []string{RoleBuyer}, "", TransitionForbiddenError},
+		{"buyer cancels unpaid order", OrderPendingPayment, OrderCancelled, []string{RoleBuyer}, RoleBuyer, nil},
+		{"manager cancels unpaid order", OrderPendingPayment, OrderCancelled, []string{RoleManager}, RoleManager, nil},
+		{"paid order can not be cancelled", OrderPaid, OrderCancelled, []string{RoleManager}, "", InvalidTransitionError},
+		{"manager assembles order", OrderPaid, OrderAssembling, []string{RoleManager}, RoleManager, nil},
+		{"buyer can not assemble order", OrderPaid, OrderAssembling, []string{RoleBuyer}, "", TransitionForbiddenError},
+		{"manager ships order", OrderAssembling, OrderShipped, []string{RoleManager}, RoleManager, nil},
+		{"shipping skips assembling", OrderPaid, OrderShipped, []string{RoleManager}, "", InvalidTransitionError},
+		{"buyer receives order", OrderShipped, OrderDelivered, []string{RoleBuyer}, RoleBuyer, nil},
+		{"system refunds delivered order", OrderDelivered, OrderRefunded, []string{RoleSystem}, RoleSystem, nil},
+		{"buyer can not refund order", OrderDelivered, OrderRefunded, []string{RoleBuyer}, "", TransitionForbiddenError},
+		{"unpaid order can not be refunded", OrderPendingPayment, OrderRefunded, []string{RoleManager}, "", InvalidTransitionError},
+		{"cancelled order is final", OrderCancelled, OrderPendingPayment, []string{RoleSystem}, "", InvalidTransitionError},
+		{"refunded order is final", OrderRefunded, OrderPaid, []string{RoleSystem}, "", InvalidTransitionError},
+		{"order can not stay in its status", OrderPaid, OrderPaid, []string{RoleManager}, "", InvalidTransitionError},
+		{"unknown status", OrderPaid, "lost", []string{RoleManager}, "", InvalidStatusError},
+		{"no roles", OrderShipped, OrderDelivered, nil, "", TransitionForbiddenError},
+		{"buyer who manages shop", OrderShipped, OrderDelivered, []string{RoleBuyer, RoleManager}, RoleBuyer, nil},
+		{"allowed role is chosen among actor's roles", OrderPaid, OrderAssembling, []string{RoleBuyer, RoleManager}, RoleManager, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			role, err := TransitionRole(test.from, test.to, test.roles)
+			if err != test.err {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+			if role != test.role {
+				t.Errorf("expected role %q, got %q", test.role, role)
+			}
+		})
+	}
+}
+
+func TestAllowedTransitions(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		roles    []string
+		statuses []string
+	}{
+		{"buyer of unpaid order", OrderPendingPayment, []string{RoleBuyer}, []string{OrderCancelled}},
+		{"system of unpaid order", OrderPendingPayment, []string{RoleSystem}, []string{OrderPaid, OrderCancelled}},
+		{"manager of paid order", OrderPaid, []string{RoleManager}, []string{OrderAssembling, OrderRefunded}},
+		{"buyer of paid order", OrderPaid, []string{RoleBuyer}, []string{}},
+		{"manager of assembling order", OrderAssembling, []string{RoleManager}, []string{OrderShipped, OrderRefunded}},
+		{"buyer of shipped order", OrderShipped, []string{RoleBuyer}, []string{OrderDelivered}},
+		{"manager of shipped order", OrderShipped, []string{RoleManager}, []string{OrderDelivered, OrderRefunded}},
+		{"manager of delivered order", OrderDelivered, []string{RoleManager}, []string{OrderRefunded}},
+		{"manager of cancelled order", OrderCancelled, []string{RoleManager, RoleSystem}, []string{}},
+		{"system of refunded order", OrderRefunded, []string{RoleSystem}, []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statuses := AllowedTransitions(test.from, test.roles)
+			if !reflect.DeepEqual(statuses, test.statuses) {
+				t.Errorf("expected %v, got %v", test.statuses, statuses)
+			}
+		})
+	}
+}
+
+func TestValidateCheckoutItems(t *testing.T) {
+	tooMany := make([]CheckoutItem, 0, MaxCheckoutItems+1)
+	for i := uint64(1); i <= MaxCheckoutItems+1; i++ {
+		tooMany = append(tooMany, CheckoutItem{ProductId: i, Quantity: 1})
+	}
+
+	tests := []struct {
+		name  string
+		items []CheckoutItem
+		err   error
+	}{
+		{"empty", nil, EmptyCheckoutError},
+		{"too many items", tooMany, TooManyCheckoutItemsError},
+		{"one item", []CheckoutItem{{ProductId: 1, Quantity: 1}}, nil},
+		{"max quantity", []CheckoutItem{{ProductId: 1, Quantity: MaxItemQuantity}}, nil},
+		{"zero quantity", []CheckoutItem{{ProductId: 1, Quantity: 0}}, InvalidQuantityError},
+		{"too big quantity", []CheckoutItem{{ProductId: 1, Quantity: MaxItemQuantity + 1}}, InvalidQuantityError},
+		{"different variants of product", []CheckoutItem{{ProductId: 1, VariantId: 1, Quantity: 1}, {ProductId: 1, VariantId: 2, Quantity: 1}}, nil},
+		{"repeated product", []CheckoutItem{{ProductId: 1, Quantity: 1}, {ProductId: 1, Quantity: 2}}, InvalidQuantityError},
+		{"repeated variant", []CheckoutItem{{ProductId: 1, VariantId: 3, Quantity: 1}, {ProductId: 1, VariantId: 3, Quantity: 1}}, InvalidQuantityError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateCheckoutItems(test.items)
+			if err != test.err {
+				t.Errorf("expected error %v, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestStatusChangeValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment string
+		err     error
+	}{
+		{"empty comment", "", nil},
+		{"comment of max length", strings.Repeat("я", MaxCommentLength), nil},
+		{"too long comment", strings.Repeat("a", MaxCommentLength+1), CommentTooLongError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := StatusChange{Comment: test.comment}.Validate()
+			if err != test.err {
+				t.Errorf("expected error %v, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestOrderWithPromoCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		subtotal uint64
+		discount uint64
+		result   uint64
+		total    uint64
+	}{
+		{"partial discount", 10000, 2500, 2500, 7500},
+		{"discount of whole subtotal", 10000, 10000, 10000, 0},
+		{"discount above subtotal", 10000, 15000, 10000, 0},
+		{"zero discount", 10000, 0, 0, 10000},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			order := Order{Subtotal: test.subtotal, Total: test.subtotal}.WithPromoCode("SPRING", 7, test.discount)
+			if order.Discount != test.result || order.Total != test.total {
+				t.Errorf("expected discount %d and total %d, got %d and %d", test.result, test.total, order.Discount, order.Total)
+			}
+			if order.PromoCode != "SPRING" || order.PromoRedemptionId != 7 {
+				t.Errorf("expected promo code SPRING with redemption 7, got %q with %d", order.PromoCode, order.PromoRedemptionId)
+			}
+		})
+	}
+}
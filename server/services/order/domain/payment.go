@@ -0,0 +1,51 @@
+package domain
+
+import "time"
+
+const (
+	// FakeProviderName is name of built-in provider, which lets checkout be tested without real payments
+	FakeProviderName = "fake"
+	// PaymentReconcileAfter is age of pending payment after which its status is requested from provider,
+	// in case provider's webhook was lost
+	PaymentReconcileAfter = 10 * time.Minute
+	// ReconcileBatchSize limits amount of payments checked at once
+	ReconcileBatchSize = 100
+)
+
+// Payment statuses, only pending payments can change status except for refunds of succeeded ones
+const (
+	PaymentPending   = "pending"
+	PaymentSucceeded = "succeeded"
+	PaymentFailed    = "failed"
+	PaymentRefunded  = "refunded"
+)
+
+// Payment is buyer's attempt to pay for order, it is created at provider and then confirmed by buyer on provider's page
+type Payment struct {
+	Id                uint64
+	OrderId           uint64
+	UserId            uint64
+	Provider          string
+	ProviderPaymentId string
+	Status            string
+	// Amount is in Currency of order
+	Amount   uint64
+	Currency string
+	// ConfirmationURL is provider's page where buyer pays
+	ConfirmationURL string
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// PaymentIntent is payment as it is created at provider
+type PaymentIntent struct {
+	ProviderPaymentId string
+	ConfirmationURL   string
+}
+
+// PaymentEvent is provider's notification about payment status, EventId is unique within provider
+type PaymentEvent struct {
+	EventId           string
+	ProviderPaymentId string
+	Status            string
+}
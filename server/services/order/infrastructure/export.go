@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/order/domain"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// exportQuery selects rows of one section of user's data export. Query takes user id as its only parameter
+type exportQuery struct {
+	section string
+	query   string
+	// mediaColumns contain links to media files which should be added to archive
+	mediaColumns []string
+}
+
+// exportQueries describe everything tied to user in order service's tables, one archive file per query
+var exportQueries = []exportQuery{
+	{
+		section: "orders",
+		query: `SELECT id, shop_id, shop_title, status, subtotal, discount, promo_code, total, currency, created_at, updated_at
+				FROM orders
+				WHERE user_id = $1`,
+	},
+	{
+		section: "order_items",
+		query: `SELECT order_items.order_id, order_items.product_id, order_items.variant_id, order_items.title, order_items.sku,
+					   order_items.variant_title, order_items.price, order_items.original_price, order_items.quantity
+				FROM order_items
+				JOIN orders ON orders.id = order_items.order_id
+				WHERE orders.user_id = $1`,
+	},
+	{
+		section: "payments",
+		query: `SELECT id, order_id, provider, status, amount, currency, created_at, updated_at
+				FROM payments
+				WHERE user_id = $1`,
+	},
+}
+
+// CollectUserData runs all export queries in one transaction, so that sections are consistent with each other
+func (repo *OrderRepo) CollectUserData(ctx context.Context, userID uint64) (sections []domain.ExportSection, err error) {
+	tx, err := repo.postgresDB.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	sections = make([]domain.ExportSection, 0, len(exportQueries))
+	for _, query := range exportQueries {
+		section, err := collectSection(ctx, tx, query, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		sections = append(sections, section)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return sections, nil
+}
+
+func collectSection(ctx context.Context, tx pgx.Tx, query exportQuery, userID uint64) (section domain.ExportSection, err error) {
+	rows, err := tx.Query(ctx, query.query, userID)
+	if err != nil {
+		return domain.ExportSection{}, err
+	}
+	defer rows.Close()
+
+	section.Name = query.section
+	section.Records = make([]map[string]interface{}, 0)
+	section.MediaLinks = make([]string, 0)
+
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return domain.ExportSection{}, err
+		}
+
+		record := make(map[string]interface{}, len(values))
+		for i, field := range rows.FieldDescriptions() {
+			record[string(field.Name)] = values[i]
+		}
+
+		for _, column := range query.mediaColumns {
+			link, ok := record[column].(string)
+			if ok && link != "" {
+				section.MediaLinks = append(section.MediaLinks, link)
+			}
+		}
+
+		section.Records = append(section.Records, record)
+	}
+	if rows.Err() != nil {
+		return domain.ExportSection{}, rows.Err()
+	}
+
+	return section, nil
+}
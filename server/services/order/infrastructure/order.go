@@ -0,0 +1,413 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/order/domain"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type OrderRepoInterface interface {
+	CreateOrders(ctx context.Context, orders []domain.Order) (createdOrders []domain.Order, err error)
+	GetOrder(ctx context.Context, orderID uint64) (order domain.Order, err error)
+	ListOrders(ctx context.Context, filter domain.OrdersFilter, page domain.OrdersPage) (orders []domain.Order, err error)
+	ChangeOrderStatus(ctx context.Context, change domain.StatusChange, estimatedReadyAt time.Time) (err error)
+	ListExpiredOrders(ctx context.Context, now time.Time, limit uint64) (orders []domain.Order, err error)
+	HasReceivedProduct(ctx context.Context, userID uint64, productID uint64) (hasReceived bool, err error)
+	ListShopsWithOrders(ctx context.Context, shopIDs []uint64) (shopsWithOrders []uint64, err error)
+	CreatePayment(ctx context.Context, payment domain.Payment) (createdPayment domain.Payment, err error)
+	GetOrderPayment(ctx context.Context, orderID uint64, status string) (payment domain.Payment, err error)
+	GetPaymentByProviderID(ctx context.Context, provider string, providerPaymentID string) (payment domain.Payment, err error)
+	UpdatePaymentStatus(ctx context.Context, paymentID uint64, from string, to string) (err error)
+	IsPaymentEventProcessed(ctx context.Context, provider string, eventID string) (processed bool, err error)
+	AddPaymentEvent(ctx context.Context, provider string, event domain.PaymentEvent, paymentID uint64) (err error)
+	ListStalePayments(ctx context.Context, createdBefore time.Time, limit uint64) (payments []domain.Payment, err error)
+	CollectUserData(ctx context.Context, userID uint64) (sections []domain.ExportSection, err error)
+}
+
+type OrderRepo struct {
+	postgresDB *pgxpool.Pool
+}
+
+func NewOrderRepo(postgresDB *pgxpool.Pool) *OrderRepo {
+	return &OrderRepo{postgresDB: postgresDB}
+}
+
+const orderColumns = `orders.id, orders.user_id, orders.shop_id, orders.shop_title, orders.status, orders.total,
+					  orders.assembly_time, orders.reservation_id, orders.payment_deadline, orders.estimated_ready_at,
+					  orders.created_at, orders.updated_at, orders.subtotal, orders.discount, orders.promo_code,
+					  COALESCE(orders.promo_redemption_id, 0), orders.currency`
+
+func scanOrder(row pgx.Row) (order domain.Order, err error) {
+	err = row.Scan(&order.Id, &order.UserId, &order.ShopId, &order.ShopTitle, &order.Status, &order.Total,
+		&order.AssemblyTime, &order.ReservationId, &order.PaymentDeadline, &order.EstimatedReadyAt,
+		&order.CreatedAt, &order.UpdatedAt, &order.Subtotal, &order.Discount, &order.PromoCode,
+		&order.PromoRedemptionId, &order.Currency)
+	return order, err
+}
+
+// CreateOrders saves orders of one checkout together with their items and first entries of their history
+func (repo *OrderRepo) CreateOrders(ctx context.Context, orders []domain.Order) (createdOrders []domain.Order, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	createOrderQuery := `INSERT INTO orders (user_id, shop_id, shop_title, total, assembly_time, reservation_id,
+											 payment_deadline, estimated_ready_at, created_at, updated_at, subtotal,
+											 discount, promo_code, promo_redemption_id, currency)
+						 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9, $10, $11, $12, NULLIF($13, 0), $14)
+						 RETURNING id`
+	createItemQuery := `INSERT INTO order_items (order_id, product_id, variant_id, title, sku, variant_title, price,
+											 quantity, assembly_time, original_price)
+						VALUES ($1, $2, NULLIF($3, 0), $4, $5, $6, $7, $8, $9, $10)`
+
+	createdOrders = make([]domain.Order, 0, len(orders))
+
+	for _, order := range orders {
+		err = tx.QueryRow(ctx, createOrderQuery, order.UserId, order.ShopId, order.ShopTitle, order.Total,
+			order.AssemblyTime, order.ReservationId, order.PaymentDeadline, order.EstimatedReadyAt,
+			order.CreatedAt, order.Subtotal, order.Discount, order.PromoCode, int64(order.PromoRedemptionId),
+			order.Currency).Scan(&order.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range order.Items {
+			_, err = tx.Exec(ctx, createItemQuery, order.Id, item.ProductId, int64(item.VariantId), item.Title,
+				item.SKU, item.VariantTitle, item.Price, item.Quantity, item.AssemblyTime, item.OriginalPrice)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		change := domain.StatusChange{
+			OrderId:   order.Id,
+			ToStatus:  domain.OrderPendingPayment,
+			ActorId:   order.UserId,
+			ActorRole: domain.RoleBuyer,
+			CreatedAt: order.CreatedAt,
+		}
+		err = addStatusChange(ctx, tx, change)
+		if err != nil {
+			return nil, err
+		}
+
+		order.History = []domain.StatusChange{change}
+		createdOrders = append(createdOrders, order)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return createdOrders, nil
+}
+
+func (repo *OrderRepo) GetOrder(ctx context.Context, orderID uint64) (order domain.Order, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.Order{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getOrderQuery := `SELECT ` + orderColumns + `
+					  FROM orders
+					  WHERE id = $1`
+
+	order, err = scanOrder(tx.QueryRow(ctx, getOrderQuery, orderID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Order{}, domain.OrderNotFoundError
+		}
+
+		return domain.Order{}, err
+	}
+
+	items, err := getOrdersItems(ctx, tx, []uint64{order.Id})
+	if err != nil {
+		return domain.Order{}, err
+	}
+	order.Items = items[order.Id]
+
+	getHistoryQuery := `SELECT order_id, COALESCE(from_status, ''), to_status, COALESCE(actor_id, 0), actor_role, comment, created_at
+						FROM order_status_changes
+						WHERE order_id = $1
+						ORDER BY id`
+
+	rows, err := tx.Query(ctx, getHistoryQuery, order.Id)
+	if err != nil {
+		return domain.Order{}, err
+	}
+	defer rows.Close()
+
+	order.History = make([]domain.StatusChange, 0)
+
+	for rows.Next() {
+		var change domain.StatusChange
+		err = rows.Scan(&change.OrderId, &change.FromStatus, &change.ToStatus, &change.ActorId, &change.ActorRole,
+			&change.Comment, &change.CreatedAt)
+		if err != nil {
+			return domain.Order{}, err
+		}
+
+		order.History = append(order.History, change)
+	}
+	if rows.Err() != nil {
+		return domain.Order{}, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.Order{}, domain.TransactionCommitError
+	}
+	return order, nil
+}
+
+// ListOrders returns orders of buyer or shop with their items, newest orders go first.
+// One extra order is returned if there are more orders after the page
+func (repo *OrderRepo) ListOrders(ctx context.Context, filter domain.OrdersFilter, page domain.OrdersPage) (orders []domain.Order, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	listOrdersQuery := `SELECT ` + orderColumns + `
+						FROM orders
+						WHERE ($1 = 0 OR user_id = $1) AND ($2 = 0 OR shop_id = $2) AND ($3 = '' OR status = $3)
+							  AND ($4 = 0 OR id < $4)
+						ORDER BY id DESC
+						LIMIT $5 OFFSET $6`
+
+	rows, err := tx.Query(ctx, listOrdersQuery, int64(filter.UserId), int64(filter.ShopId), filter.Status,
+		int64(page.Cursor), page.Limit+1, page.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders = make([]domain.Order, 0)
+	orderIDs := make([]uint64, 0)
+
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, order)
+		orderIDs = append(orderIDs, order.Id)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	items, err := getOrdersItems(ctx, tx, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range orders {
+		orders[i].Items = items[orders[i].Id]
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return orders, nil
+}
+
+// getOrdersItems returns items of every order, every order gets at least empty slice
+func getOrdersItems(ctx context.Context, tx pgx.Tx, orderIDs []uint64) (items map[uint64][]domain.OrderItem, err error) {
+	items = make(map[uint64][]domain.OrderItem, len(orderIDs))
+	for _, orderID := range orderIDs {
+		items[orderID] = make([]domain.OrderItem, 0)
+	}
+
+	if len(orderIDs) == 0 {
+		return items, nil
+	}
+
+	getItemsQuery := `SELECT order_id, COALESCE(product_id, 0), COALESCE(variant_id, 0), title, sku, variant_title,
+							 price, quantity, assembly_time, original_price
+					  FROM order_items
+					  WHERE order_id = ANY($1)
+					  ORDER BY id`
+
+	rows, err := tx.Query(ctx, getItemsQuery, orderIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderID uint64
+		var item domain.OrderItem
+		err = rows.Scan(&orderID, &item.ProductId, &item.VariantId, &item.Title, &item.SKU, &item.VariantTitle,
+			&item.Price, &item.Quantity, &item.AssemblyTime, &item.OriginalPrice)
+		if err != nil {
+			return nil, err
+		}
+
+		items[orderID] = append(items[orderID], item)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return items, nil
+}
+
+// ChangeOrderStatus moves order from change's FromStatus to ToStatus and records transition.
+// StatusChangedError is returned if order is no longer in FromStatus
+func (repo *OrderRepo) ChangeOrderStatus(ctx context.Context, change domain.StatusChange, estimatedReadyAt time.Time) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	changeStatusQuery := `UPDATE orders
+						  SET status = $1, estimated_ready_at = $2, updated_at = $3
+						  WHERE id = $4 AND status = $5`
+
+	result, err := tx.Exec(ctx, changeStatusQuery, change.ToStatus, estimatedReadyAt, change.CreatedAt,
+		change.OrderId, change.FromStatus)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.StatusChangedError
+	}
+
+	err = addStatusChange(ctx, tx, change)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+func addStatusChange(ctx context.Context, tx pgx.Tx, change domain.StatusChange) (err error) {
+	addChangeQuery := `INSERT INTO order_status_changes (order_id, from_status, to_status, actor_id, actor_role, comment, created_at)
+					   VALUES ($1, NULLIF($2, ''), $3, NULLIF($4, 0), $5, $6, $7)`
+
+	_, err = tx.Exec(ctx, addChangeQuery, change.OrderId, change.FromStatus, change.ToStatus, int64(change.ActorId),
+		change.ActorRole, change.Comment, change.CreatedAt)
+	return err
+}
+
+// ListExpiredOrders returns unpaid orders whose payment deadline has passed, without items
+func (repo *OrderRepo) ListExpiredOrders(ctx context.Context, now time.Time, limit uint64) (orders []domain.Order, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	listExpiredQuery := `SELECT ` + orderColumns + `
+						 FROM orders
+						 WHERE status = $1 AND payment_deadline < $2
+						 ORDER BY payment_deadline
+						 LIMIT $3`
+
+	rows, err := tx.Query(ctx, listExpiredQuery, domain.OrderPendingPayment, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders = make([]domain.Order, 0)
+
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		orders = append(orders, order)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return orders, nil
+}
+
+// HasReceivedProduct checks whether user has delivered order which contains product
+func (repo *OrderRepo) HasReceivedProduct(ctx context.Context, userID uint64, productID uint64) (hasReceived bool, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return false, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	hasReceivedQuery := `SELECT EXISTS(SELECT 1
+									   FROM order_items
+									   JOIN orders ON orders.id = order_items.order_id
+									   WHERE orders.user_id = $1 AND order_items.product_id = $2 AND orders.status = $3)`
+
+	err = tx.QueryRow(ctx, hasReceivedQuery, userID, productID, domain.OrderDelivered).Scan(&hasReceived)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return false, domain.TransactionCommitError
+	}
+	return hasReceived, nil
+}
+
+// ListShopsWithOrders returns those of given shops which have at least one order
+func (repo *OrderRepo) ListShopsWithOrders(ctx context.Context, shopIDs []uint64) (shopsWithOrders []uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	listShopsQuery := `SELECT DISTINCT shop_id
+					   FROM orders
+					   WHERE shop_id = ANY($1)`
+
+	rows, err := tx.Query(ctx, listShopsQuery, shopIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shopsWithOrders = make([]uint64, 0)
+
+	for rows.Next() {
+		var shopID uint64
+		err = rows.Scan(&shopID)
+		if err != nil {
+			return nil, err
+		}
+
+		shopsWithOrders = append(shopsWithOrders, shopID)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return shopsWithOrders, nil
+}
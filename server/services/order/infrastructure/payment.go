@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/order/domain"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+const paymentColumns = `payments.id, payments.order_id, payments.user_id, payments.provider, payments.provider_payment_id,
+						payments.status, payments.amount, payments.currency, payments.confirmation_url, payments.created_at, payments.updated_at`
+
+func scanPayment(row pgx.Row) (payment domain.Payment, err error) {
+	err = row.Scan(&payment.Id, &payment.OrderId, &payment.UserId, &payment.Provider, &payment.ProviderPaymentId,
+		&payment.Status, &payment.Amount, &payment.Currency, &payment.ConfirmationURL, &payment.CreatedAt, &payment.UpdatedAt)
+	return payment, err
+}
+
+// CreatePayment saves pending payment. Order can have only one pending payment, so if another one was created
+// concurrently, that one is returned
+func (repo *OrderRepo) CreatePayment(ctx context.Context, payment domain.Payment) (createdPayment domain.Payment, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.Payment{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	createPaymentQuery := `INSERT INTO payments (order_id, user_id, provider, provider_payment_id, amount, currency, confirmation_url)
+						   VALUES ($1, $2, $3, $4, $5, $6, $7)
+						   ON CONFLICT (order_id) WHERE status = 'pending' DO NOTHING
+						   RETURNING ` + paymentColumns
+
+	createdPayment, err = scanPayment(tx.QueryRow(ctx, createPaymentQuery, payment.OrderId, payment.UserId, payment.Provider,
+		payment.ProviderPaymentId, payment.Amount, payment.Currency, payment.ConfirmationURL))
+	if err == pgx.ErrNoRows {
+		getPendingQuery := `SELECT ` + paymentColumns + `
+							FROM payments
+							WHERE order_id = $1 AND status = $2`
+
+		createdPayment, err = scanPayment(tx.QueryRow(ctx, getPendingQuery, payment.OrderId, domain.PaymentPending))
+	}
+	if err != nil {
+		return domain.Payment{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.Payment{}, domain.TransactionCommitError
+	}
+	return createdPayment, nil
+}
+
+// GetOrderPayment returns order's latest payment with status, any status matches if status is empty
+func (repo *OrderRepo) GetOrderPayment(ctx context.Context, orderID uint64, status string) (payment domain.Payment, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.Payment{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getPaymentQuery := `SELECT ` + paymentColumns + `
+						FROM payments
+						WHERE order_id = $1 AND ($2 = '' OR status = $2)
+						ORDER BY id DESC
+						LIMIT 1`
+
+	payment, err = scanPayment(tx.QueryRow(ctx, getPaymentQuery, orderID, status))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Payment{}, domain.PaymentNotFoundError
+		}
+
+		return domain.Payment{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.Payment{}, domain.TransactionCommitError
+	}
+	return payment, nil
+}
+
+func (repo *OrderRepo) GetPaymentByProviderID(ctx context.Context, provider string, providerPaymentID string) (payment domain.Payment, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.Payment{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getPaymentQuery := `SELECT ` + paymentColumns + `
+						FROM payments
+						WHERE provider = $1 AND provider_payment_id = $2`
+
+	payment, err = scanPayment(tx.QueryRow(ctx, getPaymentQuery, provider, providerPaymentID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Payment{}, domain.PaymentNotFoundError
+		}
+
+		return domain.Payment{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.Payment{}, domain.TransactionCommitError
+	}
+	return payment, nil
+}
+
+// UpdatePaymentStatus changes payment's status only if it is still in status "from",
+// otherwise PaymentStatusChangedError is returned
+func (repo *OrderRepo) UpdatePaymentStatus(ctx context.Context, paymentID uint64, from string, to string) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	updateStatusQuery := `UPDATE payments
+						  SET status = $1, updated_at = now()
+						  WHERE id = $2 AND status = $3`
+
+	result, err := tx.Exec(ctx, updateStatusQuery, to, paymentID, from)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.PaymentStatusChangedError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+func (repo *OrderRepo) IsPaymentEventProcessed(ctx context.Context, provider string, eventID string) (processed bool, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return false, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	eventExistsQuery := `SELECT EXISTS(SELECT 1 FROM payment_events WHERE provider = $1 AND event_id = $2)`
+
+	err = tx.QueryRow(ctx, eventExistsQuery, provider, eventID).Scan(&processed)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return false, domain.TransactionCommitError
+	}
+	return processed, nil
+}
+
+// AddPaymentEvent marks webhook as processed, event which was already added is ignored
+func (repo *OrderRepo) AddPaymentEvent(ctx context.Context, provider string, event domain.PaymentEvent, paymentID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	addEventQuery := `INSERT INTO payment_events (provider, event_id, payment_id, status)
+					  VALUES ($1, $2, $3, $4)
+					  ON CONFLICT DO NOTHING`
+
+	_, err = tx.Exec(ctx, addEventQuery, provider, event.EventId, paymentID, event.Status)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// ListStalePayments returns pending payments which were created before createdBefore, oldest ones go first
+func (repo *OrderRepo) ListStalePayments(ctx context.Context, createdBefore time.Time, limit uint64) (payments []domain.Payment, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	listStaleQuery := `SELECT ` + paymentColumns + `
+					   FROM payments
+					   WHERE status = $1 AND created_at < $2
+					   ORDER BY created_at
+					   LIMIT $3`
+
+	rows, err := tx.Query(ctx, listStaleQuery, domain.PaymentPending, createdBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	payments = make([]domain.Payment, 0)
+
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		payments = append(payments, payment)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return payments, nil
+}
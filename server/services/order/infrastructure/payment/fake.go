@@ -0,0 +1,193 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"pinterest/services/order/domain"
+	"sync"
+	"time"
+)
+
+const (
+	// fakeWebhookAttempts and fakeWebhookRetryDelay imitate retries of real providers
+	fakeWebhookAttempts   = 5
+	fakeWebhookRetryDelay = 2 * time.Second
+)
+
+// WebhookHandler processes webhook in the same way as one which came from network
+type WebhookHandler func(ctx context.Context, provider string, body []byte, signature string) (err error)
+
+type fakePayment struct {
+	amount    uint64
+	status    string
+	returnURL string
+}
+
+type fakeWebhook struct {
+	EventId   string `json:"event_id"`
+	PaymentId string `json:"payment_id"`
+	Status    string `json:"status"`
+}
+
+// FakeProvider keeps payments in memory, payer chooses outcome on page which is served by gateway.
+// Like real providers, it reports outcome with signed webhooks, which are delivered without network
+type FakeProvider struct {
+	secret []byte
+	// pageURL is address of fake payment page, payment's id is appended to it
+	pageURL        string
+	webhookHandler WebhookHandler
+
+	mu       sync.Mutex
+	payments map[string]*fakePayment
+}
+
+// NewFakeProvider creates provider which signs webhooks with secret, random secret is used if it is empty
+func NewFakeProvider(secret string, pageURL string) *FakeProvider {
+	key := []byte(secret)
+	if secret == "" {
+		key = []byte(randomHex(32))
+	}
+
+	return &FakeProvider{
+		secret:   key,
+		pageURL:  pageURL,
+		payments: make(map[string]*fakePayment),
+	}
+}
+
+// SetWebhookHandler sets function which receives webhooks, webhooks are dropped until it is set
+func (provider *FakeProvider) SetWebhookHandler(handler WebhookHandler) {
+	provider.webhookHandler = handler
+}
+
+func (provider *FakeProvider) Name() string {
+	return domain.FakeProviderName
+}
+
+func (provider *FakeProvider) CreatePayment(ctx context.Context, payment domain.Payment, returnURL string) (intent domain.PaymentIntent, err error) {
+	paymentID := "fake_" + randomHex(16)
+
+	provider.mu.Lock()
+	provider.payments[paymentID] = &fakePayment{
+		amount:    payment.Amount,
+		status:    domain.PaymentPending,
+		returnURL: returnURL,
+	}
+	provider.mu.Unlock()
+
+	return domain.PaymentIntent{
+		ProviderPaymentId: paymentID,
+		ConfirmationURL:   provider.pageURL + "/" + paymentID,
+	}, nil
+}
+
+func (provider *FakeProvider) GetPaymentStatus(ctx context.Context, providerPaymentID string) (status string, err error) {
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	payment, found := provider.payments[providerPaymentID]
+	if !found {
+		return "", domain.PaymentNotFoundError
+	}
+
+	return payment.status, nil
+}
+
+func (provider *FakeProvider) Refund(ctx context.Context, providerPaymentID string, amount uint64) (err error) {
+	provider.mu.Lock()
+	defer provider.mu.Unlock()
+
+	payment, found := provider.payments[providerPaymentID]
+	switch {
+	case !found:
+		return domain.PaymentNotFoundError
+	case payment.status != domain.PaymentSucceeded || amount > payment.amount:
+		return domain.RefundFailedError
+	}
+
+	payment.status = domain.PaymentRefunded
+	return nil
+}
+
+func (provider *FakeProvider) ParseWebhook(body []byte, signature string) (event domain.PaymentEvent, err error) {
+	if !hmac.Equal([]byte(provider.sign(body)), []byte(signature)) {
+		return domain.PaymentEvent{}, domain.InvalidSignatureError
+	}
+
+	var webhook fakeWebhook
+	err = json.Unmarshal(body, &webhook)
+	if err != nil || webhook.EventId == "" || webhook.PaymentId == "" {
+		return domain.PaymentEvent{}, domain.InvalidWebhookError
+	}
+
+	return domain.PaymentEvent{
+		EventId:           webhook.EventId,
+		ProviderPaymentId: webhook.PaymentId,
+		Status:            webhook.Status,
+	}, nil
+}
+
+// Complete is called when payer chooses outcome on fake payment page. It returns address to which payer is redirected,
+// webhook is sent in background
+func (provider *FakeProvider) Complete(providerPaymentID string, succeeded bool) (returnURL string, err error) {
+	provider.mu.Lock()
+	payment, found := provider.payments[providerPaymentID]
+	if !found {
+		provider.mu.Unlock()
+		return "", domain.PaymentNotFoundError
+	}
+
+	if payment.status == domain.PaymentPending {
+		payment.status = domain.PaymentFailed
+		if succeeded {
+			payment.status = domain.PaymentSucceeded
+		}
+	}
+	status := payment.status
+	returnURL = payment.returnURL
+	provider.mu.Unlock()
+
+	go provider.sendWebhook(fakeWebhook{
+		EventId:   "evt_" + randomHex(16),
+		PaymentId: providerPaymentID,
+		Status:    status,
+	})
+	return returnURL, nil
+}
+
+// sendWebhook delivers webhook until it is accepted or attempts run out
+func (provider *FakeProvider) sendWebhook(webhook fakeWebhook) {
+	if provider.webhookHandler == nil {
+		return
+	}
+
+	body, err := json.Marshal(webhook)
+	if err != nil {
+		return
+	}
+
+	for attempt := 0; attempt < fakeWebhookAttempts; attempt++ {
+		err = provider.webhookHandler(context.Background(), provider.Name(), body, provider.sign(body))
+		if err == nil {
+			return
+		}
+
+		time.Sleep(fakeWebhookRetryDelay)
+	}
+}
+
+func (provider *FakeProvider) sign(body []byte) string {
+	mac := hmac.New(sha256.New, provider.secret)
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
@@ -0,0 +1,19 @@
+package payment
+
+import (
+	"context"
+	"pinterest/services/order/domain"
+)
+
+// ProviderInterface is implemented by every payment provider. Statuses which provider returns are domain's payment statuses
+type ProviderInterface interface {
+	Name() string
+	// CreatePayment creates payment of order's total, provider redirects buyer to returnURL after payment
+	CreatePayment(ctx context.Context, payment domain.Payment, returnURL string) (intent domain.PaymentIntent, err error)
+	// GetPaymentStatus is used when buyer returns from provider and when stuck payments are reconciled.
+	// PaymentNotFoundError is returned if provider does not know payment
+	GetPaymentStatus(ctx context.Context, providerPaymentID string) (status string, err error)
+	Refund(ctx context.Context, providerPaymentID string, amount uint64) (err error)
+	// ParseWebhook checks webhook's signature and returns event it carries
+	ParseWebhook(body []byte, signature string) (event domain.PaymentEvent, err error)
+}
@@ -0,0 +1,144 @@
+package facade
+
+import (
+	"context"
+	"pinterest/services/order/application"
+	"pinterest/services/order/domain"
+	pb "pinterest/services/order/proto"
+
+	"github.com/pkg/errors"
+	_ "google.golang.org/grpc"
+)
+
+type OrderFacade struct {
+	pb.UnimplementedOrderServiceServer
+	app application.OrderAppInterface
+}
+
+func NewOrderFacade(app application.OrderAppInterface) *OrderFacade {
+	return &OrderFacade{
+		app: app,
+	}
+}
+
+func (facade *OrderFacade) Checkout(ctx context.Context, in *pb.CheckoutRequest) (*pb.OrdersList, error) {
+	orders, err := facade.app.Checkout(ctx, in.GetUserId(), domain.ToCheckoutItems(in.GetItems()), in.GetPromoCode())
+	if err != nil {
+		return &pb.OrdersList{}, errors.Wrap(err, "Could not checkout:")
+	}
+
+	return domain.ToPbOrdersList(orders, ""), nil
+}
+
+func (facade *OrderFacade) GetOrder(ctx context.Context, in *pb.OrderRequest) (*pb.Order, error) {
+	order, err := facade.app.GetOrder(ctx, in.GetId(), in.GetUserId())
+	if err != nil {
+		return &pb.Order{}, errors.Wrap(err, "Could not get order:")
+	}
+
+	return domain.ToPbOrder(order), nil
+}
+
+func (facade *OrderFacade) ListUserOrders(ctx context.Context, in *pb.ListOrdersRequest) (*pb.OrdersList, error) {
+	page, err := domain.NewOrdersPage(in.GetLimit(), in.GetCursor(), in.GetPage())
+	if err != nil {
+		return &pb.OrdersList{}, errors.Wrap(err, "Could not list user's orders:")
+	}
+
+	orders, nextCursor, err := facade.app.ListUserOrders(ctx, in.GetUserId(), in.GetStatus(), page)
+	if err != nil {
+		return &pb.OrdersList{}, errors.Wrap(err, "Could not list user's orders:")
+	}
+
+	return domain.ToPbOrdersList(orders, nextCursor), nil
+}
+
+func (facade *OrderFacade) ListShopOrders(ctx context.Context, in *pb.ListOrdersRequest) (*pb.OrdersList, error) {
+	page, err := domain.NewOrdersPage(in.GetLimit(), in.GetCursor(), in.GetPage())
+	if err != nil {
+		return &pb.OrdersList{}, errors.Wrap(err, "Could not list shop's orders:")
+	}
+
+	orders, nextCursor, err := facade.app.ListShopOrders(ctx, in.GetShopId(), in.GetUserId(), in.GetStatus(), page)
+	if err != nil {
+		return &pb.OrdersList{}, errors.Wrap(err, "Could not list shop's orders:")
+	}
+
+	return domain.ToPbOrdersList(orders, nextCursor), nil
+}
+
+func (facade *OrderFacade) ChangeOrderStatus(ctx context.Context, in *pb.ChangeStatusRequest) (*pb.Order, error) {
+	order, err := facade.app.ChangeOrderStatus(ctx, in.GetId(), in.GetUserId(), in.GetStatus(), in.GetComment())
+	if err != nil {
+		return &pb.Order{}, errors.Wrap(err, "Could not change order status:")
+	}
+
+	return domain.ToPbOrder(order), nil
+}
+
+func (facade *OrderFacade) CreatePayment(ctx context.Context, in *pb.CreatePaymentRequest) (*pb.Payment, error) {
+	payment, err := facade.app.CreatePayment(ctx, in.GetOrderId(), in.GetUserId(), in.GetReturnUrl())
+	if err != nil {
+		return &pb.Payment{}, errors.Wrap(err, "Could not create payment:")
+	}
+
+	return domain.ToPbPayment(payment), nil
+}
+
+func (facade *OrderFacade) SyncPayment(ctx context.Context, in *pb.OrderRequest) (*pb.Payment, error) {
+	payment, err := facade.app.SyncPayment(ctx, in.GetId(), in.GetUserId())
+	if err != nil {
+		return &pb.Payment{}, errors.Wrap(err, "Could not sync payment:")
+	}
+
+	return domain.ToPbPayment(payment), nil
+}
+
+func (facade *OrderFacade) HandlePaymentWebhook(ctx context.Context, in *pb.PaymentWebhookRequest) (*pb.Empty, error) {
+	err := facade.app.HandlePaymentWebhook(ctx, in.GetProvider(), in.GetBody(), in.GetSignature())
+	if err != nil {
+		return &pb.Empty{}, errors.Wrap(err, "Could not handle payment webhook:")
+	}
+
+	return &pb.Empty{}, nil
+}
+
+func (facade *OrderFacade) CompleteFakePayment(ctx context.Context, in *pb.FakePaymentRequest) (*pb.FakePaymentResponse, error) {
+	returnURL, err := facade.app.CompleteFakePayment(ctx, in.GetProviderPaymentId(), in.GetSucceeded())
+	if err != nil {
+		return &pb.FakePaymentResponse{}, errors.Wrap(err, "Could not complete fake payment:")
+	}
+
+	return &pb.FakePaymentResponse{ReturnUrl: returnURL}, nil
+}
+
+func (facade *OrderFacade) HasReceivedProduct(ctx context.Context, in *pb.ProductBuyerRequest) (*pb.ProductBuyerResponse, error) {
+	hasReceived, err := facade.app.HasReceivedProduct(ctx, in.GetUserId(), in.GetProductId())
+	if err != nil {
+		return &pb.ProductBuyerResponse{}, errors.Wrap(err, "Could not check whether user has received product:")
+	}
+
+	return &pb.ProductBuyerResponse{HasReceived: hasReceived}, nil
+}
+
+func (facade *OrderFacade) ListShopsWithOrders(ctx context.Context, in *pb.ShopsRequest) (*pb.ShopsResponse, error) {
+	shopIDs, err := facade.app.ListShopsWithOrders(ctx, in.GetShopIds())
+	if err != nil {
+		return &pb.ShopsResponse{}, errors.Wrap(err, "Could not list shops with orders:")
+	}
+
+	return &pb.ShopsResponse{ShopIds: shopIDs}, nil
+}
+
+func (facade *OrderFacade) ExportUserData(ctx context.Context, in *pb.UserRequest) (*pb.ExportSections, error) {
+	sections, err := facade.app.ExportUserData(ctx, in.GetUserId())
+	if err != nil {
+		return &pb.ExportSections{}, errors.Wrap(err, "Could not export user data:")
+	}
+
+	pbSections, err := domain.ToPbExportSections(sections)
+	if err != nil {
+		return &pb.ExportSections{}, errors.Wrap(err, "Could not export user data:")
+	}
+	return pbSections, nil
+}
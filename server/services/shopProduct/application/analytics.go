@@ -0,0 +1,34 @@
+package application
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+	"time"
+)
+
+// RollupViews aggregates views into daily rollups. Last rolled up day is rebuilt, as it could be incomplete,
+// and all views are rolled up on first run
+func (app *ShopProductApp) RollupViews(ctx context.Context) (err error) {
+	lastDay, err := app.repo.GetLastRollupDay(ctx)
+	if err != nil {
+		return err
+	}
+
+	return app.repo.RollupViews(ctx, domain.StartOfDay(lastDay))
+}
+
+// GetShopAnalytics returns report about views of shop and its products during date range, only shop's managers can
+// see it. Range is parsed by domain.ParseAnalyticsRange, views of current hour may be not rolled up yet
+func (app *ShopProductApp) GetShopAnalytics(ctx context.Context, shopID uint64, userID uint64, from string, to string) (analytics domain.ShopAnalytics, err error) {
+	start, end, err := domain.ParseAnalyticsRange(from, to, time.Now())
+	if err != nil {
+		return domain.ShopAnalytics{}, err
+	}
+
+	err = app.checkManager(ctx, shopID, userID)
+	if err != nil {
+		return domain.ShopAnalytics{}, err
+	}
+
+	return app.repo.GetShopAnalytics(ctx, shopID, start, end)
+}
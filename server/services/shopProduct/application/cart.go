@@ -0,0 +1,140 @@
+package application
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+	"time"
+)
+
+// GetCart returns owner's cart repriced with active sales and discounts and checked against current stock.
+// Prices are also converted into currency if it is not empty.
+// Anonymous cart with unknown token is returned empty and without token
+func (app *ShopProductApp) GetCart(ctx context.Context, owner domain.CartOwner, currency string) (cart domain.Cart, err error) {
+	if owner.IsAnonymous() {
+		if owner.Token == "" {
+			return app.convertCart(ctx, domain.NewCart(0, owner, nil, nil, domain.Pricing{}), currency)
+		}
+
+		_, err = app.repo.GetCartID(ctx, owner)
+		switch {
+		case err == domain.CartNotFoundError:
+			return app.convertCart(ctx, domain.NewCart(0, domain.CartOwner{}, nil, nil, domain.Pricing{}), currency)
+		case err != nil:
+			return domain.Cart{}, err
+		}
+	}
+
+	return app.getCart(ctx, owner, currency)
+}
+
+// getCart returns owner's cart with prices converted into currency
+func (app *ShopProductApp) getCart(ctx context.Context, owner domain.CartOwner, currency string) (cart domain.Cart, err error) {
+	cart, err = app.repo.GetCart(ctx, owner, time.Now())
+	if err != nil {
+		return domain.Cart{}, err
+	}
+
+	return app.convertCart(ctx, cart, currency)
+}
+
+// AddToCart adds quantity of product's variant to owner's cart, creating the cart if needed. New anonymous carts get new token,
+// which is returned in cart. variantID is 0 for products without variants
+func (app *ShopProductApp) AddToCart(ctx context.Context, owner domain.CartOwner, productID uint64, variantID uint64, quantity uint64, currency string) (cart domain.Cart, err error) {
+	err = checkCartQuantity(quantity)
+	if err != nil {
+		return domain.Cart{}, err
+	}
+
+	//TODO: add transactions here?
+	cartID, err := app.repo.GetCartID(ctx, owner)
+	if err == domain.CartNotFoundError {
+		if owner.IsAnonymous() {
+			owner.Token = randString(domain.CartTokenLength)
+		}
+
+		cartID, err = app.repo.CreateCart(ctx, owner)
+	}
+	if err != nil {
+		return domain.Cart{}, err
+	}
+
+	err = app.repo.AddCartItem(ctx, cartID, productID, variantID, quantity)
+	if err != nil {
+		return domain.Cart{}, err
+	}
+
+	return app.getCart(ctx, owner, currency)
+}
+
+// UpdateCartItem sets quantity of product's variant in owner's cart, item is removed if quantity is 0
+func (app *ShopProductApp) UpdateCartItem(ctx context.Context, owner domain.CartOwner, productID uint64, variantID uint64, quantity uint64, currency string) (cart domain.Cart, err error) {
+	if quantity == 0 {
+		return app.RemoveFromCart(ctx, owner, productID, variantID, currency)
+	}
+
+	err = checkCartQuantity(quantity)
+	if err != nil {
+		return domain.Cart{}, err
+	}
+
+	cartID, err := app.ownerCartID(ctx, owner)
+	if err != nil {
+		return domain.Cart{}, err
+	}
+
+	err = app.repo.UpdateCartItem(ctx, cartID, productID, variantID, quantity)
+	if err != nil {
+		return domain.Cart{}, err
+	}
+
+	return app.getCart(ctx, owner, currency)
+}
+
+func (app *ShopProductApp) RemoveFromCart(ctx context.Context, owner domain.CartOwner, productID uint64, variantID uint64, currency string) (cart domain.Cart, err error) {
+	cartID, err := app.ownerCartID(ctx, owner)
+	if err != nil {
+		return domain.Cart{}, err
+	}
+
+	err = app.repo.RemoveCartItem(ctx, cartID, productID, variantID)
+	if err != nil {
+		return domain.Cart{}, err
+	}
+
+	return app.getCart(ctx, owner, currency)
+}
+
+// MergeCarts moves items of anonymous cart into user's cart when user logs in
+func (app *ShopProductApp) MergeCarts(ctx context.Context, token string, userID uint64) (err error) {
+	if token == "" || userID == 0 {
+		return nil
+	}
+
+	return app.repo.MergeCarts(ctx, token, userID)
+}
+
+// PurgeAbandonedCarts deletes anonymous carts which were not changed for domain.AnonymousCartLifetime
+func (app *ShopProductApp) PurgeAbandonedCarts(ctx context.Context) (err error) {
+	return app.repo.DeleteAbandonedCarts(ctx, time.Now().Add(-domain.AnonymousCartLifetime))
+}
+
+// ownerCartID returns id of owner's cart, missing cart is reported as missing item, as every item is missing from it
+func (app *ShopProductApp) ownerCartID(ctx context.Context, owner domain.CartOwner) (cartID uint64, err error) {
+	cartID, err = app.repo.GetCartID(ctx, owner)
+	if err == domain.CartNotFoundError {
+		return 0, domain.CartItemNotFoundError
+	}
+
+	return cartID, err
+}
+
+func checkCartQuantity(quantity uint64) error {
+	switch {
+	case quantity == 0:
+		return domain.InvalidQuantityError
+	case quantity > domain.MaxCartItemQuantity:
+		return domain.CartQuantityTooLargeError
+	}
+
+	return nil
+}
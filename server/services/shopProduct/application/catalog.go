@@ -0,0 +1,318 @@
+package application
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+	"sort"
+	"time"
+)
+
+// importTarget is state of shop which rows of catalog file are checked against
+type importTarget struct {
+	shop       domain.Shop
+	categories map[uint64]bool
+	// products are shop's products which have SKUs of imported rows, keyed by SKU
+	products map[string]domain.Product
+	variants map[uint64][]domain.ProductVariant
+}
+
+// ImportProducts reads catalog file and starts applying its rows in background, only shop's managers can do it.
+// Rows which can not be read are reported as import's row errors, the whole file is rejected only if it can not be read at all
+func (app *ShopProductApp) ImportProducts(ctx context.Context, shopID uint64, userID uint64, format string, dryRun bool, data []byte) (productImport domain.ProductImport, err error) {
+	if !domain.ValidCatalogFormat(format) {
+		return domain.ProductImport{}, domain.InvalidCatalogFormatError
+	}
+
+	err = app.checkManager(ctx, shopID, userID)
+	if err != nil {
+		return domain.ProductImport{}, err
+	}
+
+	rows, rowErrors, err := domain.ParseCatalog(format, data)
+	if err != nil {
+		return domain.ProductImport{}, err
+	}
+
+	productImport, err = app.repo.CreateImport(ctx, domain.ProductImport{
+		ShopId:        shopID,
+		UserId:        userID,
+		Format:        format,
+		DryRun:        dryRun,
+		Status:        domain.ImportStatusPending,
+		TotalRows:     uint64(len(rows) + len(rowErrors)),
+		ProcessedRows: uint64(len(rowErrors)),
+		FailedCount:   uint64(len(rowErrors)),
+		Errors:        rowErrors,
+	})
+	if err != nil {
+		return domain.ProductImport{}, err
+	}
+
+	go app.processImport(productImport, rows)
+
+	return productImport, nil
+}
+
+// GetProductImport returns status and progress of shop's import, only shop's managers can see it
+func (app *ShopProductApp) GetProductImport(ctx context.Context, shopID uint64, importID uint64, userID uint64) (productImport domain.ProductImport, err error) {
+	err = app.checkManager(ctx, shopID, userID)
+	if err != nil {
+		return domain.ProductImport{}, err
+	}
+
+	productImport, err = app.repo.GetImport(ctx, importID)
+	if err != nil {
+		return domain.ProductImport{}, err
+	}
+
+	if productImport.ShopId != shopID {
+		return domain.ProductImport{}, domain.ImportNotFoundError
+	}
+
+	return productImport, nil
+}
+
+// ExportProducts returns all shop's products in format which ImportProducts reads, only shop's managers can do it
+func (app *ShopProductApp) ExportProducts(ctx context.Context, shopID uint64, userID uint64, format string) (data []byte, err error) {
+	if !domain.ValidCatalogFormat(format) {
+		return nil, domain.InvalidCatalogFormatError
+	}
+
+	err = app.checkManager(ctx, shopID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := app.repo.ListShopCatalog(ctx, shopID)
+	if err != nil {
+		return nil, err
+	}
+
+	productIDs := make([]uint64, 0, len(products))
+	for _, product := range products {
+		productIDs = append(productIDs, product.Id)
+	}
+
+	_, variants, err := app.repo.GetProductsVariants(ctx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := make([]domain.CatalogRow, 0, len(products))
+	for _, product := range products {
+		rows = append(rows, domain.ToCatalogRow(product, len(variants[product.Id]) != 0))
+	}
+
+	return domain.WriteCatalog(format, rows)
+}
+
+// processImport applies rows of import one by one, failed rows are recorded and do not stop import.
+// Errors are saved in import itself
+func (app *ShopProductApp) processImport(productImport domain.ProductImport, rows []domain.CatalogRow) {
+	ctx := context.Background()
+
+	productImport.Status = domain.ImportStatusProcessing
+	err := app.repo.UpdateImport(ctx, productImport)
+	if err != nil {
+		return
+	}
+
+	err = app.applyImportRows(ctx, &productImport, rows)
+	if err != nil {
+		productImport.Status = domain.ImportStatusFailed
+		productImport.ErrorMessage = err.Error()
+		productImport.FinishedAt = time.Now()
+		app.repo.UpdateImport(ctx, productImport)
+		return
+	}
+
+	// Errors of rows which could not be read were recorded first, so errors are put in order of rows
+	sort.SliceStable(productImport.Errors, func(i, j int) bool {
+		return productImport.Errors[i].Row < productImport.Errors[j].Row
+	})
+
+	productImport.Status = domain.ImportStatusDone
+	productImport.FinishedAt = time.Now()
+	app.repo.UpdateImport(ctx, productImport)
+}
+
+// applyImportRows imports rows and saves progress of import every domain.ImportProgressStep rows
+func (app *ShopProductApp) applyImportRows(ctx context.Context, productImport *domain.ProductImport, rows []domain.CatalogRow) (err error) {
+	target, err := app.loadImportTarget(ctx, productImport.ShopId, rows)
+	if err != nil {
+		return err
+	}
+
+	seenSKUs := make(map[string]bool, len(rows))
+	for i, row := range rows {
+		if seenSKUs[row.SKU] {
+			productImport.AddRowError(row, "sku", domain.RepeatedImportSKUError)
+		} else {
+			seenSKUs[row.SKU] = true
+
+			created, column, err := app.importRow(ctx, *productImport, row, target)
+			switch {
+			case err != nil:
+				productImport.AddRowError(row, column, err)
+			case created:
+				productImport.CreatedCount++
+			default:
+				productImport.UpdatedCount++
+			}
+		}
+
+		productImport.ProcessedRows++
+		if (i+1)%domain.ImportProgressStep == 0 {
+			err = app.repo.UpdateImport(ctx, *productImport)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (app *ShopProductApp) loadImportTarget(ctx context.Context, shopID uint64, rows []domain.CatalogRow) (target importTarget, err error) {
+	target.shop, err = app.repo.GetShop(ctx, shopID)
+	if err != nil {
+		return importTarget{}, err
+	}
+
+	categories, err := app.repo.GetCategories(ctx)
+	if err != nil {
+		return importTarget{}, err
+	}
+
+	target.categories = make(map[uint64]bool, len(categories))
+	for _, category := range categories {
+		target.categories[category.Id] = true
+	}
+
+	skus := make([]string, 0, len(rows))
+	for _, row := range rows {
+		skus = append(skus, row.SKU)
+	}
+
+	products, err := app.repo.GetProductsBySKU(ctx, shopID, skus)
+	if err != nil {
+		return importTarget{}, err
+	}
+
+	target.products = make(map[string]domain.Product, len(products))
+	productIDs := make([]uint64, 0, len(products))
+	for _, product := range products {
+		target.products[product.SKU] = product
+		productIDs = append(productIDs, product.Id)
+	}
+
+	_, target.variants, err = app.repo.GetProductsVariants(ctx, productIDs)
+	if err != nil {
+		return importTarget{}, err
+	}
+
+	return target, nil
+}
+
+// importRow creates product with row's SKU or updates existing one the same way EditProduct does.
+// Dry run imports stop after checks. Returns column whose value caused error, it is empty if whole row failed
+func (app *ShopProductApp) importRow(ctx context.Context, productImport domain.ProductImport, row domain.CatalogRow, target importTarget) (created bool, column string, err error) {
+	if !domain.ValidSKU(row.SKU) {
+		return false, "sku", domain.InvalidSKUError
+	}
+
+	price, err := domain.Money{Amount: row.Price, Currency: row.Currency}.InCurrency(target.shop.Currency)
+	if err != nil {
+		return false, "currency", err
+	}
+
+	if row.CategoryId != 0 && !target.categories[row.CategoryId] {
+		return false, "categoryID", domain.CategoryNotFoundError
+	}
+
+	product, found := target.products[row.SKU]
+	if !found {
+		if row.Title == "" {
+			return false, "title", domain.EmptyTitleError
+		}
+
+		product = domain.Product{
+			SKU:          row.SKU,
+			Title:        row.Title,
+			Description:  row.Description,
+			Price:        price,
+			AssemblyTime: row.AssemblyTime,
+			PartsAmount:  row.PartsAmount,
+			Size:         row.Size,
+			CategoryId:   row.CategoryId,
+			ShopId:       target.shop.Id,
+		}
+		if row.Stock != nil {
+			product.Stock = *row.Stock
+		}
+
+		if productImport.DryRun {
+			return true, "", nil
+		}
+
+		_, err = app.repo.CreateProduct(ctx, product, productImport.UserId)
+		return true, "", err
+	}
+
+	var stockDelta int64
+	if row.Stock != nil && *row.Stock != product.Stock {
+		if len(target.variants[product.Id]) != 0 {
+			return false, "stock", domain.VariantRequiredError
+		}
+		if *row.Stock < product.Reserved {
+			return false, "stock", domain.StockBelowReservedError
+		}
+
+		stockDelta = int64(*row.Stock) - int64(product.Stock)
+	}
+
+	if row.Title != "" {
+		product.Title = row.Title
+	}
+	if row.Description != "" {
+		product.Description = row.Description
+	}
+	if row.Price != 0 {
+		product.Price = price
+	}
+	if row.AssemblyTime != 0 {
+		product.AssemblyTime = row.AssemblyTime
+	}
+	if row.PartsAmount != 0 {
+		product.PartsAmount = row.PartsAmount
+	}
+	if row.Size != "" {
+		product.Size = row.Size
+	}
+	if row.CategoryId != 0 {
+		product.CategoryId = row.CategoryId
+	}
+
+	if productImport.DryRun {
+		return false, "", nil
+	}
+
+	err = app.repo.UpdateProduct(ctx, product, productImport.UserId)
+	if err != nil {
+		return false, "", err
+	}
+
+	if stockDelta != 0 {
+		_, err = app.repo.AdjustStock(ctx, domain.StockAdjustment{
+			ProductId: product.Id,
+			UserId:    productImport.UserId,
+			Delta:     stockDelta,
+			Comment:   domain.ImportStockComment,
+		})
+		if err != nil {
+			return false, "stock", err
+		}
+	}
+
+	return false, "", nil
+}
@@ -0,0 +1,118 @@
+package application
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+)
+
+// GetCategories returns tree of all categories, root categories and children are ordered by position
+func (app *ShopProductApp) GetCategories(ctx context.Context) (categories []domain.Category, err error) {
+	categories, err = app.repo.GetCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.BuildCategoryTree(categories), nil
+}
+
+// CreateCategory creates category, only admins can do it
+func (app *ShopProductApp) CreateCategory(ctx context.Context, category domain.Category, userID uint64) (id uint64, err error) {
+	err = app.checkAdmin(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	err = category.Validate()
+	if err != nil {
+		return 0, err
+	}
+
+	return app.repo.CreateCategory(ctx, category)
+}
+
+// EditCategory replaces category's slug, parent, position and names, only admins can do it
+func (app *ShopProductApp) EditCategory(ctx context.Context, category domain.Category, userID uint64) (err error) {
+	err = app.checkAdmin(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = category.Validate()
+	if err != nil {
+		return err
+	}
+
+	if category.ParentId == category.Id {
+		return domain.CategoryCycleError
+	}
+
+	return app.repo.UpdateCategory(ctx, category)
+}
+
+// DeleteCategory deletes category without subcategories, its products are moved to replacement category.
+// Only admins can do it
+func (app *ShopProductApp) DeleteCategory(ctx context.Context, id uint64, replacementID uint64, userID uint64) (err error) {
+	err = app.checkAdmin(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if replacementID == id {
+		return domain.CategoryNotEmptyError
+	}
+
+	return app.repo.DeleteCategory(ctx, id, replacementID)
+}
+
+// ListProductsByCategory returns page of products of category and its descendants and cursor of next page,
+// which is empty if this page is the last one
+func (app *ShopProductApp) ListProductsByCategory(ctx context.Context, categoryID uint64, page domain.ProductsPage, currency string) (products []domain.Product, nextCursor string, err error) {
+	products, err = app.repo.ListProductsByCategory(ctx, categoryID, page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(products) == 0 {
+		err = app.checkCategory(ctx, categoryID) // Empty category and missing category should be distinguished
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	products, nextCursor = cutPage(products, page)
+	err = app.applyPricing(ctx, products, currency)
+	if err != nil {
+		return nil, "", err
+	}
+	return products, nextCursor, nil
+}
+
+// checkCategory returns CategoryNotFoundError if there is no category with such id
+func (app *ShopProductApp) checkCategory(ctx context.Context, categoryID uint64) (err error) {
+	categories, err := app.repo.GetCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, category := range categories {
+		if category.Id == categoryID {
+			return nil
+		}
+	}
+
+	return domain.CategoryNotFoundError
+}
+
+// checkAdmin returns NotAdminError if user can not edit categories
+func (app *ShopProductApp) checkAdmin(ctx context.Context, userID uint64) (err error) {
+	isAdmin, err := app.repo.IsAdmin(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !isAdmin {
+		return domain.NotAdminError
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"pinterest/services/shopProduct/domain"
+	"time"
+)
+
+// exchangeRatesFile is format of file with exchange rates, rates may be written either as strings or as numbers
+type exchangeRatesFile struct {
+	Base  string `json:"base"`
+	Rates []struct {
+		Currency string      `json:"currency"`
+		Rate     json.Number `json:"rate"`
+		Rounding string      `json:"rounding"`
+	} `json:"rates"`
+}
+
+// GetExchangeRates returns current exchange rates
+func (app *ShopProductApp) GetExchangeRates(ctx context.Context) (rates domain.ExchangeRates, err error) {
+	dbRates, err := app.repo.GetExchangeRates(ctx)
+	if err != nil {
+		return domain.ExchangeRates{}, err
+	}
+
+	return domain.NewExchangeRates(domain.DefaultCurrency, dbRates)
+}
+
+// UpdateExchangeRates replaces all exchange rates, only administrators can do it
+func (app *ShopProductApp) UpdateExchangeRates(ctx context.Context, base string, rates []domain.ExchangeRate, userID uint64) (err error) {
+	err = app.checkAdmin(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return app.replaceExchangeRates(ctx, base, rates)
+}
+
+// LoadExchangeRatesFile replaces all exchange rates with ones from JSON file, it is used to update rates
+// without administrator
+func (app *ShopProductApp) LoadExchangeRatesFile(ctx context.Context, path string) (err error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	file := new(exchangeRatesFile)
+	err = json.Unmarshal(data, file)
+	if err != nil {
+		return err
+	}
+
+	rates := make([]domain.ExchangeRate, 0, len(file.Rates))
+	for _, rate := range file.Rates {
+		rates = append(rates, domain.ExchangeRate{
+			Currency: rate.Currency,
+			Rate:     rate.Rate.String(),
+			Rounding: rate.Rounding,
+		})
+	}
+
+	return app.replaceExchangeRates(ctx, file.Base, rates)
+}
+
+func (app *ShopProductApp) replaceExchangeRates(ctx context.Context, base string, rates []domain.ExchangeRate) (err error) {
+	exchangeRates, err := domain.NewExchangeRates(base, rates)
+	if err != nil {
+		return err
+	}
+
+	return app.repo.ReplaceExchangeRates(ctx, exchangeRates.Rates, time.Now())
+}
+
+// displayRates returns exchange rates which can convert prices into currency which buyer prefers.
+// Empty currency means that prices are not converted, then empty rates are returned
+func (app *ShopProductApp) displayRates(ctx context.Context, currency string) (rates domain.ExchangeRates, err error) {
+	if currency == "" {
+		return domain.ExchangeRates{}, nil
+	}
+
+	if !domain.ValidCurrency(currency) {
+		return domain.ExchangeRates{}, domain.InvalidCurrencyError
+	}
+
+	rates, err = app.GetExchangeRates(ctx)
+	if err != nil {
+		return domain.ExchangeRates{}, err
+	}
+
+	if !rates.Has(currency) {
+		return domain.ExchangeRates{}, domain.ExchangeRateNotFoundError
+	}
+
+	return rates, nil
+}
+
+// convertCart sets converted prices of cart if buyer prefers some currency
+func (app *ShopProductApp) convertCart(ctx context.Context, cart domain.Cart, currency string) (domain.Cart, error) {
+	rates, err := app.displayRates(ctx, currency)
+	if err != nil {
+		return domain.Cart{}, err
+	}
+
+	if currency == "" {
+		return cart, nil
+	}
+
+	return rates.ConvertCart(cart, currency), nil
+}
@@ -0,0 +1,167 @@
+package application
+
+import (
+	"context"
+	orderpb "pinterest/services/order/proto"
+	"pinterest/services/shopProduct/domain"
+	"time"
+)
+
+// DeleteProduct hides product from listings, search and feeds, only managers of product's shop can do it.
+// Product keeps its images and can be restored during domain.DeletedRetention
+func (app *ShopProductApp) DeleteProduct(ctx context.Context, id uint64, userID uint64) (err error) {
+	_, err = app.checkProductManager(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	return app.repo.DeleteProduct(ctx, id, time.Now())
+}
+
+// PurgeProduct permanently deletes product together with its image files, only managers of product's shop can do it.
+// It rolls back creation of product whose images could not be uploaded, such product should not be restorable
+func (app *ShopProductApp) PurgeProduct(ctx context.Context, id uint64, userID uint64) (err error) {
+	_, err = app.checkProductManager(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	images, err := app.repo.PurgeProduct(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	for _, image := range images {
+		app.removeImageFiles(image)
+	}
+	return nil
+}
+
+// RestoreProduct restores product deleted less than domain.DeletedRetention ago. Products of deleted shop
+// can not be restored one by one, they are restored together with shop
+func (app *ShopProductApp) RestoreProduct(ctx context.Context, id uint64, userID uint64) (err error) {
+	product, err := app.repo.GetDeletedProduct(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Managers of deleted shop are not found by checkManager, so they are checked here
+	shop, err := app.repo.GetDeletedShop(ctx, product.ShopId)
+	switch err {
+	case nil:
+		for _, managerID := range shop.ManagerIDs {
+			if managerID == userID {
+				return domain.ShopDeletedError
+			}
+		}
+		return domain.NotShopManagerError
+	case domain.ShopNotFoundError:
+	default:
+		return err
+	}
+
+	err = app.checkManager(ctx, product.ShopId, userID)
+	if err != nil {
+		return err
+	}
+
+	if !domain.IsRestorable(product.DeletedAt, time.Now()) {
+		return domain.RestoreExpiredError
+	}
+
+	return app.repo.RestoreProduct(ctx, id)
+}
+
+// ListDeletedProducts returns shop's products which can still be restored, only shop's managers can see them
+func (app *ShopProductApp) ListDeletedProducts(ctx context.Context, shopID uint64, userID uint64) (products []domain.Product, err error) {
+	err = app.checkManager(ctx, shopID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.repo.ListDeletedProducts(ctx, shopID, time.Now().Add(-domain.DeletedRetention))
+}
+
+// DeleteShop deletes shop together with its products, only shop's owners can do it
+func (app *ShopProductApp) DeleteShop(ctx context.Context, shopID uint64, userID uint64) (err error) {
+	err = app.checkOwner(ctx, shopID, userID)
+	if err != nil {
+		return err
+	}
+
+	return app.repo.DeleteShop(ctx, shopID, time.Now())
+}
+
+// GetShopIncludingDeleted returns shop even if it is deleted but not purged yet. Orders of deleted shops still have
+// to be seen and handled by its managers
+func (app *ShopProductApp) GetShopIncludingDeleted(ctx context.Context, id uint64) (shop domain.Shop, err error) {
+	shop, err = app.repo.GetShop(ctx, id)
+	if err != domain.ShopNotFoundError {
+		return shop, err
+	}
+
+	return app.repo.GetDeletedShop(ctx, id)
+}
+
+// RestoreShop restores shop deleted less than domain.DeletedRetention ago together with products which were
+// deleted with it. Only shop's owners can do it
+func (app *ShopProductApp) RestoreShop(ctx context.Context, shopID uint64, userID uint64) (err error) {
+	shop, err := app.repo.GetDeletedShop(ctx, shopID)
+	if err != nil {
+		return err
+	}
+
+	isOwner := false
+	for _, ownerID := range shop.OwnerIDs {
+		if ownerID == userID {
+			isOwner = true
+			break
+		}
+	}
+	if !isOwner {
+		return domain.NotShopOwnerError
+	}
+
+	if !domain.IsRestorable(shop.DeletedAt, time.Now()) {
+		return domain.RestoreExpiredError
+	}
+
+	return app.repo.RestoreShop(ctx, shopID)
+}
+
+// PurgeDeleted permanently deletes shops and products whose retention has expired, together with image files.
+// Shops with orders are kept, as orders reference them, but they stay deleted
+func (app *ShopProductApp) PurgeDeleted(ctx context.Context) (err error) {
+	before := time.Now().Add(-domain.DeletedRetention)
+	shopIDs, err := app.repo.ListDeletedShops(ctx, before)
+	if err != nil {
+		return err
+	}
+
+	shopsWithOrders, err := app.orderClient.ListShopsWithOrders(ctx, &orderpb.ShopsRequest{ShopIds: shopIDs})
+	if err != nil {
+		return err
+	}
+
+	hasOrders := make(map[uint64]bool, len(shopsWithOrders.GetShopIds()))
+	for _, shopID := range shopsWithOrders.GetShopIds() {
+		hasOrders[shopID] = true
+	}
+
+	purgedShopIDs := make([]uint64, 0, len(shopIDs))
+	for _, shopID := range shopIDs {
+		if !hasOrders[shopID] {
+			purgedShopIDs = append(purgedShopIDs, shopID)
+		}
+	}
+
+	images, err := app.repo.PurgeDeleted(ctx, before, purgedShopIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, image := range images {
+		app.removeImageFiles(image)
+	}
+	return nil
+}
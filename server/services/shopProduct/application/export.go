@@ -0,0 +1,12 @@
+package application
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+)
+
+// ExportUserData collects user's shops, products, reviews, carts and everything else kept by this service
+// for user's data export
+func (app *ShopProductApp) ExportUserData(ctx context.Context, userID uint64) (sections []domain.ExportSection, err error) {
+	return app.repo.CollectUserData(ctx, userID)
+}
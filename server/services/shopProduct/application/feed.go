@@ -0,0 +1,59 @@
+package application
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+)
+
+// GetFeed returns page of user's feed and cursor of next page, which is empty if this page is the last one.
+// First page creates feed snapshot, following pages are read from it, so products are never repeated.
+// Anonymous users (userID is 0) get most popular products
+func (app *ShopProductApp) GetFeed(ctx context.Context, userID uint64, limit uint64, cursor string, currency string) (products []domain.Product, nextCursor string, err error) {
+	if limit == 0 || limit > domain.MaxProductsPageSize {
+		limit = domain.MaxProductsPageSize
+	}
+
+	var feedCursor domain.FeedCursor
+	if cursor != "" {
+		feedCursor, err = domain.DecodeFeedCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+	} else {
+		feedCursor.FeedId, err = app.repo.CreateFeed(ctx, userID)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	products, positions, err := app.repo.GetFeedPage(ctx, feedCursor, userID, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if uint64(len(products)) > limit {
+		products = products[:limit]
+		feedCursor.Position = positions[limit-1]
+		nextCursor = domain.EncodeFeedCursor(feedCursor)
+	}
+
+	err = app.applyPricing(ctx, products, currency)
+	if err != nil {
+		return nil, "", err
+	}
+	return products, nextCursor, nil
+}
+
+// FollowShop makes products of shop preferred in user's feed, following shop twice is not an error
+func (app *ShopProductApp) FollowShop(ctx context.Context, shopID uint64, userID uint64) (err error) {
+	return app.repo.FollowShop(ctx, shopID, userID)
+}
+
+func (app *ShopProductApp) UnfollowShop(ctx context.Context, shopID uint64, userID uint64) (err error) {
+	return app.repo.UnfollowShop(ctx, shopID, userID)
+}
+
+// PurgeExpiredFeeds deletes feed snapshots older than domain.FeedLifetimeHours
+func (app *ShopProductApp) PurgeExpiredFeeds(ctx context.Context) (err error) {
+	return app.repo.DeleteExpiredFeeds(ctx)
+}
@@ -0,0 +1,142 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"pinterest/services/shopProduct/domain"
+)
+
+// UploadProductImage validates image, saves its renditions and adds it to the end of product's gallery.
+// Only managers of product's shop can do it
+func (app *ShopProductApp) UploadProductImage(ctx context.Context, productID uint64, userID uint64, data []byte) (image domain.ProductImage, err error) {
+	_, err = app.checkProductManager(ctx, productID, userID)
+	if err != nil {
+		return domain.ProductImage{}, err
+	}
+
+	images, err := app.repo.GetProductImages(ctx, productID)
+	if err != nil {
+		return domain.ProductImage{}, err
+	}
+	if len(images) >= domain.MaxProductImages {
+		return domain.ProductImage{}, domain.TooManyImagesError
+	}
+
+	rendered, err := renderImage(data)
+	if err != nil {
+		return domain.ProductImage{}, err
+	}
+
+	links, err := app.saveRenditions(productID, rendered)
+	if err != nil {
+		return domain.ProductImage{}, err
+	}
+
+	image = domain.ProductImage{
+		ProductId:     productID,
+		ThumbnailLink: links["thumbnail"],
+		MediumLink:    links["medium"],
+		LargeLink:     links["large"],
+		Height:        rendered.height,
+		Width:         rendered.width,
+		AvgColor:      rendered.avgColor,
+	}
+
+	image.Id, err = app.repo.AddProductImage(ctx, image)
+	if err != nil {
+		app.removeImageFiles(image)
+		return domain.ProductImage{}, err
+	}
+
+	return image, nil
+}
+
+func (app *ShopProductApp) GetProductImages(ctx context.Context, productID uint64) (images []domain.ProductImage, err error) {
+	return app.repo.GetProductImages(ctx, productID)
+}
+
+// ReorderProductImages changes order of product's images, imageIDs must contain all of them.
+// Primary image is still shown first
+func (app *ShopProductApp) ReorderProductImages(ctx context.Context, productID uint64, userID uint64, imageIDs []uint64) (err error) {
+	_, err = app.checkProductManager(ctx, productID, userID)
+	if err != nil {
+		return err
+	}
+
+	return app.repo.ReorderProductImages(ctx, productID, imageIDs)
+}
+
+func (app *ShopProductApp) SetPrimaryProductImage(ctx context.Context, productID uint64, imageID uint64, userID uint64) (err error) {
+	_, err = app.checkProductManager(ctx, productID, userID)
+	if err != nil {
+		return err
+	}
+
+	return app.repo.SetPrimaryProductImage(ctx, productID, imageID)
+}
+
+// DeleteProductImage removes image from product's gallery and deletes its files
+func (app *ShopProductApp) DeleteProductImage(ctx context.Context, productID uint64, imageID uint64, userID uint64) (err error) {
+	_, err = app.checkProductManager(ctx, productID, userID)
+	if err != nil {
+		return err
+	}
+
+	image, err := app.repo.DeleteProductImage(ctx, productID, imageID)
+	if err != nil {
+		return err
+	}
+
+	app.removeImageFiles(image)
+	return nil
+}
+
+// saveRenditions writes renditions to media directory and returns their links, keyed by rendition name
+func (app *ShopProductApp) saveRenditions(productID uint64, rendered renderedImage) (links map[string]string, err error) {
+	dir := path.Join(domain.ProductImagesDir, fmt.Sprint(productID))
+	err = os.MkdirAll(filepath.Join(app.mediaDir, filepath.FromSlash(dir)), 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	name := randString(domain.ImageNameLength)
+	links = make(map[string]string, len(rendered.renditions))
+	for renditionName, data := range rendered.renditions {
+		link := path.Join(dir, name+"_"+renditionName+".jpg")
+		err = ioutil.WriteFile(filepath.Join(app.mediaDir, filepath.FromSlash(link)), data, 0644)
+		if err != nil {
+			for _, savedLink := range links {
+				os.Remove(filepath.Join(app.mediaDir, filepath.FromSlash(savedLink)))
+			}
+			return nil, err
+		}
+
+		links[renditionName] = link
+	}
+
+	return links, nil
+}
+
+// removeImageFiles deletes all renditions of image. Files which are already missing are ignored
+func (app *ShopProductApp) removeImageFiles(image domain.ProductImage) {
+	for _, link := range []string{image.ThumbnailLink, image.MediumLink, image.LargeLink} {
+		if link != "" {
+			os.Remove(filepath.Join(app.mediaDir, filepath.FromSlash(link)))
+		}
+	}
+}
+
+func randString(n int) string {
+	const alphanum = "0123456789abcdefghijklmnopqrstuvwxyz"
+	var bytes = make([]byte, n)
+	rand.Read(bytes)
+	for i, b := range bytes {
+		bytes[i] = alphanum[b%byte(len(alphanum))]
+	}
+	return string(bytes)
+}
@@ -0,0 +1,145 @@
+package application
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"pinterest/services/shopProduct/domain"
+
+	_ "image/gif" // Registers decoders which are used by image.Decode
+	_ "image/png"
+)
+
+// renderedImage is uploaded image scaled down to standard renditions and encoded as JPEG
+type renderedImage struct {
+	// renditions are keyed by rendition name
+	renditions map[string][]byte
+	width      uint64
+	height     uint64
+	avgColor   string
+}
+
+// renderImage validates image and creates all renditions of it
+func renderImage(data []byte) (rendered renderedImage, err error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return renderedImage{}, domain.InvalidImageError
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return renderedImage{}, domain.InvalidImageError
+	}
+	if config.Width > domain.MaxImageSide || config.Height > domain.MaxImageSide {
+		return renderedImage{}, domain.ImageTooLargeError
+	}
+
+	original, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return renderedImage{}, domain.InvalidImageError
+	}
+
+	rendered = renderedImage{
+		renditions: make(map[string][]byte, len(domain.ImageRenditions)),
+		width:      uint64(config.Width),
+		height:     uint64(config.Height),
+	}
+
+	current := original
+	var smallest *image.RGBA
+	for _, rendition := range domain.ImageRenditions {
+		smallest = scaleDown(current, rendition.MaxSide)
+		current = smallest
+
+		buffer := new(bytes.Buffer)
+		err = jpeg.Encode(buffer, current, &jpeg.Options{Quality: domain.ImageQuality})
+		if err != nil {
+			return renderedImage{}, err
+		}
+		rendered.renditions[rendition.Name] = buffer.Bytes()
+	}
+
+	// Smallest rendition is averaged from the whole image, so its average colour is the same
+	rendered.avgColor = averageColor(smallest)
+	return rendered, nil
+}
+
+// scaleDown proportionally shrinks image so that it fits into maxSide x maxSide square.
+// Every pixel of result is average of pixels it covers, transparent pixels are put on white background
+func scaleDown(src image.Image, maxSide int) *image.RGBA {
+	bounds := src.Bounds()
+	srcWidth, srcHeight := bounds.Dx(), bounds.Dy()
+
+	width, height := srcWidth, srcHeight
+	if width > maxSide || height > maxSide {
+		if width >= height {
+			width, height = maxSide, srcHeight*maxSide/srcWidth
+		} else {
+			width, height = srcWidth*maxSide/srcHeight, maxSide
+		}
+	}
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		srcY0 := bounds.Min.Y + y*srcHeight/height
+		srcY1 := bounds.Min.Y + (y+1)*srcHeight/height
+		if srcY1 == srcY0 {
+			srcY1++
+		}
+
+		for x := 0; x < width; x++ {
+			srcX0 := bounds.Min.X + x*srcWidth/width
+			srcX1 := bounds.Min.X + (x+1)*srcWidth/width
+			if srcX1 == srcX0 {
+				srcX1++
+			}
+
+			var r, g, b, a, count uint64
+			for srcY := srcY0; srcY < srcY1; srcY++ {
+				for srcX := srcX0; srcX < srcX1; srcX++ {
+					pixelR, pixelG, pixelB, pixelA := src.At(srcX, srcY).RGBA()
+					r += uint64(pixelR)
+					g += uint64(pixelG)
+					b += uint64(pixelB)
+					a += uint64(pixelA)
+					count++
+				}
+			}
+
+			// Colours are alpha-premultiplied, so adding missing alpha puts pixel on white background
+			background := count*0xffff - a
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8((r + background) / count >> 8),
+				G: uint8((g + background) / count >> 8),
+				B: uint8((b + background) / count >> 8),
+				A: 0xff,
+			})
+		}
+	}
+
+	return dst
+}
+
+// averageColor returns average colour of image as hex string, same as imageavgcolor of pins and boards
+func averageColor(img *image.RGBA) string {
+	bounds := img.Bounds()
+
+	var r, g, b, count uint64
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			pixel := img.RGBAAt(x, y)
+			r += uint64(pixel.R)
+			g += uint64(pixel.G)
+			b += uint64(pixel.B)
+			count++
+		}
+	}
+
+	return fmt.Sprintf("%02X%02X%02X", r/count, g/count, b/count)
+}
@@ -0,0 +1,58 @@
+package application
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+)
+
+// InviteShopManager invites user to become shop's manager or owner, only owners can do it.
+// Managers can be invited to become owners
+func (app *ShopProductApp) InviteShopManager(ctx context.Context, invitation domain.ShopInvitation) (id uint64, err error) {
+	if invitation.Role == "" {
+		invitation.Role = domain.RoleManager
+	}
+	if invitation.Role != domain.RoleManager && invitation.Role != domain.RoleOwner {
+		return 0, domain.InvalidRoleError
+	}
+
+	err = app.checkOwner(ctx, invitation.ShopId, invitation.InviterId)
+	if err != nil {
+		return 0, err
+	}
+
+	role, err := app.repo.GetManagerRole(ctx, invitation.ShopId, invitation.InvitedUserId)
+	switch err {
+	case nil:
+		if role == domain.RoleOwner || role == invitation.Role {
+			return 0, domain.AlreadyManagerError
+		}
+	case domain.NotShopManagerError:
+		break
+	default:
+		return 0, err
+	}
+
+	return app.repo.CreateInvitation(ctx, invitation)
+}
+
+// RemoveShopManager removes manager or owner from shop, only owners can do it. Last owner can not be removed
+func (app *ShopProductApp) RemoveShopManager(ctx context.Context, shopID uint64, userID uint64, managerID uint64) (err error) {
+	err = app.checkOwner(ctx, shopID, userID)
+	if err != nil {
+		return err
+	}
+
+	return app.repo.RemoveShopManager(ctx, shopID, managerID)
+}
+
+func (app *ShopProductApp) GetShopInvitations(ctx context.Context, userID uint64) (invitations []domain.ShopInvitation, err error) {
+	return app.repo.GetUserInvitations(ctx, userID)
+}
+
+func (app *ShopProductApp) AcceptShopInvitation(ctx context.Context, id uint64, userID uint64) (err error) {
+	return app.repo.AcceptInvitation(ctx, id, userID)
+}
+
+func (app *ShopProductApp) DeclineShopInvitation(ctx context.Context, id uint64, userID uint64) (err error) {
+	return app.repo.DeleteInvitation(ctx, id, userID)
+}
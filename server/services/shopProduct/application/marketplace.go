@@ -0,0 +1,151 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"pinterest/services/shopProduct/domain"
+	"time"
+)
+
+// GetMarketplaceFeed returns feed of shop's products in format, shopID 0 means feed of the whole catalog.
+// Cached feed is returned while its products do not change, otherwise only changed products are rendered again
+func (app *ShopProductApp) GetMarketplaceFeed(ctx context.Context, shopID uint64, format string) (document []byte, err error) {
+	if !domain.ValidFeedFormat(format) {
+		return nil, domain.InvalidFeedFormatError
+	}
+
+	if shopID != 0 {
+		_, err = app.repo.GetShop(ctx, shopID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	feed, err := app.refreshMarketplaceFeed(ctx, shopID, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return feed.Document, nil
+}
+
+// RefreshMarketplaceFeeds brings feeds which were requested before up to date, so that marketplaces
+// get them without waiting for changed products to be rendered
+func (app *ShopProductApp) RefreshMarketplaceFeeds(ctx context.Context) (err error) {
+	feeds, err := app.repo.ListMarketplaceFeeds(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, feed := range feeds {
+		_, err = app.refreshMarketplaceFeed(ctx, feed.ShopId, feed.Format)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// refreshMarketplaceFeed returns cached feed if it is up to date. Otherwise it renders offers of changed products
+// and assembles feed from them and from offers rendered before
+func (app *ShopProductApp) refreshMarketplaceFeed(ctx context.Context, shopID uint64, format string) (feed domain.MarketplaceFeed, err error) {
+	now := time.Now()
+
+	feed, err = app.repo.GetMarketplaceFeed(ctx, shopID, format)
+	if err != nil && err != domain.FeedNotFoundError {
+		return domain.MarketplaceFeed{}, err
+	}
+	isCached := err == nil
+
+	staleProducts, err := app.repo.GetStaleFeedProducts(ctx, shopID, format, now)
+	if err != nil {
+		return domain.MarketplaceFeed{}, err
+	}
+
+	scope, err := app.repo.GetFeedScope(ctx, shopID)
+	if err != nil {
+		return domain.MarketplaceFeed{}, err
+	}
+
+	if isCached && len(staleProducts) == 0 && scope.ProductsCount == feed.OffersCount && now.Sub(feed.GeneratedAt) < domain.FeedMaxAge {
+		return feed, nil
+	}
+
+	err = app.applyPricing(ctx, staleProducts, "")
+	if err != nil {
+		return domain.MarketplaceFeed{}, err
+	}
+
+	offers := make([]domain.FeedOffer, 0, len(staleProducts))
+	for _, product := range staleProducts {
+		offer, err := domain.RenderFeedOffer(format, product, app.siteURL)
+		if err != nil {
+			return domain.MarketplaceFeed{}, err
+		}
+
+		offers = append(offers, offer)
+	}
+
+	err = app.repo.SaveFeedOffers(ctx, offers, now)
+	if err != nil {
+		return domain.MarketplaceFeed{}, err
+	}
+
+	fragments, err := app.repo.ListFeedOffers(ctx, shopID, format)
+	if err != nil {
+		return domain.MarketplaceFeed{}, err
+	}
+
+	site, err := app.feedSite(ctx, shopID)
+	if err != nil {
+		return domain.MarketplaceFeed{}, err
+	}
+
+	categories, err := app.repo.GetCategories(ctx)
+	if err != nil {
+		return domain.MarketplaceFeed{}, err
+	}
+
+	rates, err := app.GetExchangeRates(ctx)
+	if err != nil {
+		return domain.MarketplaceFeed{}, err
+	}
+
+	document, err := domain.AssembleFeed(format, site, fragments, categories, scope, rates, now)
+	if err != nil {
+		return domain.MarketplaceFeed{}, err
+	}
+
+	feed = domain.MarketplaceFeed{
+		ShopId:      shopID,
+		Format:      format,
+		Document:    document,
+		OffersCount: uint64(len(fragments)),
+		GeneratedAt: now,
+	}
+
+	err = app.repo.SaveMarketplaceFeed(ctx, feed)
+	if err != nil {
+		return domain.MarketplaceFeed{}, err
+	}
+	return feed, nil
+}
+
+// feedSite describes shop in its feed, feed of the whole catalog describes site itself
+func (app *ShopProductApp) feedSite(ctx context.Context, shopID uint64) (site domain.FeedSite, err error) {
+	if shopID == 0 {
+		return domain.FeedSite{Name: domain.FeedSiteName, URL: app.siteURL}, nil
+	}
+
+	shop, err := app.repo.GetShop(ctx, shopID)
+	if err != nil {
+		return domain.FeedSite{}, err
+	}
+
+	return domain.FeedSite{
+		Name:        shop.Title,
+		Description: shop.Description,
+		URL:         app.siteURL + fmt.Sprintf(domain.ShopPagePath, shopID),
+	}, nil
+}
@@ -0,0 +1,210 @@
+package application
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+	"time"
+)
+
+// applyPricing sets discounted prices of products and of their variants according to sales and discounts active now,
+// and converts prices into currency which buyer prefers unless it is empty. Products must not have selected variants yet
+func (app *ShopProductApp) applyPricing(ctx context.Context, products []domain.Product, currency string) (err error) {
+	rates, err := app.displayRates(ctx, currency)
+	if err != nil {
+		return err
+	}
+
+	if len(products) == 0 {
+		return nil
+	}
+
+	productIDs := make([]uint64, 0, len(products))
+	shopIDs := make([]uint64, 0)
+	seenShops := make(map[uint64]bool)
+	for _, product := range products {
+		productIDs = append(productIDs, product.Id)
+		if !seenShops[product.ShopId] {
+			seenShops[product.ShopId] = true
+			shopIDs = append(shopIDs, product.ShopId)
+		}
+	}
+
+	pricing, err := app.repo.GetActivePricing(ctx, productIDs, shopIDs, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for i := range products {
+		products[i] = pricing.Apply(products[i])
+		if currency != "" {
+			products[i] = rates.ConvertProduct(products[i], currency)
+		}
+	}
+	return nil
+}
+
+// CreateProductSale schedules sale of product or of its variant, sale without start starts now.
+// Only managers of product's shop can do it
+func (app *ShopProductApp) CreateProductSale(ctx context.Context, sale domain.ProductSale, userID uint64) (id uint64, err error) {
+	now := time.Now()
+	if sale.StartsAt.IsZero() {
+		sale.StartsAt = now
+	}
+
+	err = sale.Validate(now)
+	if err != nil {
+		return 0, err
+	}
+
+	product, err := app.checkProductManager(ctx, sale.ProductId, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	sale.SalePrice, err = sale.SalePrice.InCurrency(product.Price.Currency)
+	if err != nil {
+		return 0, err
+	}
+
+	return app.repo.CreateSale(ctx, sale, userID)
+}
+
+// ListProductSales returns active and scheduled sales of product, only managers of product's shop can see them
+func (app *ShopProductApp) ListProductSales(ctx context.Context, productID uint64, userID uint64) (sales []domain.ProductSale, err error) {
+	_, err = app.checkProductManager(ctx, productID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.repo.ListSales(ctx, productID, time.Now())
+}
+
+// DeleteProductSale cancels sale of product, only managers of product's shop can do it
+func (app *ShopProductApp) DeleteProductSale(ctx context.Context, productID uint64, saleID uint64, userID uint64) (err error) {
+	_, err = app.checkProductManager(ctx, productID, userID)
+	if err != nil {
+		return err
+	}
+
+	return app.repo.DeleteSale(ctx, productID, saleID, userID)
+}
+
+// CreateShopDiscount schedules percentage discount of all shop's products, discount without start starts now.
+// Only shop's managers can do it
+func (app *ShopProductApp) CreateShopDiscount(ctx context.Context, discount domain.ShopDiscount, userID uint64) (id uint64, err error) {
+	now := time.Now()
+	if discount.StartsAt.IsZero() {
+		discount.StartsAt = now
+	}
+
+	err = discount.Validate(now)
+	if err != nil {
+		return 0, err
+	}
+
+	err = app.checkManager(ctx, discount.ShopId, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return app.repo.CreateShopDiscount(ctx, discount, userID)
+}
+
+// ListShopDiscounts returns active and scheduled discounts of shop, only shop's managers can see them
+func (app *ShopProductApp) ListShopDiscounts(ctx context.Context, shopID uint64, userID uint64) (discounts []domain.ShopDiscount, err error) {
+	err = app.checkManager(ctx, shopID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.repo.ListShopDiscounts(ctx, shopID, time.Now())
+}
+
+// DeleteShopDiscount cancels discount of shop, only shop's managers can do it
+func (app *ShopProductApp) DeleteShopDiscount(ctx context.Context, shopID uint64, discountID uint64, userID uint64) (err error) {
+	err = app.checkManager(ctx, shopID, userID)
+	if err != nil {
+		return err
+	}
+
+	return app.repo.DeleteShopDiscount(ctx, shopID, discountID, userID)
+}
+
+// CreatePromoCode creates promo code of shop, only shop's managers can do it
+func (app *ShopProductApp) CreatePromoCode(ctx context.Context, promoCode domain.PromoCode, userID uint64) (id uint64, err error) {
+	promoCode.Code = domain.NormalizePromoCode(promoCode.Code)
+	err = promoCode.Validate(time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	err = app.checkManager(ctx, promoCode.ShopId, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	shop, err := app.repo.GetShop(ctx, promoCode.ShopId)
+	if err != nil {
+		return 0, err
+	}
+	promoCode.Currency = shop.Currency
+
+	return app.repo.CreatePromoCode(ctx, promoCode)
+}
+
+// ListPromoCodes returns active promo codes of shop with their usage, only shop's managers can see them
+func (app *ShopProductApp) ListPromoCodes(ctx context.Context, shopID uint64, userID uint64) (promoCodes []domain.PromoCode, err error) {
+	err = app.checkManager(ctx, shopID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.repo.ListPromoCodes(ctx, shopID)
+}
+
+// DeletePromoCode deactivates promo code, orders which already used it keep their discount.
+// Only shop's managers can do it
+func (app *ShopProductApp) DeletePromoCode(ctx context.Context, shopID uint64, promoCodeID uint64, userID uint64) (err error) {
+	err = app.checkManager(ctx, shopID, userID)
+	if err != nil {
+		return err
+	}
+
+	return app.repo.DeletePromoCode(ctx, shopID, promoCodeID)
+}
+
+// RedeemPromoCode applies shop's promo code to order of user with subtotal and counts its use.
+// Subtotal must be in currency of promo code. It is called by order service at checkout
+func (app *ShopProductApp) RedeemPromoCode(ctx context.Context, code string, shopID uint64, userID uint64, subtotal domain.Money) (redemption domain.PromoRedemption, err error) {
+	code = domain.NormalizePromoCode(code)
+	if code == "" {
+		return domain.PromoRedemption{}, domain.PromoCodeNotFoundError
+	}
+
+	return app.repo.RedeemPromoCode(ctx, code, shopID, userID, subtotal, time.Now())
+}
+
+// ReleasePromoRedemption returns use of promo code back when order is not paid
+func (app *ShopProductApp) ReleasePromoRedemption(ctx context.Context, redemptionID uint64) (err error) {
+	return app.repo.ReleasePromoRedemption(ctx, redemptionID, time.Now())
+}
+
+// ListPriceHistory returns page of product's price changes, including discounts of its shop,
+// and cursor of next page, which is empty if this page is the last one
+func (app *ShopProductApp) ListPriceHistory(ctx context.Context, productID uint64, page domain.PriceHistoryPage) (changes []domain.PriceChange, nextCursor string, err error) {
+	_, err = app.repo.GetProduct(ctx, productID) // Product without history and missing product should be distinguished
+	if err != nil {
+		return nil, "", err
+	}
+
+	changes, err = app.repo.ListPriceHistory(ctx, productID, page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if uint64(len(changes)) > page.Limit {
+		changes = changes[:page.Limit]
+		nextCursor = page.NextCursor(changes[len(changes)-1])
+	}
+	return changes, nextCursor, nil
+}
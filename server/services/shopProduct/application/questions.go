@@ -0,0 +1,167 @@
+package application
+
+import (
+	"context"
+	orderpb "pinterest/services/order/proto"
+	"pinterest/services/shopProduct/domain"
+)
+
+// AskQuestion creates question about product, anyone who is logged in can ask unless they and manager of
+// product's shop have blocked one another
+func (app *ShopProductApp) AskQuestion(ctx context.Context, question domain.ProductQuestion) (id uint64, err error) {
+	err = domain.ValidateQuestionText(question.Text, domain.MaxQuestionLength)
+	if err != nil {
+		return 0, err
+	}
+
+	product, err := app.repo.GetProduct(ctx, question.ProductId)
+	if err != nil {
+		return 0, err
+	}
+
+	err = app.checkNotBlockedByShop(ctx, product.ShopId, question.UserId)
+	if err != nil {
+		return 0, err
+	}
+
+	return app.repo.CreateQuestion(ctx, question)
+}
+
+// AnswerQuestion creates answer to question. Managers of product's shop answer as managers and users who have
+// received product answer as buyers, nobody else can answer. Nobody can answer question of user whom they have
+// blocked or who has blocked them, buyers also can not answer if they and shop's managers have blocked one another
+func (app *ShopProductApp) AnswerQuestion(ctx context.Context, answer domain.ProductAnswer) (id uint64, err error) {
+	err = domain.ValidateQuestionText(answer.Text, domain.MaxAnswerLength)
+	if err != nil {
+		return 0, err
+	}
+
+	question, err := app.repo.GetQuestion(ctx, answer.QuestionId)
+	if err != nil {
+		return 0, err
+	}
+
+	product, err := app.repo.GetProduct(ctx, question.ProductId)
+	if err != nil {
+		return 0, err
+	}
+
+	err = app.checkManager(ctx, product.ShopId, answer.UserId)
+	switch err {
+	case nil:
+		err = app.checkNotBlocked(ctx, answer.UserId, []uint64{question.UserId})
+		if err != nil {
+			return 0, err
+		}
+		answer.AuthorRole = domain.AnswerByManager
+	case domain.NotShopManagerError:
+		purchase, err := app.orderClient.HasReceivedProduct(ctx, &orderpb.ProductBuyerRequest{
+			UserId:    answer.UserId,
+			ProductId: product.Id,
+		})
+		if err != nil {
+			return 0, err
+		}
+		if !purchase.GetHasReceived() {
+			return 0, domain.NotAllowedToAnswerError
+		}
+
+		err = app.checkNotBlockedByShop(ctx, product.ShopId, answer.UserId, question.UserId)
+		if err != nil {
+			return 0, err
+		}
+		answer.AuthorRole = domain.AnswerByBuyer
+	default:
+		return 0, err
+	}
+
+	return app.repo.CreateAnswer(ctx, answer)
+}
+
+// SetOfficialAnswer marks answer as official answer of its question or removes the mark,
+// only managers of product's shop can do it. Answers of buyers can be marked too
+func (app *ShopProductApp) SetOfficialAnswer(ctx context.Context, answerID uint64, userID uint64, isOfficial bool) (err error) {
+	answer, err := app.repo.GetAnswer(ctx, answerID)
+	if err != nil {
+		return err
+	}
+
+	question, err := app.repo.GetQuestion(ctx, answer.QuestionId)
+	if err != nil {
+		return err
+	}
+
+	_, err = app.checkProductManager(ctx, question.ProductId, userID)
+	if err != nil {
+		return err
+	}
+
+	return app.repo.SetOfficialAnswer(ctx, answer, isOfficial)
+}
+
+// VoteQuestion counts user's vote for question, every user can vote for question once
+func (app *ShopProductApp) VoteQuestion(ctx context.Context, questionID uint64, userID uint64) (err error) {
+	return app.repo.VoteQuestion(ctx, questionID, userID)
+}
+
+// UnvoteQuestion takes back user's vote for question
+func (app *ShopProductApp) UnvoteQuestion(ctx context.Context, questionID uint64, userID uint64) (err error) {
+	_, err = app.repo.GetQuestion(ctx, questionID)
+	if err != nil {
+		return err
+	}
+
+	return app.repo.UnvoteQuestion(ctx, questionID, userID)
+}
+
+// ListQuestions returns page of product's questions with their answers and cursor of next page, which is empty
+// if this page is the last one. Questions show whether viewer voted for them, viewerID is 0 for anonymous viewers
+func (app *ShopProductApp) ListQuestions(ctx context.Context, productID uint64, page domain.QuestionsPage, viewerID uint64) (questions []domain.ProductQuestion, nextCursor string, err error) {
+	questions, err = app.repo.ListQuestions(ctx, productID, page, viewerID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = app.setQuestionUsernames(ctx, questions)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(questions) == 0 {
+		_, err = app.repo.GetProduct(ctx, productID) // Product without questions and missing product should be distinguished
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	if uint64(len(questions)) <= page.Limit {
+		return questions, "", nil
+	}
+
+	questions = questions[:page.Limit]
+	return questions, page.Sorting.NextCursor(questions[len(questions)-1]), nil
+}
+
+// setQuestionUsernames sets usernames of authors of questions and their answers
+func (app *ShopProductApp) setQuestionUsernames(ctx context.Context, questions []domain.ProductQuestion) (err error) {
+	userIDs := make([]uint64, 0, len(questions))
+	for _, question := range questions {
+		userIDs = append(userIDs, question.UserId)
+		for _, answer := range question.Answers {
+			userIDs = append(userIDs, answer.UserId)
+		}
+	}
+
+	usernames, err := app.getUsernames(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range questions {
+		questions[i].Username = usernames[questions[i].UserId]
+		for j := range questions[i].Answers {
+			questions[i].Answers[j].Username = usernames[questions[i].Answers[j].UserId]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package application
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+)
+
+// GetRelatedProducts returns products similar to product by category, size, parts amount and price, and products
+// which its viewers also viewed. While there are few views products of the same shop and best rated ones are suggested
+func (app *ShopProductApp) GetRelatedProducts(ctx context.Context, productID uint64, limit uint64, currency string) (products []domain.Product, err error) {
+	if limit == 0 {
+		limit = domain.DefaultRelatedProducts
+	}
+	if limit > domain.MaxRelatedProducts {
+		limit = domain.MaxRelatedProducts
+	}
+
+	product, err := app.repo.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err = app.repo.GetRelatedProducts(ctx, product, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = app.applyPricing(ctx, products, currency)
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// RefreshCoViews rebuilds statistics of products viewed by the same users from recent views
+func (app *ShopProductApp) RefreshCoViews(ctx context.Context) (err error) {
+	return app.repo.RefreshCoViews(ctx)
+}
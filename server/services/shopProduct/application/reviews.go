@@ -0,0 +1,101 @@
+package application
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+)
+
+// CreateReview creates user's review of product. Every user can review product only once,
+// managers of product's shop can not review it at all and users who are blocked by them or blocked them can not too
+func (app *ShopProductApp) CreateReview(ctx context.Context, review domain.ProductReview) (id uint64, err error) {
+	err = review.Validate()
+	if err != nil {
+		return 0, err
+	}
+
+	product, err := app.repo.GetProduct(ctx, review.ProductId)
+	if err != nil {
+		return 0, err
+	}
+
+	err = app.checkManager(ctx, product.ShopId, review.UserId)
+	switch err {
+	case nil:
+		return 0, domain.OwnProductReviewError
+	case domain.NotShopManagerError:
+		break
+	default:
+		return 0, err
+	}
+
+	err = app.checkNotBlockedByShop(ctx, product.ShopId, review.UserId)
+	if err != nil {
+		return 0, err
+	}
+
+	return app.repo.CreateReview(ctx, review)
+}
+
+// EditReview changes only fields which were passed, only review's author can do it
+func (app *ShopProductApp) EditReview(ctx context.Context, review domain.ProductReview) (err error) {
+	//TODO: add transactions here?
+	dbReview, err := app.repo.GetReview(ctx, review.Id)
+	if err != nil {
+		return err
+	}
+
+	if dbReview.UserId != review.UserId {
+		return domain.NotReviewAuthorError
+	}
+
+	if review.Title != "" {
+		dbReview.Title = review.Title
+	}
+	if review.Text != "" {
+		dbReview.Text = review.Text
+	}
+	if review.Rating != 0 {
+		dbReview.Rating = review.Rating
+	}
+
+	err = dbReview.Validate()
+	if err != nil {
+		return err
+	}
+
+	return app.repo.UpdateReview(ctx, dbReview)
+}
+
+// ListReviews returns page of product's reviews and cursor of next page, which is empty if this page is the last one
+func (app *ShopProductApp) ListReviews(ctx context.Context, productID uint64, page domain.ReviewsPage) (reviews []domain.ProductReview, nextCursor string, err error) {
+	reviews, err = app.repo.ListReviews(ctx, productID, page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	userIDs := make([]uint64, 0, len(reviews))
+	for _, review := range reviews {
+		userIDs = append(userIDs, review.UserId)
+	}
+	usernames, err := app.getUsernames(ctx, userIDs)
+	if err != nil {
+		return nil, "", err
+	}
+	for i := range reviews {
+		reviews[i].Username = usernames[reviews[i].UserId]
+	}
+
+	if len(reviews) == 0 {
+		_, err = app.repo.GetProduct(ctx, productID) // Product without reviews and missing product should be distinguished
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	if uint64(len(reviews)) <= page.Limit {
+		return reviews, "", nil
+	}
+
+	reviews = reviews[:page.Limit]
+	return reviews, page.Sorting.NextCursor(reviews[len(reviews)-1]), nil
+}
@@ -2,9 +2,533 @@ package application
 
 import (
 	"context"
+	orderpb "pinterest/services/order/proto"
 	"pinterest/services/shopProduct/domain"
+	repository "pinterest/services/shopProduct/infrastructure"
+	userpb "pinterest/services/user/proto"
+	"strings"
+	"time"
 )
 
 type ShopProductAppInterface interface {
-	CreateShop(ctx context.Context, shop domain.Shop) (id uint64, err error)
+	CreateShop(ctx context.Context, shop domain.Shop, userID uint64) (id uint64, err error)
+	EditShop(ctx context.Context, shop domain.Shop, userID uint64) (err error)
+	GetShop(ctx context.Context, id uint64, view domain.PageView) (shop domain.Shop, err error)
+	GetShopIncludingDeleted(ctx context.Context, id uint64) (shop domain.Shop, err error)
+	CreateProduct(ctx context.Context, product domain.Product, userID uint64) (id uint64, err error)
+	EditProduct(ctx context.Context, product domain.Product, userID uint64) (err error)
+	GetProduct(ctx context.Context, id uint64, variantID uint64, view domain.PageView, currency string) (product domain.Product, err error)
+	GetProductsByIDs(ctx context.Context, ids []uint64, currency string) (products []domain.Product, err error)
+	DeleteProduct(ctx context.Context, id uint64, userID uint64) (err error)
+	PurgeProduct(ctx context.Context, id uint64, userID uint64) (err error)
+	ListProductsByShop(ctx context.Context, shopID uint64, page domain.ProductsPage, currency string) (products []domain.Product, nextCursor string, err error)
+	GetCategories(ctx context.Context) (categories []domain.Category, err error)
+	CreateCategory(ctx context.Context, category domain.Category, userID uint64) (id uint64, err error)
+	EditCategory(ctx context.Context, category domain.Category, userID uint64) (err error)
+	DeleteCategory(ctx context.Context, id uint64, replacementID uint64, userID uint64) (err error)
+	ListProductsByCategory(ctx context.Context, categoryID uint64, page domain.ProductsPage, currency string) (products []domain.Product, nextCursor string, err error)
+	GetCart(ctx context.Context, owner domain.CartOwner, currency string) (cart domain.Cart, err error)
+	AddToCart(ctx context.Context, owner domain.CartOwner, productID uint64, variantID uint64, quantity uint64, currency string) (cart domain.Cart, err error)
+	UpdateCartItem(ctx context.Context, owner domain.CartOwner, productID uint64, variantID uint64, quantity uint64, currency string) (cart domain.Cart, err error)
+	RemoveFromCart(ctx context.Context, owner domain.CartOwner, productID uint64, variantID uint64, currency string) (cart domain.Cart, err error)
+	MergeCarts(ctx context.Context, token string, userID uint64) (err error)
+	PurgeAbandonedCarts(ctx context.Context) (err error)
+	SearchProducts(ctx context.Context, filters domain.SearchFilters, page domain.ProductsPage) (result domain.SearchResult, err error)
+	GetFeed(ctx context.Context, userID uint64, limit uint64, cursor string, currency string) (products []domain.Product, nextCursor string, err error)
+	FollowShop(ctx context.Context, shopID uint64, userID uint64) (err error)
+	UnfollowShop(ctx context.Context, shopID uint64, userID uint64) (err error)
+	PurgeExpiredFeeds(ctx context.Context) (err error)
+	AdjustStock(ctx context.Context, adjustment domain.StockAdjustment) (movement domain.InventoryMovement, err error)
+	ReserveStock(ctx context.Context, items []domain.StockItem, ttl time.Duration) (reservation domain.Reservation, err error)
+	GetReservation(ctx context.Context, reservationID uint64) (reservation domain.Reservation, err error)
+	CommitReservation(ctx context.Context, reservationID uint64) (err error)
+	ReleaseReservation(ctx context.Context, reservationID uint64) (err error)
+	ExpireReservations(ctx context.Context) (err error)
+	ListInventoryMovements(ctx context.Context, productID uint64, userID uint64, page domain.MovementsPage) (movements []domain.InventoryMovement, nextCursor string, err error)
+	CreateReview(ctx context.Context, review domain.ProductReview) (id uint64, err error)
+	EditReview(ctx context.Context, review domain.ProductReview) (err error)
+	ListReviews(ctx context.Context, productID uint64, page domain.ReviewsPage) (reviews []domain.ProductReview, nextCursor string, err error)
+	InviteShopManager(ctx context.Context, invitation domain.ShopInvitation) (id uint64, err error)
+	RemoveShopManager(ctx context.Context, shopID uint64, userID uint64, managerID uint64) (err error)
+	GetShopInvitations(ctx context.Context, userID uint64) (invitations []domain.ShopInvitation, err error)
+	AcceptShopInvitation(ctx context.Context, id uint64, userID uint64) (err error)
+	DeclineShopInvitation(ctx context.Context, id uint64, userID uint64) (err error)
+	UploadProductImage(ctx context.Context, productID uint64, userID uint64, data []byte) (image domain.ProductImage, err error)
+	GetProductImages(ctx context.Context, productID uint64) (images []domain.ProductImage, err error)
+	ReorderProductImages(ctx context.Context, productID uint64, userID uint64, imageIDs []uint64) (err error)
+	SetPrimaryProductImage(ctx context.Context, productID uint64, imageID uint64, userID uint64) (err error)
+	DeleteProductImage(ctx context.Context, productID uint64, imageID uint64, userID uint64) (err error)
+	ListWishlists(ctx context.Context, ownerID uint64, viewerID uint64) (wishlists []domain.Wishlist, err error)
+	GetWishlist(ctx context.Context, wishlistID uint64, viewerID uint64, currency string) (wishlist domain.Wishlist, err error)
+	GetSharedWishlist(ctx context.Context, token string, viewerID uint64, currency string) (wishlist domain.Wishlist, err error)
+	CreateWishlist(ctx context.Context, wishlist domain.Wishlist) (id uint64, err error)
+	EditWishlist(ctx context.Context, wishlist domain.Wishlist, userID uint64) (err error)
+	DeleteWishlist(ctx context.Context, wishlistID uint64, userID uint64) (err error)
+	SaveProduct(ctx context.Context, productID uint64, userID uint64, wishlistID uint64) (savedTo uint64, err error)
+	UnsaveProduct(ctx context.Context, productID uint64, userID uint64, wishlistID uint64) (err error)
+	SetProductOptions(ctx context.Context, productID uint64, userID uint64, options []domain.ProductOption) (err error)
+	CreateVariant(ctx context.Context, variant domain.ProductVariant, userID uint64) (id uint64, err error)
+	EditVariant(ctx context.Context, variant domain.ProductVariant, userID uint64) (err error)
+	DeleteVariant(ctx context.Context, productID uint64, variantID uint64, userID uint64) (err error)
+	CreateProductSale(ctx context.Context, sale domain.ProductSale, userID uint64) (id uint64, err error)
+	ListProductSales(ctx context.Context, productID uint64, userID uint64) (sales []domain.ProductSale, err error)
+	DeleteProductSale(ctx context.Context, productID uint64, saleID uint64, userID uint64) (err error)
+	CreateShopDiscount(ctx context.Context, discount domain.ShopDiscount, userID uint64) (id uint64, err error)
+	ListShopDiscounts(ctx context.Context, shopID uint64, userID uint64) (discounts []domain.ShopDiscount, err error)
+	DeleteShopDiscount(ctx context.Context, shopID uint64, discountID uint64, userID uint64) (err error)
+	CreatePromoCode(ctx context.Context, promoCode domain.PromoCode, userID uint64) (id uint64, err error)
+	ListPromoCodes(ctx context.Context, shopID uint64, userID uint64) (promoCodes []domain.PromoCode, err error)
+	DeletePromoCode(ctx context.Context, shopID uint64, promoCodeID uint64, userID uint64) (err error)
+	RedeemPromoCode(ctx context.Context, code string, shopID uint64, userID uint64, subtotal domain.Money) (redemption domain.PromoRedemption, err error)
+	ReleasePromoRedemption(ctx context.Context, redemptionID uint64) (err error)
+	ListPriceHistory(ctx context.Context, productID uint64, page domain.PriceHistoryPage) (changes []domain.PriceChange, nextCursor string, err error)
+	GetExchangeRates(ctx context.Context) (rates domain.ExchangeRates, err error)
+	UpdateExchangeRates(ctx context.Context, base string, rates []domain.ExchangeRate, userID uint64) (err error)
+	LoadExchangeRatesFile(ctx context.Context, path string) (err error)
+	ImportProducts(ctx context.Context, shopID uint64, userID uint64, format string, dryRun bool, data []byte) (productImport domain.ProductImport, err error)
+	GetProductImport(ctx context.Context, shopID uint64, importID uint64, userID uint64) (productImport domain.ProductImport, err error)
+	ExportProducts(ctx context.Context, shopID uint64, userID uint64, format string) (data []byte, err error)
+	GetMarketplaceFeed(ctx context.Context, shopID uint64, format string) (document []byte, err error)
+	RefreshMarketplaceFeeds(ctx context.Context) (err error)
+	DeleteShop(ctx context.Context, shopID uint64, userID uint64) (err error)
+	RestoreShop(ctx context.Context, shopID uint64, userID uint64) (err error)
+	RestoreProduct(ctx context.Context, id uint64, userID uint64) (err error)
+	ListDeletedProducts(ctx context.Context, shopID uint64, userID uint64) (products []domain.Product, err error)
+	PurgeDeleted(ctx context.Context) (err error)
+	GetRelatedProducts(ctx context.Context, productID uint64, limit uint64, currency string) (products []domain.Product, err error)
+	RefreshCoViews(ctx context.Context) (err error)
+	RollupViews(ctx context.Context) (err error)
+	GetShopAnalytics(ctx context.Context, shopID uint64, userID uint64, from string, to string) (analytics domain.ShopAnalytics, err error)
+	AskQuestion(ctx context.Context, question domain.ProductQuestion) (id uint64, err error)
+	AnswerQuestion(ctx context.Context, answer domain.ProductAnswer) (id uint64, err error)
+	SetOfficialAnswer(ctx context.Context, answerID uint64, userID uint64, isOfficial bool) (err error)
+	VoteQuestion(ctx context.Context, questionID uint64, userID uint64) (err error)
+	UnvoteQuestion(ctx context.Context, questionID uint64, userID uint64) (err error)
+	ListQuestions(ctx context.Context, productID uint64, page domain.QuestionsPage, viewerID uint64) (questions []domain.ProductQuestion, nextCursor string, err error)
+	ExportUserData(ctx context.Context, userID uint64) (sections []domain.ExportSection, err error)
+}
+
+// ShopProductApp keeps shops and products, blocks between users are checked by user service
+// and purchases of products are checked by order service
+type ShopProductApp struct {
+	repo        repository.ShopProductRepoInterface
+	userClient  userpb.UserClient
+	orderClient orderpb.OrderServiceClient
+	// mediaDir is the root which image links stored in database are relative to
+	mediaDir string
+	// siteURL is public URL of site without trailing slash, marketplace feeds link to its pages
+	siteURL string
+}
+
+func NewShopProductApp(repo repository.ShopProductRepoInterface, userClient userpb.UserClient, orderClient orderpb.OrderServiceClient,
+	mediaDir string, siteURL string) *ShopProductApp {
+	return &ShopProductApp{
+		repo:        repo,
+		userClient:  userClient,
+		orderClient: orderClient,
+		mediaDir:    mediaDir,
+		siteURL:     strings.TrimSuffix(siteURL, "/"),
+	}
+}
+
+// CreateShop creates shop, user who creates it becomes its owner. Shop without currency gets domain.DefaultCurrency
+func (app *ShopProductApp) CreateShop(ctx context.Context, shop domain.Shop, userID uint64) (id uint64, err error) {
+	if shop.Title == "" {
+		return 0, domain.EmptyTitleError
+	}
+
+	if shop.Currency == "" {
+		shop.Currency = domain.DefaultCurrency
+	}
+	if !domain.ValidCurrency(shop.Currency) {
+		return 0, domain.InvalidCurrencyError
+	}
+
+	return app.repo.CreateShop(ctx, shop, userID)
+}
+
+// EditShop changes only fields which were passed. Only shop's managers can edit it, managers themselves are not changed
+func (app *ShopProductApp) EditShop(ctx context.Context, shop domain.Shop, userID uint64) (err error) {
+	err = app.checkManager(ctx, shop.Id, userID)
+	if err != nil {
+		return err
+	}
+
+	//TODO: add transactions here?
+	dbShop, err := app.repo.GetShop(ctx, shop.Id)
+	if err != nil {
+		return err
+	}
+
+	if shop.Title != "" {
+		dbShop.Title = shop.Title
+	}
+	if shop.Description != "" {
+		dbShop.Description = shop.Description
+	}
+	if shop.Currency != "" {
+		if !domain.ValidCurrency(shop.Currency) {
+			return domain.InvalidCurrencyError
+		}
+		dbShop.Currency = shop.Currency
+	}
+
+	return app.repo.UpdateShop(ctx, dbShop)
+}
+
+// GetShop returns shop and records view of its page if view has session. Other services get shops without session,
+// so their requests are not counted
+func (app *ShopProductApp) GetShop(ctx context.Context, id uint64, view domain.PageView) (shop domain.Shop, err error) {
+	shop, err = app.repo.GetShop(ctx, id)
+	if err != nil {
+		return domain.Shop{}, err
+	}
+
+	if view.Session != "" && domain.ValidViewSession(view.Session) {
+		view.Referrer = domain.ReferrerHost(view.Referrer)
+		err = app.repo.AddShopView(ctx, id, view)
+		if err != nil {
+			return domain.Shop{}, err
+		}
+	}
+	return shop, nil
+}
+
+// CreateProduct creates product in shop with initial stock, only shop's managers can do it.
+// Price must be in shop's currency, price without currency is considered to be in it. SKU is optional
+func (app *ShopProductApp) CreateProduct(ctx context.Context, product domain.Product, userID uint64) (id uint64, err error) {
+	if product.Title == "" {
+		return 0, domain.EmptyTitleError
+	}
+	if product.SKU != "" && !domain.ValidSKU(product.SKU) {
+		return 0, domain.InvalidSKUError
+	}
+
+	err = app.checkManager(ctx, product.ShopId, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	shop, err := app.repo.GetShop(ctx, product.ShopId)
+	if err != nil {
+		return 0, err
+	}
+
+	product.Price, err = product.Price.InCurrency(shop.Currency)
+	if err != nil {
+		return 0, err
+	}
+
+	return app.repo.CreateProduct(ctx, product, userID)
+}
+
+// EditProduct changes only fields which were passed. Rating and availability are never changed,
+// as they are derived from reviews and stock.
+// Only managers of product's shop can edit it. Moving product to another shop requires managing both shops,
+// which must have the same currency
+func (app *ShopProductApp) EditProduct(ctx context.Context, product domain.Product, userID uint64) (err error) {
+	//TODO: add transactions here?
+	dbProduct, err := app.repo.GetProduct(ctx, product.Id)
+	if err != nil {
+		return err
+	}
+
+	err = app.checkManager(ctx, dbProduct.ShopId, userID)
+	if err != nil {
+		return err
+	}
+
+	if product.Title != "" {
+		dbProduct.Title = product.Title
+	}
+	if product.Description != "" {
+		dbProduct.Description = product.Description
+	}
+	if product.SKU != "" {
+		if !domain.ValidSKU(product.SKU) {
+			return domain.InvalidSKUError
+		}
+		dbProduct.SKU = product.SKU
+	}
+	if product.Price.Amount != 0 {
+		dbProduct.Price, err = product.Price.InCurrency(dbProduct.Price.Currency)
+		if err != nil {
+			return err
+		}
+	}
+	if product.AssemblyTime != 0 {
+		dbProduct.AssemblyTime = product.AssemblyTime
+	}
+	if product.PartsAmount != 0 {
+		dbProduct.PartsAmount = product.PartsAmount
+	}
+	if product.Size != "" {
+		dbProduct.Size = product.Size
+	}
+	if product.CategoryId != 0 {
+		dbProduct.CategoryId = product.CategoryId
+	}
+	if product.ShopId != 0 && product.ShopId != dbProduct.ShopId {
+		err = app.checkManager(ctx, product.ShopId, userID)
+		if err != nil {
+			return err
+		}
+
+		shop, err := app.repo.GetShop(ctx, product.ShopId)
+		if err != nil {
+			return err
+		}
+		if shop.Currency != dbProduct.Price.Currency {
+			return domain.CurrencyMismatchError
+		}
+
+		dbProduct.ShopId = product.ShopId
+	}
+
+	return app.repo.UpdateProduct(ctx, dbProduct, userID)
+}
+
+// GetProduct returns product together with its gallery, options, variants and discounted prices and records view
+// by viewer if view has session, it is counted once per view session. Product with variants is returned with price, stock and images
+// of selected variant if variantID is not 0. Product shows whether viewer has saved it.
+// Prices are also converted into currency if it is not empty
+func (app *ShopProductApp) GetProduct(ctx context.Context, id uint64, variantID uint64, view domain.PageView, currency string) (product domain.Product, err error) {
+	product, err = app.repo.GetProduct(ctx, id)
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	options, variants, err := app.repo.GetProductsVariants(ctx, []uint64{id})
+	if err != nil {
+		return domain.Product{}, err
+	}
+	product.Options = options[id]
+	product.Variants = variants[id]
+
+	priced := []domain.Product{product}
+	err = app.applyPricing(ctx, priced, currency)
+	if err != nil {
+		return domain.Product{}, err
+	}
+	product = priced[0]
+
+	var variant domain.ProductVariant
+	if variantID != 0 {
+		variant, err = domain.SelectVariant(product.Variants, variantID)
+		if err != nil {
+			return domain.Product{}, err
+		}
+	}
+
+	if view.Session != "" && domain.ValidViewSession(view.Session) {
+		view.Referrer = domain.ReferrerHost(view.Referrer)
+		err = app.repo.AddProductView(ctx, id, view)
+		if err != nil {
+			return domain.Product{}, err
+		}
+	}
+
+	product.Images, err = app.repo.GetProductImages(ctx, id)
+	if err != nil {
+		return domain.Product{}, err
+	}
+	product = product.WithVariant(variant)
+
+	if view.UserID != 0 {
+		product.IsSaved, err = app.repo.IsProductSaved(ctx, id, view.UserID)
+		if err != nil {
+			return domain.Product{}, err
+		}
+	}
+
+	return product, nil
+}
+
+// GetProductsByIDs returns products with their options and variants, but without images and without counting views,
+// it is used by other services. Products are returned in order of ids, unknown ids are skipped
+func (app *ShopProductApp) GetProductsByIDs(ctx context.Context, ids []uint64, currency string) (products []domain.Product, err error) {
+	if len(ids) == 0 {
+		return []domain.Product{}, app.applyPricing(ctx, nil, currency)
+	}
+
+	products, err = app.repo.GetProductsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	options, variants, err := app.repo.GetProductsVariants(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range products {
+		products[i].Options = options[products[i].Id]
+		products[i].Variants = variants[products[i].Id]
+	}
+
+	err = app.applyPricing(ctx, products, currency)
+	if err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// ListProductsByShop returns page of shop's products and cursor of next page, which is empty if this page is the last one
+func (app *ShopProductApp) ListProductsByShop(ctx context.Context, shopID uint64, page domain.ProductsPage, currency string) (products []domain.Product, nextCursor string, err error) {
+	products, err = app.repo.ListProductsByShop(ctx, shopID, page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(products) == 0 {
+		_, err = app.repo.GetShop(ctx, shopID) // Empty shop and missing shop should be distinguished
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	products, nextCursor = cutPage(products, page)
+	err = app.applyPricing(ctx, products, currency)
+	if err != nil {
+		return nil, "", err
+	}
+	return products, nextCursor, nil
+}
+
+// SearchProducts returns page of products which match search query and filters, together with facets.
+// Empty query matches all products. Price filters and facets are in currency of filters, which is domain.DefaultCurrency
+// if it is empty
+func (app *ShopProductApp) SearchProducts(ctx context.Context, filters domain.SearchFilters, page domain.ProductsPage) (result domain.SearchResult, err error) {
+	if filters.Currency == "" {
+		filters.Currency = domain.DefaultCurrency
+	}
+
+	err = filters.Validate()
+	if err != nil {
+		return domain.SearchResult{}, err
+	}
+
+	result, err = app.repo.SearchProducts(ctx, filters, page)
+	if err != nil {
+		return domain.SearchResult{}, err
+	}
+
+	result.Products, result.NextCursor = cutPage(result.Products, page)
+	err = app.applyPricing(ctx, result.Products, filters.Currency)
+	if err != nil {
+		return domain.SearchResult{}, err
+	}
+	return result, nil
+}
+
+// cutPage removes extra product which repository returns if there is next page, and returns cursor of next page
+func cutPage(products []domain.Product, page domain.ProductsPage) ([]domain.Product, string) {
+	if uint64(len(products)) <= page.Limit {
+		return products, ""
+	}
+
+	products = products[:page.Limit]
+	return products, page.Sorting.NextCursor(products[len(products)-1])
+}
+
+// checkProductManager returns product if user can manage its shop, NotShopManagerError otherwise
+func (app *ShopProductApp) checkProductManager(ctx context.Context, productID uint64, userID uint64) (product domain.Product, err error) {
+	product, err = app.repo.GetProduct(ctx, productID)
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	err = app.checkManager(ctx, product.ShopId, userID)
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	return product, nil
+}
+
+// checkManager returns NotShopManagerError if user can not manage shop
+func (app *ShopProductApp) checkManager(ctx context.Context, shopID uint64, userID uint64) (err error) {
+	_, err = app.repo.GetManagerRole(ctx, shopID, userID)
+	return err
+}
+
+// checkNotBlockedByShop returns BlockedUserError if user and any of shop's managers or of other users have blocked
+// one another in any direction
+func (app *ShopProductApp) checkNotBlockedByShop(ctx context.Context, shopID uint64, userID uint64, otherUserIDs ...uint64) (err error) {
+	shop, err := app.repo.GetShop(ctx, shopID)
+	if err != nil {
+		return err
+	}
+
+	targetIDs := make([]uint64, 0, len(shop.ManagerIDs)+len(otherUserIDs))
+	targetIDs = append(targetIDs, shop.ManagerIDs...)
+	targetIDs = append(targetIDs, otherUserIDs...)
+	return app.checkNotBlocked(ctx, userID, targetIDs)
+}
+
+// checkNotBlocked returns BlockedUserError if user and any of target users have blocked one another in any direction
+func (app *ShopProductApp) checkNotBlocked(ctx context.Context, userID uint64, targetIDs []uint64) (err error) {
+	pairs := make([]*userpb.UserPair, 0, len(targetIDs))
+	for _, targetID := range targetIDs {
+		if targetID != userID {
+			pairs = append(pairs, &userpb.UserPair{UserID: userID, TargetID: targetID})
+		}
+	}
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	result, err := app.userClient.IsBlocked(ctx, &userpb.BlockCheckInput{Pairs: pairs})
+	if err != nil {
+		return err
+	}
+
+	for _, isBlocked := range result.GetBlocked() {
+		if isBlocked {
+			return domain.BlockedUserError
+		}
+	}
+	return nil
+}
+
+// getUsernames gets usernames of users from user service, users are kept there. Deleted users have empty usernames
+func (app *ShopProductApp) getUsernames(ctx context.Context, userIDs []uint64) (usernames map[uint64]string, err error) {
+	usernames = make(map[uint64]string, len(userIDs))
+	uniqueIDs := make([]uint64, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if _, ok := usernames[userID]; !ok {
+			usernames[userID] = ""
+			uniqueIDs = append(uniqueIDs, userID)
+		}
+	}
+	if len(uniqueIDs) == 0 {
+		return usernames, nil
+	}
+
+	result, err := app.userClient.GetUsernames(ctx, &userpb.UserIDs{UserIDs: uniqueIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, username := range result.GetUsernames() {
+		if i < len(uniqueIDs) {
+			usernames[uniqueIDs[i]] = username
+		}
+	}
+	return usernames, nil
+}
+
+// checkOwner returns NotShopOwnerError if user is not shop's owner
+func (app *ShopProductApp) checkOwner(ctx context.Context, shopID uint64, userID uint64) (err error) {
+	role, err := app.repo.GetManagerRole(ctx, shopID, userID)
+	switch {
+	case err == domain.NotShopManagerError:
+		return domain.NotShopOwnerError
+	case err != nil:
+		return err
+	case role != domain.RoleOwner:
+		return domain.NotShopOwnerError
+	}
+
+	return nil
 }
@@ -0,0 +1,72 @@
+package application
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+	"time"
+)
+
+// AdjustStock changes product's stock and records it in inventory ledger, only managers of product's shop can do it
+func (app *ShopProductApp) AdjustStock(ctx context.Context, adjustment domain.StockAdjustment) (movement domain.InventoryMovement, err error) {
+	err = adjustment.Validate()
+	if err != nil {
+		return domain.InventoryMovement{}, err
+	}
+
+	_, err = app.checkProductManager(ctx, adjustment.ProductId, adjustment.UserId)
+	if err != nil {
+		return domain.InventoryMovement{}, err
+	}
+
+	return app.repo.AdjustStock(ctx, adjustment)
+}
+
+// ReserveStock holds stock of all items for ttl, ttl of 0 means default reservation time
+func (app *ShopProductApp) ReserveStock(ctx context.Context, items []domain.StockItem, ttl time.Duration) (reservation domain.Reservation, err error) {
+	err = domain.ValidateStockItems(items)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+
+	return app.repo.ReserveStock(ctx, items, time.Now().Add(domain.ReservationTTL(ttl)))
+}
+
+func (app *ShopProductApp) GetReservation(ctx context.Context, reservationID uint64) (reservation domain.Reservation, err error) {
+	return app.repo.GetReservation(ctx, reservationID)
+}
+
+// CommitReservation removes reserved units from stock, reservation must be active and not expired
+func (app *ShopProductApp) CommitReservation(ctx context.Context, reservationID uint64) (err error) {
+	return app.repo.CommitReservation(ctx, reservationID, time.Now())
+}
+
+// ReleaseReservation returns reserved units back to sale
+func (app *ShopProductApp) ReleaseReservation(ctx context.Context, reservationID uint64) (err error) {
+	return app.repo.ReleaseReservation(ctx, reservationID, time.Now())
+}
+
+// ExpireReservations releases stock of reservations which were neither committed nor released in time
+func (app *ShopProductApp) ExpireReservations(ctx context.Context) (err error) {
+	_, err = app.repo.ExpireReservations(ctx, time.Now())
+	return err
+}
+
+// ListInventoryMovements returns page of product's inventory ledger and cursor of next page,
+// which is empty if this page is the last one. Only managers of product's shop can audit it
+func (app *ShopProductApp) ListInventoryMovements(ctx context.Context, productID uint64, userID uint64, page domain.MovementsPage) (movements []domain.InventoryMovement, nextCursor string, err error) {
+	_, err = app.checkProductManager(ctx, productID, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	movements, err = app.repo.ListInventoryMovements(ctx, productID, page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if uint64(len(movements)) > page.Limit {
+		movements = movements[:page.Limit]
+		nextCursor = page.NextCursor(movements[len(movements)-1])
+	}
+	return movements, nextCursor, nil
+}
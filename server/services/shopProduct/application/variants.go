@@ -0,0 +1,108 @@
+package application
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+)
+
+// SetProductOptions replaces options of product. Existing variants must still have one allowed value of every option,
+// so values which variants use can not be removed. Only managers of product's shop can do it
+func (app *ShopProductApp) SetProductOptions(ctx context.Context, productID uint64, userID uint64, options []domain.ProductOption) (err error) {
+	err = domain.ValidateOptions(options)
+	if err != nil {
+		return err
+	}
+
+	_, err = app.checkProductManager(ctx, productID, userID)
+	if err != nil {
+		return err
+	}
+
+	_, variants, err := app.repo.GetProductsVariants(ctx, []uint64{productID})
+	if err != nil {
+		return err
+	}
+
+	if len(variants[productID]) != 0 && domain.ValidateVariants(options, variants[productID]) != nil {
+		return domain.OptionsInUseError
+	}
+
+	return app.repo.SetProductOptions(ctx, productID, options)
+}
+
+// CreateVariant creates variant of product with initial stock, only managers of product's shop can do it
+func (app *ShopProductApp) CreateVariant(ctx context.Context, variant domain.ProductVariant, userID uint64) (id uint64, err error) {
+	//TODO: add transactions here?
+	variant, err = app.checkVariants(ctx, variant, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return app.repo.CreateVariant(ctx, variant, userID)
+}
+
+// EditVariant replaces SKU, options, price override and images of variant. Stock is changed by AdjustStock
+func (app *ShopProductApp) EditVariant(ctx context.Context, variant domain.ProductVariant, userID uint64) (err error) {
+	variant, err = app.checkVariants(ctx, variant, userID)
+	if err != nil {
+		return err
+	}
+
+	return app.repo.UpdateVariant(ctx, variant, userID)
+}
+
+// DeleteVariant deletes variant which has no reserved stock, only managers of product's shop can do it
+func (app *ShopProductApp) DeleteVariant(ctx context.Context, productID uint64, variantID uint64, userID uint64) (err error) {
+	_, err = app.checkProductManager(ctx, productID, userID)
+	if err != nil {
+		return err
+	}
+
+	return app.repo.DeleteVariant(ctx, productID, variantID, userID)
+}
+
+// checkVariants checks that user manages variant's product, that price override is in currency of product
+// and that product's variants stay valid after variant is created or replaced. Variant is returned with currency
+// of its price override set
+func (app *ShopProductApp) checkVariants(ctx context.Context, variant domain.ProductVariant, userID uint64) (checked domain.ProductVariant, err error) {
+	product, err := app.checkProductManager(ctx, variant.ProductId, userID)
+	if err != nil {
+		return domain.ProductVariant{}, err
+	}
+
+	if variant.PriceOverride.Amount != 0 {
+		variant.PriceOverride, err = variant.PriceOverride.InCurrency(product.Price.Currency)
+		if err != nil {
+			return domain.ProductVariant{}, err
+		}
+	}
+
+	options, variants, err := app.repo.GetProductsVariants(ctx, []uint64{variant.ProductId})
+	if err != nil {
+		return domain.ProductVariant{}, err
+	}
+
+	productVariants := make([]domain.ProductVariant, 0, len(variants[variant.ProductId])+1)
+	replaced := variant.Id == 0
+	for _, productVariant := range variants[variant.ProductId] {
+		if productVariant.Id == variant.Id {
+			productVariant = variant
+			replaced = true
+		}
+		productVariants = append(productVariants, productVariant)
+	}
+
+	if !replaced {
+		return domain.ProductVariant{}, domain.VariantNotFoundError
+	}
+	if variant.Id == 0 {
+		productVariants = append(productVariants, variant)
+	}
+
+	err = domain.ValidateVariants(options[variant.ProductId], productVariants)
+	if err != nil {
+		return domain.ProductVariant{}, err
+	}
+
+	return variant, nil
+}
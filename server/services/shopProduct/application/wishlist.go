@@ -0,0 +1,153 @@
+package application
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+)
+
+// ListWishlists returns wishlists of owner, other users see only public ones
+func (app *ShopProductApp) ListWishlists(ctx context.Context, ownerID uint64, viewerID uint64) (wishlists []domain.Wishlist, err error) {
+	wishlists, err = app.repo.ListWishlists(ctx, ownerID, ownerID != viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range wishlists {
+		wishlists[i] = wishlists[i].HideOwnerFields(viewerID)
+	}
+
+	return wishlists, nil
+}
+
+// GetWishlist returns wishlist with its products, private wishlists of other users are reported as missing
+func (app *ShopProductApp) GetWishlist(ctx context.Context, wishlistID uint64, viewerID uint64, currency string) (wishlist domain.Wishlist, err error) {
+	wishlist, err = app.repo.GetWishlist(ctx, wishlistID)
+	if err != nil {
+		return domain.Wishlist{}, err
+	}
+
+	return app.fillWishlist(ctx, wishlist, viewerID, currency)
+}
+
+// GetSharedWishlist returns wishlist opened by share link, link of private wishlist works only for its owner
+func (app *ShopProductApp) GetSharedWishlist(ctx context.Context, token string, viewerID uint64, currency string) (wishlist domain.Wishlist, err error) {
+	wishlist, err = app.repo.GetWishlistByToken(ctx, token)
+	if err != nil {
+		return domain.Wishlist{}, err
+	}
+
+	return app.fillWishlist(ctx, wishlist, viewerID, currency)
+}
+
+func (app *ShopProductApp) fillWishlist(ctx context.Context, wishlist domain.Wishlist, viewerID uint64, currency string) (domain.Wishlist, error) {
+	if !wishlist.VisibleTo(viewerID) {
+		return domain.Wishlist{}, domain.WishlistNotFoundError
+	}
+
+	products, err := app.repo.GetWishlistProducts(ctx, wishlist.Id)
+	if err != nil {
+		return domain.Wishlist{}, err
+	}
+
+	err = app.applyPricing(ctx, products, currency)
+	if err != nil {
+		return domain.Wishlist{}, err
+	}
+
+	wishlist.Products = products
+	wishlist.ProductsCount = uint64(len(products))
+	return wishlist.HideOwnerFields(viewerID), nil
+}
+
+// CreateWishlist creates wishlist of wishlist.UserId, which is not default one
+func (app *ShopProductApp) CreateWishlist(ctx context.Context, wishlist domain.Wishlist) (id uint64, err error) {
+	err = wishlist.Validate()
+	if err != nil {
+		return 0, err
+	}
+
+	wishlist.ShareToken = randString(domain.ShareTokenLength)
+	return app.repo.CreateWishlist(ctx, wishlist)
+}
+
+// EditWishlist changes title if it was passed and always sets visibility, only owner can edit wishlist
+func (app *ShopProductApp) EditWishlist(ctx context.Context, wishlist domain.Wishlist, userID uint64) (err error) {
+	dbWishlist, err := app.ownWishlist(ctx, wishlist.Id, userID)
+	if err != nil {
+		return err
+	}
+
+	if wishlist.Title != "" {
+		dbWishlist.Title = wishlist.Title
+	}
+	dbWishlist.IsPublic = wishlist.IsPublic
+
+	err = dbWishlist.Validate()
+	if err != nil {
+		return err
+	}
+
+	return app.repo.UpdateWishlist(ctx, dbWishlist)
+}
+
+// DeleteWishlist deletes user's wishlist together with its items, default wishlist can not be deleted
+func (app *ShopProductApp) DeleteWishlist(ctx context.Context, wishlistID uint64, userID uint64) (err error) {
+	wishlist, err := app.ownWishlist(ctx, wishlistID, userID)
+	if err != nil {
+		return err
+	}
+
+	if wishlist.IsDefault {
+		return domain.DefaultWishlistError
+	}
+
+	return app.repo.DeleteWishlist(ctx, wishlistID)
+}
+
+// SaveProduct saves product to user's wishlist, default wishlist is used and created if needed when wishlistID is 0.
+// Id of wishlist where product was saved is returned
+func (app *ShopProductApp) SaveProduct(ctx context.Context, productID uint64, userID uint64, wishlistID uint64) (savedTo uint64, err error) {
+	if wishlistID == 0 {
+		wishlistID, err = app.repo.GetDefaultWishlistID(ctx, userID, randString(domain.ShareTokenLength))
+	} else {
+		_, err = app.ownWishlist(ctx, wishlistID, userID)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	err = app.repo.AddWishlistItem(ctx, wishlistID, productID)
+	if err != nil {
+		return 0, err
+	}
+
+	return wishlistID, nil
+}
+
+// UnsaveProduct removes product from user's wishlist, or from all user's wishlists if wishlistID is 0
+func (app *ShopProductApp) UnsaveProduct(ctx context.Context, productID uint64, userID uint64, wishlistID uint64) (err error) {
+	if wishlistID == 0 {
+		return app.repo.RemoveSavedProduct(ctx, userID, productID)
+	}
+
+	_, err = app.ownWishlist(ctx, wishlistID, userID)
+	if err != nil {
+		return err
+	}
+
+	return app.repo.RemoveWishlistItem(ctx, wishlistID, productID)
+}
+
+// ownWishlist returns wishlist if user owns it, wishlists of other users are reported as missing
+func (app *ShopProductApp) ownWishlist(ctx context.Context, wishlistID uint64, userID uint64) (wishlist domain.Wishlist, err error) {
+	wishlist, err = app.repo.GetWishlist(ctx, wishlistID)
+	if err != nil {
+		return domain.Wishlist{}, err
+	}
+
+	if wishlist.UserId != userID {
+		return domain.Wishlist{}, domain.WishlistNotFoundError
+	}
+
+	return wishlist, nil
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// AnalyticsDateLayout is format of days of analytics date ranges
+	AnalyticsDateLayout = "2006-01-02"
+	// DefaultAnalyticsDays is length of date range which ends today, used when range start is not set
+	DefaultAnalyticsDays = 30
+	// MaxAnalyticsDays limits length of date range of one report
+	MaxAnalyticsDays = 366
+	// MaxShopReferrers limits amount of referrers of the whole shop in report
+	MaxShopReferrers = 20
+	// MaxProductReferrers limits amount of referrers of each product in report
+	MaxProductReferrers = 5
+	// MaxReferrerLength is length of longest stored referrer, longer hosts are not real ones
+	MaxReferrerLength = 255
+	// MaxViewSessionLength is length of longest stored view session token
+	MaxViewSessionLength = 64
+)
+
+// PageView describes request for product or shop page. UserID is 0 for anonymous viewers. Session is token
+// of viewer's view session, views of one page are counted once per session. Referrer is URL of page which
+// linked to viewed one
+type PageView struct {
+	UserID   uint64
+	Session  string
+	Referrer string
+}
+
+// ReferrerViews is amount of views which came from host of referrer, empty referrer is for direct visits
+type ReferrerViews struct {
+	Referrer string
+	Views    uint64
+}
+
+// DailyViews is amount of views of shop page and its products during one day in UTC
+type DailyViews struct {
+	Day           time.Time
+	Views         uint64
+	UniqueViewers uint64
+}
+
+// ProductAnalytics contains views of product and reviews written during date range.
+// ViewToReviewRatio is share of views which were followed by review
+type ProductAnalytics struct {
+	ProductId         uint64
+	Title             string
+	SKU               string
+	Views             uint64
+	UniqueViewers     uint64
+	ReviewsCount      uint64
+	ViewToReviewRatio float64
+	Referrers         []ReferrerViews
+}
+
+// ShopAnalytics is report about views of shop page and shop's products during date range, both days are included.
+// Unique viewers are counted for each day and then summed up
+type ShopAnalytics struct {
+	ShopId            uint64
+	From              time.Time
+	To                time.Time
+	ShopViews         uint64
+	ShopUniqueViewers uint64
+	ProductViews      uint64
+	Days              []DailyViews
+	Referrers         []ReferrerViews
+	Products          []ProductAnalytics
+}
+
+// ReferrerHost returns lowercase host of referrer URL without www prefix, it is empty for invalid URLs
+func ReferrerHost(referrer string) string {
+	referrerURL, err := url.Parse(referrer)
+	if err != nil {
+		return ""
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(referrerURL.Hostname()), "www.")
+	if len(host) > MaxReferrerLength {
+		return ""
+	}
+	return host
+}
+
+// ValidViewSession checks that session token can be stored, gateway never issues longer tokens
+func ValidViewSession(session string) bool {
+	return len(session) <= MaxViewSessionLength
+}
+
+// ParseAnalyticsRange parses days of date range in UTC. Range ends today if end is not set and
+// lasts DefaultAnalyticsDays if start is not set
+func ParseAnalyticsRange(from string, to string, now time.Time) (start time.Time, end time.Time, err error) {
+	end = StartOfDay(now)
+	if to != "" {
+		end, err = time.Parse(AnalyticsDateLayout, to)
+		if err != nil {
+			return time.Time{}, time.Time{}, InvalidDateRangeError
+		}
+	}
+
+	start = end.AddDate(0, 0, -(DefaultAnalyticsDays - 1))
+	if from != "" {
+		start, err = time.Parse(AnalyticsDateLayout, from)
+		if err != nil {
+			return time.Time{}, time.Time{}, InvalidDateRangeError
+		}
+	}
+
+	if end.Before(start) || !start.AddDate(0, 0, MaxAnalyticsDays).After(end) {
+		return time.Time{}, time.Time{}, InvalidDateRangeError
+	}
+	return start, end, nil
+}
+
+// StartOfDay returns midnight in UTC of day which includes t
+func StartOfDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// ViewToReviewRatio returns share of views which were followed by review, it is 0 for products without views
+func ViewToReviewRatio(reviewsCount uint64, views uint64) float64 {
+	if views == 0 {
+		return 0
+	}
+
+	return float64(reviewsCount) / float64(views)
+}
@@ -0,0 +1,140 @@
+package domain
+
+import "time"
+
+const (
+	// MaxCartItemQuantity limits amount of units of one product in cart
+	MaxCartItemQuantity = 999
+	// CartTokenLength is length of tokens of anonymous carts
+	CartTokenLength = 32
+	// AnonymousCartLifetime is time after last change when anonymous cart is deleted
+	AnonymousCartLifetime = 30 * 24 * time.Hour
+)
+
+// Statuses of cart items, they are derived from current stock when cart is read
+const (
+	CartItemAvailable = "available"
+	// CartItemInsufficientStock means that only part of item's quantity can be bought
+	CartItemInsufficientStock = "insufficient_stock"
+	CartItemUnavailable       = "unavailable"
+)
+
+// CartOwner identifies cart either by user or by token of anonymous cart. User takes precedence over token
+type CartOwner struct {
+	UserId uint64
+	Token  string
+}
+
+func (owner CartOwner) IsAnonymous() bool {
+	return owner.UserId == 0
+}
+
+// CartItem contains current data of product, so that cart is always repriced and checked against stock.
+// Variant is empty for products without variants
+type CartItem struct {
+	Product  Product
+	Variant  ProductVariant
+	Quantity uint64
+	// VariantRequired is true if product got variants after it was added to cart without one, such item can not be bought
+	VariantRequired bool
+	// AddedPrice is price of product or its variant when it was added to cart
+	AddedPrice Money
+	AddedAt    time.Time
+	Status     string
+	// AvailableQuantity is amount of units which are not reserved
+	AvailableQuantity uint64
+}
+
+// PriceChanged reports whether product's price changed since it was added to cart
+func (item CartItem) PriceChanged() bool {
+	return item.AddedPrice != item.Product.Price
+}
+
+// CartShop groups items of one shop, subtotal includes only items which can be bought in full and uses discounted prices.
+// Subtotal is in shop's currency, ConvertedSubtotal is in currency which buyer prefers
+type CartShop struct {
+	ShopId            uint64
+	ShopTitle         string
+	Items             []CartItem
+	Subtotal          Money
+	ConvertedSubtotal Money
+}
+
+// Cart of shops with different currencies can not be summed up without conversion, so Totals contain
+// one sum per currency in order of shops. ConvertedTotal is set only when cart is converted
+type Cart struct {
+	Id uint64
+	// Token is empty for carts of users
+	Token          string
+	UserId         uint64
+	Shops          []CartShop
+	Totals         []Money
+	ConvertedTotal Money
+	ItemsCount     uint64
+}
+
+// NewCart checks items against stock of their products or variants, applies pricing and groups items by shop.
+// Items should be ordered by shop, shops follow in order of their first items
+func NewCart(id uint64, owner CartOwner, items []CartItem, shopTitles map[uint64]string, pricing Pricing) Cart {
+	cart := Cart{
+		Id:     id,
+		UserId: owner.UserId,
+		Shops:  make([]CartShop, 0),
+	}
+	if owner.IsAnonymous() {
+		cart.Token = owner.Token
+	}
+
+	for _, item := range items {
+		item.Variant = pricing.ApplyToVariant(item.Product, item.Variant)
+		item.Product = pricing.Apply(item.Product).WithVariant(item.Variant)
+		item.AvailableQuantity = item.Product.Stock - item.Product.Reserved
+		if item.VariantRequired {
+			item.AvailableQuantity = 0
+		}
+
+		switch {
+		case item.AvailableQuantity == 0:
+			item.Status = CartItemUnavailable
+		case item.AvailableQuantity < item.Quantity:
+			item.Status = CartItemInsufficientStock
+		default:
+			item.Status = CartItemAvailable
+		}
+
+		if len(cart.Shops) == 0 || cart.Shops[len(cart.Shops)-1].ShopId != item.Product.ShopId {
+			cart.Shops = append(cart.Shops, CartShop{
+				ShopId:    item.Product.ShopId,
+				ShopTitle: shopTitles[item.Product.ShopId],
+				Items:     make([]CartItem, 0),
+				Subtotal:  Money{Currency: item.Product.Price.Currency},
+			})
+		}
+
+		shop := &cart.Shops[len(cart.Shops)-1]
+		shop.Items = append(shop.Items, item)
+		if item.Status == CartItemAvailable {
+			shop.Subtotal.Amount += item.Product.DiscountedPrice.Times(item.Quantity).Amount
+		}
+		cart.ItemsCount += item.Quantity
+	}
+
+	cart.Totals = make([]Money, 0)
+	for _, shop := range cart.Shops {
+		cart.Totals = addToTotals(cart.Totals, shop.Subtotal)
+	}
+
+	return cart
+}
+
+// addToTotals adds amount to total of its currency, total of new currency goes last
+func addToTotals(totals []Money, amount Money) []Money {
+	for i := range totals {
+		if totals[i].Currency == amount.Currency {
+			totals[i].Amount += amount.Amount
+			return totals
+		}
+	}
+
+	return append(totals, amount)
+}
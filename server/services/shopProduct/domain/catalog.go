@@ -0,0 +1,340 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Formats of catalog files which shop's products are imported from and exported to
+const (
+	CatalogFormatCSV  = "csv"
+	CatalogFormatJSON = "json"
+)
+
+// Import statuses, rows are applied only while import is processing
+const (
+	ImportStatusPending    = "pending"
+	ImportStatusProcessing = "processing"
+	ImportStatusDone       = "done"
+	ImportStatusFailed     = "failed"
+)
+
+const (
+	// MaxImportFileSize is maximum size of uploaded catalog file in bytes
+	MaxImportFileSize = 10 << 20
+	// MaxImportRows limits amount of products in one catalog file
+	MaxImportRows = 5000
+	// ImportProgressStep is amount of rows after which progress of import is saved
+	ImportProgressStep = 50
+	// CatalogChunkSize is size of chunks in which exported catalog is streamed
+	CatalogChunkSize = 64 * 1024
+	// ImportStockComment is comment of stock adjustments made by imports
+	ImportStockComment = "Catalog import"
+)
+
+// CatalogColumns are columns of CSV catalog in order of export, keys of JSON catalog objects are the same
+var CatalogColumns = []string{"sku", "title", "description", "price", "currency", "stock", "assemblyTime",
+	"partsAmount", "size", "categoryID"}
+
+// CatalogRow is one product of catalog file, products are matched by SKU within shop.
+// Empty values of imported rows leave product's values unchanged, so stock, which can be 0, is a pointer
+type CatalogRow struct {
+	// Row is position of product in file counted from 1, CSV header is not counted
+	Row         uint64 `json:"-"`
+	SKU         string `json:"sku"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// Price is amount in minor units of currency, empty currency means shop's currency
+	Price    uint64 `json:"price"`
+	Currency string `json:"currency"`
+	// Stock is omitted for products with variants, as their stock is held by variants
+	Stock        *uint64 `json:"stock"`
+	AssemblyTime uint64  `json:"assemblyTime"`
+	PartsAmount  uint64  `json:"partsAmount"`
+	Size         string  `json:"size"`
+	CategoryId   uint64  `json:"categoryID"`
+}
+
+// ImportRowError explains why row of catalog file was not imported, Column is empty if whole row is wrong
+type ImportRowError struct {
+	Row     uint64 `json:"row"`
+	SKU     string `json:"sku"`
+	Column  string `json:"column"`
+	Message string `json:"message"`
+}
+
+// ProductImport is a job which creates and updates shop's products from catalog file.
+// Dry run imports only validate rows and count products which would be created and updated
+type ProductImport struct {
+	Id            uint64
+	ShopId        uint64
+	UserId        uint64
+	Format        string
+	DryRun        bool
+	Status        string
+	TotalRows     uint64
+	ProcessedRows uint64
+	CreatedCount  uint64
+	UpdatedCount  uint64
+	FailedCount   uint64
+	Errors        []ImportRowError
+	// ErrorMessage is set if import failed as a whole
+	ErrorMessage string
+	CreatedAt    time.Time
+	FinishedAt   time.Time
+}
+
+// AddRowError records that row was not imported
+func (productImport *ProductImport) AddRowError(row CatalogRow, column string, err error) {
+	productImport.Errors = append(productImport.Errors, ImportRowError{
+		Row:     row.Row,
+		SKU:     row.SKU,
+		Column:  column,
+		Message: err.Error(),
+	})
+	productImport.FailedCount++
+}
+
+// ValidSKU checks that SKU is not empty and is not too long
+func ValidSKU(sku string) bool {
+	return sku != "" && utf8.RuneCountInString(sku) <= MaxSKULength
+}
+
+func ValidCatalogFormat(format string) bool {
+	return format == CatalogFormatCSV || format == CatalogFormatJSON
+}
+
+// ToCatalogRow converts product to exported row, stock of products with variants is omitted
+func ToCatalogRow(product Product, hasVariants bool) CatalogRow {
+	row := CatalogRow{
+		SKU:          product.SKU,
+		Title:        product.Title,
+		Description:  product.Description,
+		Price:        product.Price.Amount,
+		Currency:     product.Price.Currency,
+		AssemblyTime: product.AssemblyTime,
+		PartsAmount:  product.PartsAmount,
+		Size:         product.Size,
+		CategoryId:   product.CategoryId,
+	}
+
+	if !hasVariants {
+		stock := product.Stock
+		row.Stock = &stock
+	}
+
+	return row
+}
+
+// ParseCatalog reads rows of catalog file. Rows which can not be read are returned as row errors,
+// InvalidImportFileError is returned if file can not be read at all
+func ParseCatalog(format string, data []byte) (rows []CatalogRow, rowErrors []ImportRowError, err error) {
+	switch format {
+	case CatalogFormatCSV:
+		rows, rowErrors, err = parseCSVCatalog(data)
+	case CatalogFormatJSON:
+		rows, rowErrors, err = parseJSONCatalog(data)
+	default:
+		return nil, nil, InvalidCatalogFormatError
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(rows)+len(rowErrors) > MaxImportRows {
+		return nil, nil, TooManyImportRowsError
+	}
+	return rows, rowErrors, nil
+}
+
+// parseCSVCatalog reads CSV file whose header names columns, columns can go in any order and only sku is required
+func parseCSVCatalog(data []byte) (rows []CatalogRow, rowErrors []ImportRowError, err error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff")))) // Spreadsheets add BOM
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, InvalidImportFileError
+	}
+
+	hasSKU := false
+	for i, column := range header {
+		header[i] = strings.TrimSpace(column)
+		if !isCatalogColumn(header[i]) {
+			return nil, nil, InvalidImportFileError
+		}
+		hasSKU = hasSKU || header[i] == "sku"
+	}
+	if !hasSKU {
+		return nil, nil, InvalidImportFileError
+	}
+
+	rows = make([]CatalogRow, 0)
+	rowErrors = make([]ImportRowError, 0)
+	for rowNumber := uint64(1); ; rowNumber++ {
+		record, err := reader.Read()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, nil, InvalidImportFileError
+		}
+
+		row := CatalogRow{Row: rowNumber}
+		if len(record) != len(header) {
+			rowErrors = append(rowErrors, ImportRowError{Row: rowNumber, Message: InvalidImportRowError.Error()})
+			continue
+		}
+
+		column, err := row.setValues(header, record)
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Row: rowNumber, SKU: row.SKU, Column: column, Message: err.Error()})
+			continue
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, rowErrors, nil
+}
+
+// setValues sets values of row's columns, empty values are skipped. Returns column whose value is invalid
+func (row *CatalogRow) setValues(header []string, record []string) (column string, err error) {
+	numbers := map[string]*uint64{
+		"price":        &row.Price,
+		"assemblyTime": &row.AssemblyTime,
+		"partsAmount":  &row.PartsAmount,
+		"categoryID":   &row.CategoryId,
+	}
+
+	// SKU goes first, so that errors of other columns are reported with it
+	for i, column := range header {
+		if column == "sku" {
+			row.SKU = strings.TrimSpace(record[i])
+		}
+	}
+
+	for i, column := range header {
+		value := strings.TrimSpace(record[i])
+		if value == "" {
+			continue
+		}
+
+		switch column {
+		case "title":
+			row.Title = value
+		case "description":
+			row.Description = value
+		case "currency":
+			row.Currency = strings.ToUpper(value)
+		case "size":
+			row.Size = value
+		case "stock":
+			stock, err := strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return column, InvalidImportValueError
+			}
+			row.Stock = &stock
+		default:
+			number, found := numbers[column]
+			if !found {
+				continue
+			}
+
+			*number, err = strconv.ParseUint(value, 10, 64)
+			if err != nil {
+				return column, InvalidImportValueError
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// parseJSONCatalog reads JSON array of objects, every object is parsed separately so that one invalid product
+// does not fail the whole file
+func parseJSONCatalog(data []byte) (rows []CatalogRow, rowErrors []ImportRowError, err error) {
+	var objects []json.RawMessage
+	err = json.Unmarshal(data, &objects)
+	if err != nil {
+		return nil, nil, InvalidImportFileError
+	}
+
+	rows = make([]CatalogRow, 0, len(objects))
+	rowErrors = make([]ImportRowError, 0)
+	for i, object := range objects {
+		row := CatalogRow{}
+		decoder := json.NewDecoder(bytes.NewReader(object))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(&row)
+		if err != nil {
+			rowErrors = append(rowErrors, ImportRowError{Row: uint64(i + 1), SKU: row.SKU, Message: InvalidImportRowError.Error()})
+			continue
+		}
+
+		row.Row = uint64(i + 1)
+		row.SKU = strings.TrimSpace(row.SKU)
+		row.Currency = strings.ToUpper(row.Currency)
+		rows = append(rows, row)
+	}
+
+	return rows, rowErrors, nil
+}
+
+func isCatalogColumn(name string) bool {
+	for _, column := range CatalogColumns {
+		if column == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WriteCatalog writes rows in format which ParseCatalog reads
+func WriteCatalog(format string, rows []CatalogRow) (data []byte, err error) {
+	switch format {
+	case CatalogFormatCSV:
+		return writeCSVCatalog(rows)
+	case CatalogFormatJSON:
+		return json.MarshalIndent(rows, "", "  ")
+	default:
+		return nil, InvalidCatalogFormatError
+	}
+}
+
+func writeCSVCatalog(rows []CatalogRow) (data []byte, err error) {
+	buffer := new(bytes.Buffer)
+	writer := csv.NewWriter(buffer)
+
+	err = writer.Write(CatalogColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		stock := ""
+		if row.Stock != nil {
+			stock = strconv.FormatUint(*row.Stock, 10)
+		}
+
+		err = writer.Write([]string{row.SKU, row.Title, row.Description, strconv.FormatUint(row.Price, 10), row.Currency,
+			stock, strconv.FormatUint(row.AssemblyTime, 10), strconv.FormatUint(row.PartsAmount, 10), row.Size,
+			strconv.FormatUint(row.CategoryId, 10)})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	if writer.Error() != nil {
+		return nil, writer.Error()
+	}
+	return buffer.Bytes(), nil
+}
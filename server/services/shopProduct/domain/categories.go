@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"regexp"
+	"unicode/utf8"
+)
+
+const (
+	// MaxCategoryNameLength is measured in characters, slugs are limited by the same length
+	MaxCategoryNameLength = 100
+)
+
+var (
+	// slugPattern allows lowercase letters of any script and digits, separated by single dashes
+	slugPattern = regexp.MustCompile(`^[\p{Ll}\p{Lo}\p{N}]+(-[\p{Ll}\p{Lo}\p{N}]+)*$`)
+	// localePattern allows language codes, optionally followed by region, such as "en" or "en-US"
+	localePattern = regexp.MustCompile(`^[a-z]{2}(-[A-Z]{2})?$`)
+)
+
+// Category is node of categories tree. Products of category's descendants are also shown in category
+type Category struct {
+	Id uint64
+	// ParentId is 0 for root categories
+	ParentId uint64
+	Slug     string
+	// Position orders categories with the same parent
+	Position uint64
+	// Names are keyed by locale
+	Names    map[string]string
+	Children []Category
+}
+
+// Validate checks category's slug and names, category must have at least one name
+func (category Category) Validate() error {
+	if utf8.RuneCountInString(category.Slug) > MaxCategoryNameLength || !slugPattern.MatchString(category.Slug) {
+		return InvalidSlugError
+	}
+
+	if len(category.Names) == 0 {
+		return InvalidCategoryNameError
+	}
+	for locale, name := range category.Names {
+		if !localePattern.MatchString(locale) || name == "" || utf8.RuneCountInString(name) > MaxCategoryNameLength {
+			return InvalidCategoryNameError
+		}
+	}
+
+	return nil
+}
+
+// BuildCategoryTree turns categories ordered by position into tree, returns root categories
+func BuildCategoryTree(categories []Category) []Category {
+	children := make(map[uint64][]Category)
+	for _, category := range categories {
+		children[category.ParentId] = append(children[category.ParentId], category)
+	}
+
+	return attachChildren(children, 0)
+}
+
+func attachChildren(children map[uint64][]Category, parentID uint64) []Category {
+	result := make([]Category, 0, len(children[parentID]))
+	for _, category := range children[parentID] {
+		category.Children = attachChildren(children, category.Id)
+		result = append(result, category)
+	}
+
+	return result
+}
@@ -0,0 +1,36 @@
+package domain
+
+const (
+	// RoleOwner can do everything manager can, and also add or remove shop's managers
+	RoleOwner = "owner"
+	// RoleManager can edit shop and its products
+	RoleManager = "manager"
+)
+
+const (
+	// MaxImageSize is maximum size of uploaded image in bytes
+	MaxImageSize = 10 << 20
+	// MaxImageSide limits dimensions of uploaded images, so that huge images are not decoded
+	MaxImageSide = 8000
+	// MaxProductImages is maximum amount of images in product's gallery
+	MaxProductImages = 10
+	// ImageQuality is JPEG quality of stored renditions
+	ImageQuality = 85
+	// ImageNameLength is length of random part of stored images' names
+	ImageNameLength = 16
+	// ProductImagesDir is directory inside media directory where product images are stored
+	ProductImagesDir = "products"
+)
+
+// ImageRendition is a scaled down copy of uploaded image which fits into MaxSide x MaxSide square
+type ImageRendition struct {
+	Name    string
+	MaxSide int
+}
+
+// ImageRenditions are ordered from largest to smallest, each one is scaled from the previous one
+var ImageRenditions = []ImageRendition{
+	{Name: "large", MaxSide: 1200},
+	{Name: "medium", MaxSide: 600},
+	{Name: "thumbnail", MaxSide: 200},
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// DeletedRetention is time during which deleted shops and products can be restored, they are purged after it
+const DeletedRetention = 30 * 24 * time.Hour
+
+// RestoreDeadline returns moment when item deleted at deletedAt is purged and can no longer be restored
+func RestoreDeadline(deletedAt time.Time) time.Time {
+	return deletedAt.Add(DeletedRetention)
+}
+
+// IsRestorable is true while retention of item deleted at deletedAt has not expired
+func IsRestorable(deletedAt time.Time, now time.Time) bool {
+	return now.Before(RestoreDeadline(deletedAt))
+}
@@ -0,0 +1,101 @@
+package domain
+
+import "errors"
+
+var (
+	TransactionBeginError      = errors.New("Could not begin transaction")
+	TransactionCommitError     = errors.New("Could not commit transaction")
+	ShopNotFoundError          = errors.New("Could not find shop")
+	ProductNotFoundError       = errors.New("Could not find product")
+	EmptyTitleError            = errors.New("Title can not be empty")
+	NotShopManagerError        = errors.New("User is not shop's manager")
+	NotShopOwnerError          = errors.New("User is not shop's owner")
+	AlreadyManagerError        = errors.New("User already manages this shop")
+	LastOwnerError             = errors.New("Could not remove shop's last owner")
+	InvalidRoleError           = errors.New("Invalid manager role")
+	InvitationNotFoundError    = errors.New("Could not find invitation")
+	ImageInfoMissingError      = errors.New("Image upload must start with image info")
+	InvalidImageError          = errors.New("Could not decode image")
+	ImageTooLargeError         = errors.New("Image is too large")
+	TooManyImagesError         = errors.New("Product has too many images")
+	ImageNotFoundError         = errors.New("Could not find image")
+	InvalidImageOrderError     = errors.New("Image order must contain every product image exactly once")
+	InvalidSortingError        = errors.New("Unknown sorting criterion")
+	InvalidCursorError         = errors.New("Invalid pagination cursor")
+	ShopFollowNotFoundError    = errors.New("User does not follow this shop")
+	InvalidRatingError         = errors.New("Rating must be from 1 to 5")
+	ReviewTitleTooLongError    = errors.New("Review title is too long")
+	ReviewExistsError          = errors.New("User has already reviewed this product")
+	ReviewNotFoundError        = errors.New("Could not find review")
+	NotReviewAuthorError       = errors.New("User is not review's author")
+	OwnProductReviewError      = errors.New("Shop managers can not review their own products")
+	InvalidSearchRangeError    = errors.New("Range minimum is greater than its maximum")
+	NotAdminError              = errors.New("User is not administrator")
+	CategoryNotFoundError      = errors.New("Could not find category")
+	InvalidSlugError           = errors.New("Slug must consist of lowercase letters and digits separated by dashes")
+	SlugExistsError            = errors.New("Category with this slug already exists")
+	InvalidCategoryNameError   = errors.New("Category must have names of at most 100 characters in valid locales")
+	CategoryCycleError         = errors.New("Category can not be moved into its own subtree")
+	CategoryNotEmptyError      = errors.New("Category has subcategories or products")
+	OutOfStockError            = errors.New("Not enough stock")
+	InvalidQuantityError       = errors.New("Quantities must be positive and products must not repeat")
+	StockBelowReservedError    = errors.New("Stock can not become less than reserved quantity")
+	StockCommentTooLongError   = errors.New("Stock change comment is too long")
+	ReservationNotFoundError   = errors.New("Could not find reservation")
+	ReservationNotActiveError  = errors.New("Reservation is already committed, released or expired")
+	CartQuantityTooLargeError  = errors.New("Cart can hold at most 999 units of product")
+	CartItemNotFoundError      = errors.New("Could not find product in cart")
+	CartNotFoundError          = errors.New("Could not find cart")
+	WishlistNotFoundError      = errors.New("Could not find wishlist")
+	InvalidWishlistTitleError  = errors.New("Wishlist title must have from 1 to 100 characters")
+	TooManyWishlistsError      = errors.New("User has too many wishlists")
+	DefaultWishlistError       = errors.New("Default wishlist can not be deleted")
+	WishlistItemNotFoundError  = errors.New("Product is not in wishlist")
+	InvalidOptionsError        = errors.New("Product must have at most 3 options with unique names and values of 1 to 50 characters")
+	OptionsInUseError          = errors.New("Options do not match product's variants")
+	InvalidVariantOptionsError = errors.New("Variant must have one allowed value of every product option")
+	InvalidSKUError            = errors.New("SKU must have from 1 to 64 characters")
+	DuplicateVariantError      = errors.New("Product already has variant with this SKU or option values")
+	TooManyVariantsError       = errors.New("Product has too many variants")
+	VariantNotFoundError       = errors.New("Could not find variant")
+	VariantRequiredError       = errors.New("Variant of product must be selected")
+	VariantReservedError       = errors.New("Variant has reserved stock")
+	InvalidSaleError           = errors.New("Sale must have positive price and end after it starts")
+	SaleNotFoundError          = errors.New("Could not find sale")
+	InvalidDiscountError       = errors.New("Discount must be from 1 to 90 percent and end after it starts")
+	DiscountNotFoundError      = errors.New("Could not find discount")
+	InvalidPromoCodeError      = errors.New("Promo code must have 3 to 32 letters, digits, dashes or underscores and valid discount")
+	PromoCodeExistsError       = errors.New("Shop already has promo code with this code")
+	PromoCodeNotFoundError     = errors.New("Could not find promo code")
+	PromoCodeExpiredError      = errors.New("Promo code has expired")
+	PromoCodeMinOrderError     = errors.New("Order is less than minimum of promo code")
+	PromoCodeUsedUpError       = errors.New("Promo code has reached its usage limit")
+	RedemptionNotFoundError    = errors.New("Could not find promo code redemption")
+	InvalidCurrencyError       = errors.New("Unknown currency code")
+	CurrencyMismatchError      = errors.New("Price must be in shop's currency")
+	CurrencyInUseError         = errors.New("Currency of shop with products can not be changed")
+	ExchangeRateNotFoundError  = errors.New("Could not find exchange rate of currency")
+	InvalidExchangeRatesError  = errors.New("Exchange rates must have positive rates, known roundings and base currency with rate 1")
+	ConversionOverflowError    = errors.New("Converted amount does not fit in minor units of currency")
+	DuplicateProductSKUError   = errors.New("Shop already has product with this SKU")
+	InvalidCatalogFormatError  = errors.New("Catalog format must be csv or json")
+	InvalidImportFileError     = errors.New("Could not read catalog file")
+	InvalidImportRowError      = errors.New("Could not read catalog row")
+	InvalidImportValueError    = errors.New("Value must be non-negative integer")
+	RepeatedImportSKUError     = errors.New("SKU is repeated in catalog file")
+	ImportFileTooLargeError    = errors.New("Catalog file is too large")
+	TooManyImportRowsError     = errors.New("Catalog file has too many rows")
+	ImportInfoMissingError     = errors.New("Catalog upload must start with import info")
+	ImportNotFoundError        = errors.New("Could not find catalog import")
+	InvalidFeedFormatError     = errors.New("Feed format must be yml or google")
+	FeedNotFoundError          = errors.New("Could not find marketplace feed")
+	RestoreExpiredError        = errors.New("Retention period of deleted item has expired")
+	ShopDeletedError           = errors.New("Products of deleted shop are restored together with shop")
+	InvalidDateRangeError      = errors.New("Date range must have days in YYYY-MM-DD format, start before end and be at most a year long")
+	EmptyQuestionTextError     = errors.New("Question and answer must have text")
+	QuestionTextTooLongError   = errors.New("Question or answer text is too long")
+	QuestionNotFoundError      = errors.New("Could not find question")
+	AnswerNotFoundError        = errors.New("Could not find answer")
+	NotAllowedToAnswerError    = errors.New("Only shop's managers and buyers of product can answer its questions")
+	BlockedUserError           = errors.New("Users who have blocked one another can not interact")
+)
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxFeedSize is amount of products which are ranked when feed is created, feed ends after them
+	MaxFeedSize = 500
+	// FeedLifetimeHours is how long feed snapshot can be scrolled before it is purged
+	FeedLifetimeHours = 24
+
+	// FollowedShopWeight is added to score of products from shops user follows
+	FollowedShopWeight = 3.0
+	// BrowsedCategoryWeight is multiplied by share of user's recent views which fall into product's category
+	BrowsedCategoryWeight = 2.0
+	// TrendingWeight is multiplied by logarithm of product's recent views
+	TrendingWeight = 1.0
+	// SaveWeight is how many views one save of product is worth when popularity is computed
+	SaveWeight = 5.0
+	// BrowsedCategoryDays is how far back user's views are taken into account
+	BrowsedCategoryDays = 30
+	// TrendingDays is how far back views are counted for trending products
+	TrendingDays = 7
+)
+
+// FeedCursor points at the last product of previous page in feed snapshot
+type FeedCursor struct {
+	FeedId   uint64
+	Position uint64
+}
+
+func EncodeFeedCursor(cursor FeedCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d_%d", cursor.FeedId, cursor.Position)))
+}
+
+func DecodeFeedCursor(encoded string) (cursor FeedCursor, err error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return FeedCursor{}, InvalidCursorError
+	}
+
+	parts := strings.Split(string(decoded), "_")
+	if len(parts) != 2 {
+		return FeedCursor{}, InvalidCursorError
+	}
+
+	cursor.FeedId, err = strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		return FeedCursor{}, InvalidCursorError
+	}
+
+	cursor.Position, err = strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return FeedCursor{}, InvalidCursorError
+	}
+
+	return cursor, nil
+}
@@ -1,7 +1,12 @@
 package domain
 
 import (
+	"encoding/json"
 	pb "pinterest/services/shopProduct/proto"
+	"sort"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func ToShop(pbShop *pb.Shop) Shop {
@@ -9,7 +14,9 @@ func ToShop(pbShop *pb.Shop) Shop {
 		Id:          pbShop.GetId(),
 		Title:       pbShop.GetTitle(),
 		Description: pbShop.GetDescription(),
+		Currency:    pbShop.GetCurrency(),
 		ManagerIDs:  pbShop.GetManagerIds(),
+		OwnerIDs:    pbShop.GetOwnerIds(),
 	}
 }
 
@@ -18,40 +25,888 @@ func ToPbShop(shop Shop) *pb.Shop {
 		Id:          shop.Id,
 		Title:       shop.Title,
 		Description: shop.Description,
+		Currency:    shop.Currency,
 		ManagerIds:  shop.ManagerIDs,
+		OwnerIds:    shop.OwnerIDs,
 	}
 }
 
 func ToProduct(pbProduct *pb.Product) Product {
+	return Product{
+		Id:                       pbProduct.GetId(),
+		Title:                    pbProduct.GetTitle(),
+		Description:              pbProduct.GetDescription(),
+		Price:                    ToMoney(pbProduct.GetPrice()),
+		DiscountedPrice:          ToMoney(pbProduct.GetDiscountedPrice()),
+		ConvertedPrice:           ToMoney(pbProduct.GetConvertedPrice()),
+		ConvertedDiscountedPrice: ToMoney(pbProduct.GetConvertedDiscountedPrice()),
+		Availability:             pbProduct.GetAvailability(),
+		Stock:                    pbProduct.GetStock(),
+		Reserved:                 pbProduct.GetReserved(),
+		AssemblyTime:             pbProduct.GetAssemblyTime(),
+		PartsAmount:              pbProduct.GetPartsAmount(),
+		Rating:                   pbProduct.GetRating(),
+		ReviewsCount:             pbProduct.GetReviewsCount(),
+		RatingHistogram:          pbProduct.GetRatingHistogram(),
+		SavesCount:               pbProduct.GetSavesCount(),
+		IsSaved:                  pbProduct.GetIsSaved(),
+		Size:                     pbProduct.GetSize(),
+		CategoryId:               pbProduct.GetCategoryId(),
+		ImageLinks:               pbProduct.GetImageLinks(),
+		ShopId:                   pbProduct.GetShopId(),
+		Options:                  ToOptions(pbProduct.GetOptions()),
+		Variants:                 ToVariants(pbProduct.GetVariants()),
+		SelectedVariantId:        pbProduct.GetSelectedVariantId(),
+	}
+}
+
+func ToPbProduct(product Product) *pb.Product {
+	return &pb.Product{
+		Id:                       product.Id,
+		Title:                    product.Title,
+		Description:              product.Description,
+		Sku:                      product.SKU,
+		Price:                    ToPbMoney(product.Price),
+		DiscountedPrice:          ToPbMoney(product.DiscountedPrice),
+		ConvertedPrice:           ToPbMoney(product.ConvertedPrice),
+		ConvertedDiscountedPrice: ToPbMoney(product.ConvertedDiscountedPrice),
+		Availability:             product.Availability,
+		Stock:                    product.Stock,
+		Reserved:                 product.Reserved,
+		AssemblyTime:             product.AssemblyTime,
+		PartsAmount:              product.PartsAmount,
+		Rating:                   product.Rating,
+		ReviewsCount:             product.ReviewsCount,
+		RatingHistogram:          product.RatingHistogram,
+		SavesCount:               product.SavesCount,
+		IsSaved:                  product.IsSaved,
+		Size:                     product.Size,
+		CategoryId:               product.CategoryId,
+		ImageLinks:               product.ImageLinks,
+		Images:                   ToPbProductImages(product.Images).GetImages(),
+		ShopId:                   product.ShopId,
+		Options:                  ToPbOptions(product.Options),
+		Variants:                 ToPbVariants(product.Variants),
+		SelectedVariantId:        product.SelectedVariantId,
+		DeletedAt:                toPbTime(product.DeletedAt),
+	}
+}
+
+func ToOptions(pbOptions []*pb.ProductOption) []ProductOption {
+	options := make([]ProductOption, 0, len(pbOptions))
+	for _, pbOption := range pbOptions {
+		options = append(options, ProductOption{Name: pbOption.GetName(), Values: pbOption.GetValues()})
+	}
+
+	return options
+}
+
+func ToPbOptions(options []ProductOption) []*pb.ProductOption {
+	pbOptions := make([]*pb.ProductOption, 0, len(options))
+	for _, option := range options {
+		pbOptions = append(pbOptions, &pb.ProductOption{Name: option.Name, Values: option.Values})
+	}
+
+	return pbOptions
+}
+
+func ToVariant(pbVariant *pb.ProductVariant) ProductVariant {
+	return ProductVariant{
+		Id:                       pbVariant.GetId(),
+		ProductId:                pbVariant.GetProductId(),
+		SKU:                      pbVariant.GetSku(),
+		Options:                  pbVariant.GetOptions(),
+		Price:                    ToMoney(pbVariant.GetPrice()),
+		PriceOverride:            ToMoney(pbVariant.GetPriceOverride()),
+		DiscountedPrice:          ToMoney(pbVariant.GetDiscountedPrice()),
+		ConvertedPrice:           ToMoney(pbVariant.GetConvertedPrice()),
+		ConvertedDiscountedPrice: ToMoney(pbVariant.GetConvertedDiscountedPrice()),
+		Stock:                    pbVariant.GetStock(),
+		Reserved:                 pbVariant.GetReserved(),
+		Availability:             pbVariant.GetAvailability(),
+		ImageIds:                 pbVariant.GetImageIds(),
+		ImageLinks:               pbVariant.GetImageLinks(),
+	}
+}
+
+func ToPbVariant(variant ProductVariant) *pb.ProductVariant {
+	return &pb.ProductVariant{
+		Id:                       variant.Id,
+		ProductId:                variant.ProductId,
+		Sku:                      variant.SKU,
+		Options:                  variant.Options,
+		Price:                    ToPbMoney(variant.Price),
+		PriceOverride:            ToPbMoney(variant.PriceOverride),
+		DiscountedPrice:          ToPbMoney(variant.DiscountedPrice),
+		ConvertedPrice:           ToPbMoney(variant.ConvertedPrice),
+		ConvertedDiscountedPrice: ToPbMoney(variant.ConvertedDiscountedPrice),
+		Stock:                    variant.Stock,
+		Reserved:                 variant.Reserved,
+		Availability:             variant.Availability,
+		ImageIds:                 variant.ImageIds,
+		ImageLinks:               variant.ImageLinks,
+	}
+}
+
+func ToVariants(pbVariants []*pb.ProductVariant) []ProductVariant {
+	variants := make([]ProductVariant, 0, len(pbVariants))
+	for _, pbVariant := range pbVariants {
+		variants = append(variants, ToVariant(pbVariant))
+	}
+
+	return variants
+}
+
+func ToPbVariants(variants []ProductVariant) []*pb.ProductVariant {
+	pbVariants := make([]*pb.ProductVariant, 0, len(variants))
+	for _, variant := range variants {
+		pbVariants = append(pbVariants, ToPbVariant(variant))
+	}
+
+	return pbVariants
+}
+
+// EditVariantRequestToVariant takes product id from request, so that variant of another product can not be edited
+func EditVariantRequestToVariant(pbRequest *pb.EditVariantRequest) ProductVariant {
+	variant := ToVariant(pbRequest.GetVariant())
+	variant.ProductId = pbRequest.GetProductId()
+	return variant
+}
+
+func CreateShopRequestToShop(pbShop *pb.CreateShopRequest) Shop {
+	return Shop{
+		Title:       pbShop.GetTitle(),
+		Description: pbShop.GetDescription(),
+		Currency:    pbShop.GetCurrency(),
+	}
+}
+
+func EditShopRequestToShop(pbShop *pb.EditShopRequest) Shop {
+	return Shop{
+		Id:          pbShop.GetId(),
+		Title:       pbShop.GetTitle(),
+		Description: pbShop.GetDescription(),
+		Currency:    pbShop.GetCurrency(),
+	}
+}
+
+func CreateProductRequestToProduct(pbProduct *pb.CreateProductRequest) Product {
+	return Product{
+		Title:        pbProduct.GetTitle(),
+		Description:  pbProduct.GetDescription(),
+		SKU:          pbProduct.GetSku(),
+		Price:        ToMoney(pbProduct.GetPrice()),
+		Stock:        pbProduct.GetStock(),
+		AssemblyTime: pbProduct.GetAssemblyTime(),
+		PartsAmount:  pbProduct.GetPartsAmount(),
+		Size:         pbProduct.GetSize(),
+		CategoryId:   pbProduct.GetCategoryId(),
+		ShopId:       pbProduct.GetShopId(),
+	}
+}
+
+func EditProductRequestToProduct(pbProduct *pb.EditProductRequest) Product {
 	return Product{
 		Id:           pbProduct.GetId(),
 		Title:        pbProduct.GetTitle(),
 		Description:  pbProduct.GetDescription(),
-		Price:        pbProduct.GetPrice(),
-		Availability: pbProduct.GetAvailability(),
+		SKU:          pbProduct.GetSku(),
+		Price:        ToMoney(pbProduct.GetPrice()),
 		AssemblyTime: pbProduct.GetAssemblyTime(),
 		PartsAmount:  pbProduct.GetPartsAmount(),
-		Rating:       pbProduct.GetRating(),
 		Size:         pbProduct.GetSize(),
-		Category:     pbProduct.GetCategory(),
-		ImageLinks:   pbProduct.GetImageLinks(),
+		CategoryId:   pbProduct.GetCategoryId(),
 		ShopId:       pbProduct.GetShopId(),
 	}
 }
 
-func ToPbProduct(product Product) *pb.Product {
-	return &pb.Product{
-		Id:           product.Id,
-		Title:        product.Title,
-		Description:  product.Description,
-		Price:        product.Price,
-		Availability: product.Availability,
-		AssemblyTime: product.AssemblyTime,
-		PartsAmount:  product.PartsAmount,
-		Rating:       product.Rating,
-		Size:         product.Size,
-		Category:     product.Category,
-		ImageLinks:   product.ImageLinks,
-		ShopId:       product.ShopId,
+func InviteShopManagerRequestToInvitation(pbInvitation *pb.InviteShopManagerRequest) ShopInvitation {
+	return ShopInvitation{
+		ShopId:        pbInvitation.GetShopId(),
+		InviterId:     pbInvitation.GetUserId(),
+		InvitedUserId: pbInvitation.GetInvitedUserId(),
+		Role:          pbInvitation.GetRole(),
+	}
+}
+
+func ToPbShopInvitation(invitation ShopInvitation) *pb.ShopInvitation {
+	return &pb.ShopInvitation{
+		Id:        invitation.Id,
+		ShopId:    invitation.ShopId,
+		ShopTitle: invitation.ShopTitle,
+		InviterId: invitation.InviterId,
+		Role:      invitation.Role,
+	}
+}
+
+func ToPbShopInvitations(invitations []ShopInvitation) *pb.ShopInvitations {
+	pbInvitations := make([]*pb.ShopInvitation, 0, len(invitations))
+	for _, invitation := range invitations {
+		pbInvitations = append(pbInvitations, ToPbShopInvitation(invitation))
+	}
+
+	return &pb.ShopInvitations{Invitations: pbInvitations}
+}
+
+func ToPbProductImage(image ProductImage) *pb.ProductImage {
+	return &pb.ProductImage{
+		Id:            image.Id,
+		ProductId:     image.ProductId,
+		Position:      image.Position,
+		IsPrimary:     image.IsPrimary,
+		ThumbnailLink: image.ThumbnailLink,
+		MediumLink:    image.MediumLink,
+		LargeLink:     image.LargeLink,
+		Height:        image.Height,
+		Width:         image.Width,
+		AvgColor:      image.AvgColor,
+	}
+}
+
+func ToPbProductImages(images []ProductImage) *pb.ProductImages {
+	pbImages := make([]*pb.ProductImage, 0, len(images))
+	for _, image := range images {
+		pbImages = append(pbImages, ToPbProductImage(image))
+	}
+
+	return &pb.ProductImages{Images: pbImages}
+}
+
+func ToPbProductsList(products []Product, nextCursor string) *pb.ProductsList {
+	pbProducts := make([]*pb.Product, 0, len(products))
+	for _, product := range products {
+		pbProducts = append(pbProducts, ToPbProduct(product))
+	}
+
+	return &pb.ProductsList{Products: pbProducts, NextCursor: nextCursor}
+}
+
+func CreateReviewRequestToReview(pbReview *pb.CreateReviewRequest) ProductReview {
+	return ProductReview{
+		ProductId: pbReview.GetProductId(),
+		UserId:    pbReview.GetUserId(),
+		Title:     pbReview.GetTitle(),
+		Text:      pbReview.GetText(),
+		Rating:    pbReview.GetRating(),
+	}
+}
+
+func EditReviewRequestToReview(pbReview *pb.EditReviewRequest) ProductReview {
+	return ProductReview{
+		Id:     pbReview.GetId(),
+		UserId: pbReview.GetUserId(),
+		Title:  pbReview.GetTitle(),
+		Text:   pbReview.GetText(),
+		Rating: pbReview.GetRating(),
+	}
+}
+
+func ToPbReview(review ProductReview) *pb.Review {
+	return &pb.Review{
+		Id:        review.Id,
+		ProductId: review.ProductId,
+		UserId:    review.UserId,
+		Username:  review.Username,
+		Title:     review.Title,
+		Text:      review.Text,
+		Rating:    review.Rating,
+		CreatedAt: timestamppb.New(review.CreatedAt),
+		UpdatedAt: timestamppb.New(review.UpdatedAt),
+	}
+}
+
+func ToPbReviewsList(reviews []ProductReview, nextCursor string) *pb.ReviewsList {
+	pbReviews := make([]*pb.Review, 0, len(reviews))
+	for _, review := range reviews {
+		pbReviews = append(pbReviews, ToPbReview(review))
+	}
+
+	return &pb.ReviewsList{Reviews: pbReviews, NextCursor: nextCursor}
+}
+
+func SearchProductsRequestToFilters(pbRequest *pb.SearchProductsRequest) SearchFilters {
+	return SearchFilters{
+		Query:           pbRequest.GetQuery(),
+		PriceMin:        pbRequest.GetPriceMin(),
+		PriceMax:        pbRequest.GetPriceMax(),
+		CategoryIds:     pbRequest.GetCategoryIds(),
+		Sizes:           pbRequest.GetSizes(),
+		OnlyAvailable:   pbRequest.GetOnlyAvailable(),
+		AssemblyTimeMin: pbRequest.GetAssemblyTimeMin(),
+		AssemblyTimeMax: pbRequest.GetAssemblyTimeMax(),
+		PartsAmountMin:  pbRequest.GetPartsAmountMin(),
+		PartsAmountMax:  pbRequest.GetPartsAmountMax(),
+		MinRating:       pbRequest.GetMinRating(),
+		Currency:        pbRequest.GetCurrency(),
+	}
+}
+
+func ToPbFacetValues(values []FacetValue) []*pb.FacetValue {
+	pbValues := make([]*pb.FacetValue, 0, len(values))
+	for _, value := range values {
+		pbValues = append(pbValues, &pb.FacetValue{Value: value.Value, Count: value.Count})
+	}
+
+	return pbValues
+}
+
+func ToPbRangeFacet(facet RangeFacet) *pb.RangeFacet {
+	return &pb.RangeFacet{Min: facet.Min, Max: facet.Max}
+}
+
+func ToPbSearchProductsResponse(result SearchResult) *pb.SearchProductsResponse {
+	pbProducts := make([]*pb.Product, 0, len(result.Products))
+	for _, product := range result.Products {
+		pbProducts = append(pbProducts, ToPbProduct(product))
+	}
+
+	return &pb.SearchProductsResponse{
+		Products: pbProducts,
+		Total:    result.Total,
+		Facets: &pb.SearchFacets{
+			Categories:    ToPbFacetValues(result.Facets.Categories),
+			Sizes:         ToPbFacetValues(result.Facets.Sizes),
+			Availability:  ToPbFacetValues(result.Facets.Availability),
+			Price:         ToPbRangeFacet(result.Facets.Price),
+			AssemblyTime:  ToPbRangeFacet(result.Facets.AssemblyTime),
+			PartsAmount:   ToPbRangeFacet(result.Facets.PartsAmount),
+			MinRating:     ToPbFacetValues(result.Facets.MinRating),
+			PriceCurrency: result.Facets.PriceCurrency,
+		},
+		NextCursor: result.NextCursor,
+	}
+}
+
+func ToCategory(pbCategory *pb.Category) Category {
+	names := make(map[string]string, len(pbCategory.GetNames()))
+	for _, name := range pbCategory.GetNames() {
+		names[name.GetLocale()] = name.GetName()
+	}
+
+	return Category{
+		Id:       pbCategory.GetId(),
+		ParentId: pbCategory.GetParentId(),
+		Slug:     pbCategory.GetSlug(),
+		Position: pbCategory.GetPosition(),
+		Names:    names,
+	}
+}
+
+func ToPbCategory(category Category) *pb.Category {
+	locales := make([]string, 0, len(category.Names))
+	for locale := range category.Names {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	names := make([]*pb.LocalizedName, 0, len(locales))
+	for _, locale := range locales {
+		names = append(names, &pb.LocalizedName{Locale: locale, Name: category.Names[locale]})
+	}
+
+	return &pb.Category{
+		Id:       category.Id,
+		ParentId: category.ParentId,
+		Slug:     category.Slug,
+		Position: category.Position,
+		Names:    names,
+		Children: ToPbCategories(category.Children),
 	}
 }
+
+func ToPbCategories(categories []Category) []*pb.Category {
+	pbCategories := make([]*pb.Category, 0, len(categories))
+	for _, category := range categories {
+		pbCategories = append(pbCategories, ToPbCategory(category))
+	}
+
+	return pbCategories
+}
+
+func AdjustStockRequestToAdjustment(pbRequest *pb.AdjustStockRequest) StockAdjustment {
+	return StockAdjustment{
+		ProductId: pbRequest.GetProductId(),
+		VariantId: pbRequest.GetVariantId(),
+		UserId:    pbRequest.GetUserId(),
+		Delta:     pbRequest.GetDelta(),
+		Comment:   pbRequest.GetComment(),
+	}
+}
+
+func ToPbInventoryMovement(movement InventoryMovement) *pb.InventoryMovement {
+	return &pb.InventoryMovement{
+		Id:            movement.Id,
+		ProductId:     movement.ProductId,
+		VariantId:     movement.VariantId,
+		Kind:          movement.Kind,
+		StockDelta:    movement.StockDelta,
+		ReservedDelta: movement.ReservedDelta,
+		StockAfter:    movement.StockAfter,
+		ReservedAfter: movement.ReservedAfter,
+		ReservationId: movement.ReservationId,
+		UserId:        movement.UserId,
+		Comment:       movement.Comment,
+		CreatedAt:     timestamppb.New(movement.CreatedAt),
+	}
+}
+
+func ToPbInventoryMovements(movements []InventoryMovement, nextCursor string) *pb.InventoryMovements {
+	pbMovements := make([]*pb.InventoryMovement, 0, len(movements))
+	for _, movement := range movements {
+		pbMovements = append(pbMovements, ToPbInventoryMovement(movement))
+	}
+
+	return &pb.InventoryMovements{Movements: pbMovements, NextCursor: nextCursor}
+}
+
+func ToStockItems(pbItems []*pb.StockItem) []StockItem {
+	items := make([]StockItem, 0, len(pbItems))
+	for _, pbItem := range pbItems {
+		items = append(items, StockItem{
+			ProductId: pbItem.GetProductId(),
+			VariantId: pbItem.GetVariantId(),
+			Quantity:  pbItem.GetQuantity(),
+		})
+	}
+
+	return items
+}
+
+func ToPbStockItems(items []StockItem) []*pb.StockItem {
+	pbItems := make([]*pb.StockItem, 0, len(items))
+	for _, item := range items {
+		pbItems = append(pbItems, &pb.StockItem{ProductId: item.ProductId, VariantId: item.VariantId, Quantity: item.Quantity})
+	}
+
+	return pbItems
+}
+
+func ToPbReservation(reservation Reservation) *pb.Reservation {
+	return &pb.Reservation{
+		Id:        reservation.Id,
+		Items:     ToPbStockItems(reservation.Items),
+		Status:    reservation.Status,
+		ExpiresAt: timestamppb.New(reservation.ExpiresAt),
+		CreatedAt: timestamppb.New(reservation.CreatedAt),
+	}
+}
+
+func ToCartOwner(pbOwner *pb.CartOwner) CartOwner {
+	return CartOwner{
+		UserId: pbOwner.GetUserId(),
+		Token:  pbOwner.GetToken(),
+	}
+}
+
+func ToPbCartItem(item CartItem) *pb.CartItem {
+	var pbVariant *pb.ProductVariant
+	if item.Variant.Id != 0 {
+		pbVariant = ToPbVariant(item.Variant)
+	}
+
+	return &pb.CartItem{
+		Product:           ToPbProduct(item.Product),
+		Quantity:          item.Quantity,
+		AddedPrice:        ToPbMoney(item.AddedPrice),
+		AddedAt:           timestamppb.New(item.AddedAt),
+		Status:            item.Status,
+		AvailableQuantity: item.AvailableQuantity,
+		Variant:           pbVariant,
+	}
+}
+
+func ToPbCart(cart Cart) *pb.Cart {
+	pbShops := make([]*pb.CartShop, 0, len(cart.Shops))
+	for _, shop := range cart.Shops {
+		pbItems := make([]*pb.CartItem, 0, len(shop.Items))
+		for _, item := range shop.Items {
+			pbItems = append(pbItems, ToPbCartItem(item))
+		}
+
+		pbShops = append(pbShops, &pb.CartShop{
+			ShopId:            shop.ShopId,
+			ShopTitle:         shop.ShopTitle,
+			Items:             pbItems,
+			Subtotal:          ToPbMoney(shop.Subtotal),
+			ConvertedSubtotal: ToPbMoney(shop.ConvertedSubtotal),
+		})
+	}
+
+	pbTotals := make([]*pb.Money, 0, len(cart.Totals))
+	for _, total := range cart.Totals {
+		pbTotals = append(pbTotals, ToPbMoney(total))
+	}
+
+	return &pb.Cart{
+		Token:          cart.Token,
+		Shops:          pbShops,
+		Totals:         pbTotals,
+		ConvertedTotal: ToPbMoney(cart.ConvertedTotal),
+		ItemsCount:     cart.ItemsCount,
+	}
+}
+
+func EditWishlistRequestToWishlist(pbRequest *pb.EditWishlistRequest) Wishlist {
+	return Wishlist{
+		Id:       pbRequest.GetId(),
+		UserId:   pbRequest.GetUserId(),
+		Title:    pbRequest.GetTitle(),
+		IsPublic: pbRequest.GetIsPublic(),
+	}
+}
+
+func ToPbWishlist(wishlist Wishlist) *pb.Wishlist {
+	pbProducts := make([]*pb.Product, 0, len(wishlist.Products))
+	for _, product := range wishlist.Products {
+		pbProducts = append(pbProducts, ToPbProduct(product))
+	}
+
+	return &pb.Wishlist{
+		Id:            wishlist.Id,
+		UserId:        wishlist.UserId,
+		Title:         wishlist.Title,
+		IsDefault:     wishlist.IsDefault,
+		IsPublic:      wishlist.IsPublic,
+		ShareToken:    wishlist.ShareToken,
+		ProductsCount: wishlist.ProductsCount,
+		Products:      pbProducts,
+		CreatedAt:     timestamppb.New(wishlist.CreatedAt),
+		UpdatedAt:     timestamppb.New(wishlist.UpdatedAt),
+	}
+}
+
+func ToPbWishlists(wishlists []Wishlist) *pb.Wishlists {
+	pbWishlists := make([]*pb.Wishlist, 0, len(wishlists))
+	for _, wishlist := range wishlists {
+		pbWishlists = append(pbWishlists, ToPbWishlist(wishlist))
+	}
+
+	return &pb.Wishlists{Wishlists: pbWishlists}
+}
+
+// toTime converts optional timestamp, missing timestamp becomes zero time
+func toTime(timestamp *timestamppb.Timestamp) time.Time {
+	if timestamp == nil {
+		return time.Time{}
+	}
+
+	return timestamp.AsTime()
+}
+
+// ToMoney converts optional money, missing money becomes zero money
+func ToMoney(pbMoney *pb.Money) Money {
+	if pbMoney == nil {
+		return Money{}
+	}
+
+	return Money{Amount: pbMoney.GetAmount(), Currency: pbMoney.GetCurrency()}
+}
+
+// ToPbMoney converts optional money, zero money is not set in message
+func ToPbMoney(money Money) *pb.Money {
+	if money.IsZero() {
+		return nil
+	}
+
+	return &pb.Money{Amount: money.Amount, Currency: money.Currency}
+}
+
+// toPbTime converts optional time, zero time is not set in message
+func toPbTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+
+	return timestamppb.New(t)
+}
+
+func CreateSaleRequestToSale(pbRequest *pb.CreateSaleRequest) ProductSale {
+	return ProductSale{
+		ProductId: pbRequest.GetSale().GetProductId(),
+		VariantId: pbRequest.GetSale().GetVariantId(),
+		SalePrice: ToMoney(pbRequest.GetSale().GetSalePrice()),
+		StartsAt:  toTime(pbRequest.GetSale().GetStartsAt()),
+		EndsAt:    toTime(pbRequest.GetSale().GetEndsAt()),
+	}
+}
+
+func ToPbSale(sale ProductSale) *pb.ProductSale {
+	return &pb.ProductSale{
+		Id:        sale.Id,
+		ProductId: sale.ProductId,
+		VariantId: sale.VariantId,
+		SalePrice: ToPbMoney(sale.SalePrice),
+		StartsAt:  toPbTime(sale.StartsAt),
+		EndsAt:    toPbTime(sale.EndsAt),
+		CreatedAt: timestamppb.New(sale.CreatedAt),
+	}
+}
+
+func ToPbSales(sales []ProductSale) *pb.ProductSales {
+	pbSales := make([]*pb.ProductSale, 0, len(sales))
+	for _, sale := range sales {
+		pbSales = append(pbSales, ToPbSale(sale))
+	}
+
+	return &pb.ProductSales{Sales: pbSales}
+}
+
+func CreateShopDiscountRequestToDiscount(pbRequest *pb.CreateShopDiscountRequest) ShopDiscount {
+	return ShopDiscount{
+		ShopId:   pbRequest.GetDiscount().GetShopId(),
+		Percent:  pbRequest.GetDiscount().GetPercent(),
+		StartsAt: toTime(pbRequest.GetDiscount().GetStartsAt()),
+		EndsAt:   toTime(pbRequest.GetDiscount().GetEndsAt()),
+	}
+}
+
+func ToPbShopDiscount(discount ShopDiscount) *pb.ShopDiscount {
+	return &pb.ShopDiscount{
+		Id:        discount.Id,
+		ShopId:    discount.ShopId,
+		Percent:   discount.Percent,
+		StartsAt:  toPbTime(discount.StartsAt),
+		EndsAt:    toPbTime(discount.EndsAt),
+		CreatedAt: timestamppb.New(discount.CreatedAt),
+	}
+}
+
+func ToPbShopDiscounts(discounts []ShopDiscount) *pb.ShopDiscounts {
+	pbDiscounts := make([]*pb.ShopDiscount, 0, len(discounts))
+	for _, discount := range discounts {
+		pbDiscounts = append(pbDiscounts, ToPbShopDiscount(discount))
+	}
+
+	return &pb.ShopDiscounts{Discounts: pbDiscounts}
+}
+
+func CreatePromoCodeRequestToPromoCode(pbRequest *pb.CreatePromoCodeRequest) PromoCode {
+	pbPromoCode := pbRequest.GetPromoCode()
+	return PromoCode{
+		ShopId:         pbPromoCode.GetShopId(),
+		Code:           NormalizePromoCode(pbPromoCode.GetCode()),
+		Kind:           pbPromoCode.GetKind(),
+		Value:          pbPromoCode.GetValue(),
+		MinOrder:       pbPromoCode.GetMinOrder(),
+		MaxUses:        pbPromoCode.GetMaxUses(),
+		MaxUsesPerUser: pbPromoCode.GetMaxUsesPerUser(),
+		ExpiresAt:      toTime(pbPromoCode.GetExpiresAt()),
+	}
+}
+
+func ToPbPromoCode(promoCode PromoCode) *pb.PromoCode {
+	return &pb.PromoCode{
+		Id:             promoCode.Id,
+		ShopId:         promoCode.ShopId,
+		Code:           promoCode.Code,
+		Kind:           promoCode.Kind,
+		Value:          promoCode.Value,
+		MinOrder:       promoCode.MinOrder,
+		MaxUses:        promoCode.MaxUses,
+		MaxUsesPerUser: promoCode.MaxUsesPerUser,
+		UsedCount:      promoCode.UsedCount,
+		Currency:       promoCode.Currency,
+		ExpiresAt:      toPbTime(promoCode.ExpiresAt),
+		CreatedAt:      timestamppb.New(promoCode.CreatedAt),
+	}
+}
+
+func ToPbPromoCodes(promoCodes []PromoCode) *pb.PromoCodes {
+	pbPromoCodes := make([]*pb.PromoCode, 0, len(promoCodes))
+	for _, promoCode := range promoCodes {
+		pbPromoCodes = append(pbPromoCodes, ToPbPromoCode(promoCode))
+	}
+
+	return &pb.PromoCodes{PromoCodes: pbPromoCodes}
+}
+
+func ToPbPromoRedemption(redemption PromoRedemption) *pb.PromoRedemption {
+	return &pb.PromoRedemption{
+		Id:          redemption.Id,
+		PromoCodeId: redemption.PromoCodeId,
+		Code:        redemption.Code,
+		Discount:    ToPbMoney(redemption.Discount),
+	}
+}
+
+func ToPbPriceChange(change PriceChange) *pb.PriceChange {
+	return &pb.PriceChange{
+		Id:        change.Id,
+		ProductId: change.ProductId,
+		VariantId: change.VariantId,
+		ShopId:    change.ShopId,
+		Kind:      change.Kind,
+		OldPrice:  ToPbMoney(change.OldPrice),
+		NewPrice:  ToPbMoney(change.NewPrice),
+		Percent:   change.Percent,
+		StartsAt:  toPbTime(change.StartsAt),
+		EndsAt:    toPbTime(change.EndsAt),
+		UserId:    change.UserId,
+		CreatedAt: timestamppb.New(change.CreatedAt),
+	}
+}
+
+func ToPbPriceHistory(changes []PriceChange, nextCursor string) *pb.PriceHistory {
+	pbChanges := make([]*pb.PriceChange, 0, len(changes))
+	for _, change := range changes {
+		pbChanges = append(pbChanges, ToPbPriceChange(change))
+	}
+
+	return &pb.PriceHistory{Changes: pbChanges, NextCursor: nextCursor}
+}
+
+func ToPbExchangeRates(rates ExchangeRates) *pb.ExchangeRates {
+	pbRates := make([]*pb.ExchangeRate, 0, len(rates.Rates))
+	for _, rate := range rates.Rates {
+		pbRates = append(pbRates, &pb.ExchangeRate{
+			Currency:   rate.Currency,
+			Rate:       rate.Rate,
+			Rounding:   rate.Rounding,
+			MinorUnits: MinorUnits(rate.Currency),
+			UpdatedAt:  toPbTime(rate.UpdatedAt),
+		})
+	}
+
+	return &pb.ExchangeRates{Base: rates.Base, Rates: pbRates}
+}
+
+func ToExchangeRates(pbRates *pb.ExchangeRates) (base string, rates []ExchangeRate) {
+	rates = make([]ExchangeRate, 0, len(pbRates.GetRates()))
+	for _, pbRate := range pbRates.GetRates() {
+		rates = append(rates, ExchangeRate{
+			Currency: pbRate.GetCurrency(),
+			Rate:     pbRate.GetRate(),
+			Rounding: pbRate.GetRounding(),
+		})
+	}
+
+	return pbRates.GetBase(), rates
+}
+
+func ToPbProductImport(productImport ProductImport) *pb.ProductImport {
+	pbErrors := make([]*pb.ImportRowError, 0, len(productImport.Errors))
+	for _, rowError := range productImport.Errors {
+		pbErrors = append(pbErrors, &pb.ImportRowError{
+			Row:     rowError.Row,
+			Sku:     rowError.SKU,
+			Column:  rowError.Column,
+			Message: rowError.Message,
+		})
+	}
+
+	return &pb.ProductImport{
+		Id:            productImport.Id,
+		ShopId:        productImport.ShopId,
+		Format:        productImport.Format,
+		DryRun:        productImport.DryRun,
+		Status:        productImport.Status,
+		TotalRows:     productImport.TotalRows,
+		ProcessedRows: productImport.ProcessedRows,
+		CreatedCount:  productImport.CreatedCount,
+		UpdatedCount:  productImport.UpdatedCount,
+		FailedCount:   productImport.FailedCount,
+		Errors:        pbErrors,
+		ErrorMessage:  productImport.ErrorMessage,
+		CreatedAt:     timestamppb.New(productImport.CreatedAt),
+		FinishedAt:    toPbTime(productImport.FinishedAt),
+	}
+}
+
+func ToPbShopAnalytics(analytics ShopAnalytics) *pb.ShopAnalytics {
+	pbDays := make([]*pb.DailyViews, 0, len(analytics.Days))
+	for _, day := range analytics.Days {
+		pbDays = append(pbDays, &pb.DailyViews{
+			Day:           day.Day.Format(AnalyticsDateLayout),
+			Views:         day.Views,
+			UniqueViewers: day.UniqueViewers,
+		})
+	}
+
+	pbProducts := make([]*pb.ProductAnalytics, 0, len(analytics.Products))
+	for _, product := range analytics.Products {
+		pbProducts = append(pbProducts, &pb.ProductAnalytics{
+			ProductId:         product.ProductId,
+			Title:             product.Title,
+			Sku:               product.SKU,
+			Views:             product.Views,
+			UniqueViewers:     product.UniqueViewers,
+			ReviewsCount:      product.ReviewsCount,
+			ViewToReviewRatio: product.ViewToReviewRatio,
+			Referrers:         toPbReferrerViews(product.Referrers),
+		})
+	}
+
+	return &pb.ShopAnalytics{
+		ShopId:            analytics.ShopId,
+		From:              analytics.From.Format(AnalyticsDateLayout),
+		To:                analytics.To.Format(AnalyticsDateLayout),
+		ShopViews:         analytics.ShopViews,
+		ShopUniqueViewers: analytics.ShopUniqueViewers,
+		ProductViews:      analytics.ProductViews,
+		Days:              pbDays,
+		Referrers:         toPbReferrerViews(analytics.Referrers),
+		Products:          pbProducts,
+	}
+}
+
+func toPbReferrerViews(referrers []ReferrerViews) []*pb.ReferrerViews {
+	pbReferrers := make([]*pb.ReferrerViews, 0, len(referrers))
+	for _, referrer := range referrers {
+		pbReferrers = append(pbReferrers, &pb.ReferrerViews{Referrer: referrer.Referrer, Views: referrer.Views})
+	}
+
+	return pbReferrers
+}
+
+func ToPbQuestion(question ProductQuestion) *pb.Question {
+	pbAnswers := make([]*pb.Answer, 0, len(question.Answers))
+	for _, answer := range question.Answers {
+		pbAnswers = append(pbAnswers, &pb.Answer{
+			Id:         answer.Id,
+			QuestionId: answer.QuestionId,
+			UserId:     answer.UserId,
+			Username:   answer.Username,
+			Text:       answer.Text,
+			AuthorRole: answer.AuthorRole,
+			IsOfficial: answer.IsOfficial,
+			CreatedAt:  timestamppb.New(answer.CreatedAt),
+		})
+	}
+
+	return &pb.Question{
+		Id:           question.Id,
+		ProductId:    question.ProductId,
+		UserId:       question.UserId,
+		Username:     question.Username,
+		Text:         question.Text,
+		VotesCount:   question.VotesCount,
+		AnswersCount: question.AnswersCount,
+		IsVoted:      question.IsVoted,
+		Answers:      pbAnswers,
+		CreatedAt:    timestamppb.New(question.CreatedAt),
+	}
+}
+
+func ToPbQuestionsList(questions []ProductQuestion, nextCursor string) *pb.QuestionsList {
+	pbQuestions := make([]*pb.Question, 0, len(questions))
+	for _, question := range questions {
+		pbQuestions = append(pbQuestions, ToPbQuestion(question))
+	}
+
+	return &pb.QuestionsList{Questions: pbQuestions, NextCursor: nextCursor}
+}
+
+// ToPbExportSections encodes records of every section as JSON array
+func ToPbExportSections(sections []ExportSection) (*pb.ExportSections, error) {
+	pbSections := make([]*pb.ExportSection, 0, len(sections))
+	for _, section := range sections {
+		data, err := json.Marshal(section.Records)
+		if err != nil {
+			return nil, err
+		}
+
+		pbSections = append(pbSections, &pb.ExportSection{Name: section.Name, Data: data, MediaLinks: section.MediaLinks})
+	}
+
+	return &pb.ExportSections{Sections: pbSections}, nil
+}
@@ -0,0 +1,357 @@
+package domain
+
+import (
+	"encoding/xml"
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats of marketplace feeds: Yandex Market Language and Google Merchant RSS
+const (
+	FeedFormatYML    = "yml"
+	FeedFormatGoogle = "google"
+)
+
+const (
+	// FeedMaxAge is time after which feed is assembled again even if none of its products changed,
+	// so that categories and exchange rates in it are kept up to date
+	FeedMaxAge = time.Hour
+	// FeedLocale is locale of category names in feeds, other locales are used if category has no name in it
+	FeedLocale = "ru"
+	// FeedSiteName is name of the whole catalog feed
+	FeedSiteName = "Pinterbest"
+	// MaxFeedPictures is maximum amount of pictures of one offer which marketplaces accept
+	MaxFeedPictures = 10
+	// ProductPagePath and ShopPagePath are paths of pages which feeds link to, relative to site's URL
+	ProductPagePath = "/product/%d"
+	ShopPagePath    = "/shop/%d"
+	// MediaURLPath is prefix of media links, it is the same path gateway serves media at
+	MediaURLPath = "/api/media/"
+)
+
+// MarketplaceFeed is cached document of feed, ShopId is 0 for feed of the whole catalog.
+// OffersCount is amount of products in feed, it changes when products are deleted
+type MarketplaceFeed struct {
+	ShopId      uint64
+	Format      string
+	Document    []byte
+	OffersCount uint64
+	GeneratedAt time.Time
+}
+
+// FeedOffer is rendered XML of one product in feed format, offers are rendered again only when their product changes
+type FeedOffer struct {
+	ProductId uint64
+	Format    string
+	Fragment  string
+}
+
+// FeedScope describes products which go into feed
+type FeedScope struct {
+	ProductsCount uint64
+	// Currencies are currencies of products' prices
+	Currencies []string
+}
+
+// FeedSite is what feed's header tells about site or shop the feed belongs to
+type FeedSite struct {
+	Name        string
+	Description string
+	URL         string
+}
+
+func ValidFeedFormat(format string) bool {
+	return format == FeedFormatYML || format == FeedFormatGoogle
+}
+
+// Decimal formats amount in major units of currency, such as "1990.00"
+func (money Money) Decimal() string {
+	units := MinorUnits(money.Currency)
+	value := new(big.Rat).SetFrac(new(big.Int).SetUint64(money.Amount), pow10(units))
+	return value.FloatString(int(units))
+}
+
+// BaseRate returns price of one unit of currency in base currency, which is how YML lists currencies
+func (rates ExchangeRates) BaseRate(currency string) (rate string, found bool) {
+	ratio := rates.ratios[currency]
+	if ratio == nil {
+		return "", false
+	}
+
+	return new(big.Rat).Inv(ratio).FloatString(4), true
+}
+
+// CategoryName returns category's name in FeedLocale, or in the first of its locales if it has no such name
+func (category Category) CategoryName() string {
+	if name, found := category.Names[FeedLocale]; found {
+		return name
+	}
+
+	locales := make([]string, 0, len(category.Names))
+	for locale := range category.Names {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+
+	if len(locales) == 0 {
+		return category.Slug
+	}
+	return category.Names[locales[0]]
+}
+
+// ymlOffer is offer element of YML feed
+type ymlOffer struct {
+	XMLName     xml.Name   `xml:"offer"`
+	Id          uint64     `xml:"id,attr"`
+	Available   bool       `xml:"available,attr"`
+	URL         string     `xml:"url"`
+	Price       string     `xml:"price"`
+	OldPrice    string     `xml:"oldprice,omitempty"`
+	CurrencyId  string     `xml:"currencyId"`
+	CategoryId  uint64     `xml:"categoryId,omitempty"`
+	Pictures    []string   `xml:"picture"`
+	Name        string     `xml:"name"`
+	VendorCode  string     `xml:"vendorCode,omitempty"`
+	Description string     `xml:"description,omitempty"`
+	Params      []ymlParam `xml:"param"`
+}
+
+type ymlParam struct {
+	Name  string `xml:"name,attr"`
+	Unit  string `xml:"unit,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+type ymlCatalog struct {
+	XMLName xml.Name `xml:"yml_catalog"`
+	Date    string   `xml:"date,attr"`
+	Shop    ymlShop  `xml:"shop"`
+}
+
+type ymlShop struct {
+	Name       string        `xml:"name"`
+	Company    string        `xml:"company"`
+	URL        string        `xml:"url"`
+	Currencies []ymlCurrency `xml:"currencies>currency"`
+	Categories []ymlCategory `xml:"categories>category"`
+	Offers     rawOffers     `xml:"offers"`
+}
+
+type ymlCurrency struct {
+	Id   string `xml:"id,attr"`
+	Rate string `xml:"rate,attr"`
+}
+
+type ymlCategory struct {
+	Id       uint64 `xml:"id,attr"`
+	ParentId uint64 `xml:"parentId,attr,omitempty"`
+	Name     string `xml:",chardata"`
+}
+
+// googleItem is item element of Google Merchant feed, elements of "g" namespace are named with prefix
+type googleItem struct {
+	XMLName              xml.Name `xml:"item"`
+	Id                   uint64   `xml:"g:id"`
+	Title                string   `xml:"g:title"`
+	Description          string   `xml:"g:description"`
+	Link                 string   `xml:"g:link"`
+	ImageLink            string   `xml:"g:image_link,omitempty"`
+	AdditionalImageLinks []string `xml:"g:additional_image_link"`
+	Availability         string   `xml:"g:availability"`
+	Price                string   `xml:"g:price"`
+	SalePrice            string   `xml:"g:sale_price,omitempty"`
+	Condition            string   `xml:"g:condition"`
+	MPN                  string   `xml:"g:mpn,omitempty"`
+	IdentifierExists     string   `xml:"g:identifier_exists"`
+	Size                 string   `xml:"g:size,omitempty"`
+}
+
+type googleRSS struct {
+	XMLName   xml.Name      `xml:"rss"`
+	Version   string        `xml:"version,attr"`
+	Namespace string        `xml:"xmlns:g,attr"`
+	Channel   googleChannel `xml:"channel"`
+}
+
+type googleChannel struct {
+	Title       string `xml:"title"`
+	Link        string `xml:"link"`
+	Description string `xml:"description"`
+	// Items are rendered items, they are put into channel as they are
+	Items string `xml:",innerxml"`
+}
+
+// rawOffers holds rendered offers of YML, they are put into offers element as they are
+type rawOffers struct {
+	XML string `xml:",innerxml"`
+}
+
+// RenderFeedOffer renders product as offer of feed. Product must have discounted price, links are made absolute with siteURL
+func RenderFeedOffer(format string, product Product, siteURL string) (offer FeedOffer, err error) {
+	var element interface{}
+	switch format {
+	case FeedFormatYML:
+		element = toYMLOffer(product, siteURL)
+	case FeedFormatGoogle:
+		element = toGoogleItem(product, siteURL)
+	default:
+		return FeedOffer{}, InvalidFeedFormatError
+	}
+
+	fragment, err := xml.Marshal(element)
+	if err != nil {
+		return FeedOffer{}, err
+	}
+
+	return FeedOffer{ProductId: product.Id, Format: format, Fragment: string(fragment)}, nil
+}
+
+func toYMLOffer(product Product, siteURL string) ymlOffer {
+	offer := ymlOffer{
+		Id:          product.Id,
+		Available:   product.Availability,
+		URL:         siteURL + fmt.Sprintf(ProductPagePath, product.Id),
+		Price:       product.DiscountedPrice.Decimal(),
+		CurrencyId:  product.Price.Currency,
+		CategoryId:  product.CategoryId,
+		Pictures:    feedPictures(product, siteURL),
+		Name:        product.Title,
+		VendorCode:  product.SKU,
+		Description: product.Description,
+		Params:      make([]ymlParam, 0),
+	}
+
+	if product.DiscountedPrice.Amount < product.Price.Amount {
+		offer.OldPrice = product.Price.Decimal()
+	}
+
+	if product.Size != "" {
+		offer.Params = append(offer.Params, ymlParam{Name: "Size", Value: product.Size})
+	}
+	if product.PartsAmount != 0 {
+		offer.Params = append(offer.Params, ymlParam{Name: "Parts amount", Value: strconv.FormatUint(product.PartsAmount, 10)})
+	}
+	if product.AssemblyTime != 0 {
+		offer.Params = append(offer.Params, ymlParam{Name: "Assembly time", Unit: "min", Value: strconv.FormatUint(product.AssemblyTime, 10)})
+	}
+
+	return offer
+}
+
+func toGoogleItem(product Product, siteURL string) googleItem {
+	item := googleItem{
+		Id:                   product.Id,
+		Title:                product.Title,
+		Description:          product.Description,
+		Link:                 siteURL + fmt.Sprintf(ProductPagePath, product.Id),
+		AdditionalImageLinks: make([]string, 0),
+		Availability:         "out_of_stock",
+		Price:                product.Price.Decimal() + " " + product.Price.Currency,
+		Condition:            "new",
+		MPN:                  product.SKU,
+		IdentifierExists:     "no",
+		Size:                 product.Size,
+	}
+
+	if product.Availability {
+		item.Availability = "in_stock"
+	}
+	if product.DiscountedPrice.Amount < product.Price.Amount {
+		item.SalePrice = product.DiscountedPrice.Decimal() + " " + product.DiscountedPrice.Currency
+	}
+
+	pictures := feedPictures(product, siteURL)
+	if len(pictures) != 0 {
+		item.ImageLink = pictures[0]
+		item.AdditionalImageLinks = pictures[1:]
+	}
+
+	return item
+}
+
+func feedPictures(product Product, siteURL string) []string {
+	pictures := make([]string, 0, len(product.ImageLinks))
+	for _, link := range product.ImageLinks {
+		if len(pictures) == MaxFeedPictures {
+			break
+		}
+		pictures = append(pictures, siteURL+MediaURLPath+link)
+	}
+
+	return pictures
+}
+
+// AssembleFeed puts rendered offers into document of feed. Categories and exchange rates are used only by YML
+func AssembleFeed(format string, site FeedSite, offers []string, categories []Category, scope FeedScope,
+	rates ExchangeRates, now time.Time) (document []byte, err error) {
+	var root interface{}
+	switch format {
+	case FeedFormatYML:
+		root = ymlCatalog{
+			Date: now.Format(time.RFC3339),
+			Shop: ymlShop{
+				Name:       site.Name,
+				Company:    site.Name,
+				URL:        site.URL,
+				Currencies: ymlCurrencies(scope.Currencies, rates),
+				Categories: ymlCategories(categories),
+				Offers:     rawOffers{XML: strings.Join(offers, "")},
+			},
+		}
+	case FeedFormatGoogle:
+		root = googleRSS{
+			Version:   "2.0",
+			Namespace: "http://base.google.com/ns/1.0",
+			Channel: googleChannel{
+				Title:       site.Name,
+				Link:        site.URL,
+				Description: site.Description,
+				Items:       strings.Join(offers, ""),
+			},
+		}
+	default:
+		return nil, InvalidFeedFormatError
+	}
+
+	body, err := xml.Marshal(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), body...), nil
+}
+
+// ymlCurrencies lists currencies of offers, base currency has rate 1. Currencies without exchange rate
+// are listed with rate of the Central Bank of Russia, which Yandex Market takes itself
+func ymlCurrencies(currencies []string, rates ExchangeRates) []ymlCurrency {
+	ymlRates := make([]ymlCurrency, 0, len(currencies))
+	for _, currency := range currencies {
+		rate, found := rates.BaseRate(currency)
+		if currency == DefaultCurrency {
+			rate = "1"
+		} else if !found {
+			rate = "CBRF"
+		}
+
+		ymlRates = append(ymlRates, ymlCurrency{Id: currency, Rate: rate})
+	}
+
+	return ymlRates
+}
+
+func ymlCategories(categories []Category) []ymlCategory {
+	ymlCategoriesList := make([]ymlCategory, 0, len(categories))
+	for _, category := range categories {
+		ymlCategoriesList = append(ymlCategoriesList, ymlCategory{
+			Id:       category.Id,
+			ParentId: category.ParentId,
+			Name:     category.CategoryName(),
+		})
+	}
+
+	return ymlCategoriesList
+}
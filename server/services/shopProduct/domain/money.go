@@ -0,0 +1,266 @@
+package domain
+
+import (
+	"math/big"
+	"time"
+)
+
+// DefaultCurrency is currency of shops which did not choose one. It is also base currency of exchange rates
+const DefaultCurrency = "RUB"
+
+// Rounding rules of converted amounts, they are applied to minor units of target currency
+const (
+	// RoundHalfUp rounds halves away from zero, it is used if exchange rate does not specify rounding
+	RoundHalfUp = "half_up"
+	// RoundHalfEven rounds halves to even amount, so that rounding errors do not pile up in sums
+	RoundHalfEven = "half_even"
+	RoundUp       = "up"
+	RoundDown     = "down"
+)
+
+// currencyMinorUnits maps ISO 4217 codes of supported currencies to amount of decimal digits of their minor units
+var currencyMinorUnits = map[string]uint32{
+	"RUB": 2,
+	"BYN": 2,
+	"KZT": 2,
+	"UAH": 2,
+	"AMD": 2,
+	"AZN": 2,
+	"GEL": 2,
+	"KGS": 2,
+	"MDL": 2,
+	"TJS": 2,
+	"TMT": 2,
+	"UZS": 2,
+	"CNY": 2,
+	"TRY": 2,
+	"USD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"JPY": 0,
+	"KRW": 0,
+}
+
+// Money is amount in minor units of currency, such as kopecks. Currency is ISO 4217 code
+type Money struct {
+	Amount   uint64
+	Currency string
+}
+
+// ValidCurrency reports whether currency is supported
+func ValidCurrency(currency string) bool {
+	_, found := currencyMinorUnits[currency]
+	return found
+}
+
+// MinorUnits returns amount of decimal digits of currency's minor units
+func MinorUnits(currency string) uint32 {
+	return currencyMinorUnits[currency]
+}
+
+// Times returns price of quantity units
+func (money Money) Times(quantity uint64) Money {
+	return Money{Amount: money.Amount * quantity, Currency: money.Currency}
+}
+
+// IsZero reports whether money is not set, zero amount of known currency is not zero money
+func (money Money) IsZero() bool {
+	return money.Amount == 0 && money.Currency == ""
+}
+
+// ExchangeRate is amount of currency which equals one unit of base currency. Rate is decimal string,
+// so that it is kept exactly. Rounding is applied to amounts converted into currency
+type ExchangeRate struct {
+	Currency  string
+	Rate      string
+	Rounding  string
+	UpdatedAt time.Time
+}
+
+// ExchangeRates converts money between currencies which have rates. Rates are parsed once, when they are created
+type ExchangeRates struct {
+	Base  string
+	Rates []ExchangeRate
+	// ratios and byCode map currencies to their parsed rates and to rates themselves
+	ratios map[string]*big.Rat
+	byCode map[string]ExchangeRate
+}
+
+// NewExchangeRates checks rates: currencies must be known and must not repeat, rates must be positive decimals
+// and base currency must have rate 1. Empty rounding becomes RoundHalfUp
+func NewExchangeRates(base string, rates []ExchangeRate) (ExchangeRates, error) {
+	exchangeRates := ExchangeRates{
+		Base:   base,
+		Rates:  make([]ExchangeRate, 0, len(rates)),
+		ratios: make(map[string]*big.Rat, len(rates)),
+		byCode: make(map[string]ExchangeRate, len(rates)),
+	}
+
+	if base != DefaultCurrency {
+		return ExchangeRates{}, InvalidExchangeRatesError
+	}
+
+	for _, rate := range rates {
+		if !ValidCurrency(rate.Currency) {
+			return ExchangeRates{}, InvalidCurrencyError
+		}
+
+		ratio, ok := new(big.Rat).SetString(rate.Rate)
+		if !ok || ratio.Sign() <= 0 || exchangeRates.ratios[rate.Currency] != nil {
+			return ExchangeRates{}, InvalidExchangeRatesError
+		}
+
+		if rate.Rounding == "" {
+			rate.Rounding = RoundHalfUp
+		}
+		switch rate.Rounding {
+		case RoundHalfUp, RoundHalfEven, RoundUp, RoundDown:
+		default:
+			return ExchangeRates{}, InvalidExchangeRatesError
+		}
+
+		exchangeRates.Rates = append(exchangeRates.Rates, rate)
+		exchangeRates.ratios[rate.Currency] = ratio
+		exchangeRates.byCode[rate.Currency] = rate
+	}
+
+	baseRatio := exchangeRates.ratios[base]
+	if baseRatio == nil || baseRatio.Cmp(big.NewRat(1, 1)) != 0 {
+		return ExchangeRates{}, InvalidExchangeRatesError
+	}
+
+	return exchangeRates, nil
+}
+
+// Has reports whether money can be converted from and into currency
+func (rates ExchangeRates) Has(currency string) bool {
+	return rates.ratios[currency] != nil
+}
+
+// Convert converts money into currency and rounds result to minor units of currency by its rounding rule.
+// Money in the same currency is returned unchanged, money whose converted amount overflows is rejected
+func (rates ExchangeRates) Convert(money Money, currency string) (Money, error) {
+	if money.Currency == currency {
+		return money, nil
+	}
+
+	fromRatio, toRatio := rates.ratios[money.Currency], rates.ratios[currency]
+	if fromRatio == nil || toRatio == nil {
+		return Money{}, ExchangeRateNotFoundError
+	}
+
+	// amount / 10^fromUnits / fromRatio * toRatio * 10^toUnits
+	value := new(big.Rat).SetInt(new(big.Int).SetUint64(money.Amount))
+	value.Mul(value, toRatio)
+	value.Quo(value, fromRatio)
+	value.Mul(value, new(big.Rat).SetInt(pow10(MinorUnits(currency))))
+	value.Quo(value, new(big.Rat).SetInt(pow10(MinorUnits(money.Currency))))
+
+	amount := round(value, rates.byCode[currency].Rounding)
+	if !amount.IsUint64() {
+		return Money{}, ConversionOverflowError
+	}
+
+	return Money{Amount: amount.Uint64(), Currency: currency}, nil
+}
+
+func pow10(exponent uint32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+}
+
+// round rounds non-negative value to integer by rounding rule
+func round(value *big.Rat, rounding string) *big.Int {
+	quotient, remainder := new(big.Int).QuoRem(value.Num(), value.Denom(), new(big.Int))
+	if remainder.Sign() == 0 {
+		return quotient
+	}
+
+	// Comparison of doubled remainder with denominator tells whether fraction is less than, equal to or more than half
+	half := new(big.Int).Lsh(remainder, 1).Cmp(value.Denom())
+	var roundUp bool
+	switch rounding {
+	case RoundUp:
+		roundUp = true
+	case RoundDown:
+	case RoundHalfEven:
+		roundUp = half > 0 || (half == 0 && quotient.Bit(0) == 1)
+	default:
+		roundUp = half >= 0
+	}
+
+	if roundUp {
+		quotient.Add(quotient, big.NewInt(1))
+	}
+	return quotient
+}
+
+// ConvertProduct sets converted prices of product and of its variants. Product in currency without rate is left
+// unconverted, rates must have currency itself
+func (rates ExchangeRates) ConvertProduct(product Product, currency string) Product {
+	if !rates.Has(product.Price.Currency) {
+		return product
+	}
+
+	product.ConvertedPrice, _ = rates.Convert(product.Price, currency)
+	product.ConvertedDiscountedPrice, _ = rates.Convert(product.DiscountedPrice, currency)
+
+	if len(product.Variants) != 0 {
+		variants := make([]ProductVariant, 0, len(product.Variants))
+		for _, variant := range product.Variants {
+			variant.ConvertedPrice, _ = rates.Convert(variant.Price, currency)
+			variant.ConvertedDiscountedPrice, _ = rates.Convert(variant.DiscountedPrice, currency)
+			variants = append(variants, variant)
+		}
+		product.Variants = variants
+	}
+
+	return product
+}
+
+// ConvertCart sets converted prices of cart's products, converted subtotals of its shops and converted total.
+// Converted total is sum of converted subtotals, it is zero if some of shops are in currency without rate
+// or their subtotals can not be converted
+func (rates ExchangeRates) ConvertCart(cart Cart, currency string) Cart {
+	cart.ConvertedTotal = Money{Currency: currency}
+	for i := range cart.Shops {
+		shop := &cart.Shops[i]
+		for j := range shop.Items {
+			item := &shop.Items[j]
+			item.Product = rates.ConvertProduct(item.Product, currency)
+			if item.Variant.Id != 0 {
+				item.Variant.ConvertedPrice = item.Product.ConvertedPrice
+				item.Variant.ConvertedDiscountedPrice = item.Product.ConvertedDiscountedPrice
+			}
+		}
+
+		if !rates.Has(shop.Subtotal.Currency) {
+			cart.ConvertedTotal = Money{}
+			continue
+		}
+
+		var err error
+		shop.ConvertedSubtotal, err = rates.Convert(shop.Subtotal, currency)
+		if err != nil {
+			cart.ConvertedTotal = Money{}
+			continue
+		}
+		if !cart.ConvertedTotal.IsZero() {
+			cart.ConvertedTotal.Amount += shop.ConvertedSubtotal.Amount
+		}
+	}
+
+	return cart
+}
+
+// InCurrency sets currency of money which was passed without one, money in another currency is rejected
+func (money Money) InCurrency(currency string) (Money, error) {
+	if money.Currency == "" {
+		money.Currency = currency
+	}
+
+	if money.Currency != currency {
+		return Money{}, CurrencyMismatchError
+	}
+
+	return money, nil
+}
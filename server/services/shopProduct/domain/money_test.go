@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestNewExchangeRates(t *testing.T) {
+	tests := []struct {
+		name  string
+		base  string
+		rates []ExchangeRate
+		err   error
+	}{
+		{"valid", DefaultCurrency, []ExchangeRate{{Currency: "RUB", Rate: "1"}, {Currency: "USD", Rate: "0.011", Rounding: RoundHalfEven}}, nil},
+		{"other base", "USD", []ExchangeRate{{Currency: "USD", Rate: "1"}}, InvalidExchangeRatesError},
+		{"without base", DefaultCurrency, []ExchangeRate{{Currency: "USD", Rate: "0.011"}}, InvalidExchangeRatesError},
+		{"base rate is not 1", DefaultCurrency, []ExchangeRate{{Currency: "RUB", Rate: "1.01"}}, InvalidExchangeRatesError},
+		{"unknown currency", DefaultCurrency, []ExchangeRate{{Currency: "RUB", Rate: "1"}, {Currency: "XXX", Rate: "2"}}, InvalidCurrencyError},
+		{"repeated currency", DefaultCurrency, []ExchangeRate{{Currency: "RUB", Rate: "1"}, {Currency: "USD", Rate: "0.011"}, {Currency: "USD", Rate: "0.012"}}, InvalidExchangeRatesError},
+		{"zero rate", DefaultCurrency, []ExchangeRate{{Currency: "RUB", Rate: "1"}, {Currency: "USD", Rate: "0"}}, InvalidExchangeRatesError},
+		{"negative rate", DefaultCurrency, []ExchangeRate{{Currency: "RUB", Rate: "1"}, {Currency: "USD", Rate: "-0.011"}}, InvalidExchangeRatesError},
+		{"rate is not number", DefaultCurrency, []ExchangeRate{{Currency: "RUB", Rate: "1"}, {Currency: "USD", Rate: "cheap"}}, InvalidExchangeRatesError},
+		{"unknown rounding", DefaultCurrency, []ExchangeRate{{Currency: "RUB", Rate: "1"}, {Currency: "USD", Rate: "0.011", Rounding: "ceiling"}}, InvalidExchangeRatesError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewExchangeRates(test.base, test.rates)
+			if err != test.err {
+				t.Errorf("expected error %v, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestNewExchangeRatesDefaultRounding(t *testing.T) {
+	rates, err := NewExchangeRates(DefaultCurrency, []ExchangeRate{{Currency: "RUB", Rate: "1"}})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	if rates.Rates[0].Rounding != RoundHalfUp {
+		t.Errorf("expected rounding %q, got %q", RoundHalfUp, rates.Rates[0].Rounding)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     ExchangeRate
+		money    Money
+		currency string
+		result   Money
+		err      error
+	}{
+		{"exact", ExchangeRate{Currency: "USD", Rate: "0.011"}, Money{Amount: 10000, Currency: "RUB"}, "USD", Money{Amount: 110, Currency: "USD"}, nil},
+		{"into base currency", ExchangeRate{Currency: "USD", Rate: "0.011"}, Money{Amount: 110, Currency: "USD"}, "RUB", Money{Amount: 10000, Currency: "RUB"}, nil},
+		{"same currency", ExchangeRate{Currency: "USD", Rate: "0.011"}, Money{Amount: 123, Currency: "GBP"}, "GBP", Money{Amount: 123, Currency: "GBP"}, nil},
+		{"currency without minor units", ExchangeRate{Currency: "JPY", Rate: "1.65"}, Money{Amount: 10000, Currency: "RUB"}, "JPY", Money{Amount: 165, Currency: "JPY"}, nil},
+		{"half up rounds up", ExchangeRate{Currency: "USD", Rate: "0.5", Rounding: RoundHalfUp}, Money{Amount: 1, Currency: "RUB"}, "USD", Money{Amount: 1, Currency: "USD"}, nil},
+		{"half up rounds down", ExchangeRate{Currency: "USD", Rate: "0.011", Rounding: RoundHalfUp}, Money{Amount: 40, Currency: "RUB"}, "USD", Money{Amount: 0, Currency: "USD"}, nil},
+		{"half even rounds to even below", ExchangeRate{Currency: "USD", Rate: "0.5", Rounding: RoundHalfEven}, Money{Amount: 5, Currency: "RUB"}, "USD", Money{Amount: 2, Currency: "USD"}, nil},
+		{"half even rounds to even above", ExchangeRate{Currency: "USD", Rate: "0.5", Rounding: RoundHalfEven}, Money{Amount: 7, Currency: "RUB"}, "USD", Money{Amount: 4, Currency: "USD"}, nil},
+		{"half even rounds more than half up", ExchangeRate{Currency: "USD", Rate: "0.6", Rounding: RoundHalfEven}, Money{Amount: 1, Currency: "RUB"}, "USD", Money{Amount: 1, Currency: "USD"}, nil},
+		{"up", ExchangeRate{Currency: "USD", Rate: "0.011", Rounding: RoundUp}, Money{Amount: 1, Currency: "RUB"}, "USD", Money{Amount: 1, Currency: "USD"}, nil},
+		{"down", ExchangeRate{Currency: "USD", Rate: "0.019", Rounding: RoundDown}, Money{Amount: 100, Currency: "RUB"}, "USD", Money{Amount: 1, Currency: "USD"}, nil},
+		{"zero amount", ExchangeRate{Currency: "USD", Rate: "0.011", Rounding: RoundUp}, Money{Amount: 0, Currency: "RUB"}, "USD", Money{Amount: 0, Currency: "USD"}, nil},
+		{"overflow", ExchangeRate{Currency: "USD", Rate: "1000"}, Money{Amount: math.MaxUint64, Currency: "RUB"}, "USD", Money{}, ConversionOverflowError},
+		{"without rate of target", ExchangeRate{Currency: "USD", Rate: "0.011"}, Money{Amount: 100, Currency: "RUB"}, "EUR", Money{}, ExchangeRateNotFoundError},
+		{"without rate of source", ExchangeRate{Currency: "USD", Rate: "0.011"}, Money{Amount: 100, Currency: "EUR"}, "RUB", Money{}, ExchangeRateNotFoundError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rates, err := NewExchangeRates(DefaultCurrency, []ExchangeRate{{Currency: "RUB", Rate: "1"}, test.rate})
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+
+			result, err := rates.Convert(test.money, test.currency)
+			if err != test.err {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+			if result != test.result {
+				t.Errorf("expected %v, got %v", test.result, result)
+			}
+		})
+	}
+}
+
+func TestRound(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    *big.Rat
+		rounding string
+		result   int64
+	}{
+		{"integer", big.NewRat(4, 1), RoundUp, 4},
+		{"half up below half", big.NewRat(9, 4), RoundHalfUp, 2},
+		{"half up at half", big.NewRat(5, 2), RoundHalfUp, 3},
+		{"empty rounding is half up", big.NewRat(5, 2), "", 3},
+		{"half even at half of even", big.NewRat(5, 2), RoundHalfEven, 2},
+		{"half even at half of odd", big.NewRat(7, 2), RoundHalfEven, 4},
+		{"half even above half", big.NewRat(11, 4), RoundHalfEven, 3},
+		{"up", big.NewRat(9, 4), RoundUp, 3},
+		{"down", big.NewRat(11, 4), RoundDown, 2},
+		{"less than one", big.NewRat(1, 3), RoundDown, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := round(test.value, test.rounding)
+			if result.Cmp(big.NewInt(test.result)) != 0 {
+				t.Errorf("expected %d, got %s", test.result, result)
+			}
+		})
+	}
+}
+
+func TestMoneyInCurrency(t *testing.T) {
+	tests := []struct {
+		name   string
+		money  Money
+		result Money
+		err    error
+	}{
+		{"without currency", Money{Amount: 100}, Money{Amount: 100, Currency: "RUB"}, nil},
+		{"in currency", Money{Amount: 100, Currency: "RUB"}, Money{Amount: 100, Currency: "RUB"}, nil},
+		{"in other currency", Money{Amount: 100, Currency: "USD"}, Money{}, CurrencyMismatchError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := test.money.InCurrency("RUB")
+			if err != test.err {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+			if result != test.result {
+				t.Errorf("expected %v, got %v", test.result, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,277 @@
+package domain
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxDiscountPercent limits shop discounts, so that products are not given away by mistake
+	MaxDiscountPercent = 90
+	// MaxPriceHistoryPageSize is used when limit is not specified or is too big
+	MaxPriceHistoryPageSize = 100
+)
+
+// Kinds of promo codes
+const (
+	// PromoFixed takes fixed amount off order, but not more than order's subtotal
+	PromoFixed   = "fixed"
+	PromoPercent = "percent"
+)
+
+// Kinds of price changes
+const (
+	// PriceChangeList is change of list price of product or of price override of variant
+	PriceChangeList              = "list"
+	PriceChangeSale              = "sale"
+	PriceChangeSaleCancelled     = "sale_cancelled"
+	PriceChangeDiscount          = "discount"
+	PriceChangeDiscountCancelled = "discount_cancelled"
+)
+
+// promoCodeRegexp matches promo codes after they are converted to upper case
+var promoCodeRegexp = regexp.MustCompile(`^[A-Z0-9_-]{3,32}$`)
+
+// ProductSale is scheduled sale price of product or of its variant. Sale of product applies to its variants
+// without price override, sale of variant applies only to it. EndsAt is zero for sales without end.
+// SalePrice is in currency of product
+type ProductSale struct {
+	Id        uint64
+	ProductId uint64
+	// VariantId is 0 for sales of whole product
+	VariantId uint64
+	SalePrice Money
+	StartsAt  time.Time
+	EndsAt    time.Time
+	CreatedAt time.Time
+}
+
+// Validate checks sale price and period, sales which have already ended are rejected
+func (sale ProductSale) Validate(now time.Time) error {
+	if sale.SalePrice.Amount == 0 || !validPeriod(sale.StartsAt, sale.EndsAt, now) {
+		return InvalidSaleError
+	}
+
+	return nil
+}
+
+// ShopDiscount is percentage discount of all shop's products. EndsAt is zero for discounts without end
+type ShopDiscount struct {
+	Id        uint64
+	ShopId    uint64
+	Percent   uint64
+	StartsAt  time.Time
+	EndsAt    time.Time
+	CreatedAt time.Time
+}
+
+func (discount ShopDiscount) Validate(now time.Time) error {
+	if discount.Percent == 0 || discount.Percent > MaxDiscountPercent || !validPeriod(discount.StartsAt, discount.EndsAt, now) {
+		return InvalidDiscountError
+	}
+
+	return nil
+}
+
+// Apply returns price reduced by discount, prices are rounded down to minor units
+func (discount ShopDiscount) Apply(price Money) Money {
+	return Money{Amount: price.Amount * (100 - discount.Percent) / 100, Currency: price.Currency}
+}
+
+// validPeriod checks that period ends after it starts and after now, zero end means period without end
+func validPeriod(startsAt time.Time, endsAt time.Time, now time.Time) bool {
+	if startsAt.IsZero() {
+		return false
+	}
+
+	return endsAt.IsZero() || (endsAt.After(startsAt) && endsAt.After(now))
+}
+
+// Pricing contains sales and shop discounts which are active at one moment.
+// Sales are grouped by product and discounts are grouped by shop
+type Pricing struct {
+	Sales     map[uint64][]ProductSale
+	Discounts map[uint64][]ShopDiscount
+}
+
+// Price returns the lowest of list price, active sale price and list price reduced by the best shop discount.
+// VariantId is 0 for price of product itself, overridden is true for variants with their own price.
+// Sales in currency other than price's one are ignored, as they were set before currency of shop was changed
+func (pricing Pricing) Price(productID uint64, shopID uint64, variantID uint64, price Money, overridden bool) Money {
+	best := price
+	for _, sale := range pricing.Sales[productID] {
+		applies := sale.VariantId == variantID || (sale.VariantId == 0 && !overridden)
+		if applies && sale.SalePrice.Currency == price.Currency && sale.SalePrice.Amount < best.Amount {
+			best = sale.SalePrice
+		}
+	}
+
+	for _, discount := range pricing.Discounts[shopID] {
+		if discounted := discount.Apply(price); discounted.Amount < best.Amount {
+			best = discounted
+		}
+	}
+
+	return best
+}
+
+// Apply sets discounted prices of product and of its variants. It must be called before variant is selected
+func (pricing Pricing) Apply(product Product) Product {
+	product.DiscountedPrice = pricing.Price(product.Id, product.ShopId, 0, product.Price, false)
+
+	if len(product.Variants) != 0 {
+		variants := make([]ProductVariant, 0, len(product.Variants))
+		for _, variant := range product.Variants {
+			variants = append(variants, pricing.ApplyToVariant(product, variant))
+		}
+		product.Variants = variants
+	}
+
+	return product
+}
+
+// ApplyToVariant sets discounted price of product's variant
+func (pricing Pricing) ApplyToVariant(product Product, variant ProductVariant) ProductVariant {
+	variant.DiscountedPrice = pricing.Price(product.Id, product.ShopId, variant.Id, variant.Price, variant.PriceOverride.Amount != 0)
+	return variant
+}
+
+// PromoCode is code which buyers enter at checkout to get discount on order of shop.
+// MaxUses and MaxUsesPerUser of 0 mean no limit, ExpiresAt is zero for codes without expiry.
+// Fixed Value and MinOrder are amounts in Currency, which is currency of shop
+type PromoCode struct {
+	Id     uint64
+	ShopId uint64
+	// Code is stored in upper case, as codes are entered case-insensitively
+	Code           string
+	Kind           string
+	Value          uint64
+	MinOrder       uint64
+	Currency       string
+	MaxUses        uint64
+	MaxUsesPerUser uint64
+	// UsedCount is amount of redemptions which were not released
+	UsedCount uint64
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// NormalizePromoCode converts code to the form in which it is stored
+func NormalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate checks code, kind and value of promo code. Code must be normalized
+func (promoCode PromoCode) Validate(now time.Time) error {
+	if !promoCodeRegexp.MatchString(promoCode.Code) || promoCode.Value == 0 {
+		return InvalidPromoCodeError
+	}
+
+	switch promoCode.Kind {
+	case PromoFixed:
+	case PromoPercent:
+		if promoCode.Value > 100 {
+			return InvalidPromoCodeError
+		}
+	default:
+		return InvalidPromoCodeError
+	}
+
+	if !promoCode.ExpiresAt.IsZero() && !promoCode.ExpiresAt.After(now) {
+		return InvalidPromoCodeError
+	}
+
+	return nil
+}
+
+// Discount returns amount which promo code takes off order's subtotal. Usage limits are checked by repository,
+// as they depend on other redemptions. Subtotal must be in currency of promo code
+func (promoCode PromoCode) Discount(subtotal Money, now time.Time) (Money, error) {
+	if subtotal.Currency != promoCode.Currency {
+		return Money{}, CurrencyMismatchError
+	}
+
+	if !promoCode.ExpiresAt.IsZero() && !promoCode.ExpiresAt.After(now) {
+		return Money{}, PromoCodeExpiredError
+	}
+
+	if subtotal.Amount < promoCode.MinOrder {
+		return Money{}, PromoCodeMinOrderError
+	}
+
+	if promoCode.Kind == PromoPercent {
+		return Money{Amount: subtotal.Amount * promoCode.Value / 100, Currency: subtotal.Currency}, nil
+	}
+
+	if promoCode.Value > subtotal.Amount {
+		return subtotal, nil
+	}
+
+	return Money{Amount: promoCode.Value, Currency: subtotal.Currency}, nil
+}
+
+// PromoRedemption is use of promo code by order, it is released if order is cancelled before payment
+type PromoRedemption struct {
+	Id          uint64
+	PromoCodeId uint64
+	Code        string
+	UserId      uint64
+	Discount    Money
+}
+
+// PriceChange is entry of price history. Changes of list price have old and new price, sales have new price
+// and period, shop discounts have percent and period. Shop discounts have ProductId 0
+type PriceChange struct {
+	Id        uint64
+	ProductId uint64
+	VariantId uint64
+	ShopId    uint64
+	Kind      string
+	OldPrice  Money
+	NewPrice  Money
+	Percent   uint64
+	StartsAt  time.Time
+	EndsAt    time.Time
+	// UserId is id of manager who made change, it is 0 for changes made before history was kept
+	UserId    uint64
+	CreatedAt time.Time
+}
+
+// PriceHistoryPage describes which price changes should be returned, newest changes go first.
+// Cursor takes precedence over offset
+type PriceHistoryPage struct {
+	Limit uint64
+	// Cursor is id of the last change of previous page, it is 0 on the first page
+	Cursor uint64
+	Offset uint64
+}
+
+// NewPriceHistoryPage checks cursor which came from client. Page is used only if cursor is empty and is counted from 0
+func NewPriceHistoryPage(limit uint64, cursor string, page uint64) (historyPage PriceHistoryPage, err error) {
+	if limit == 0 || limit > MaxPriceHistoryPageSize {
+		limit = MaxPriceHistoryPageSize
+	}
+
+	historyPage = PriceHistoryPage{
+		Limit:  limit,
+		Offset: page * limit,
+	}
+
+	if cursor != "" {
+		_, historyPage.Cursor, err = decodeKeysetCursor(cursor)
+		if err != nil {
+			return PriceHistoryPage{}, err
+		}
+
+		historyPage.Offset = 0
+	}
+
+	return historyPage, nil
+}
+
+// NextCursor returns cursor which points after price change
+func (page PriceHistoryPage) NextCursor(change PriceChange) string {
+	return encodeKeysetCursor(strconv.FormatUint(change.Id, 10), change.Id)
+}
@@ -0,0 +1,243 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func rub(amount uint64) Money {
+	return Money{Amount: amount, Currency: "RUB"}
+}
+
+func TestShopDiscountValidate(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		discount ShopDiscount
+		err      error
+	}{
+		{"without end", ShopDiscount{Percent: 10, StartsAt: now}, nil},
+		{"ends in future", ShopDiscount{Percent: MaxDiscountPercent, StartsAt: now, EndsAt: now.Add(time.Hour)}, nil},
+		{"zero percent", ShopDiscount{Percent: 0, StartsAt: now}, InvalidDiscountError},
+		{"too big percent", ShopDiscount{Percent: MaxDiscountPercent + 1, StartsAt: now}, InvalidDiscountError},
+		{"without start", ShopDiscount{Percent: 10}, InvalidDiscountError},
+		{"ends before start", ShopDiscount{Percent: 10, StartsAt: now.Add(2 * time.Hour), EndsAt: now.Add(time.Hour)}, InvalidDiscountError},
+		{"has ended", ShopDiscount{Percent: 10, StartsAt: now.Add(-2 * time.Hour), EndsAt: now.Add(-time.Hour)}, InvalidDiscountError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.discount.Validate(now)
+			if err != test.err {
+				t.Errorf("expected error %v, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestShopDiscountApply(t *testing.T) {
+	tests := []struct {
+		name    string
+		percent uint64
+		price   Money
+		result  Money
+	}{
+		{"whole amount", 10, rub(1000), rub(900)},
+		{"rounds down", 15, rub(999), rub(849)},
+		{"max discount", MaxDiscountPercent, rub(1001), rub(100)},
+		{"zero price", 50, rub(0), rub(0)},
+		{"keeps currency", 25, Money{Amount: 400, Currency: "USD"}, Money{Amount: 300, Currency: "USD"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := ShopDiscount{Percent: test.percent}.Apply(test.price)
+			if result != test.result {
+				t.Errorf("expected %v, got %v", test.result, result)
+			}
+		})
+	}
+}
+
+func TestPricingPrice(t *testing.T) {
+	const productID, shopID, variantID = 1, 2, 3
+
+	tests := []struct {
+		name       string
+		pricing    Pricing
+		variantID  uint64
+		price      Money
+		overridden bool
+		result     Money
+	}{
+		{"no sales and discounts", Pricing{}, 0, rub(1000), false, rub(1000)},
+		{
+			"sale of product",
+			Pricing{Sales: map[uint64][]ProductSale{productID: {{SalePrice: rub(700)}}}},
+			0, rub(1000), false, rub(700),
+		},
+		{
+			"sale above list price is ignored",
+			Pricing{Sales: map[uint64][]ProductSale{productID: {{SalePrice: rub(1200)}}}},
+			0, rub(1000), false, rub(1000),
+		},
+		{
+			"lowest of sales",
+			Pricing{Sales: map[uint64][]ProductSale{productID: {{SalePrice: rub(800)}, {SalePrice: rub(600)}}}},
+			0, rub(1000), false, rub(600),
+		},
+		{
+			"sale in other currency is ignored",
+			Pricing{Sales: map[uint64][]ProductSale{productID: {{SalePrice: Money{Amount: 5, Currency: "USD"}}}}},
+			0, rub(1000), false, rub(1000),
+		},
+		{
+			"sale of product applies to variant without override",
+			Pricing{Sales: map[uint64][]ProductSale{productID: {{SalePrice: rub(700)}}}},
+			variantID, rub(1000), false, rub(700),
+		},
+		{
+			"sale of product does not apply to variant with override",
+			Pricing{Sales: map[uint64][]ProductSale{productID: {{SalePrice: rub(700)}}}},
+			variantID, rub(1500), true, rub(1500),
+		},
+		{
+			"sale of variant",
+			Pricing{Sales: map[uint64][]ProductSale{productID: {{VariantId: variantID, SalePrice: rub(1100)}}}},
+			variantID, rub(1500), true, rub(1100),
+		},
+		{
+			"sale of variant does not apply to product",
+			Pricing{Sales: map[uint64][]ProductSale{productID: {{VariantId: variantID, SalePrice: rub(500)}}}},
+			0, rub(1000), false, rub(1000),
+		},
+		{
+			"shop discount",
+			Pricing{Discounts: map[uint64][]ShopDiscount{shopID: {{Percent: 20}}}},
+			variantID, rub(1500), true, rub(1200),
+		},
+		{
+			"best of shop discounts",
+			Pricing{Discounts: map[uint64][]ShopDiscount{shopID: {{Percent: 20}, {Percent: 35}}}},
+			0, rub(1000), false, rub(650),
+		},
+		{
+			"discount of other shop is ignored",
+			Pricing{Discounts: map[uint64][]ShopDiscount{shopID + 1: {{Percent: 50}}}},
+			0, rub(1000), false, rub(1000),
+		},
+		{
+			"discount is not applied on top of sale",
+			Pricing{
+				Sales:     map[uint64][]ProductSale{productID: {{SalePrice: rub(700)}}},
+				Discounts: map[uint64][]ShopDiscount{shopID: {{Percent: 20}}},
+			},
+			0, rub(1000), false, rub(700),
+		},
+		{
+			"discount lower than sale",
+			Pricing{
+				Sales:     map[uint64][]ProductSale{productID: {{SalePrice: rub(900)}}},
+				Discounts: map[uint64][]ShopDiscount{shopID: {{Percent: 20}}},
+			},
+			0, rub(1000), false, rub(800),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := test.pricing.Price(productID, shopID, test.variantID, test.price, test.overridden)
+			if result != test.result {
+				t.Errorf("expected %v, got %v", test.result, result)
+			}
+		})
+	}
+}
+
+func TestNormalizePromoCode(t *testing.T) {
+	tests := []struct {
+		code   string
+		result string
+	}{
+		{"SPRING", "SPRING"},
+		{"spring-10", "SPRING-10"},
+		{"  Summer_Sale \n", "SUMMER_SALE"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.code, func(t *testing.T) {
+			result := NormalizePromoCode(test.code)
+			if result != test.result {
+				t.Errorf("expected %q, got %q", test.result, result)
+			}
+		})
+	}
+}
+
+func TestPromoCodeValidate(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		promoCode PromoCode
+		err       error
+	}{
+		{"fixed", PromoCode{Code: "SPRING", Kind: PromoFixed, Value: 50000}, nil},
+		{"percent", PromoCode{Code: "SPRING", Kind: PromoPercent, Value: 100}, nil},
+		{"expires in future", PromoCode{Code: "SPRING", Kind: PromoPercent, Value: 10, ExpiresAt: now.Add(time.Hour)}, nil},
+		{"expired", PromoCode{Code: "SPRING", Kind: PromoPercent, Value: 10, ExpiresAt: now}, InvalidPromoCodeError},
+		{"too big percent", PromoCode{Code: "SPRING", Kind: PromoPercent, Value: 101}, InvalidPromoCodeError},
+		{"zero value", PromoCode{Code: "SPRING", Kind: PromoFixed, Value: 0}, InvalidPromoCodeError},
+		{"unknown kind", PromoCode{Code: "SPRING", Kind: "free_shipping", Value: 10}, InvalidPromoCodeError},
+		{"too short code", PromoCode{Code: "AB", Kind: PromoFixed, Value: 10}, InvalidPromoCodeError},
+		{"too long code", PromoCode{Code: "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456", Kind: PromoFixed, Value: 10}, InvalidPromoCodeError},
+		{"code is not normalized", PromoCode{Code: "spring", Kind: PromoFixed, Value: 10}, InvalidPromoCodeError},
+		{"code with space", PromoCode{Code: "SPRING SALE", Kind: PromoFixed, Value: 10}, InvalidPromoCodeError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.promoCode.Validate(now)
+			if err != test.err {
+				t.Errorf("expected error %v, got %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestPromoCodeDiscount(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		promoCode PromoCode
+		subtotal  Money
+		discount  Money
+		err       error
+	}{
+		{"fixed", PromoCode{Kind: PromoFixed, Value: 30000, Currency: "RUB"}, rub(100000), rub(30000), nil},
+		{"fixed above subtotal", PromoCode{Kind: PromoFixed, Value: 30000, Currency: "RUB"}, rub(20000), rub(20000), nil},
+		{"percent", PromoCode{Kind: PromoPercent, Value: 15, Currency: "RUB"}, rub(100000), rub(15000), nil},
+		{"percent rounds down", PromoCode{Kind: PromoPercent, Value: 15, Currency: "RUB"}, rub(999), rub(149), nil},
+		{"whole subtotal", PromoCode{Kind: PromoPercent, Value: 100, Currency: "RUB"}, rub(12345), rub(12345), nil},
+		{"min order is reached", PromoCode{Kind: PromoPercent, Value: 10, MinOrder: 5000, Currency: "RUB"}, rub(5000), rub(500), nil},
+		{"min order is not reached", PromoCode{Kind: PromoPercent, Value: 10, MinOrder: 5000, Currency: "RUB"}, rub(4999), Money{}, PromoCodeMinOrderError},
+		{"not expired", PromoCode{Kind: PromoFixed, Value: 100, Currency: "RUB", ExpiresAt: now.Add(time.Second)}, rub(1000), rub(100), nil},
+		{"expired", PromoCode{Kind: PromoFixed, Value: 100, Currency: "RUB", ExpiresAt: now}, rub(1000), Money{}, PromoCodeExpiredError},
+		{"other currency", PromoCode{Kind: PromoFixed, Value: 100, Currency: "RUB"}, Money{Amount: 1000, Currency: "USD"}, Money{}, CurrencyMismatchError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			discount, err := test.promoCode.Discount(test.subtotal, now)
+			if err != test.err {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+			if discount != test.discount {
+				t.Errorf("expected %v, got %v", test.discount, discount)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxQuestionLength is measured in characters
+	MaxQuestionLength = 1000
+	// MaxAnswerLength is measured in characters
+	MaxAnswerLength = 2000
+	// DefaultQuestionSorting is used when sorting is not specified, most voted questions go first
+	DefaultQuestionSorting = "votes"
+	// MaxQuestionsPageSize is used when limit is not specified or is too big
+	MaxQuestionsPageSize = 50
+
+	// AnswerByManager is role of answer written by manager of product's shop
+	AnswerByManager = "manager"
+	// AnswerByBuyer is role of answer written by user who has received product
+	AnswerByBuyer = "buyer"
+)
+
+// ProductQuestion is user's question about product. IsVoted shows whether user who requested question voted for it.
+// Answers go in order in which they are shown: official answer first, then older answers first
+type ProductQuestion struct {
+	Id           uint64
+	ProductId    uint64
+	UserId       uint64
+	Username     string
+	Text         string
+	VotesCount   uint64
+	AnswersCount uint64
+	IsVoted      bool
+	Answers      []ProductAnswer
+	CreatedAt    time.Time
+}
+
+// ProductAnswer is answer to question, AuthorRole is AnswerByManager or AnswerByBuyer.
+// Each question has at most one official answer
+type ProductAnswer struct {
+	Id         uint64
+	QuestionId uint64
+	UserId     uint64
+	Username   string
+	Text       string
+	AuthorRole string
+	IsOfficial bool
+	CreatedAt  time.Time
+}
+
+// ValidateQuestionText checks that question or answer text is not empty and is not longer than maxLength characters
+func ValidateQuestionText(text string, maxLength int) error {
+	if strings.TrimSpace(text) == "" {
+		return EmptyQuestionTextError
+	}
+	if utf8.RuneCountInString(text) > maxLength {
+		return QuestionTextTooLongError
+	}
+
+	return nil
+}
+
+// QuestionSorting describes how questions are ordered, ties are broken by question id in the same direction
+type QuestionSorting struct {
+	// Column is product_questions table column which questions are sorted by
+	Column string
+	// ColumnType is postgres type which cursor values are cast to
+	ColumnType string
+	Descending bool
+	// value returns question's value of Column as it is stored in cursor
+	value func(question ProductQuestion) string
+}
+
+// QuestionSortings are keyed by names which clients pass as sorting criterion
+var QuestionSortings = map[string]QuestionSorting{
+	"votes": {
+		Column: "product_questions.votes_count", ColumnType: "bigint", Descending: true,
+		value: func(question ProductQuestion) string { return strconv.FormatUint(question.VotesCount, 10) },
+	},
+	"newest": {
+		Column: "product_questions.id", ColumnType: "bigint", Descending: true,
+		value: func(question ProductQuestion) string { return strconv.FormatUint(question.Id, 10) },
+	},
+	"unanswered": {
+		Column: "product_questions.answers_count", ColumnType: "bigint", Descending: false,
+		value: func(question ProductQuestion) string { return strconv.FormatUint(question.AnswersCount, 10) },
+	},
+}
+
+// QuestionCursor points at the last question of previous page
+type QuestionCursor struct {
+	Value      string
+	QuestionId uint64
+}
+
+// QuestionsPage describes which questions should be returned. Cursor takes precedence over offset.
+// Query is text search query, questions match it if their text or text of any answer matches it
+type QuestionsPage struct {
+	Sorting QuestionSorting
+	Limit   uint64
+	Cursor  *QuestionCursor
+	Offset  uint64
+	Query   string
+}
+
+// NewQuestionsPage checks sorting name and cursor which came from client.
+// Page is used only if cursor is empty and is counted from 0
+func NewQuestionsPage(sortingName string, limit uint64, cursor string, page uint64, query string) (questionsPage QuestionsPage, err error) {
+	if sortingName == "" {
+		sortingName = DefaultQuestionSorting
+	}
+
+	sorting, found := QuestionSortings[sortingName]
+	if !found {
+		return QuestionsPage{}, InvalidSortingError
+	}
+
+	if limit == 0 || limit > MaxQuestionsPageSize {
+		limit = MaxQuestionsPageSize
+	}
+
+	questionsPage = QuestionsPage{
+		Sorting: sorting,
+		Limit:   limit,
+		Offset:  page * limit,
+		Query:   strings.TrimSpace(query),
+	}
+
+	if cursor != "" {
+		var questionCursor QuestionCursor
+		questionCursor.Value, questionCursor.QuestionId, err = decodeKeysetCursor(cursor)
+		if err != nil {
+			return QuestionsPage{}, err
+		}
+
+		err = checkCursorValue(questionCursor.Value, sorting.ColumnType)
+		if err != nil {
+			return QuestionsPage{}, err
+		}
+
+		questionsPage.Cursor = &questionCursor
+		questionsPage.Offset = 0
+	}
+
+	return questionsPage, nil
+}
+
+// NextCursor returns cursor which points after question
+func (sorting QuestionSorting) NextCursor(question ProductQuestion) string {
+	return encodeKeysetCursor(sorting.value(question), question.Id)
+}
@@ -0,0 +1,31 @@
+package domain
+
+const (
+	// DefaultRelatedProducts is amount of related products returned when limit is not set
+	DefaultRelatedProducts = 8
+	// MaxRelatedProducts limits amount of related products of one request
+	MaxRelatedProducts = 24
+
+	// SameCategoryWeight is added to score of products from category of viewed product
+	SameCategoryWeight = 3.0
+	// SameSizeWeight is added to score of products of the same size
+	SameSizeWeight = 1.0
+	// PartsAmountWeight is multiplied by closeness of parts amounts, which is 1 for equal amounts
+	PartsAmountWeight = 1.0
+	// PriceBandWeight is multiplied by closeness of prices, which is 1 for equal prices and 0 outside of price band
+	PriceBandWeight = 1.5
+	// PriceBand is share of viewed product's price by which price of related product can differ
+	PriceBand = 0.3
+	// CoViewWeight is multiplied by logarithm of amount of users who viewed both products
+	CoViewWeight = 2.0
+	// SameShopWeight is added to score of products from the same shop, so that they are suggested when
+	// there is little data about other products
+	SameShopWeight = 0.5
+
+	// CoViewWindowHours is time between views of one user in which viewed products are considered related
+	CoViewWindowHours = 24
+	// CoViewDays is how far back views are used for co-view statistics
+	CoViewDays = 90
+	// MinCoViewers is amount of users who must view both products for pair to be counted, rarer pairs are noise
+	MinCoViewers = 2
+)
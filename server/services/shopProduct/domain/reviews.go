@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	MinRating = 1
+	MaxRating = 5
+	// MaxReviewTitleLength is measured in characters
+	MaxReviewTitleLength = 100
+	// DefaultReviewSorting is used when sorting is not specified, newest reviews go first
+	DefaultReviewSorting = "newest"
+	// MaxReviewsPageSize is used when limit is not specified or is too big
+	MaxReviewsPageSize = 100
+)
+
+// ProductReview is user's opinion of product, every user can review product only once
+type ProductReview struct {
+	Id        uint64
+	ProductId uint64
+	UserId    uint64
+	Username  string
+	Title     string
+	Text      string
+	Rating    uint32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Validate checks review's rating and title, text can be empty
+func (review ProductReview) Validate() error {
+	if review.Rating < MinRating || review.Rating > MaxRating {
+		return InvalidRatingError
+	}
+	if review.Title == "" {
+		return EmptyTitleError
+	}
+	if utf8.RuneCountInString(review.Title) > MaxReviewTitleLength {
+		return ReviewTitleTooLongError
+	}
+
+	return nil
+}
+
+// ReviewSorting describes how reviews are ordered, ties are broken by review id in the same direction
+type ReviewSorting struct {
+	// Column is product_reviews table column which reviews are sorted by
+	Column string
+	// ColumnType is postgres type which cursor values are cast to
+	ColumnType string
+	Descending bool
+	// value returns review's value of Column as it is stored in cursor
+	value func(review ProductReview) string
+}
+
+// ReviewSortings are keyed by names which clients pass as sorting criterion
+var ReviewSortings = map[string]ReviewSorting{
+	"newest": {
+		Column: "product_reviews.id", ColumnType: "bigint", Descending: true,
+		value: func(review ProductReview) string { return strconv.FormatUint(review.Id, 10) },
+	},
+	"highest": {
+		Column: "product_reviews.rating", ColumnType: "bigint", Descending: true,
+		value: func(review ProductReview) string { return strconv.FormatUint(uint64(review.Rating), 10) },
+	},
+	"lowest": {
+		Column: "product_reviews.rating", ColumnType: "bigint", Descending: false,
+		value: func(review ProductReview) string { return strconv.FormatUint(uint64(review.Rating), 10) },
+	},
+}
+
+// ReviewCursor points at the last review of previous page
+type ReviewCursor struct {
+	Value    string
+	ReviewId uint64
+}
+
+// ReviewsPage describes which reviews should be returned. Cursor takes precedence over offset
+type ReviewsPage struct {
+	Sorting ReviewSorting
+	Limit   uint64
+	Cursor  *ReviewCursor
+	Offset  uint64
+}
+
+// NewReviewsPage checks sorting name and cursor which came from client.
+// Page is used only if cursor is empty and is counted from 0
+func NewReviewsPage(sortingName string, limit uint64, cursor string, page uint64) (reviewsPage ReviewsPage, err error) {
+	if sortingName == "" {
+		sortingName = DefaultReviewSorting
+	}
+
+	sorting, found := ReviewSortings[sortingName]
+	if !found {
+		return ReviewsPage{}, InvalidSortingError
+	}
+
+	if limit == 0 || limit > MaxReviewsPageSize {
+		limit = MaxReviewsPageSize
+	}
+
+	reviewsPage = ReviewsPage{
+		Sorting: sorting,
+		Limit:   limit,
+		Offset:  page * limit,
+	}
+
+	if cursor != "" {
+		var reviewCursor ReviewCursor
+		reviewCursor.Value, reviewCursor.ReviewId, err = decodeKeysetCursor(cursor)
+		if err != nil {
+			return ReviewsPage{}, err
+		}
+
+		err = checkCursorValue(reviewCursor.Value, sorting.ColumnType)
+		if err != nil {
+			return ReviewsPage{}, err
+		}
+
+		reviewsPage.Cursor = &reviewCursor
+		reviewsPage.Offset = 0
+	}
+
+	return reviewsPage, nil
+}
+
+// NextCursor returns cursor which points after review
+func (sorting ReviewSorting) NextCursor(review ProductReview) string {
+	return encodeKeysetCursor(sorting.value(review), review.Id)
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"strconv"
+)
+
+const (
+	// RelevanceSorting orders search results by text rank, it is default sorting when search query is not empty
+	RelevanceSorting = "relevance"
+	// SearchConfiguration is postgres text search configuration. It stems Russian words with Russian stemmer
+	// and words in Latin script with English stemmer
+	SearchConfiguration = "russian"
+)
+
+// relevanceSorting can only be used in search queries, which compute relevance.rank
+var relevanceSorting = ProductSorting{
+	Column: "relevance.rank", ColumnType: "real", Descending: true,
+	value: func(product Product) string { return strconv.FormatFloat(float64(product.Relevance), 'g', -1, 32) },
+}
+
+// SearchFilters narrow down search results. Zero values mean that filter is not applied,
+// products must match any of categories, including their descendants, and any of sizes.
+// Price range is in minor units of Currency, products in other currencies are compared by converted prices
+type SearchFilters struct {
+	Query           string
+	PriceMin        uint64
+	PriceMax        uint64
+	Currency        string
+	CategoryIds     []uint64
+	Sizes           []string
+	OnlyAvailable   bool
+	AssemblyTimeMin uint64
+	AssemblyTimeMax uint64
+	PartsAmountMin  uint64
+	PartsAmountMax  uint64
+	MinRating       float32
+}
+
+// Validate checks that ranges are not empty, that minimal rating can be reached and that currency is known
+func (filters SearchFilters) Validate() error {
+	if !ValidCurrency(filters.Currency) {
+		return InvalidCurrencyError
+	}
+
+	if filters.PriceMax != 0 && filters.PriceMin > filters.PriceMax ||
+		filters.AssemblyTimeMax != 0 && filters.AssemblyTimeMin > filters.AssemblyTimeMax ||
+		filters.PartsAmountMax != 0 && filters.PartsAmountMin > filters.PartsAmountMax {
+		return InvalidSearchRangeError
+	}
+
+	if filters.MinRating < 0 || filters.MinRating > MaxRating {
+		return InvalidRatingError
+	}
+
+	return nil
+}
+
+// FacetValue is amount of products with value of filter
+type FacetValue struct {
+	Value string
+	Count uint64
+}
+
+// RangeFacet contains the smallest and the biggest values of range filter
+type RangeFacet struct {
+	Min uint64
+	Max uint64
+}
+
+// SearchFacets describe what each filter can narrow search results down to. Facet of a filter is counted
+// with all other filters applied, so that changing the filter does not make its own options disappear
+type SearchFacets struct {
+	// Categories are keyed by category id
+	Categories   []FacetValue
+	Sizes        []FacetValue
+	Availability []FacetValue
+	Price        RangeFacet
+	// PriceCurrency is currency of price facet, it is currency of filters
+	PriceCurrency string
+	AssemblyTime  RangeFacet
+	PartsAmount   RangeFacet
+	// MinRating contains amounts of products whose rating is at least 1, 2, 3 and 4
+	MinRating []FacetValue
+}
+
+// SearchResult is one page of found products together with total amount of them and facets
+type SearchResult struct {
+	Products   []Product
+	Total      uint64
+	Facets     SearchFacets
+	NextCursor string
+}
+
+// NewSearchPage works as NewProductsPage, but also accepts relevance sorting, which is default
+// if search query is not empty
+func NewSearchPage(sortingName string, query string, limit uint64, cursor string, page uint64) (productsPage ProductsPage, err error) {
+	if sortingName == RelevanceSorting || sortingName == "" && query != "" {
+		if query == "" {
+			return ProductsPage{}, InvalidSortingError
+		}
+
+		return newProductsPage(relevanceSorting, limit, cursor, page)
+	}
+
+	return NewProductsPage(sortingName, limit, cursor, page)
+}
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultProductSorting is used when sorting is not specified, newest products go first
+	DefaultProductSorting = "newest"
+	// MaxProductsPageSize is used when limit is not specified or is too big
+	MaxProductsPageSize = 100
+)
+
+// ProductSorting describes how products are ordered. Ties are broken by product id in the same direction,
+// so that every product has unique position and keyset pagination does not skip or repeat products
+type ProductSorting struct {
+	// Column is products table column which products are sorted by
+	Column string
+	// ColumnType is postgres type which cursor values are cast to
+	ColumnType string
+	Descending bool
+	// value returns product's value of Column as it is stored in cursor
+	value func(product Product) string
+}
+
+// ProductSortings are keyed by names which clients pass as sorting criterion
+var ProductSortings = map[string]ProductSorting{
+	"newest": {
+		Column: "products.id", ColumnType: "bigint", Descending: true,
+		value: func(product Product) string { return strconv.FormatUint(product.Id, 10) },
+	},
+	// Prices are compared by amounts, which are in currencies of products' shops
+	"price_asc": {
+		Column: "products.price", ColumnType: "bigint", Descending: false,
+		value: func(product Product) string { return strconv.FormatUint(product.Price.Amount, 10) },
+	},
+	"price_desc": {
+		Column: "products.price", ColumnType: "bigint", Descending: true,
+		value: func(product Product) string { return strconv.FormatUint(product.Price.Amount, 10) },
+	},
+	"popular": {
+		Column: "products.saves_count", ColumnType: "bigint", Descending: true,
+		value: func(product Product) string { return strconv.FormatUint(product.SavesCount, 10) },
+	},
+	"rating": {
+		Column: "products.rating", ColumnType: "real", Descending: true,
+		value: func(product Product) string { return strconv.FormatFloat(float64(product.Rating), 'g', -1, 32) },
+	},
+	"assembly_time_asc": {
+		Column: "products.assembly_time", ColumnType: "bigint", Descending: false,
+		value: func(product Product) string { return strconv.FormatUint(product.AssemblyTime, 10) },
+	},
+	"assembly_time_desc": {
+		Column: "products.assembly_time", ColumnType: "bigint", Descending: true,
+		value: func(product Product) string { return strconv.FormatUint(product.AssemblyTime, 10) },
+	},
+	"parts_amount_asc": {
+		Column: "products.parts_amount", ColumnType: "bigint", Descending: false,
+		value: func(product Product) string { return strconv.FormatUint(product.PartsAmount, 10) },
+	},
+	"parts_amount_desc": {
+		Column: "products.parts_amount", ColumnType: "bigint", Descending: true,
+		value: func(product Product) string { return strconv.FormatUint(product.PartsAmount, 10) },
+	},
+}
+
+// ProductCursor points at the last product of previous page
+type ProductCursor struct {
+	Value     string
+	ProductId uint64
+}
+
+// ProductsPage describes which products should be returned. Cursor takes precedence over offset
+type ProductsPage struct {
+	Sorting ProductSorting
+	Limit   uint64
+	Cursor  *ProductCursor
+	Offset  uint64
+}
+
+// NewProductsPage checks sorting name and cursor which came from client.
+// Page is used only if cursor is empty and is counted from 0
+func NewProductsPage(sortingName string, limit uint64, cursor string, page uint64) (productsPage ProductsPage, err error) {
+	if sortingName == "" {
+		sortingName = DefaultProductSorting
+	}
+
+	sorting, found := ProductSortings[sortingName]
+	if !found {
+		return ProductsPage{}, InvalidSortingError
+	}
+
+	return newProductsPage(sorting, limit, cursor, page)
+}
+
+func newProductsPage(sorting ProductSorting, limit uint64, cursor string, page uint64) (productsPage ProductsPage, err error) {
+	if limit == 0 || limit > MaxProductsPageSize {
+		limit = MaxProductsPageSize
+	}
+
+	productsPage = ProductsPage{
+		Sorting: sorting,
+		Limit:   limit,
+		Offset:  page * limit,
+	}
+
+	if cursor != "" {
+		productCursor, err := DecodeProductCursor(cursor)
+		if err != nil {
+			return ProductsPage{}, err
+		}
+
+		err = checkCursorValue(productCursor.Value, sorting.ColumnType)
+		if err != nil {
+			return ProductsPage{}, err
+		}
+
+		productsPage.Cursor = &productCursor
+		productsPage.Offset = 0
+	}
+
+	return productsPage, nil
+}
+
+// NextCursor returns cursor which points after product
+func (sorting ProductSorting) NextCursor(product Product) string {
+	return EncodeProductCursor(ProductCursor{Value: sorting.value(product), ProductId: product.Id})
+}
+
+func EncodeProductCursor(cursor ProductCursor) string {
+	return encodeKeysetCursor(cursor.Value, cursor.ProductId)
+}
+
+func DecodeProductCursor(encoded string) (cursor ProductCursor, err error) {
+	cursor.Value, cursor.ProductId, err = decodeKeysetCursor(encoded)
+	if err != nil {
+		return ProductCursor{}, err
+	}
+
+	return cursor, nil
+}
+
+// encodeKeysetCursor encodes value of sorting column and id of the last row of page
+func encodeKeysetCursor(value string, id uint64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%s_%d", value, id)))
+}
+
+func decodeKeysetCursor(encoded string) (value string, id uint64, err error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", 0, InvalidCursorError
+	}
+
+	separator := strings.LastIndex(string(decoded), "_")
+	if separator == -1 {
+		return "", 0, InvalidCursorError
+	}
+
+	id, err = strconv.ParseUint(string(decoded[separator+1:]), 10, 64)
+	if err != nil {
+		return "", 0, InvalidCursorError
+	}
+
+	return string(decoded[:separator]), id, nil
+}
+
+// checkCursorValue checks cursor's value, which is passed to database, so it must be valid for sorting's column
+func checkCursorValue(value string, columnType string) (err error) {
+	if columnType == "bigint" {
+		_, err = strconv.ParseUint(value, 10, 64)
+	} else {
+		_, err = strconv.ParseFloat(value, 32)
+	}
+	if err != nil {
+		return InvalidCursorError
+	}
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultReservationTTL is used when reservation time is not specified
+	DefaultReservationTTL = 15 * time.Minute
+	// MaxReservationTTL limits how long stock can be held without checkout
+	MaxReservationTTL = 24 * time.Hour
+	// MaxStockCommentLength is measured in characters
+	MaxStockCommentLength = 500
+	// MaxMovementsPageSize is used when limit is not specified or is too big
+	MaxMovementsPageSize = 100
+)
+
+// Reservation statuses, only active reservations hold stock
+const (
+	ReservationActive    = "active"
+	ReservationCommitted = "committed"
+	ReservationReleased  = "released"
+	ReservationExpired   = "expired"
+)
+
+// Kinds of inventory movements
+const (
+	// MovementAdjustment is change of stock made by shop's manager
+	MovementAdjustment = "adjustment"
+	MovementReserve    = "reserve"
+	// MovementCommit removes reserved units from stock, as they are sold
+	MovementCommit  = "commit"
+	MovementRelease = "release"
+	MovementExpire  = "expire"
+)
+
+// StockItem is quantity of one product, VariantId is required for products with variants and is 0 for other products
+type StockItem struct {
+	ProductId uint64
+	VariantId uint64
+	Quantity  uint64
+}
+
+// ValidateStockItems checks that items are not empty, quantities are positive and every product's variant is listed once
+func ValidateStockItems(items []StockItem) error {
+	if len(items) == 0 {
+		return InvalidQuantityError
+	}
+
+	variants := make(map[[2]uint64]bool, len(items))
+	for _, item := range items {
+		key := [2]uint64{item.ProductId, item.VariantId}
+		if item.Quantity == 0 || variants[key] {
+			return InvalidQuantityError
+		}
+		variants[key] = true
+	}
+
+	return nil
+}
+
+// Reservation holds stock of several products until it is committed, released or expires
+type Reservation struct {
+	Id        uint64
+	Items     []StockItem
+	Status    string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+}
+
+// ReservationTTL returns time for which reservation holds stock, ttl of 0 means default time
+func ReservationTTL(ttl time.Duration) time.Duration {
+	switch {
+	case ttl <= 0:
+		return DefaultReservationTTL
+	case ttl > MaxReservationTTL:
+		return MaxReservationTTL
+	}
+
+	return ttl
+}
+
+// StockAdjustment is manual change of product's stock, such as delivery or write-off.
+// Stock of products with variants is adjusted for every variant separately
+type StockAdjustment struct {
+	ProductId uint64
+	VariantId uint64
+	UserId    uint64
+	Delta     int64
+	Comment   string
+}
+
+func (adjustment StockAdjustment) Validate() error {
+	if adjustment.Delta == 0 {
+		return InvalidQuantityError
+	}
+	if utf8.RuneCountInString(adjustment.Comment) > MaxStockCommentLength {
+		return StockCommentTooLongError
+	}
+
+	return nil
+}
+
+// InventoryMovement is ledger entry which records change of product's stock and reserved quantities.
+// Quantities of movements with VariantId are quantities of variant
+type InventoryMovement struct {
+	Id            uint64
+	ProductId     uint64
+	VariantId     uint64
+	Kind          string
+	StockDelta    int64
+	ReservedDelta int64
+	// StockAfter and ReservedAfter are quantities right after movement
+	StockAfter    uint64
+	ReservedAfter uint64
+	// ReservationId is 0 for adjustments
+	ReservationId uint64
+	// UserId is id of manager who adjusted stock, 0 for reservation movements
+	UserId    uint64
+	Comment   string
+	CreatedAt time.Time
+}
+
+// MovementsPage describes which movements should be returned, newest movements go first.
+// Cursor takes precedence over offset
+type MovementsPage struct {
+	Limit uint64
+	// Cursor is id of the last movement of previous page, it is 0 on the first page
+	Cursor uint64
+	Offset uint64
+}
+
+// NewMovementsPage checks cursor which came from client. Page is used only if cursor is empty and is counted from 0
+func NewMovementsPage(limit uint64, cursor string, page uint64) (movementsPage MovementsPage, err error) {
+	if limit == 0 || limit > MaxMovementsPageSize {
+		limit = MaxMovementsPageSize
+	}
+
+	movementsPage = MovementsPage{
+		Limit:  limit,
+		Offset: page * limit,
+	}
+
+	if cursor != "" {
+		_, movementsPage.Cursor, err = decodeKeysetCursor(cursor)
+		if err != nil {
+			return MovementsPage{}, err
+		}
+
+		movementsPage.Offset = 0
+	}
+
+	return movementsPage, nil
+}
+
+// NextCursor returns cursor which points after movement
+func (page MovementsPage) NextCursor(movement InventoryMovement) string {
+	return encodeKeysetCursor(strconv.FormatUint(movement.Id, 10), movement.Id)
+}
@@ -1,24 +1,89 @@
 package domain
 
+import "time"
+
 type Shop struct {
 	Id          uint64
 	Title       string
 	Description string
-	ManagerIDs  []uint64
+	// ManagerIDs contain all users who can manage shop, including owners
+	ManagerIDs []uint64
+	OwnerIDs   []uint64
+	// Currency is shop's base currency, prices of shop's products are set in it
+	Currency string
+	// DeletedAt is zero for shops which were not deleted, it is filled only when deleted shop is read
+	DeletedAt time.Time
+}
+
+// ShopInvitation is an offer to become shop's manager, which invited user can accept or decline
+type ShopInvitation struct {
+	Id            uint64
+	ShopId        uint64
+	ShopTitle     string
+	InviterId     uint64
+	InvitedUserId uint64
+	Role          string
 }
 
 type Product struct {
-	Id           uint64
-	Title        string
-	Description  string
-	Price        uint64
+	Id          uint64
+	Title       string
+	Description string
+	// SKU is unique within shop, it is empty for products created without one
+	SKU string
+	// Price is in currency of product's shop. DiscountedPrice is price after active sale or shop discount,
+	// it equals Price if there are none
+	Price           Money
+	DiscountedPrice Money
+	// Converted prices are in currency which buyer prefers, they are zero if prices were not converted
+	ConvertedPrice           Money
+	ConvertedDiscountedPrice Money
+	// Availability is true if some units are not reserved, it is derived from stock
 	Availability bool
+	// Stock is amount of units on hand, including Reserved ones
+	Stock    uint64
+	Reserved uint64
 	// AssemblyTime is measured in minutes
 	AssemblyTime uint64
 	PartsAmount  uint64
+	// Rating is average rating of product's reviews, it is 0 if product has no reviews
 	Rating       float32
-	Size         string
-	Category     string
-	ImageLinks   []string
-	ShopId       uint64
+	ReviewsCount uint64
+	// RatingHistogram contains amounts of reviews with ratings from MinRating to MaxRating
+	RatingHistogram []uint64
+	// SavesCount is amount of users who saved product to their wishlists
+	SavesCount uint64
+	// IsSaved is true if viewer saved product to any of their wishlists, it is set only for single product
+	IsSaved bool
+	Size    string
+	// CategoryId is 0 if product has no category
+	CategoryId uint64
+	// ImageLinks contain links to large renditions of product's images, primary image goes first
+	ImageLinks []string
+	Images     []ProductImage
+	ShopId     uint64
+	// Options and Variants are filled only for single products. Price, stock and images of selected variant
+	// replace product's ones, SelectedVariantId is 0 if no variant was selected
+	Options           []ProductOption
+	Variants          []ProductVariant
+	SelectedVariantId uint64
+	// Relevance is text rank of product in search results, it is 0 outside of search
+	Relevance float32
+	// DeletedAt is zero for products which were not deleted, it is filled only when deleted products are read
+	DeletedAt time.Time
+}
+
+// ProductImage is one image of product's gallery. Links are relative to media directory
+type ProductImage struct {
+	Id            uint64
+	ProductId     uint64
+	Position      uint64
+	IsPrimary     bool
+	ThumbnailLink string
+	MediumLink    string
+	LargeLink     string
+	// Height, width and average colour describe uploaded image, same as pins and boards do
+	Height   uint64
+	Width    uint64
+	AvgColor string
 }
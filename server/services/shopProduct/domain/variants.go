@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxProductOptions limits amount of option types, such as size and colour, of one product
+	MaxProductOptions = 3
+	// MaxOptionValues limits amount of values of one option
+	MaxOptionValues = 30
+	// MaxOptionLength limits option names and values, it is measured in characters
+	MaxOptionLength = 50
+	// MaxSKULength is measured in characters
+	MaxSKULength = 64
+	// MaxProductVariants limits amount of variants of one product
+	MaxProductVariants = 100
+)
+
+// ProductOption is option type, such as size or colour, with values which product's variants can have
+type ProductOption struct {
+	Name   string
+	Values []string
+}
+
+// ProductVariant is purchasable combination of product's option values with its own SKU, stock and images
+type ProductVariant struct {
+	Id        uint64
+	ProductId uint64
+	SKU       string
+	// Options map option names to values of variant, every product's option has a value
+	Options map[string]string
+	// Price is price which buyer pays, PriceOverride is zero if variant costs the same as product
+	Price                    Money
+	PriceOverride            Money
+	DiscountedPrice          Money
+	ConvertedPrice           Money
+	ConvertedDiscountedPrice Money
+	Stock                    uint64
+	Reserved                 uint64
+	Availability             bool
+	// ImageIds are subset of product's images, empty subset means all of product's images
+	ImageIds   []uint64
+	ImageLinks []string
+}
+
+// ValidateOptions checks that there are not too many options, and that names and values are unique and not empty
+func ValidateOptions(options []ProductOption) error {
+	if len(options) > MaxProductOptions {
+		return InvalidOptionsError
+	}
+
+	names := make(map[string]bool, len(options))
+	for _, option := range options {
+		if !isOptionText(option.Name) || names[option.Name] || len(option.Values) == 0 || len(option.Values) > MaxOptionValues {
+			return InvalidOptionsError
+		}
+		names[option.Name] = true
+
+		values := make(map[string]bool, len(option.Values))
+		for _, value := range option.Values {
+			if !isOptionText(value) || values[value] {
+				return InvalidOptionsError
+			}
+			values[value] = true
+		}
+	}
+
+	return nil
+}
+
+func isOptionText(text string) bool {
+	return strings.TrimSpace(text) != "" && utf8.RuneCountInString(text) <= MaxOptionLength
+}
+
+// ValidateVariants checks variants against product's options. Every variant must have one allowed value
+// of every option, and variants must differ in both SKU and option values
+func ValidateVariants(options []ProductOption, variants []ProductVariant) error {
+	if len(variants) > MaxProductVariants {
+		return TooManyVariantsError
+	}
+
+	skus := make(map[string]bool, len(variants))
+	combinations := make(map[string]bool, len(variants))
+	for _, variant := range variants {
+		if !ValidSKU(variant.SKU) {
+			return InvalidSKUError
+		}
+
+		combination, err := variant.combination(options)
+		if err != nil {
+			return err
+		}
+
+		if skus[variant.SKU] || combinations[combination] {
+			return DuplicateVariantError
+		}
+		skus[variant.SKU] = true
+		combinations[combination] = true
+	}
+
+	return nil
+}
+
+// combination returns variant's option values in order of options, error is returned if they are not allowed
+func (variant ProductVariant) combination(options []ProductOption) (string, error) {
+	if len(options) == 0 || len(variant.Options) != len(options) {
+		return "", InvalidVariantOptionsError
+	}
+
+	values := make([]string, 0, len(options))
+	for _, option := range options {
+		value, found := variant.Options[option.Name]
+		if !found || !containsString(option.Values, value) {
+			return "", InvalidVariantOptionsError
+		}
+
+		values = append(values, value)
+	}
+
+	return strings.Join(values, "\x00"), nil
+}
+
+// Describe returns variant's option values in order of options, such as "L / Red"
+func (variant ProductVariant) Describe(options []ProductOption) string {
+	values := make([]string, 0, len(options))
+	for _, option := range options {
+		if value, found := variant.Options[option.Name]; found {
+			values = append(values, value)
+		}
+	}
+
+	return strings.Join(values, " / ")
+}
+
+func containsString(values []string, value string) bool {
+	for _, item := range values {
+		if item == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SelectVariant returns variant with id variantID. Products with variants require variant to be selected,
+// while products without variants accept only variantID of 0, in which case empty variant is returned
+func SelectVariant(variants []ProductVariant, variantID uint64) (ProductVariant, error) {
+	switch {
+	case len(variants) == 0 && variantID == 0:
+		return ProductVariant{}, nil
+	case len(variants) == 0:
+		return ProductVariant{}, VariantNotFoundError
+	case variantID == 0:
+		return ProductVariant{}, VariantRequiredError
+	}
+
+	for _, variant := range variants {
+		if variant.Id == variantID {
+			return variant, nil
+		}
+	}
+
+	return ProductVariant{}, VariantNotFoundError
+}
+
+// WithVariant replaces product's price, stock and images with ones of variant, empty variant leaves product unchanged
+func (product Product) WithVariant(variant ProductVariant) Product {
+	if variant.Id == 0 {
+		return product
+	}
+
+	product.SelectedVariantId = variant.Id
+	product.Price = variant.Price
+	product.DiscountedPrice = variant.DiscountedPrice
+	product.ConvertedPrice = variant.ConvertedPrice
+	product.ConvertedDiscountedPrice = variant.ConvertedDiscountedPrice
+	product.Stock = variant.Stock
+	product.Reserved = variant.Reserved
+	product.Availability = variant.Availability
+
+	if len(variant.ImageIds) != 0 {
+		product.ImageLinks = variant.ImageLinks
+
+		if product.Images != nil {
+			images := make([]ProductImage, 0, len(variant.ImageIds))
+			for _, image := range product.Images {
+				if containsID(variant.ImageIds, image.Id) {
+					images = append(images, image)
+				}
+			}
+			product.Images = images
+		}
+	}
+
+	return product
+}
+
+func containsID(ids []uint64, id uint64) bool {
+	for _, item := range ids {
+		if item == id {
+			return true
+		}
+	}
+
+	return false
+}
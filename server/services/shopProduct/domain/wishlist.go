@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultWishlistTitle is title of wishlist which is created when user saves first product
+	DefaultWishlistTitle = "Saved"
+	// MaxWishlistTitleLength is measured in characters
+	MaxWishlistTitleLength = 100
+	// MaxWishlists limits amount of wishlists of one user, including default one
+	MaxWishlists = 50
+	// ShareTokenLength is length of tokens in wishlists' share links
+	ShareTokenLength = 24
+)
+
+// Wishlist is named list of products saved by user. ShareToken is shown only to wishlist's owner
+type Wishlist struct {
+	Id         uint64
+	UserId     uint64
+	Title      string
+	IsDefault  bool
+	IsPublic   bool
+	ShareToken string
+	// ProductsCount is filled when wishlists are listed, Products are filled when single wishlist is read
+	ProductsCount uint64
+	Products      []Product
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Validate checks title of wishlist which is created or edited
+func (wishlist Wishlist) Validate() error {
+	if wishlist.Title == "" || utf8.RuneCountInString(wishlist.Title) > MaxWishlistTitleLength {
+		return InvalidWishlistTitleError
+	}
+
+	return nil
+}
+
+// VisibleTo reports whether user can see wishlist, userID is 0 for anonymous users
+func (wishlist Wishlist) VisibleTo(userID uint64) bool {
+	return wishlist.IsPublic || (userID != 0 && wishlist.UserId == userID)
+}
+
+// HideOwnerFields removes fields which only wishlist's owner can see
+func (wishlist Wishlist) HideOwnerFields(userID uint64) Wishlist {
+	if wishlist.UserId != userID {
+		wishlist.ShareToken = ""
+	}
+
+	return wishlist
+}
@@ -0,0 +1,299 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+	"time"
+)
+
+// viewDay is day in UTC of view, rollups are grouped by it
+const viewDay = `(viewed_at AT TIME ZONE 'UTC')::date`
+
+// dayStart converts date parameter to midnight of that day in UTC, so that views and reviews can be compared with it
+const dayStart = `::timestamp AT TIME ZONE 'UTC'`
+
+// AddShopView records view of shop page unless shop was already viewed during view session
+func (repo *ShopProductRepo) AddShopView(ctx context.Context, shopID uint64, view domain.PageView) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	addViewQuery := `INSERT INTO shop_views (shop_id, user_id, session, referrer)
+					 VALUES ($1, NULLIF($2::bigint, 0), $3, $4)
+					 ON CONFLICT (session, shop_id) DO NOTHING`
+
+	_, err = tx.Exec(ctx, addViewQuery, shopID, int64(view.UserID), view.Session, view.Referrer)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.ShopNotFoundError
+		}
+
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// GetLastRollupDay returns latest day which has rollups, it is zero time if views were never rolled up
+func (repo *ShopProductRepo) GetLastRollupDay(ctx context.Context) (day time.Time, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return time.Time{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getDayQuery := `SELECT max(day)
+					FROM view_rollups`
+
+	var lastDay *time.Time
+	err = tx.QueryRow(ctx, getDayQuery).Scan(&lastDay)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return time.Time{}, domain.TransactionCommitError
+	}
+	return fromNullTime(lastDay), nil
+}
+
+// RollupViews rebuilds daily rollups of days starting from given one. Views of purged products are lost
+// together with products, but rollups of earlier days keep them
+func (repo *ShopProductRepo) RollupViews(ctx context.Context, from time.Time) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	deleteRollupsQuery := `DELETE FROM view_rollups
+						   WHERE day >= $1::date`
+
+	_, err = tx.Exec(ctx, deleteRollupsQuery, from)
+	if err != nil {
+		return err
+	}
+
+	deleteReferrersQuery := `DELETE FROM referrer_rollups
+							 WHERE day >= $1::date`
+
+	_, err = tx.Exec(ctx, deleteReferrersQuery, from)
+	if err != nil {
+		return err
+	}
+
+	// Logged in viewers are counted by user, so that their views from several sessions make one viewer
+	rollupViewsQuery := `INSERT INTO view_rollups (day, shop_id, product_id, views, unique_viewers)
+						 SELECT ` + viewDay + `, products.shop_id, product_views.product_id, count(*),
+								count(DISTINCT COALESCE('user:' || product_views.user_id, 'session:' || NULLIF(product_views.session, '')))
+						 FROM product_views
+						 JOIN products ON products.id = product_views.product_id
+						 WHERE viewed_at >= $1::date` + dayStart + `
+						 GROUP BY 1, 2, 3
+						 UNION ALL
+						 SELECT ` + viewDay + `, shop_id, 0, count(*),
+								count(DISTINCT COALESCE('user:' || user_id, 'session:' || session))
+						 FROM shop_views
+						 WHERE viewed_at >= $1::date` + dayStart + `
+						 GROUP BY 1, 2`
+
+	_, err = tx.Exec(ctx, rollupViewsQuery, from)
+	if err != nil {
+		return err
+	}
+
+	rollupReferrersQuery := `INSERT INTO referrer_rollups (day, shop_id, product_id, referrer, views)
+							 SELECT ` + viewDay + `, products.shop_id, product_views.product_id, product_views.referrer, count(*)
+							 FROM product_views
+							 JOIN products ON products.id = product_views.product_id
+							 WHERE viewed_at >= $1::date` + dayStart + `
+							 GROUP BY 1, 2, 3, 4
+							 UNION ALL
+							 SELECT ` + viewDay + `, shop_id, 0, referrer, count(*)
+							 FROM shop_views
+							 WHERE viewed_at >= $1::date` + dayStart + `
+							 GROUP BY 1, 2, 4`
+
+	_, err = tx.Exec(ctx, rollupReferrersQuery, from)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// GetShopAnalytics builds report from rollups of days from from to to, both are included. Products which are
+// deleted are reported only if they were viewed during date range
+func (repo *ShopProductRepo) GetShopAnalytics(ctx context.Context, shopID uint64, from time.Time, to time.Time) (analytics domain.ShopAnalytics, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.ShopAnalytics{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	analytics = domain.ShopAnalytics{
+		ShopId:    shopID,
+		From:      from,
+		To:        to,
+		Days:      make([]domain.DailyViews, 0),
+		Referrers: make([]domain.ReferrerViews, 0),
+		Products:  make([]domain.ProductAnalytics, 0),
+	}
+
+	getTotalsQuery := `SELECT COALESCE(sum(views) FILTER (WHERE product_id = 0), 0),
+							  COALESCE(sum(unique_viewers) FILTER (WHERE product_id = 0), 0),
+							  COALESCE(sum(views) FILTER (WHERE product_id <> 0), 0)
+					   FROM view_rollups
+					   WHERE shop_id = $1 AND day BETWEEN $2::date AND $3::date`
+
+	err = tx.QueryRow(ctx, getTotalsQuery, shopID, from, to).
+		Scan(&analytics.ShopViews, &analytics.ShopUniqueViewers, &analytics.ProductViews)
+	if err != nil {
+		return domain.ShopAnalytics{}, err
+	}
+
+	getDaysQuery := `SELECT day, sum(views), sum(unique_viewers)
+					 FROM view_rollups
+					 WHERE shop_id = $1 AND day BETWEEN $2::date AND $3::date
+					 GROUP BY day
+					 ORDER BY day`
+
+	rows, err := tx.Query(ctx, getDaysQuery, shopID, from, to)
+	if err != nil {
+		return domain.ShopAnalytics{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		day := domain.DailyViews{}
+		err = rows.Scan(&day.Day, &day.Views, &day.UniqueViewers)
+		if err != nil {
+			return domain.ShopAnalytics{}, err
+		}
+
+		analytics.Days = append(analytics.Days, day)
+	}
+	if rows.Err() != nil {
+		return domain.ShopAnalytics{}, rows.Err()
+	}
+
+	getReferrersQuery := `SELECT referrer, sum(views)
+						  FROM referrer_rollups
+						  WHERE shop_id = $1 AND day BETWEEN $2::date AND $3::date
+						  GROUP BY referrer
+						  ORDER BY 2 DESC, referrer
+						  LIMIT $4`
+
+	rows, err = tx.Query(ctx, getReferrersQuery, shopID, from, to, domain.MaxShopReferrers)
+	if err != nil {
+		return domain.ShopAnalytics{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		referrer := domain.ReferrerViews{}
+		err = rows.Scan(&referrer.Referrer, &referrer.Views)
+		if err != nil {
+			return domain.ShopAnalytics{}, err
+		}
+
+		analytics.Referrers = append(analytics.Referrers, referrer)
+	}
+	if rows.Err() != nil {
+		return domain.ShopAnalytics{}, rows.Err()
+	}
+
+	getProductsQuery := `WITH views AS (
+							 SELECT product_id, sum(views) AS views, sum(unique_viewers) AS unique_viewers
+							 FROM view_rollups
+							 WHERE shop_id = $1 AND product_id <> 0 AND day BETWEEN $2::date AND $3::date
+							 GROUP BY product_id
+						 ), reviews AS (
+							 SELECT product_id, count(*) AS reviews_count
+							 FROM product_reviews
+							 WHERE product_id IN (SELECT id FROM products WHERE shop_id = $1)
+							   AND created_at >= $2::date` + dayStart + `
+							   AND created_at < ($3::date + 1)` + dayStart + `
+							 GROUP BY product_id
+						 )
+						 SELECT products.id, products.title, products.sku, COALESCE(views.views, 0),
+								COALESCE(views.unique_viewers, 0), COALESCE(reviews.reviews_count, 0)
+						 FROM products
+						 LEFT JOIN views ON views.product_id = products.id
+						 LEFT JOIN reviews ON reviews.product_id = products.id
+						 WHERE products.shop_id = $1 AND (products.deleted_at IS NULL OR views.product_id IS NOT NULL)
+						 ORDER BY 4 DESC, products.id`
+
+	rows, err = tx.Query(ctx, getProductsQuery, shopID, from, to)
+	if err != nil {
+		return domain.ShopAnalytics{}, err
+	}
+	defer rows.Close()
+
+	productIndexes := make(map[uint64]int)
+	for rows.Next() {
+		product := domain.ProductAnalytics{Referrers: make([]domain.ReferrerViews, 0)}
+		err = rows.Scan(&product.ProductId, &product.Title, &product.SKU, &product.Views,
+			&product.UniqueViewers, &product.ReviewsCount)
+		if err != nil {
+			return domain.ShopAnalytics{}, err
+		}
+
+		product.ViewToReviewRatio = domain.ViewToReviewRatio(product.ReviewsCount, product.Views)
+		productIndexes[product.ProductId] = len(analytics.Products)
+		analytics.Products = append(analytics.Products, product)
+	}
+	if rows.Err() != nil {
+		return domain.ShopAnalytics{}, rows.Err()
+	}
+
+	getProductReferrersQuery := `SELECT product_id, referrer, views
+								 FROM (SELECT product_id, referrer, sum(views) AS views,
+											  row_number() OVER (PARTITION BY product_id ORDER BY sum(views) DESC, referrer) AS place
+									   FROM referrer_rollups
+									   WHERE shop_id = $1 AND product_id <> 0 AND day BETWEEN $2::date AND $3::date
+									   GROUP BY product_id, referrer) AS referrers
+								 WHERE place <= $4
+								 ORDER BY product_id, place`
+
+	rows, err = tx.Query(ctx, getProductReferrersQuery, shopID, from, to, domain.MaxProductReferrers)
+	if err != nil {
+		return domain.ShopAnalytics{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var productID uint64
+		referrer := domain.ReferrerViews{}
+		err = rows.Scan(&productID, &referrer.Referrer, &referrer.Views)
+		if err != nil {
+			return domain.ShopAnalytics{}, err
+		}
+
+		index, found := productIndexes[productID]
+		if !found { // Product was purged or moved to another shop
+			continue
+		}
+		analytics.Products[index].Referrers = append(analytics.Products[index].Referrers, referrer)
+	}
+	if rows.Err() != nil {
+		return domain.ShopAnalytics{}, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.ShopAnalytics{}, domain.TransactionCommitError
+	}
+	return analytics, nil
+}
@@ -0,0 +1,353 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// cartItemConflict is target of upserts into cart_items, products without variants are stored with NULL variant
+const cartItemConflict = `(cart_id, product_id, (COALESCE(variant_id, 0)))`
+
+// cartOwnerCondition selects cart of user if user id is not 0, and anonymous cart with token otherwise.
+// Takes user id and token as $1 and $2
+const cartOwnerCondition = `CASE WHEN $1::bigint <> 0 THEN carts.user_id = $1::bigint
+							ELSE carts.user_id IS NULL AND carts.token = $2::text END`
+
+func (repo *ShopProductRepo) GetCartID(ctx context.Context, owner domain.CartOwner) (cartID uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getCartIDQuery := `SELECT carts.id
+					   FROM carts
+					   WHERE ` + cartOwnerCondition
+
+	err = tx.QueryRow(ctx, getCartIDQuery, owner.UserId, owner.Token).Scan(&cartID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, domain.CartNotFoundError
+		}
+
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return cartID, nil
+}
+
+// CreateCart creates cart of owner, existing cart of user is returned instead of creating new one
+func (repo *ShopProductRepo) CreateCart(ctx context.Context, owner domain.CartOwner) (cartID uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	cartID, err = createCart(ctx, tx, owner)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return cartID, nil
+}
+
+func createCart(ctx context.Context, tx pgx.Tx, owner domain.CartOwner) (cartID uint64, err error) {
+	if !owner.IsAnonymous() {
+		createUserCartQuery := `INSERT INTO carts (user_id)
+								VALUES ($1)
+								ON CONFLICT (user_id) DO UPDATE SET updated_at = now()
+								RETURNING id`
+
+		err = tx.QueryRow(ctx, createUserCartQuery, owner.UserId).Scan(&cartID)
+		return cartID, err
+	}
+
+	createAnonymousCartQuery := `INSERT INTO carts (token)
+								 VALUES ($1)
+								 RETURNING id`
+
+	err = tx.QueryRow(ctx, createAnonymousCartQuery, owner.Token).Scan(&cartID)
+	return cartID, err
+}
+
+// GetCart returns owner's cart with current data of its products and variants and with pricing which is active at now,
+// empty cart is returned if owner has no cart
+func (repo *ShopProductRepo) GetCart(ctx context.Context, owner domain.CartOwner, now time.Time) (cart domain.Cart, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.Cart{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getCartItemsQuery := `SELECT ` + productColumns + `, carts.id, cart_items.quantity, cart_items.added_price,
+							     cart_items.added_at, shops.title,
+							     EXISTS(SELECT 1 FROM product_variants AS variants WHERE variants.product_id = products.id),
+							     ` + variantColumns + `
+						  FROM carts
+						  INNER JOIN cart_items ON cart_items.cart_id = carts.id
+						  INNER JOIN products ON products.id = cart_items.product_id
+						  INNER JOIN shops ON shops.id = products.shop_id
+						  LEFT JOIN product_variants ON product_variants.id = cart_items.variant_id
+						  WHERE ` + cartOwnerCondition + ` AND products.deleted_at IS NULL
+						  ORDER BY products.shop_id, cart_items.added_at, products.id, cart_items.variant_id NULLS FIRST`
+
+	rows, err := tx.Query(ctx, getCartItemsQuery, owner.UserId, owner.Token)
+	if err != nil {
+		return domain.Cart{}, err
+	}
+	defer rows.Close()
+
+	var cartID uint64
+	items := make([]domain.CartItem, 0)
+	shopTitles := make(map[uint64]string)
+
+	for rows.Next() {
+		var item domain.CartItem
+		var shopTitle string
+		var hasVariants bool
+		var variant variantScan
+		extra := append([]interface{}{&cartID, &item.Quantity, &item.AddedPrice.Amount, &item.AddedAt, &shopTitle, &hasVariants},
+			variant.destinations()...)
+		item.Product, err = scanProduct(rows, extra...)
+		if err != nil {
+			return domain.Cart{}, err
+		}
+
+		// Price at which item was added is in currency of product, which can not change while shop has products
+		item.AddedPrice.Currency = item.Product.Price.Currency
+		item.Variant = variant.result()
+		item.VariantRequired = hasVariants && item.Variant.Id == 0
+
+		items = append(items, item)
+		shopTitles[item.Product.ShopId] = shopTitle
+	}
+	if rows.Err() != nil {
+		return domain.Cart{}, rows.Err()
+	}
+	rows.Close()
+
+	productIDs := make([]uint64, 0, len(items))
+	for _, item := range items {
+		productIDs = append(productIDs, item.Product.Id)
+	}
+	shopIDs := make([]uint64, 0, len(shopTitles))
+	for shopID := range shopTitles {
+		shopIDs = append(shopIDs, shopID)
+	}
+
+	pricing, err := getActivePricing(ctx, tx, productIDs, shopIDs, now)
+	if err != nil {
+		return domain.Cart{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.Cart{}, domain.TransactionCommitError
+	}
+	return domain.NewCart(cartID, owner, items, shopTitles, pricing), nil
+}
+
+// AddCartItem adds quantity of product's variant to cart, quantity is added to existing one if variant is already in cart.
+// Variant must be selected as product requires
+func (repo *ShopProductRepo) AddCartItem(ctx context.Context, cartID uint64, productID uint64, variantID uint64, quantity uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	err = checkVariantSelection(ctx, tx, productID, variantID)
+	if err != nil {
+		return err
+	}
+
+	addCartItemQuery := `INSERT INTO cart_items (cart_id, product_id, variant_id, quantity, added_price)
+						 SELECT $1, products.id, product_variants.id, $4, COALESCE(product_variants.price, products.price)
+						 FROM products
+						 LEFT JOIN product_variants ON product_variants.id = $3 AND product_variants.product_id = products.id
+						 WHERE products.id = $2 AND products.deleted_at IS NULL
+						 ON CONFLICT ` + cartItemConflict + ` DO UPDATE SET quantity = cart_items.quantity + EXCLUDED.quantity
+						 RETURNING quantity`
+
+	var newQuantity uint64
+	err = tx.QueryRow(ctx, addCartItemQuery, cartID, productID, int64(variantID), quantity).Scan(&newQuantity)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ProductNotFoundError
+		}
+
+		return err
+	}
+
+	if newQuantity > domain.MaxCartItemQuantity {
+		return domain.CartQuantityTooLargeError
+	}
+
+	err = touchCart(ctx, tx, cartID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+func (repo *ShopProductRepo) UpdateCartItem(ctx context.Context, cartID uint64, productID uint64, variantID uint64, quantity uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	updateCartItemQuery := `UPDATE cart_items
+							SET quantity = $4
+							WHERE cart_id = $1 AND product_id = $2 AND COALESCE(variant_id, 0) = $3`
+
+	result, err := tx.Exec(ctx, updateCartItemQuery, cartID, productID, int64(variantID), quantity)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.CartItemNotFoundError
+	}
+
+	err = touchCart(ctx, tx, cartID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+func (repo *ShopProductRepo) RemoveCartItem(ctx context.Context, cartID uint64, productID uint64, variantID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	removeCartItemQuery := `DELETE FROM cart_items
+							WHERE cart_id = $1 AND product_id = $2 AND COALESCE(variant_id, 0) = $3`
+
+	result, err := tx.Exec(ctx, removeCartItemQuery, cartID, productID, int64(variantID))
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.CartItemNotFoundError
+	}
+
+	err = touchCart(ctx, tx, cartID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// touchCart marks cart as changed, so that anonymous carts in use are not deleted
+func touchCart(ctx context.Context, tx pgx.Tx, cartID uint64) (err error) {
+	_, err = tx.Exec(ctx, `UPDATE carts SET updated_at = now() WHERE id = $1`, cartID)
+	return err
+}
+
+// MergeCarts moves items of anonymous cart into user's cart and deletes anonymous cart. Quantities of products
+// and variants which are in both carts are added up. Nothing is done if there is no anonymous cart with such token
+func (repo *ShopProductRepo) MergeCarts(ctx context.Context, token string, userID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getAnonymousCartQuery := `SELECT id
+							  FROM carts
+							  WHERE user_id IS NULL AND token = $1
+							  FOR UPDATE`
+
+	var anonymousCartID uint64
+	err = tx.QueryRow(ctx, getAnonymousCartQuery, token).Scan(&anonymousCartID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil
+		}
+
+		return err
+	}
+
+	userCartID, err := createCart(ctx, tx, domain.CartOwner{UserId: userID})
+	if err != nil {
+		return err
+	}
+
+	mergeItemsQuery := `INSERT INTO cart_items (cart_id, product_id, variant_id, quantity, added_price, added_at)
+						SELECT $2, product_id, variant_id, LEAST(quantity, $3), added_price, added_at
+						FROM cart_items
+						WHERE cart_id = $1
+						ON CONFLICT ` + cartItemConflict + ` DO UPDATE
+						SET quantity = LEAST(cart_items.quantity + EXCLUDED.quantity, $3)`
+
+	_, err = tx.Exec(ctx, mergeItemsQuery, anonymousCartID, userCartID, domain.MaxCartItemQuantity)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM carts WHERE id = $1`, anonymousCartID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// DeleteAbandonedCarts deletes anonymous carts which were not changed since before
+func (repo *ShopProductRepo) DeleteAbandonedCarts(ctx context.Context, before time.Time) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	deleteAbandonedCartsQuery := `DELETE FROM carts
+								  WHERE user_id IS NULL AND updated_at < $1`
+
+	_, err = tx.Exec(ctx, deleteAbandonedCartsQuery, before)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+const importColumns = `id, shop_id, user_id, format, dry_run, status, total_rows, processed_rows, created_count,
+					   updated_count, failed_count, row_errors, error_message, created_at, finished_at`
+
+func scanImport(row pgx.Row) (productImport domain.ProductImport, err error) {
+	var finishedAt *time.Time
+	productImport.Errors = make([]domain.ImportRowError, 0)
+	err = row.Scan(&productImport.Id, &productImport.ShopId, &productImport.UserId, &productImport.Format,
+		&productImport.DryRun, &productImport.Status, &productImport.TotalRows, &productImport.ProcessedRows,
+		&productImport.CreatedCount, &productImport.UpdatedCount, &productImport.FailedCount, &productImport.Errors,
+		&productImport.ErrorMessage, &productImport.CreatedAt, &finishedAt)
+	if err != nil {
+		return domain.ProductImport{}, err
+	}
+
+	productImport.FinishedAt = fromNullTime(finishedAt)
+	return productImport, nil
+}
+
+// GetProductsBySKU returns shop's products which have one of SKUs
+func (repo *ShopProductRepo) GetProductsBySKU(ctx context.Context, shopID uint64, skus []string) (products []domain.Product, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getProductsQuery := `SELECT ` + productColumns + `
+						 FROM products
+						 WHERE products.shop_id = $1 AND products.sku = ANY($2::text[]) AND products.deleted_at IS NULL`
+
+	products, err = queryProducts(ctx, tx, getProductsQuery, shopID, skus)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return products, nil
+}
+
+// ListShopCatalog returns all shop's products in order of their creation
+func (repo *ShopProductRepo) ListShopCatalog(ctx context.Context, shopID uint64) (products []domain.Product, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	listProductsQuery := `SELECT ` + productColumns + `
+						  FROM products
+						  WHERE products.shop_id = $1 AND products.deleted_at IS NULL
+						  ORDER BY products.id`
+
+	products, err = queryProducts(ctx, tx, listProductsQuery, shopID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return products, nil
+}
+
+func queryProducts(ctx context.Context, tx pgx.Tx, query string, args ...interface{}) (products []domain.Product, err error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products = make([]domain.Product, 0)
+
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		products = append(products, product)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return products, nil
+}
+
+// CreateImport saves pending import, its row errors are the ones found while reading file
+func (repo *ShopProductRepo) CreateImport(ctx context.Context, productImport domain.ProductImport) (createdImport domain.ProductImport, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.ProductImport{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	createImportQuery := `INSERT INTO product_imports (shop_id, user_id, format, dry_run, status, total_rows,
+													   processed_rows, failed_count, row_errors)
+						  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+						  RETURNING ` + importColumns
+
+	row := tx.QueryRow(ctx, createImportQuery, productImport.ShopId, productImport.UserId, productImport.Format,
+		productImport.DryRun, productImport.Status, productImport.TotalRows, productImport.ProcessedRows,
+		productImport.FailedCount, productImport.Errors)
+	createdImport, err = scanImport(row)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.ProductImport{}, domain.ShopNotFoundError
+		}
+
+		return domain.ProductImport{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.ProductImport{}, domain.TransactionCommitError
+	}
+	return createdImport, nil
+}
+
+func (repo *ShopProductRepo) GetImport(ctx context.Context, importID uint64) (productImport domain.ProductImport, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.ProductImport{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getImportQuery := `SELECT ` + importColumns + `
+					   FROM product_imports
+					   WHERE id = $1`
+
+	productImport, err = scanImport(tx.QueryRow(ctx, getImportQuery, importID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ProductImport{}, domain.ImportNotFoundError
+		}
+
+		return domain.ProductImport{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.ProductImport{}, domain.TransactionCommitError
+	}
+	return productImport, nil
+}
+
+// UpdateImport saves status, progress and row errors of import
+func (repo *ShopProductRepo) UpdateImport(ctx context.Context, productImport domain.ProductImport) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	updateImportQuery := `UPDATE product_imports
+						  SET status = $2, processed_rows = $3, created_count = $4, updated_count = $5,
+							  failed_count = $6, row_errors = $7, error_message = $8, finished_at = $9
+						  WHERE id = $1`
+
+	result, err := tx.Exec(ctx, updateImportQuery, productImport.Id, productImport.Status, productImport.ProcessedRows,
+		productImport.CreatedCount, productImport.UpdatedCount, productImport.FailedCount, productImport.Errors,
+		productImport.ErrorMessage, nullTime(productImport.FinishedAt))
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.ImportNotFoundError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"pinterest/services/shopProduct/domain"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// categorySubtrees selects ids of categories passed as array argument and of all their descendants.
+// Contains %s in place of the argument
+const categorySubtrees = `WITH RECURSIVE subtree AS (
+							  SELECT id FROM categories WHERE id = ANY(%s)
+							  UNION
+							  SELECT categories.id FROM categories INNER JOIN subtree ON categories.parent_id = subtree.id
+						  )
+						  SELECT id FROM subtree`
+
+const categoryColumns = `categories.id, COALESCE(categories.parent_id, 0), categories.slug, categories.position,
+						 ARRAY(SELECT locale FROM category_names WHERE category_id = categories.id ORDER BY locale),
+						 ARRAY(SELECT name FROM category_names WHERE category_id = categories.id ORDER BY locale)`
+
+func scanCategory(row pgx.Row) (category domain.Category, err error) {
+	locales := make([]string, 0)
+	names := make([]string, 0)
+	err = row.Scan(&category.Id, &category.ParentId, &category.Slug, &category.Position, &locales, &names)
+	if err != nil {
+		return domain.Category{}, err
+	}
+
+	category.Names = make(map[string]string, len(locales))
+	for i, locale := range locales {
+		category.Names[locale] = names[i]
+	}
+	return category, nil
+}
+
+// IsAdmin checks whether user can edit catalog-wide data
+func (repo *ShopProductRepo) IsAdmin(ctx context.Context, userID uint64) (isAdmin bool, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return false, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	isAdminQuery := `SELECT EXISTS(SELECT 1 FROM admins WHERE user_id = $1)`
+
+	err = tx.QueryRow(ctx, isAdminQuery, userID).Scan(&isAdmin)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return false, domain.TransactionCommitError
+	}
+	return isAdmin, nil
+}
+
+// GetCategories returns all categories ordered by position, children are not filled
+func (repo *ShopProductRepo) GetCategories(ctx context.Context) (categories []domain.Category, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getCategoriesQuery := `SELECT ` + categoryColumns + `
+						   FROM categories
+						   ORDER BY categories.position, categories.id`
+
+	rows, err := tx.Query(ctx, getCategoriesQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories = make([]domain.Category, 0)
+
+	for rows.Next() {
+		category, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		categories = append(categories, category)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return categories, nil
+}
+
+func (repo *ShopProductRepo) CreateCategory(ctx context.Context, category domain.Category) (categoryID uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	createCategoryQuery := `INSERT INTO categories (parent_id, slug, position)
+							VALUES (NULLIF($1, 0), $2, $3)
+							RETURNING id`
+
+	row := tx.QueryRow(ctx, createCategoryQuery, int64(category.ParentId), category.Slug, category.Position)
+	err = row.Scan(&categoryID)
+	if err != nil {
+		switch {
+		case isUniqueViolation(err):
+			return 0, domain.SlugExistsError
+		case isForeignKeyViolation(err):
+			return 0, domain.CategoryNotFoundError
+		}
+
+		return 0, err
+	}
+
+	err = addCategoryNames(ctx, tx, categoryID, category.Names)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return categoryID, nil
+}
+
+// UpdateCategory replaces category's slug, parent, position and names. Category can not become
+// descendant of itself
+func (repo *ShopProductRepo) UpdateCategory(ctx context.Context, category domain.Category) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	// Tree is locked, so that two concurrent moves can not create cycle together
+	_, err = tx.Exec(ctx, `LOCK TABLE categories IN SHARE ROW EXCLUSIVE MODE`)
+	if err != nil {
+		return err
+	}
+
+	if category.ParentId != 0 {
+		ancestorsQuery := `WITH RECURSIVE ancestors AS (
+							   SELECT id, parent_id FROM categories WHERE id = $1
+							   UNION
+							   SELECT categories.id, categories.parent_id FROM categories
+							   INNER JOIN ancestors ON categories.id = ancestors.parent_id
+						   )
+						   SELECT EXISTS(SELECT 1 FROM ancestors WHERE id = $2)`
+
+		var isCycle bool
+		err = tx.QueryRow(ctx, ancestorsQuery, category.ParentId, category.Id).Scan(&isCycle)
+		if err != nil {
+			return err
+		}
+
+		if isCycle {
+			return domain.CategoryCycleError
+		}
+	}
+
+	updateCategoryQuery := `UPDATE categories
+							SET parent_id = NULLIF($2, 0), slug = $3, position = $4
+							WHERE id = $1`
+
+	result, err := tx.Exec(ctx, updateCategoryQuery, category.Id, int64(category.ParentId), category.Slug, category.Position)
+	if err != nil {
+		switch {
+		case isUniqueViolation(err):
+			return domain.SlugExistsError
+		case isForeignKeyViolation(err):
+			return domain.CategoryNotFoundError
+		}
+
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.CategoryNotFoundError
+	}
+
+	deleteNamesQuery := `DELETE FROM category_names
+						 WHERE category_id = $1`
+
+	_, err = tx.Exec(ctx, deleteNamesQuery, category.Id)
+	if err != nil {
+		return err
+	}
+
+	err = addCategoryNames(ctx, tx, category.Id, category.Names)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+func addCategoryNames(ctx context.Context, tx pgx.Tx, categoryID uint64, names map[string]string) (err error) {
+	addNameQuery := `INSERT INTO category_names (category_id, locale, name)
+					 VALUES ($1, $2, $3)`
+
+	for locale, name := range names {
+		_, err = tx.Exec(ctx, addNameQuery, categoryID, locale, name)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteCategory deletes category without subcategories. Its products are moved to replacement category,
+// if replacementID is 0 and category has products, CategoryNotEmptyError is returned
+func (repo *ShopProductRepo) DeleteCategory(ctx context.Context, categoryID uint64, replacementID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	if replacementID != 0 {
+		moveProductsQuery := `UPDATE products
+							  SET category_id = $2
+							  WHERE category_id = $1`
+
+		_, err = tx.Exec(ctx, moveProductsQuery, categoryID, replacementID)
+		if err != nil {
+			if isForeignKeyViolation(err) {
+				return domain.CategoryNotFoundError
+			}
+
+			return err
+		}
+	}
+
+	deleteCategoryQuery := `DELETE FROM categories
+							WHERE id = $1`
+
+	result, err := tx.Exec(ctx, deleteCategoryQuery, categoryID)
+	if err != nil {
+		// Subcategories and products reference category
+		if isForeignKeyViolation(err) {
+			return domain.CategoryNotEmptyError
+		}
+
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.CategoryNotFoundError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// ListProductsByCategory returns page of products of category and its descendants.
+// Up to page.Limit + 1 products are returned
+func (repo *ShopProductRepo) ListProductsByCategory(ctx context.Context, categoryID uint64, page domain.ProductsPage) (products []domain.Product, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	condition, ordering, pageArgs := pageClauses(page, 1)
+	listProductsQuery := `SELECT ` + productColumns + `
+						  FROM products
+						  WHERE products.category_id IN (` + fmt.Sprintf(categorySubtrees, "$1") + `) AND products.deleted_at IS NULL
+							AND ` + condition + `
+						  ` + ordering
+
+	rows, err := tx.Query(ctx, listProductsQuery, append([]interface{}{[]uint64{categoryID}}, pageArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products = make([]domain.Product, 0)
+
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		products = append(products, product)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return products, nil
+}
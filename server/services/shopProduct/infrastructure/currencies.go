@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+	"time"
+)
+
+// GetExchangeRates returns all exchange rates in order of currencies
+func (repo *ShopProductRepo) GetExchangeRates(ctx context.Context) (rates []domain.ExchangeRate, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getRatesQuery := `SELECT currency, rate::text, rounding, updated_at
+					  FROM exchange_rates
+					  ORDER BY currency`
+
+	rows, err := tx.Query(ctx, getRatesQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rates = make([]domain.ExchangeRate, 0)
+
+	for rows.Next() {
+		var rate domain.ExchangeRate
+		err = rows.Scan(&rate.Currency, &rate.Rate, &rate.Rounding, &rate.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		rates = append(rates, rate)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return rates, nil
+}
+
+// ReplaceExchangeRates replaces all exchange rates, rates which did not change keep their update time.
+// Minor units of currencies are stored together with rates, so that prices can be converted by queries
+func (repo *ShopProductRepo) ReplaceExchangeRates(ctx context.Context, rates []domain.ExchangeRate, now time.Time) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	currencies := make([]string, 0, len(rates))
+	for _, rate := range rates {
+		currencies = append(currencies, rate.Currency)
+	}
+
+	var currencyInUse bool
+	currencyInUseQuery := `SELECT EXISTS (SELECT 1 FROM shops WHERE NOT currency = ANY($1::text[]))`
+	err = tx.QueryRow(ctx, currencyInUseQuery, currencies).Scan(&currencyInUse)
+	if err != nil {
+		return err
+	}
+	if currencyInUse {
+		return domain.CurrencyInUseError
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM exchange_rates WHERE NOT currency = ANY($1::text[])`, currencies)
+	if err != nil {
+		return err
+	}
+
+	upsertRateQuery := `INSERT INTO exchange_rates (currency, rate, minor_units, rounding, updated_at)
+						VALUES ($1, $2::numeric, $3, $4, $5)
+						ON CONFLICT (currency) DO UPDATE
+						SET rate = EXCLUDED.rate, minor_units = EXCLUDED.minor_units, rounding = EXCLUDED.rounding,
+							updated_at = EXCLUDED.updated_at
+						WHERE exchange_rates.rate <> EXCLUDED.rate OR exchange_rates.rounding <> EXCLUDED.rounding`
+
+	for _, rate := range rates {
+		_, err = tx.Exec(ctx, upsertRateQuery, rate.Currency, rate.Rate, domain.MinorUnits(rate.Currency), rate.Rounding, now)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
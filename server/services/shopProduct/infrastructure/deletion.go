@@ -0,0 +1,431 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// DeleteProduct hides product everywhere except orders, it can be restored until it is purged
+func (repo *ShopProductRepo) DeleteProduct(ctx context.Context, productID uint64, deletedAt time.Time) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	deleteProductQuery := `UPDATE products
+						   SET deleted_at = $2
+						   WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := tx.Exec(ctx, deleteProductQuery, productID, deletedAt)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.ProductNotFoundError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// PurgeProduct permanently deletes product, its images are returned, so that their files can be removed
+func (repo *ShopProductRepo) PurgeProduct(ctx context.Context, productID uint64) (images []domain.ProductImage, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getImagesQuery := `SELECT ` + productImageColumns + `
+					   FROM product_images
+					   WHERE product_id = $1`
+
+	rows, err := tx.Query(ctx, getImagesQuery, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	images = make([]domain.ProductImage, 0)
+
+	for rows.Next() {
+		image, err := scanProductImage(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		images = append(images, image)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	purgeProductQuery := `DELETE FROM products
+						  WHERE id = $1`
+
+	result, err := tx.Exec(ctx, purgeProductQuery, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.RowsAffected() != 1 {
+		return nil, domain.ProductNotFoundError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return images, nil
+}
+
+// DeleteShop deletes shop together with its products which were not deleted before. They get the same deletion
+// time as shop, so that restored shop gets back exactly them
+func (repo *ShopProductRepo) DeleteShop(ctx context.Context, shopID uint64, deletedAt time.Time) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	deleteShopQuery := `UPDATE shops
+						SET deleted_at = $2
+						WHERE id = $1 AND deleted_at IS NULL`
+
+	result, err := tx.Exec(ctx, deleteShopQuery, shopID, deletedAt)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.ShopNotFoundError
+	}
+
+	deleteProductsQuery := `UPDATE products
+							SET deleted_at = $2
+							WHERE shop_id = $1 AND deleted_at IS NULL`
+
+	_, err = tx.Exec(ctx, deleteProductsQuery, shopID, deletedAt)
+	if err != nil {
+		return err
+	}
+
+	// Feeds of deleted shop are no longer served, they are generated again if shop is restored
+	deleteFeedsQuery := `DELETE FROM marketplace_feeds
+						 WHERE shop_id = $1`
+
+	_, err = tx.Exec(ctx, deleteFeedsQuery, shopID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// GetDeletedShop returns shop which was deleted and not purged yet, together with its managers
+func (repo *ShopProductRepo) GetDeletedShop(ctx context.Context, shopID uint64) (shop domain.Shop, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.Shop{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getShopQuery := `SELECT id, title, description, currency,
+							ARRAY(SELECT user_id FROM shop_managers WHERE shop_id = shops.id ORDER BY user_id),
+							ARRAY(SELECT user_id FROM shop_managers WHERE shop_id = shops.id AND role = 'owner' ORDER BY user_id),
+							deleted_at
+					 FROM shops
+					 WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	managerIDs := make([]int64, 0)
+	ownerIDs := make([]int64, 0)
+	row := tx.QueryRow(ctx, getShopQuery, shopID)
+	err = row.Scan(&shop.Id, &shop.Title, &shop.Description, &shop.Currency, &managerIDs, &ownerIDs, &shop.DeletedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Shop{}, domain.ShopNotFoundError
+		}
+
+		return domain.Shop{}, err
+	}
+
+	shop.ManagerIDs = toUint64s(managerIDs)
+	shop.OwnerIDs = toUint64s(ownerIDs)
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.Shop{}, domain.TransactionCommitError
+	}
+	return shop, nil
+}
+
+// RestoreShop restores deleted shop and products which were deleted together with it.
+// Products which were deleted before shop stay deleted
+func (repo *ShopProductRepo) RestoreShop(ctx context.Context, shopID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getDeletedAtQuery := `SELECT deleted_at
+						  FROM shops
+						  WHERE id = $1 AND deleted_at IS NOT NULL
+						  FOR UPDATE`
+
+	var deletedAt time.Time
+	err = tx.QueryRow(ctx, getDeletedAtQuery, shopID).Scan(&deletedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ShopNotFoundError
+		}
+
+		return err
+	}
+
+	restoreShopQuery := `UPDATE shops
+						 SET deleted_at = NULL
+						 WHERE id = $1`
+
+	_, err = tx.Exec(ctx, restoreShopQuery, shopID)
+	if err != nil {
+		return err
+	}
+
+	// Restored products go back into marketplace feeds, so their offers have to be rendered again
+	restoreProductsQuery := `UPDATE products
+							 SET deleted_at = NULL, feed_changed_at = now()
+							 WHERE shop_id = $1 AND deleted_at = $2`
+
+	_, err = tx.Exec(ctx, restoreProductsQuery, shopID, deletedAt)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.DuplicateProductSKUError
+		}
+
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// GetDeletedProduct returns product which was deleted and not purged yet
+func (repo *ShopProductRepo) GetDeletedProduct(ctx context.Context, productID uint64) (product domain.Product, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.Product{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getProductQuery := `SELECT ` + productColumns + `, products.deleted_at
+						FROM products
+						WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	var deletedAt time.Time
+	product, err = scanProduct(tx.QueryRow(ctx, getProductQuery, productID), &deletedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Product{}, domain.ProductNotFoundError
+		}
+
+		return domain.Product{}, err
+	}
+
+	product.DeletedAt = deletedAt
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.Product{}, domain.TransactionCommitError
+	}
+	return product, nil
+}
+
+// RestoreProduct restores deleted product. Its SKU may have been taken by another product while it was deleted
+func (repo *ShopProductRepo) RestoreProduct(ctx context.Context, productID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	// Restored product goes back into marketplace feeds, so its offer has to be rendered again
+	restoreProductQuery := `UPDATE products
+							SET deleted_at = NULL, feed_changed_at = now()
+							WHERE id = $1 AND deleted_at IS NOT NULL`
+
+	result, err := tx.Exec(ctx, restoreProductQuery, productID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.DuplicateProductSKUError
+		}
+
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.ProductNotFoundError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// ListDeletedProducts returns shop's products which were deleted after given time, recently deleted go first.
+// Products deleted earlier can no longer be restored even if they were not purged yet
+func (repo *ShopProductRepo) ListDeletedProducts(ctx context.Context, shopID uint64, deletedAfter time.Time) (products []domain.Product, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	listProductsQuery := `SELECT ` + productColumns + `, products.deleted_at
+						  FROM products
+						  WHERE products.shop_id = $1 AND products.deleted_at > $2
+						  ORDER BY products.deleted_at DESC, products.id DESC`
+
+	rows, err := tx.Query(ctx, listProductsQuery, shopID, deletedAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products = make([]domain.Product, 0)
+
+	for rows.Next() {
+		var deletedAt time.Time
+		product, err := scanProduct(rows, &deletedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		product.DeletedAt = deletedAt
+		products = append(products, product)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return products, nil
+}
+
+// ListDeletedShops returns shops which were deleted before given time
+func (repo *ShopProductRepo) ListDeletedShops(ctx context.Context, before time.Time) (shopIDs []uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	listShopsQuery := `SELECT id
+					   FROM shops
+					   WHERE deleted_at <= $1`
+
+	rows, err := tx.Query(ctx, listShopsQuery, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	shopIDs = make([]uint64, 0)
+
+	for rows.Next() {
+		var shopID uint64
+		err = rows.Scan(&shopID)
+		if err != nil {
+			return nil, err
+		}
+
+		shopIDs = append(shopIDs, shopID)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return shopIDs, nil
+}
+
+// PurgeDeleted permanently deletes products which were deleted before given time and given deleted shops
+// which have no products left. Images of purged products are returned, so that their files can be removed
+func (repo *ShopProductRepo) PurgeDeleted(ctx context.Context, before time.Time, shopIDs []uint64) (images []domain.ProductImage, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getImagesQuery := `SELECT ` + productImageColumns + `
+					   FROM product_images
+					   WHERE product_id IN (SELECT id FROM products WHERE deleted_at <= $1)`
+
+	rows, err := tx.Query(ctx, getImagesQuery, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	images = make([]domain.ProductImage, 0)
+
+	for rows.Next() {
+		image, err := scanProductImage(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		images = append(images, image)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	// Orders keep snapshots of purged products, their items lose only links to them
+	purgeProductsQuery := `DELETE FROM products
+						   WHERE deleted_at <= $1`
+
+	_, err = tx.Exec(ctx, purgeProductsQuery, before)
+	if err != nil {
+		return nil, err
+	}
+
+	purgeShopsQuery := `DELETE FROM shops
+						WHERE id = ANY($1) AND deleted_at <= $2
+						  AND NOT EXISTS(SELECT 1 FROM products WHERE products.shop_id = shops.id)`
+
+	_, err = tx.Exec(ctx, purgeShopsQuery, shopIDs, before)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return images, nil
+}
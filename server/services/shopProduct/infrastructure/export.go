@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// exportQuery selects rows of one section of user's data export. Query takes user id as its only parameter
+type exportQuery struct {
+	section string
+	query   string
+	// mediaColumns contain links to media files which should be added to archive
+	mediaColumns []string
+}
+
+// exportQueries describe everything tied to user in shopProduct service's tables, one archive file per query
+var exportQueries = []exportQuery{
+	{
+		section: "shops_managed",
+		query: `SELECT shops.id, shops.title, shops.description, shops.created_at, shop_managers.role
+				FROM shop_managers
+				INNER JOIN shops ON shops.id = shop_managers.shop_id
+				WHERE shop_managers.user_id = $1`,
+	},
+	{
+		section: "products",
+		query: `SELECT products.id, products.shop_id, products.sku, products.title, products.description, products.price,
+					   products.currency, products.stock, products.assembly_time, products.parts_amount, products.size,
+					   products.category_id, products.created_at, products.deleted_at
+				FROM products
+				INNER JOIN shop_managers ON shop_managers.shop_id = products.shop_id
+				WHERE shop_managers.user_id = $1`,
+	},
+	{
+		section: "product_images",
+		query: `SELECT product_images.id, product_images.product_id, product_images."position", product_images.is_primary,
+					   product_images.thumbnail_link, product_images.medium_link, product_images.large_link,
+					   product_images.created_at
+				FROM product_images
+				INNER JOIN products ON products.id = product_images.product_id
+				INNER JOIN shop_managers ON shop_managers.shop_id = products.shop_id
+				WHERE shop_managers.user_id = $1`,
+		mediaColumns: []string{"thumbnail_link", "medium_link", "large_link"},
+	},
+	{
+		section: "shop_invitations",
+		query: `SELECT id, shop_id, inviter_id, role, created_at
+				FROM shop_invitations
+				WHERE invited_user_id = $1`,
+	},
+	{
+		section: "shops_followed",
+		query: `SELECT shops.id, shops.title, shop_followers.created_at
+				FROM shop_followers
+				INNER JOIN shops ON shops.id = shop_followers.shop_id
+				WHERE shop_followers.user_id = $1`,
+	},
+	{
+		section: "product_views",
+		query: `SELECT product_id, referrer, viewed_at
+				FROM product_views
+				WHERE user_id = $1`,
+	},
+	{
+		section: "shop_views",
+		query: `SELECT shop_id, referrer, viewed_at
+				FROM shop_views
+				WHERE user_id = $1`,
+	},
+	{
+		section: "product_reviews",
+		query: `SELECT id, product_id, title, text, rating, created_at, updated_at
+				FROM product_reviews
+				WHERE user_id = $1`,
+	},
+	{
+		section: "product_questions",
+		query: `SELECT id, product_id, text, created_at
+				FROM product_questions
+				WHERE user_id = $1`,
+	},
+	{
+		section: "product_answers",
+		query: `SELECT id, question_id, text, author_role, is_official, created_at
+				FROM product_answers
+				WHERE user_id = $1`,
+	},
+	{
+		section: "question_votes",
+		query: `SELECT question_id, created_at
+				FROM question_votes
+				WHERE user_id = $1`,
+	},
+	{
+		section: "inventory_adjustments",
+		query: `SELECT id, product_id, stock_delta, comment, created_at
+				FROM inventory_movements
+				WHERE user_id = $1`,
+	},
+	{
+		section: "product_imports",
+		query: `SELECT id, shop_id, format, dry_run, status, total_rows, created_count, updated_count, failed_count,
+					   created_at, finished_at
+				FROM product_imports
+				WHERE user_id = $1`,
+	},
+	{
+		section: "cart_items",
+		query: `SELECT cart_items.product_id, cart_items.variant_id, cart_items.quantity, cart_items.added_price,
+					   products.currency, cart_items.added_at
+				FROM cart_items
+				JOIN carts ON carts.id = cart_items.cart_id
+				JOIN products ON products.id = cart_items.product_id
+				WHERE carts.user_id = $1`,
+	},
+	{
+		section: "wishlists",
+		query: `SELECT id, title, is_default, is_public, created_at, updated_at
+				FROM wishlists
+				WHERE user_id = $1`,
+	},
+	{
+		section: "wishlist_items",
+		query: `SELECT wishlist_items.wishlist_id, wishlist_items.product_id, wishlist_items.added_at
+				FROM wishlist_items
+				JOIN wishlists ON wishlists.id = wishlist_items.wishlist_id
+				WHERE wishlists.user_id = $1`,
+	},
+	{
+		section: "promo_redemptions",
+		query: `SELECT promo_redemptions.id, promo_codes.shop_id, promo_codes.code, promo_redemptions.discount,
+					   promo_redemptions.currency, promo_redemptions.created_at, promo_redemptions.released_at
+				FROM promo_redemptions
+				JOIN promo_codes ON promo_codes.id = promo_redemptions.promo_code_id
+				WHERE promo_redemptions.user_id = $1`,
+	},
+}
+
+// CollectUserData runs all export queries in one transaction, so that sections are consistent with each other
+func (repo *ShopProductRepo) CollectUserData(ctx context.Context, userID uint64) (sections []domain.ExportSection, err error) {
+	tx, err := repo.postgresDB.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	sections = make([]domain.ExportSection, 0, len(exportQueries))
+	for _, query := range exportQueries {
+		section, err := collectSection(ctx, tx, query, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		sections = append(sections, section)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return sections, nil
+}
+
+func collectSection(ctx context.Context, tx pgx.Tx, query exportQuery, userID uint64) (section domain.ExportSection, err error) {
+	rows, err := tx.Query(ctx, query.query, userID)
+	if err != nil {
+		return domain.ExportSection{}, err
+	}
+	defer rows.Close()
+
+	section.Name = query.section
+	section.Records = make([]map[string]interface{}, 0)
+	section.MediaLinks = make([]string, 0)
+
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return domain.ExportSection{}, err
+		}
+
+		record := make(map[string]interface{}, len(values))
+		for i, field := range rows.FieldDescriptions() {
+			record[string(field.Name)] = values[i]
+		}
+
+		for _, column := range query.mediaColumns {
+			link, ok := record[column].(string)
+			if ok && link != "" {
+				section.MediaLinks = append(section.MediaLinks, link)
+			}
+		}
+
+		section.Records = append(section.Records, record)
+	}
+	if rows.Err() != nil {
+		return domain.ExportSection{}, rows.Err()
+	}
+
+	return section, nil
+}
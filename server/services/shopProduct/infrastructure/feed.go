@@ -0,0 +1,257 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"pinterest/services/shopProduct/domain"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// AddProductView records view of product page unless product was already viewed during view session
+func (repo *ShopProductRepo) AddProductView(ctx context.Context, productID uint64, view domain.PageView) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	addViewQuery := `INSERT INTO product_views (product_id, user_id, session, referrer)
+					 VALUES ($1, NULLIF($2::bigint, 0), $3, $4)
+					 ON CONFLICT (session, product_id) WHERE NOT session = '' DO NOTHING`
+
+	_, err = tx.Exec(ctx, addViewQuery, productID, int64(view.UserID), view.Session, view.Referrer)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.ProductNotFoundError
+		}
+
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+func (repo *ShopProductRepo) FollowShop(ctx context.Context, shopID uint64, userID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	followShopQuery := `INSERT INTO shop_followers (shop_id, user_id)
+						VALUES ($1, $2)
+						ON CONFLICT DO NOTHING`
+
+	_, err = tx.Exec(ctx, followShopQuery, shopID, userID)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.ShopNotFoundError
+		}
+
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+func (repo *ShopProductRepo) UnfollowShop(ctx context.Context, shopID uint64, userID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	unfollowShopQuery := `DELETE FROM shop_followers
+						  WHERE shop_id = $1 AND user_id = $2`
+
+	result, err := tx.Exec(ctx, unfollowShopQuery, shopID, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.ShopFollowNotFoundError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// personalFeedScores ranks products by followed shops, categories user has recently browsed and recent views.
+// Takes user id, weights of followed shops, browsed categories and trending, and both periods in days
+const personalFeedScores = `SELECT products.id, products.rating,
+								   (CASE WHEN followed.shop_id IS NULL THEN 0 ELSE $3::double precision END
+									+ COALESCE(browsed.share, 0) * $4::double precision
+									+ ln(1 + COALESCE(trending.views, 0)) * $5::double precision) AS score
+							FROM products
+							LEFT JOIN (SELECT shop_id FROM shop_followers WHERE user_id = $2) AS followed
+								ON followed.shop_id = products.shop_id
+							LEFT JOIN (SELECT products.category_id,
+											  count(*)::double precision / SUM(count(*)) OVER () AS share
+									   FROM product_views
+									   INNER JOIN products ON products.id = product_views.product_id
+									   WHERE product_views.user_id = $2
+										 AND product_views.viewed_at > now() - make_interval(days => $6)
+									   GROUP BY products.category_id) AS browsed
+								ON browsed.category_id = products.category_id
+							LEFT JOIN (SELECT product_id, count(*) AS views
+									   FROM product_views
+									   WHERE viewed_at > now() - make_interval(days => $7)
+									   GROUP BY product_id) AS trending
+								ON trending.product_id = products.id
+							WHERE products.deleted_at IS NULL`
+
+// popularFeedScores ranks products by all views they ever had and their saves, is used for anonymous users.
+// Takes weight of one save
+const popularFeedScores = `SELECT products.id, products.rating,
+								  COALESCE(popularity.views, 0)::double precision + products.saves_count * $2::double precision AS score
+						   FROM products
+						   LEFT JOIN (SELECT product_id, count(*) AS views
+									  FROM product_views
+									  GROUP BY product_id) AS popularity
+							   ON popularity.product_id = products.id
+						   WHERE products.deleted_at IS NULL`
+
+// CreateFeed ranks products for user and saves the best of them as feed snapshot
+func (repo *ShopProductRepo) CreateFeed(ctx context.Context, userID uint64) (feedID uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	createFeedQuery := `INSERT INTO product_feeds (user_id)
+						VALUES (NULLIF($1, 0))
+						RETURNING id`
+
+	err = tx.QueryRow(ctx, createFeedQuery, int64(userID)).Scan(&feedID)
+	if err != nil {
+		return 0, err
+	}
+
+	scores, args := popularFeedScores, []interface{}{feedID, domain.SaveWeight}
+	if userID != 0 {
+		scores = personalFeedScores
+		args = []interface{}{feedID, userID, domain.FollowedShopWeight, domain.BrowsedCategoryWeight, domain.TrendingWeight,
+			domain.BrowsedCategoryDays, domain.TrendingDays}
+	}
+
+	args = append(args, domain.MaxFeedSize)
+
+	// Ties are broken by rating and id, so that feed order is deterministic
+	addFeedItemsQuery := fmt.Sprintf(`INSERT INTO product_feed_items (feed_id, position, product_id)
+									  SELECT $1, row_number() OVER (ORDER BY score DESC, rating DESC, id DESC), id
+									  FROM (%s
+											ORDER BY score DESC, rating DESC, id DESC
+											LIMIT $%d) AS ranked`, scores, len(args))
+
+	_, err = tx.Exec(ctx, addFeedItemsQuery, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return feedID, nil
+}
+
+// GetFeedPage returns products which follow cursor in feed snapshot, together with their positions.
+// Expired feeds and feeds of other users are reported as invalid cursors
+func (repo *ShopProductRepo) GetFeedPage(ctx context.Context, cursor domain.FeedCursor, userID uint64, limit uint64) (products []domain.Product, positions []uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	checkFeedQuery := `SELECT COALESCE(user_id, 0)
+					   FROM product_feeds
+					   WHERE id = $1 AND created_at > now() - make_interval(hours => $2)`
+
+	var feedUserID uint64
+	err = tx.QueryRow(ctx, checkFeedQuery, cursor.FeedId, domain.FeedLifetimeHours).Scan(&feedUserID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil, domain.InvalidCursorError
+		}
+
+		return nil, nil, err
+	}
+
+	if feedUserID != userID {
+		return nil, nil, domain.InvalidCursorError
+	}
+
+	getFeedPageQuery := `SELECT ` + productColumns + `, product_feed_items.position
+						 FROM product_feed_items
+						 INNER JOIN products ON products.id = product_feed_items.product_id
+						 WHERE product_feed_items.feed_id = $1 AND product_feed_items.position > $2 AND products.deleted_at IS NULL
+						 ORDER BY product_feed_items.position
+						 LIMIT $3`
+
+	rows, err := tx.Query(ctx, getFeedPageQuery, cursor.FeedId, cursor.Position, limit)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	products = make([]domain.Product, 0)
+	positions = make([]uint64, 0)
+
+	for rows.Next() {
+		var position uint64
+		product, err := scanProduct(rows, &position)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		products = append(products, product)
+		positions = append(positions, position)
+	}
+	if rows.Err() != nil {
+		return nil, nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, nil, domain.TransactionCommitError
+	}
+	return products, positions, nil
+}
+
+// DeleteExpiredFeeds deletes feed snapshots which can no longer be scrolled
+func (repo *ShopProductRepo) DeleteExpiredFeeds(ctx context.Context) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	deleteFeedsQuery := `DELETE FROM product_feeds
+						 WHERE created_at <= now() - make_interval(hours => $1)`
+
+	_, err = tx.Exec(ctx, deleteFeedsQuery, domain.FeedLifetimeHours)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+
+	"github.com/jackc/pgx/v4"
+)
+
+const productImageColumns = `id, product_id, position, is_primary, thumbnail_link, medium_link, large_link,
+							 imageheight, imagewidth, imageavgcolor`
+
+func scanProductImage(row pgx.Row) (image domain.ProductImage, err error) {
+	err = row.Scan(&image.Id, &image.ProductId, &image.Position, &image.IsPrimary, &image.ThumbnailLink,
+		&image.MediumLink, &image.LargeLink, &image.Height, &image.Width, &image.AvgColor)
+	if err != nil {
+		return domain.ProductImage{}, err
+	}
+
+	return image, nil
+}
+
+// AddProductImage adds image to the end of product's gallery. First image of product becomes primary
+func (repo *ShopProductRepo) AddProductImage(ctx context.Context, image domain.ProductImage) (imageID uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	// Product is locked so that concurrent uploads get different positions and only one of them becomes primary
+	lockProductQuery := `SELECT id
+						 FROM products
+						 WHERE id = $1
+						 FOR UPDATE`
+
+	var lockedID uint64
+	err = tx.QueryRow(ctx, lockProductQuery, image.ProductId).Scan(&lockedID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, domain.ProductNotFoundError
+		}
+
+		return 0, err
+	}
+
+	addImageQuery := `INSERT INTO product_images (product_id, position, is_primary, thumbnail_link, medium_link,
+												  large_link, imageheight, imagewidth, imageavgcolor)
+					  SELECT $1, COALESCE(MAX(position) + 1, 0), COUNT(*) = 0, $2, $3, $4, $5, $6, $7
+					  FROM product_images
+					  WHERE product_id = $1
+					  RETURNING id`
+
+	row := tx.QueryRow(ctx, addImageQuery, image.ProductId, image.ThumbnailLink, image.MediumLink, image.LargeLink,
+		image.Height, image.Width, image.AvgColor)
+	err = row.Scan(&imageID)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return imageID, nil
+}
+
+// GetProductImages returns product's images in gallery order
+func (repo *ShopProductRepo) GetProductImages(ctx context.Context, productID uint64) (images []domain.ProductImage, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getImagesQuery := `SELECT ` + productImageColumns + `
+					   FROM product_images
+					   WHERE product_id = $1
+					   ORDER BY is_primary DESC, position, id`
+
+	rows, err := tx.Query(ctx, getImagesQuery, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	images = make([]domain.ProductImage, 0)
+
+	for rows.Next() {
+		image, err := scanProductImage(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		images = append(images, image)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return images, nil
+}
+
+// ReorderProductImages sets images' positions according to their order in imageIDs,
+// which must contain every image of product exactly once
+func (repo *ShopProductRepo) ReorderProductImages(ctx context.Context, productID uint64, imageIDs []uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	countImagesQuery := `SELECT count(*)
+						 FROM product_images
+						 WHERE product_id = $1`
+
+	var imagesCount int
+	err = tx.QueryRow(ctx, countImagesQuery, productID).Scan(&imagesCount)
+	if err != nil {
+		return err
+	}
+
+	if imagesCount != len(imageIDs) {
+		return domain.InvalidImageOrderError
+	}
+
+	updatePositionQuery := `UPDATE product_images
+							SET position = $3
+							WHERE product_id = $1 AND id = $2`
+
+	for position, imageID := range imageIDs {
+		result, err := tx.Exec(ctx, updatePositionQuery, productID, imageID, position)
+		if err != nil {
+			return err
+		}
+
+		if result.RowsAffected() != 1 {
+			return domain.InvalidImageOrderError
+		}
+	}
+
+	// Repeated ids would leave some images with old positions, which is found by checking that positions are unique
+	countPositionsQuery := `SELECT count(DISTINCT position)
+							FROM product_images
+							WHERE product_id = $1`
+
+	var positionsCount int
+	err = tx.QueryRow(ctx, countPositionsQuery, productID).Scan(&positionsCount)
+	if err != nil {
+		return err
+	}
+
+	if positionsCount != len(imageIDs) {
+		return domain.InvalidImageOrderError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+func (repo *ShopProductRepo) SetPrimaryProductImage(ctx context.Context, productID uint64, imageID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	resetPrimaryQuery := `UPDATE product_images
+						  SET is_primary = false
+						  WHERE product_id = $1 AND is_primary`
+
+	_, err = tx.Exec(ctx, resetPrimaryQuery, productID)
+	if err != nil {
+		return err
+	}
+
+	setPrimaryQuery := `UPDATE product_images
+						SET is_primary = true
+						WHERE product_id = $1 AND id = $2`
+
+	result, err := tx.Exec(ctx, setPrimaryQuery, productID, imageID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.ImageNotFoundError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// DeleteProductImage deletes image and returns it, so that its files can be removed.
+// If primary image is deleted, first of remaining images becomes primary
+func (repo *ShopProductRepo) DeleteProductImage(ctx context.Context, productID uint64, imageID uint64) (image domain.ProductImage, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.ProductImage{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	deleteImageQuery := `DELETE FROM product_images
+						 WHERE product_id = $1 AND id = $2
+						 RETURNING ` + productImageColumns
+
+	image, err = scanProductImage(tx.QueryRow(ctx, deleteImageQuery, productID, imageID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ProductImage{}, domain.ImageNotFoundError
+		}
+
+		return domain.ProductImage{}, err
+	}
+
+	if image.IsPrimary {
+		promoteImageQuery := `UPDATE product_images
+							  SET is_primary = true
+							  WHERE id = (SELECT id FROM product_images WHERE product_id = $1 ORDER BY position, id LIMIT 1)`
+
+		_, err = tx.Exec(ctx, promoteImageQuery, productID)
+		if err != nil {
+			return domain.ProductImage{}, err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.ProductImage{}, domain.TransactionCommitError
+	}
+	return image, nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"pinterest/services/shopProduct/domain"
+)
+
+// pageClauses returns condition and ordering which select requested page of products.
+// argsCount is amount of query's own arguments, page's arguments are numbered after them.
+// One extra product is selected, so that caller knows whether there is next page
+func pageClauses(page domain.ProductsPage, argsCount int) (condition string, ordering string, args []interface{}) {
+	keyset := keysetPage{
+		column:     page.Sorting.Column,
+		columnType: page.Sorting.ColumnType,
+		idColumn:   "products.id",
+		descending: page.Sorting.Descending,
+		limit:      page.Limit,
+		offset:     page.Offset,
+	}
+	if page.Cursor != nil {
+		keyset.cursor = []interface{}{page.Cursor.Value, page.Cursor.ProductId}
+	}
+
+	return keyset.clauses(argsCount)
+}
+
+// keysetPage describes page of rows sorted by column, ties are broken by idColumn in the same direction
+type keysetPage struct {
+	column     string
+	columnType string
+	idColumn   string
+	descending bool
+	// cursor contains column's value and id of the last row of previous page, it is nil on the first page
+	cursor []interface{}
+	limit  uint64
+	offset uint64
+}
+
+// clauses returns condition and ordering which select page and one extra row
+func (page keysetPage) clauses(argsCount int) (condition string, ordering string, args []interface{}) {
+	direction, comparison := "ASC", ">"
+	if page.descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	condition = "TRUE"
+	if page.cursor != nil {
+		condition = fmt.Sprintf("(%s, %s) %s ($%d::%s, $%d::bigint)",
+			page.column, page.idColumn, comparison, argsCount+1, page.columnType, argsCount+2)
+		args = append(args, page.cursor...)
+		argsCount += 2
+	}
+
+	ordering = fmt.Sprintf("ORDER BY %s %s, %s %s LIMIT $%d OFFSET $%d",
+		page.column, direction, page.idColumn, direction, argsCount+1, argsCount+2)
+	args = append(args, page.limit+1, page.offset)
+
+	return condition, ordering, args
+}
+
+// ListProductsByShop returns page of shop's products. Up to page.Limit + 1 products are returned
+func (repo *ShopProductRepo) ListProductsByShop(ctx context.Context, shopID uint64, page domain.ProductsPage) (products []domain.Product, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	condition, ordering, pageArgs := pageClauses(page, 1)
+	listProductsQuery := `SELECT ` + productColumns + `
+						  FROM products
+						  WHERE products.shop_id = $1 AND products.deleted_at IS NULL AND ` + condition + `
+						  ` + ordering
+
+	rows, err := tx.Query(ctx, listProductsQuery, append([]interface{}{shopID}, pageArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products = make([]domain.Product, 0)
+
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		products = append(products, product)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return products, nil
+}
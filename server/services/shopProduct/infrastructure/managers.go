@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// GetManagerRole returns user's role in shop. Returns NotShopManagerError if user does not manage shop
+func (repo *ShopProductRepo) GetManagerRole(ctx context.Context, shopID uint64, userID uint64) (role string, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return "", domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getManagerRoleQuery := `SELECT COALESCE(shop_managers.role, '')
+							FROM shops
+							LEFT JOIN shop_managers ON shop_managers.shop_id = shops.id AND shop_managers.user_id = $2
+							WHERE shops.id = $1 AND shops.deleted_at IS NULL`
+
+	row := tx.QueryRow(ctx, getManagerRoleQuery, shopID, userID)
+	err = row.Scan(&role)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", domain.ShopNotFoundError
+		}
+
+		return "", err
+	}
+
+	if role == "" {
+		return "", domain.NotShopManagerError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return "", domain.TransactionCommitError
+	}
+	return role, nil
+}
+
+// RemoveShopManager removes manager from shop, making sure that shop keeps at least one owner
+func (repo *ShopProductRepo) RemoveShopManager(ctx context.Context, shopID uint64, managerID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	// Shop is locked so that owners can not concurrently remove each other
+	lockShopQuery := `SELECT id
+					  FROM shops
+					  WHERE id = $1
+					  FOR UPDATE`
+
+	var lockedID uint64
+	err = tx.QueryRow(ctx, lockShopQuery, shopID).Scan(&lockedID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ShopNotFoundError
+		}
+
+		return err
+	}
+
+	removeManagerQuery := `DELETE FROM shop_managers
+						   WHERE shop_id = $1 AND user_id = $2
+						   RETURNING role`
+
+	var role string
+	err = tx.QueryRow(ctx, removeManagerQuery, shopID, managerID).Scan(&role)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.NotShopManagerError
+		}
+
+		return err
+	}
+
+	if role == domain.RoleOwner {
+		countOwnersQuery := `SELECT count(*)
+							 FROM shop_managers
+							 WHERE shop_id = $1 AND role = 'owner'`
+
+		var ownersCount uint64
+		err = tx.QueryRow(ctx, countOwnersQuery, shopID).Scan(&ownersCount)
+		if err != nil {
+			return err
+		}
+
+		if ownersCount == 0 {
+			return domain.LastOwnerError
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// CreateInvitation saves invitation. Repeated invitation of the same user replaces previous one
+func (repo *ShopProductRepo) CreateInvitation(ctx context.Context, invitation domain.ShopInvitation) (invitationID uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	createInvitationQuery := `INSERT INTO shop_invitations (shop_id, invited_user_id, inviter_id, role)
+							  VALUES ($1, $2, $3, $4)
+							  ON CONFLICT (shop_id, invited_user_id)
+							  DO UPDATE SET inviter_id = EXCLUDED.inviter_id, role = EXCLUDED.role, created_at = now()
+							  RETURNING id`
+
+	row := tx.QueryRow(ctx, createInvitationQuery, invitation.ShopId, invitation.InvitedUserId,
+		invitation.InviterId, invitation.Role)
+	err = row.Scan(&invitationID)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return 0, domain.ShopNotFoundError
+		}
+
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return invitationID, nil
+}
+
+func (repo *ShopProductRepo) GetUserInvitations(ctx context.Context, userID uint64) (invitations []domain.ShopInvitation, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getInvitationsQuery := `SELECT shop_invitations.id, shop_invitations.shop_id, shops.title,
+								   shop_invitations.inviter_id, shop_invitations.invited_user_id, shop_invitations.role
+							FROM shop_invitations
+							INNER JOIN shops ON shops.id = shop_invitations.shop_id
+							WHERE shop_invitations.invited_user_id = $1
+							ORDER BY shop_invitations.created_at DESC`
+
+	rows, err := tx.Query(ctx, getInvitationsQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	invitations = make([]domain.ShopInvitation, 0)
+
+	for rows.Next() {
+		invitation := domain.ShopInvitation{}
+		err = rows.Scan(&invitation.Id, &invitation.ShopId, &invitation.ShopTitle,
+			&invitation.InviterId, &invitation.InvitedUserId, &invitation.Role)
+		if err != nil {
+			return nil, err
+		}
+
+		invitations = append(invitations, invitation)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return invitations, nil
+}
+
+// AcceptInvitation makes invited user shop's manager and deletes invitation.
+// Owners who accept invitation to become managers stay owners
+func (repo *ShopProductRepo) AcceptInvitation(ctx context.Context, invitationID uint64, userID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	deleteInvitationQuery := `DELETE FROM shop_invitations
+							  WHERE id = $1 AND invited_user_id = $2
+							  RETURNING shop_id, role`
+
+	var shopID uint64
+	var role string
+	err = tx.QueryRow(ctx, deleteInvitationQuery, invitationID, userID).Scan(&shopID, &role)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.InvitationNotFoundError
+		}
+
+		return err
+	}
+
+	addManagerQuery := `INSERT INTO shop_managers (shop_id, user_id, role)
+						VALUES ($1, $2, $3)
+						ON CONFLICT (shop_id, user_id)
+						DO UPDATE SET role = CASE WHEN shop_managers.role = 'owner' THEN 'owner' ELSE EXCLUDED.role END`
+
+	_, err = tx.Exec(ctx, addManagerQuery, shopID, userID, role)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// DeleteInvitation deletes invitation, only invited user can do it
+func (repo *ShopProductRepo) DeleteInvitation(ctx context.Context, invitationID uint64, userID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	deleteInvitationQuery := `DELETE FROM shop_invitations
+							  WHERE id = $1 AND invited_user_id = $2`
+
+	result, err := tx.Exec(ctx, deleteInvitationQuery, invitationID, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.InvitationNotFoundError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
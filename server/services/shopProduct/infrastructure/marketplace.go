@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// feedScopeCondition selects products of shop $1, or all products if $1 is 0. Deleted products are left out
+const feedScopeCondition = `($1::bigint = 0 OR products.shop_id = $1) AND products.deleted_at IS NULL`
+
+func (repo *ShopProductRepo) GetMarketplaceFeed(ctx context.Context, shopID uint64, format string) (feed domain.MarketplaceFeed, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.MarketplaceFeed{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getFeedQuery := `SELECT shop_id, format, document, offers_count, generated_at
+					 FROM marketplace_feeds
+					 WHERE shop_id = $1 AND format = $2`
+
+	err = tx.QueryRow(ctx, getFeedQuery, shopID, format).Scan(&feed.ShopId, &feed.Format, &feed.Document,
+		&feed.OffersCount, &feed.GeneratedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.MarketplaceFeed{}, domain.FeedNotFoundError
+		}
+
+		return domain.MarketplaceFeed{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.MarketplaceFeed{}, domain.TransactionCommitError
+	}
+	return feed, nil
+}
+
+// ListMarketplaceFeeds returns shops and formats of cached feeds without their documents
+func (repo *ShopProductRepo) ListMarketplaceFeeds(ctx context.Context) (feeds []domain.MarketplaceFeed, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	listFeedsQuery := `SELECT shop_id, format, offers_count, generated_at
+					   FROM marketplace_feeds
+					   ORDER BY shop_id, format`
+
+	rows, err := tx.Query(ctx, listFeedsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	feeds = make([]domain.MarketplaceFeed, 0)
+
+	for rows.Next() {
+		feed := domain.MarketplaceFeed{}
+		err = rows.Scan(&feed.ShopId, &feed.Format, &feed.OffersCount, &feed.GeneratedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		feeds = append(feeds, feed)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return feeds, nil
+}
+
+// SaveMarketplaceFeed replaces cached feed of shop in format
+func (repo *ShopProductRepo) SaveMarketplaceFeed(ctx context.Context, feed domain.MarketplaceFeed) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	saveFeedQuery := `INSERT INTO marketplace_feeds (shop_id, format, document, offers_count, generated_at)
+					  VALUES ($1, $2, $3, $4, $5)
+					  ON CONFLICT (shop_id, format)
+					  DO UPDATE SET document = EXCLUDED.document, offers_count = EXCLUDED.offers_count,
+									generated_at = EXCLUDED.generated_at`
+
+	_, err = tx.Exec(ctx, saveFeedQuery, feed.ShopId, feed.Format, feed.Document, feed.OffersCount, feed.GeneratedAt)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// GetFeedScope counts products which go into feed of shop and lists currencies of their prices
+func (repo *ShopProductRepo) GetFeedScope(ctx context.Context, shopID uint64) (scope domain.FeedScope, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.FeedScope{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getScopeQuery := `SELECT count(*), COALESCE(array_agg(DISTINCT products.currency::text), '{}')
+					  FROM products
+					  WHERE ` + feedScopeCondition
+
+	scope.Currencies = make([]string, 0)
+	err = tx.QueryRow(ctx, getScopeQuery, shopID).Scan(&scope.ProductsCount, &scope.Currencies)
+	if err != nil {
+		return domain.FeedScope{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.FeedScope{}, domain.TransactionCommitError
+	}
+	return scope, nil
+}
+
+// GetStaleFeedProducts returns products of feed whose offers in format were not rendered yet or were rendered before
+// product or its shop changed. Offers also become stale when sale or shop discount starts or ends
+func (repo *ShopProductRepo) GetStaleFeedProducts(ctx context.Context, shopID uint64, format string, now time.Time) (products []domain.Product, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getStaleProductsQuery := `SELECT ` + productColumns + `
+							  FROM products
+							  JOIN shops ON shops.id = products.shop_id
+							  LEFT JOIN marketplace_feed_offers AS offers
+									 ON offers.product_id = products.id AND offers.format = $2
+							  WHERE ` + feedScopeCondition + ` AND (
+									offers.rendered_at IS NULL
+									OR offers.rendered_at < products.feed_changed_at
+									OR offers.rendered_at < shops.feed_changed_at
+									OR EXISTS (SELECT 1 FROM product_sales
+											   WHERE product_sales.product_id = products.id
+												 AND (product_sales.starts_at > offers.rendered_at AND product_sales.starts_at <= $3
+													  OR product_sales.ends_at > offers.rendered_at AND product_sales.ends_at <= $3))
+									OR EXISTS (SELECT 1 FROM shop_discounts
+											   WHERE shop_discounts.shop_id = products.shop_id
+												 AND (shop_discounts.starts_at > offers.rendered_at AND shop_discounts.starts_at <= $3
+													  OR shop_discounts.ends_at > offers.rendered_at AND shop_discounts.ends_at <= $3)))
+							  ORDER BY products.id`
+
+	products, err = queryProducts(ctx, tx, getStaleProductsQuery, shopID, format, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return products, nil
+}
+
+// SaveFeedOffers replaces rendered offers, renderedAt must be taken before products were read
+func (repo *ShopProductRepo) SaveFeedOffers(ctx context.Context, offers []domain.FeedOffer, renderedAt time.Time) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	saveOfferQuery := `INSERT INTO marketplace_feed_offers (product_id, format, fragment, rendered_at)
+					   VALUES ($1, $2, $3, $4)
+					   ON CONFLICT (product_id, format)
+					   DO UPDATE SET fragment = EXCLUDED.fragment, rendered_at = EXCLUDED.rendered_at`
+
+	for _, offer := range offers {
+		_, err = tx.Exec(ctx, saveOfferQuery, offer.ProductId, offer.Format, offer.Fragment, renderedAt)
+		if err != nil {
+			if isForeignKeyViolation(err) { // Product was deleted while feed was generated
+				return domain.ProductNotFoundError
+			}
+
+			return err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// ListFeedOffers returns rendered offers of feed's products in order of products
+func (repo *ShopProductRepo) ListFeedOffers(ctx context.Context, shopID uint64, format string) (fragments []string, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	listOffersQuery := `SELECT offers.fragment
+						FROM marketplace_feed_offers AS offers
+						JOIN products ON products.id = offers.product_id
+						WHERE ` + feedScopeCondition + ` AND offers.format = $2
+						ORDER BY products.id`
+
+	rows, err := tx.Query(ctx, listOffersQuery, shopID, format)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fragments = make([]string, 0)
+
+	for rows.Next() {
+		var fragment string
+		err = rows.Scan(&fragment)
+		if err != nil {
+			return nil, err
+		}
+
+		fragments = append(fragments, fragment)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return fragments, nil
+}
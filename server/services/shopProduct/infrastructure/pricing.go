@@ -0,0 +1,705 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// nullTime converts zero time to NULL, so that periods without end are stored as NULL
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+// fromNullTime converts NULL to zero time
+func fromNullTime(t *time.Time) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+
+	return *t
+}
+
+// GetActivePricing returns sales of products and discounts of shops which are active at now
+func (repo *ShopProductRepo) GetActivePricing(ctx context.Context, productIDs []uint64, shopIDs []uint64, now time.Time) (pricing domain.Pricing, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.Pricing{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	pricing, err = getActivePricing(ctx, tx, productIDs, shopIDs, now)
+	if err != nil {
+		return domain.Pricing{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.Pricing{}, domain.TransactionCommitError
+	}
+	return pricing, nil
+}
+
+func getActivePricing(ctx context.Context, tx pgx.Tx, productIDs []uint64, shopIDs []uint64, now time.Time) (pricing domain.Pricing, err error) {
+	pricing = domain.Pricing{
+		Sales:     make(map[uint64][]domain.ProductSale),
+		Discounts: make(map[uint64][]domain.ShopDiscount),
+	}
+
+	getSalesQuery := `SELECT id, product_id, COALESCE(variant_id, 0), sale_price, currency, starts_at, ends_at, created_at
+					  FROM product_sales
+					  WHERE product_id = ANY($1) AND starts_at <= $2 AND (ends_at IS NULL OR ends_at > $2)`
+
+	sales, err := querySales(ctx, tx, getSalesQuery, productIDs, now)
+	if err != nil {
+		return domain.Pricing{}, err
+	}
+	for _, sale := range sales {
+		pricing.Sales[sale.ProductId] = append(pricing.Sales[sale.ProductId], sale)
+	}
+
+	getDiscountsQuery := `SELECT id, shop_id, percent, starts_at, ends_at, created_at
+						  FROM shop_discounts
+						  WHERE shop_id = ANY($1) AND starts_at <= $2 AND (ends_at IS NULL OR ends_at > $2)`
+
+	discounts, err := queryShopDiscounts(ctx, tx, getDiscountsQuery, shopIDs, now)
+	if err != nil {
+		return domain.Pricing{}, err
+	}
+	for _, discount := range discounts {
+		pricing.Discounts[discount.ShopId] = append(pricing.Discounts[discount.ShopId], discount)
+	}
+
+	return pricing, nil
+}
+
+func querySales(ctx context.Context, tx pgx.Tx, query string, args ...interface{}) (sales []domain.ProductSale, err error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sales = make([]domain.ProductSale, 0)
+
+	for rows.Next() {
+		var sale domain.ProductSale
+		var endsAt *time.Time
+		err = rows.Scan(&sale.Id, &sale.ProductId, &sale.VariantId, &sale.SalePrice.Amount, &sale.SalePrice.Currency,
+			&sale.StartsAt, &endsAt, &sale.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		sale.EndsAt = fromNullTime(endsAt)
+		sales = append(sales, sale)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return sales, nil
+}
+
+func queryShopDiscounts(ctx context.Context, tx pgx.Tx, query string, args ...interface{}) (discounts []domain.ShopDiscount, err error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	discounts = make([]domain.ShopDiscount, 0)
+
+	for rows.Next() {
+		var discount domain.ShopDiscount
+		var endsAt *time.Time
+		err = rows.Scan(&discount.Id, &discount.ShopId, &discount.Percent, &discount.StartsAt, &endsAt, &discount.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		discount.EndsAt = fromNullTime(endsAt)
+		discounts = append(discounts, discount)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return discounts, nil
+}
+
+// addPriceChange records change in price history. Changes without prices, such as shop discounts,
+// are recorded in currency of shop
+func addPriceChange(ctx context.Context, tx pgx.Tx, change domain.PriceChange) (err error) {
+	addPriceChangeQuery := `INSERT INTO price_history (product_id, variant_id, shop_id, kind, old_price, new_price,
+													   percent, starts_at, ends_at, user_id, currency)
+							VALUES (NULLIF($1, 0), NULLIF($2, 0), $3, $4, NULLIF($5, 0), NULLIF($6, 0),
+									NULLIF($7, 0), $8, $9, NULLIF($10, 0),
+									COALESCE(NULLIF($11, ''), (SELECT currency FROM shops WHERE id = $3)))`
+
+	currency := change.NewPrice.Currency
+	if currency == "" {
+		currency = change.OldPrice.Currency
+	}
+
+	_, err = tx.Exec(ctx, addPriceChangeQuery, int64(change.ProductId), int64(change.VariantId), change.ShopId, change.Kind,
+		int64(change.OldPrice.Amount), int64(change.NewPrice.Amount), int64(change.Percent), nullTime(change.StartsAt),
+		nullTime(change.EndsAt), int64(change.UserId), currency)
+	return err
+}
+
+// CreateSale creates sale of product or of its variant and records it in price history
+func (repo *ShopProductRepo) CreateSale(ctx context.Context, sale domain.ProductSale, userID uint64) (saleID uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getProductShopQuery := `SELECT shop_id
+							FROM products
+							WHERE id = $1
+							FOR UPDATE`
+
+	var shopID uint64
+	err = tx.QueryRow(ctx, getProductShopQuery, sale.ProductId).Scan(&shopID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, domain.ProductNotFoundError
+		}
+
+		return 0, err
+	}
+
+	if sale.VariantId != 0 {
+		variantExistsQuery := `SELECT EXISTS(SELECT 1 FROM product_variants WHERE id = $1 AND product_id = $2)`
+
+		var variantExists bool
+		err = tx.QueryRow(ctx, variantExistsQuery, sale.VariantId, sale.ProductId).Scan(&variantExists)
+		if err != nil {
+			return 0, err
+		}
+
+		if !variantExists {
+			return 0, domain.VariantNotFoundError
+		}
+	}
+
+	createSaleQuery := `INSERT INTO product_sales (product_id, variant_id, sale_price, currency, starts_at, ends_at)
+						VALUES ($1, NULLIF($2, 0), $3, $4, $5, $6)
+						RETURNING id`
+
+	row := tx.QueryRow(ctx, createSaleQuery, sale.ProductId, int64(sale.VariantId), sale.SalePrice.Amount,
+		sale.SalePrice.Currency, sale.StartsAt, nullTime(sale.EndsAt))
+	err = row.Scan(&saleID)
+	if err != nil {
+		if isCheckViolation(err) {
+			return 0, domain.InvalidSaleError
+		}
+
+		return 0, err
+	}
+
+	err = addPriceChange(ctx, tx, domain.PriceChange{
+		ProductId: sale.ProductId,
+		VariantId: sale.VariantId,
+		ShopId:    shopID,
+		Kind:      domain.PriceChangeSale,
+		NewPrice:  sale.SalePrice,
+		StartsAt:  sale.StartsAt,
+		EndsAt:    sale.EndsAt,
+		UserId:    userID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return saleID, nil
+}
+
+// ListSales returns sales of product which are active or scheduled at now, in order of their start
+func (repo *ShopProductRepo) ListSales(ctx context.Context, productID uint64, now time.Time) (sales []domain.ProductSale, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	listSalesQuery := `SELECT id, product_id, COALESCE(variant_id, 0), sale_price, currency, starts_at, ends_at, created_at
+					   FROM product_sales
+					   WHERE product_id = $1 AND (ends_at IS NULL OR ends_at > $2)
+					   ORDER BY starts_at, id`
+
+	sales, err = querySales(ctx, tx, listSalesQuery, productID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return sales, nil
+}
+
+// DeleteSale deletes sale of product, its cancellation is recorded in price history
+func (repo *ShopProductRepo) DeleteSale(ctx context.Context, productID uint64, saleID uint64, userID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	deleteSaleQuery := `DELETE FROM product_sales
+						USING products
+						WHERE product_sales.id = $1 AND product_sales.product_id = $2 AND products.id = product_sales.product_id
+						RETURNING COALESCE(product_sales.variant_id, 0), product_sales.sale_price, product_sales.currency,
+								  product_sales.starts_at, product_sales.ends_at, products.shop_id`
+
+	change := domain.PriceChange{
+		ProductId: productID,
+		Kind:      domain.PriceChangeSaleCancelled,
+		UserId:    userID,
+	}
+	var endsAt *time.Time
+	err = tx.QueryRow(ctx, deleteSaleQuery, saleID, productID).Scan(&change.VariantId, &change.NewPrice.Amount,
+		&change.NewPrice.Currency, &change.StartsAt, &endsAt, &change.ShopId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.SaleNotFoundError
+		}
+
+		return err
+	}
+	change.EndsAt = fromNullTime(endsAt)
+
+	err = addPriceChange(ctx, tx, change)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// CreateShopDiscount creates discount of all shop's products and records it in price history
+func (repo *ShopProductRepo) CreateShopDiscount(ctx context.Context, discount domain.ShopDiscount, userID uint64) (discountID uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	createDiscountQuery := `INSERT INTO shop_discounts (shop_id, percent, starts_at, ends_at)
+							VALUES ($1, $2, $3, $4)
+							RETURNING id`
+
+	row := tx.QueryRow(ctx, createDiscountQuery, discount.ShopId, discount.Percent, discount.StartsAt, nullTime(discount.EndsAt))
+	err = row.Scan(&discountID)
+	if err != nil {
+		switch {
+		case isForeignKeyViolation(err):
+			return 0, domain.ShopNotFoundError
+		case isCheckViolation(err):
+			return 0, domain.InvalidDiscountError
+		}
+
+		return 0, err
+	}
+
+	err = addPriceChange(ctx, tx, domain.PriceChange{
+		ShopId:   discount.ShopId,
+		Kind:     domain.PriceChangeDiscount,
+		Percent:  discount.Percent,
+		StartsAt: discount.StartsAt,
+		EndsAt:   discount.EndsAt,
+		UserId:   userID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return discountID, nil
+}
+
+// ListShopDiscounts returns discounts of shop which are active or scheduled at now, in order of their start
+func (repo *ShopProductRepo) ListShopDiscounts(ctx context.Context, shopID uint64, now time.Time) (discounts []domain.ShopDiscount, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	listDiscountsQuery := `SELECT id, shop_id, percent, starts_at, ends_at, created_at
+						   FROM shop_discounts
+						   WHERE shop_id = $1 AND (ends_at IS NULL OR ends_at > $2)
+						   ORDER BY starts_at, id`
+
+	discounts, err = queryShopDiscounts(ctx, tx, listDiscountsQuery, shopID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return discounts, nil
+}
+
+// DeleteShopDiscount deletes discount of shop, its cancellation is recorded in price history
+func (repo *ShopProductRepo) DeleteShopDiscount(ctx context.Context, shopID uint64, discountID uint64, userID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	deleteDiscountQuery := `DELETE FROM shop_discounts
+							WHERE id = $1 AND shop_id = $2
+							RETURNING percent, starts_at, ends_at`
+
+	change := domain.PriceChange{
+		ShopId: shopID,
+		Kind:   domain.PriceChangeDiscountCancelled,
+		UserId: userID,
+	}
+	var endsAt *time.Time
+	err = tx.QueryRow(ctx, deleteDiscountQuery, discountID, shopID).Scan(&change.Percent, &change.StartsAt, &endsAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DiscountNotFoundError
+		}
+
+		return err
+	}
+	change.EndsAt = fromNullTime(endsAt)
+
+	err = addPriceChange(ctx, tx, change)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// CreatePromoCode creates promo code, active codes of one shop must be unique
+func (repo *ShopProductRepo) CreatePromoCode(ctx context.Context, promoCode domain.PromoCode) (promoCodeID uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	createPromoCodeQuery := `INSERT INTO promo_codes (shop_id, code, kind, value, min_order, max_uses,
+													  max_uses_per_user, expires_at, currency)
+							 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+							 RETURNING id`
+
+	row := tx.QueryRow(ctx, createPromoCodeQuery, promoCode.ShopId, promoCode.Code, promoCode.Kind, promoCode.Value,
+		promoCode.MinOrder, promoCode.MaxUses, promoCode.MaxUsesPerUser, nullTime(promoCode.ExpiresAt), promoCode.Currency)
+	err = row.Scan(&promoCodeID)
+	if err != nil {
+		switch {
+		case isUniqueViolation(err):
+			return 0, domain.PromoCodeExistsError
+		case isForeignKeyViolation(err):
+			return 0, domain.ShopNotFoundError
+		case isCheckViolation(err):
+			return 0, domain.InvalidPromoCodeError
+		}
+
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return promoCodeID, nil
+}
+
+// promoCodeColumns are selected by queries that return promo codes, in order expected by scanPromoCode
+const promoCodeColumns = `promo_codes.id, promo_codes.shop_id, promo_codes.code, promo_codes.kind, promo_codes.value,
+						  promo_codes.min_order, promo_codes.max_uses, promo_codes.max_uses_per_user, promo_codes.used_count,
+						  promo_codes.expires_at, promo_codes.created_at, promo_codes.currency`
+
+func scanPromoCode(row pgx.Row) (promoCode domain.PromoCode, err error) {
+	var expiresAt *time.Time
+	err = row.Scan(&promoCode.Id, &promoCode.ShopId, &promoCode.Code, &promoCode.Kind, &promoCode.Value,
+		&promoCode.MinOrder, &promoCode.MaxUses, &promoCode.MaxUsesPerUser, &promoCode.UsedCount, &expiresAt,
+		&promoCode.CreatedAt, &promoCode.Currency)
+	if err != nil {
+		return domain.PromoCode{}, err
+	}
+
+	promoCode.ExpiresAt = fromNullTime(expiresAt)
+	return promoCode, nil
+}
+
+// ListPromoCodes returns active promo codes of shop, newest codes go first
+func (repo *ShopProductRepo) ListPromoCodes(ctx context.Context, shopID uint64) (promoCodes []domain.PromoCode, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	listPromoCodesQuery := `SELECT ` + promoCodeColumns + `
+							FROM promo_codes
+							WHERE shop_id = $1 AND is_active
+							ORDER BY id DESC`
+
+	rows, err := tx.Query(ctx, listPromoCodesQuery, shopID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	promoCodes = make([]domain.PromoCode, 0)
+
+	for rows.Next() {
+		promoCode, err := scanPromoCode(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		promoCodes = append(promoCodes, promoCode)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return promoCodes, nil
+}
+
+// DeletePromoCode deactivates promo code, so that redemptions of existing orders are kept
+func (repo *ShopProductRepo) DeletePromoCode(ctx context.Context, shopID uint64, promoCodeID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	deactivatePromoCodeQuery := `UPDATE promo_codes
+								 SET is_active = false
+								 WHERE id = $1 AND shop_id = $2 AND is_active`
+
+	result, err := tx.Exec(ctx, deactivatePromoCodeQuery, promoCodeID, shopID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.PromoCodeNotFoundError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// RedeemPromoCode checks expiry, minimum order and usage limits of shop's active promo code and records its use by user.
+// Code must be normalized. Promo code is locked, so that concurrent checkouts can not exceed its limits
+func (repo *ShopProductRepo) RedeemPromoCode(ctx context.Context, code string, shopID uint64, userID uint64, subtotal domain.Money, now time.Time) (redemption domain.PromoRedemption, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.PromoRedemption{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getPromoCodeQuery := `SELECT ` + promoCodeColumns + `
+						  FROM promo_codes
+						  WHERE shop_id = $1 AND code = $2 AND is_active
+						  FOR UPDATE`
+
+	promoCode, err := scanPromoCode(tx.QueryRow(ctx, getPromoCodeQuery, shopID, code))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.PromoRedemption{}, domain.PromoCodeNotFoundError
+		}
+
+		return domain.PromoRedemption{}, err
+	}
+
+	discount, err := promoCode.Discount(subtotal, now)
+	if err != nil {
+		return domain.PromoRedemption{}, err
+	}
+
+	if promoCode.MaxUses != 0 && promoCode.UsedCount >= promoCode.MaxUses {
+		return domain.PromoRedemption{}, domain.PromoCodeUsedUpError
+	}
+
+	if promoCode.MaxUsesPerUser != 0 {
+		countUserRedemptionsQuery := `SELECT count(*)
+									  FROM promo_redemptions
+									  WHERE promo_code_id = $1 AND user_id = $2 AND released_at IS NULL`
+
+		var userRedemptions uint64
+		err = tx.QueryRow(ctx, countUserRedemptionsQuery, promoCode.Id, userID).Scan(&userRedemptions)
+		if err != nil {
+			return domain.PromoRedemption{}, err
+		}
+
+		if userRedemptions >= promoCode.MaxUsesPerUser {
+			return domain.PromoRedemption{}, domain.PromoCodeUsedUpError
+		}
+	}
+
+	redemption = domain.PromoRedemption{
+		PromoCodeId: promoCode.Id,
+		Code:        promoCode.Code,
+		UserId:      userID,
+		Discount:    discount,
+	}
+
+	createRedemptionQuery := `INSERT INTO promo_redemptions (promo_code_id, user_id, discount, currency, created_at)
+							  VALUES ($1, $2, $3, $4, $5)
+							  RETURNING id`
+
+	err = tx.QueryRow(ctx, createRedemptionQuery, promoCode.Id, userID, discount.Amount, discount.Currency, now).Scan(&redemption.Id)
+	if err != nil {
+		return domain.PromoRedemption{}, err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE promo_codes SET used_count = used_count + 1 WHERE id = $1`, promoCode.Id)
+	if err != nil {
+		return domain.PromoRedemption{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.PromoRedemption{}, domain.TransactionCommitError
+	}
+	return redemption, nil
+}
+
+// ReleasePromoRedemption releases redemption, so that it does not count towards limits of its promo code
+func (repo *ShopProductRepo) ReleasePromoRedemption(ctx context.Context, redemptionID uint64, now time.Time) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	releaseRedemptionQuery := `UPDATE promo_redemptions
+							   SET released_at = $2
+							   WHERE id = $1 AND released_at IS NULL
+							   RETURNING promo_code_id`
+
+	var promoCodeID uint64
+	err = tx.QueryRow(ctx, releaseRedemptionQuery, redemptionID, now).Scan(&promoCodeID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.RedemptionNotFoundError
+		}
+
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE promo_codes SET used_count = used_count - 1 WHERE id = $1`, promoCodeID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// ListPriceHistory returns page of price changes of product together with discounts of its shop,
+// newest changes go first. Up to page.Limit + 1 changes are returned
+func (repo *ShopProductRepo) ListPriceHistory(ctx context.Context, productID uint64, page domain.PriceHistoryPage) (changes []domain.PriceChange, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	keyset := keysetPage{
+		column:     "price_history.id",
+		columnType: "bigint",
+		idColumn:   "price_history.id",
+		descending: true,
+		limit:      page.Limit,
+		offset:     page.Offset,
+	}
+	if page.Cursor != 0 {
+		keyset.cursor = []interface{}{page.Cursor, page.Cursor}
+	}
+
+	condition, ordering, pageArgs := keyset.clauses(1)
+	listPriceHistoryQuery := `SELECT id, COALESCE(product_id, 0), COALESCE(variant_id, 0), shop_id, kind,
+									 COALESCE(old_price, 0), COALESCE(new_price, 0), currency, COALESCE(percent, 0), starts_at,
+									 ends_at, COALESCE(user_id, 0), created_at
+							  FROM price_history
+							  WHERE (product_id = $1 OR
+									 (product_id IS NULL AND shop_id = (SELECT shop_id FROM products WHERE id = $1)))
+									AND ` + condition + `
+							  ` + ordering
+
+	rows, err := tx.Query(ctx, listPriceHistoryQuery, append([]interface{}{productID}, pageArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes = make([]domain.PriceChange, 0)
+
+	for rows.Next() {
+		var change domain.PriceChange
+		var startsAt, endsAt *time.Time
+		var currency string
+		err = rows.Scan(&change.Id, &change.ProductId, &change.VariantId, &change.ShopId, &change.Kind,
+			&change.OldPrice.Amount, &change.NewPrice.Amount, &currency, &change.Percent, &startsAt, &endsAt,
+			&change.UserId, &change.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		change.OldPrice.Currency = currency
+		change.NewPrice.Currency = currency
+		change.StartsAt = fromNullTime(startsAt)
+		change.EndsAt = fromNullTime(endsAt)
+		changes = append(changes, change)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return changes, nil
+}
@@ -0,0 +1,364 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// questionColumns are selected by every query that returns questions, in order expected by scanQuestion.
+// IsVoted is checked for user passed as the second argument
+const questionColumns = `product_questions.id, product_questions.product_id, product_questions.user_id,
+						 product_questions.text, product_questions.votes_count,
+						 product_questions.answers_count, product_questions.created_at,
+						 EXISTS(SELECT 1 FROM question_votes
+								WHERE question_votes.question_id = product_questions.id AND question_votes.user_id = $2::bigint)`
+
+// answerColumns are selected by every query that returns answers, in order expected by scanAnswer
+const answerColumns = `product_answers.id, product_answers.question_id, product_answers.user_id,
+					   product_answers.text, product_answers.author_role,
+					   product_answers.is_official, product_answers.created_at`
+
+// questionSearch is true for questions whose text or text of any answer matches query passed as the third argument,
+// it is true for all questions if query is empty
+const questionSearch = `($3::text = '' OR product_questions.search_vector @@ websearch_to_tsquery('` + domain.SearchConfiguration + `', $3::text)
+						OR EXISTS(SELECT 1 FROM product_answers
+								  WHERE product_answers.question_id = product_questions.id
+									AND product_answers.search_vector @@ websearch_to_tsquery('` + domain.SearchConfiguration + `', $3::text)))`
+
+func scanQuestion(row pgx.Row) (question domain.ProductQuestion, err error) {
+	err = row.Scan(&question.Id, &question.ProductId, &question.UserId, &question.Text,
+		&question.VotesCount, &question.AnswersCount, &question.CreatedAt, &question.IsVoted)
+	if err != nil {
+		return domain.ProductQuestion{}, err
+	}
+
+	question.Answers = make([]domain.ProductAnswer, 0)
+	return question, nil
+}
+
+func scanAnswer(row pgx.Row) (answer domain.ProductAnswer, err error) {
+	err = row.Scan(&answer.Id, &answer.QuestionId, &answer.UserId, &answer.Text,
+		&answer.AuthorRole, &answer.IsOfficial, &answer.CreatedAt)
+	if err != nil {
+		return domain.ProductAnswer{}, err
+	}
+
+	return answer, nil
+}
+
+func (repo *ShopProductRepo) CreateQuestion(ctx context.Context, question domain.ProductQuestion) (questionID uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	createQuestionQuery := `INSERT INTO product_questions (product_id, user_id, text)
+							VALUES ($1, $2, $3)
+							RETURNING id`
+
+	err = tx.QueryRow(ctx, createQuestionQuery, question.ProductId, question.UserId, question.Text).Scan(&questionID)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return 0, domain.ProductNotFoundError
+		}
+
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return questionID, nil
+}
+
+// GetQuestion returns question without answers, IsVoted is not checked
+func (repo *ShopProductRepo) GetQuestion(ctx context.Context, questionID uint64) (question domain.ProductQuestion, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.ProductQuestion{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getQuestionQuery := `SELECT ` + questionColumns + `
+						 FROM product_questions
+						 WHERE product_questions.id = $1`
+
+	question, err = scanQuestion(tx.QueryRow(ctx, getQuestionQuery, questionID, 0))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ProductQuestion{}, domain.QuestionNotFoundError
+		}
+
+		return domain.ProductQuestion{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.ProductQuestion{}, domain.TransactionCommitError
+	}
+	return question, nil
+}
+
+// CreateAnswer adds answer and counts it in question's answers in one transaction
+func (repo *ShopProductRepo) CreateAnswer(ctx context.Context, answer domain.ProductAnswer) (answerID uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	createAnswerQuery := `INSERT INTO product_answers (question_id, user_id, text, author_role)
+						  VALUES ($1, $2, $3, $4)
+						  RETURNING id`
+
+	err = tx.QueryRow(ctx, createAnswerQuery, answer.QuestionId, answer.UserId, answer.Text, answer.AuthorRole).Scan(&answerID)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return 0, domain.QuestionNotFoundError
+		}
+
+		return 0, err
+	}
+
+	countAnswerQuery := `UPDATE product_questions
+						 SET answers_count = answers_count + 1
+						 WHERE id = $1`
+
+	_, err = tx.Exec(ctx, countAnswerQuery, answer.QuestionId)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return answerID, nil
+}
+
+func (repo *ShopProductRepo) GetAnswer(ctx context.Context, answerID uint64) (answer domain.ProductAnswer, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.ProductAnswer{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getAnswerQuery := `SELECT ` + answerColumns + `
+					   FROM product_answers
+					   WHERE product_answers.id = $1`
+
+	answer, err = scanAnswer(tx.QueryRow(ctx, getAnswerQuery, answerID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ProductAnswer{}, domain.AnswerNotFoundError
+		}
+
+		return domain.ProductAnswer{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.ProductAnswer{}, domain.TransactionCommitError
+	}
+	return answer, nil
+}
+
+// SetOfficialAnswer marks answer as official or removes the mark. Previous official answer of question
+// loses its mark, as question can have only one official answer
+func (repo *ShopProductRepo) SetOfficialAnswer(ctx context.Context, answer domain.ProductAnswer, isOfficial bool) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	if isOfficial {
+		unmarkQuestionQuery := `UPDATE product_answers
+								SET is_official = false
+								WHERE question_id = $1 AND is_official`
+
+		_, err = tx.Exec(ctx, unmarkQuestionQuery, answer.QuestionId)
+		if err != nil {
+			return err
+		}
+	}
+
+	setOfficialQuery := `UPDATE product_answers
+						 SET is_official = $2
+						 WHERE id = $1`
+
+	result, err := tx.Exec(ctx, setOfficialQuery, answer.Id, isOfficial)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.AnswerNotFoundError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// VoteQuestion adds user's vote for question, repeated votes are ignored
+func (repo *ShopProductRepo) VoteQuestion(ctx context.Context, questionID uint64, userID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	addVoteQuery := `INSERT INTO question_votes (question_id, user_id)
+					 VALUES ($1, $2)
+					 ON CONFLICT (question_id, user_id) DO NOTHING`
+
+	result, err := tx.Exec(ctx, addVoteQuery, questionID, userID)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.QuestionNotFoundError
+		}
+
+		return err
+	}
+
+	if result.RowsAffected() == 1 {
+		countVoteQuery := `UPDATE product_questions
+						   SET votes_count = votes_count + 1
+						   WHERE id = $1`
+
+		_, err = tx.Exec(ctx, countVoteQuery, questionID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// UnvoteQuestion removes user's vote for question, nothing happens if user has not voted
+func (repo *ShopProductRepo) UnvoteQuestion(ctx context.Context, questionID uint64, userID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	removeVoteQuery := `DELETE FROM question_votes
+						WHERE question_id = $1 AND user_id = $2`
+
+	result, err := tx.Exec(ctx, removeVoteQuery, questionID, userID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 1 {
+		uncountVoteQuery := `UPDATE product_questions
+							 SET votes_count = votes_count - 1
+							 WHERE id = $1`
+
+		_, err = tx.Exec(ctx, uncountVoteQuery, questionID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// ListQuestions returns page of product's questions which match page.Query together with their answers.
+// Up to page.Limit + 1 questions are returned. IsVoted is checked for viewer, viewerID is 0 for anonymous viewers
+func (repo *ShopProductRepo) ListQuestions(ctx context.Context, productID uint64, page domain.QuestionsPage, viewerID uint64) (questions []domain.ProductQuestion, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	keyset := keysetPage{
+		column:     page.Sorting.Column,
+		columnType: page.Sorting.ColumnType,
+		idColumn:   "product_questions.id",
+		descending: page.Sorting.Descending,
+		limit:      page.Limit,
+		offset:     page.Offset,
+	}
+	if page.Cursor != nil {
+		keyset.cursor = []interface{}{page.Cursor.Value, page.Cursor.QuestionId}
+	}
+
+	condition, ordering, pageArgs := keyset.clauses(3)
+	listQuestionsQuery := `SELECT ` + questionColumns + `
+						   FROM product_questions
+						   WHERE product_questions.product_id = $1 AND ` + questionSearch + ` AND ` + condition + `
+						   ` + ordering
+
+	args := append([]interface{}{productID, viewerID, page.Query}, pageArgs...)
+	rows, err := tx.Query(ctx, listQuestionsQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	questions = make([]domain.ProductQuestion, 0)
+	questionIDs := make([]uint64, 0)
+	questionIndexes := make(map[uint64]int)
+
+	for rows.Next() {
+		question, err := scanQuestion(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		questionIndexes[question.Id] = len(questions)
+		questionIDs = append(questionIDs, question.Id)
+		questions = append(questions, question)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	listAnswersQuery := `SELECT ` + answerColumns + `
+						 FROM product_answers
+						 WHERE product_answers.question_id = ANY($1::bigint[])
+						 ORDER BY product_answers.is_official DESC, product_answers.created_at, product_answers.id`
+
+	rows, err = tx.Query(ctx, listAnswersQuery, questionIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		answer, err := scanAnswer(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		index := questionIndexes[answer.QuestionId]
+		questions[index].Answers = append(questions[index].Answers, answer)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return questions, nil
+}
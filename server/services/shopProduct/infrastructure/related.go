@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+)
+
+// relatedScore combines content similarity and co-views of candidate product with product $1. Takes category, size,
+// parts amount, price, shop and currency of product $1, then weights of category, size, parts amount and price,
+// price band, and weights of co-views and shop. Price term is 0 if price can not be converted into product's currency
+const relatedScore = `(CASE WHEN $2::bigint <> 0 AND COALESCE(products.category_id, 0) = $2::bigint THEN $8::double precision ELSE 0 END
+					  + CASE WHEN $3::text <> '' AND products.size = $3::text THEN $9::double precision ELSE 0 END
+					  + CASE WHEN $4::bigint > 0
+							 THEN $10::double precision * (1 - abs(products.parts_amount - $4::bigint)::double precision
+														   / greatest(products.parts_amount, $4::bigint))
+							 ELSE 0 END
+					  + $11::double precision * greatest(0, 1 - abs(convert_amount(products.price, products.currency, $7) - $5::bigint)::double precision
+															/ greatest($5::bigint * $12::double precision, 1))
+					  + ln(1 + COALESCE(coviews.viewers_count, 0)) * $13::double precision
+					  + CASE WHEN products.shop_id = $6 THEN $14::double precision ELSE 0 END)`
+
+// GetRelatedProducts returns products ranked by similarity to product and by users who viewed both of them.
+// Ties are broken by rating and id, so that products are returned in the same order while nothing changes
+func (repo *ShopProductRepo) GetRelatedProducts(ctx context.Context, product domain.Product, limit uint64) (products []domain.Product, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getRelatedQuery := `SELECT ` + productColumns + `
+						FROM products
+						LEFT JOIN product_coviews AS coviews ON coviews.product_id = $1 AND coviews.related_id = products.id
+						WHERE products.id <> $1 AND products.deleted_at IS NULL
+						ORDER BY ` + relatedScore + ` DESC, products.rating DESC, products.id DESC
+						LIMIT $15`
+
+	products, err = queryProducts(ctx, tx, getRelatedQuery, product.Id, int64(product.CategoryId), product.Size,
+		int64(product.PartsAmount), int64(product.Price.Amount), product.ShopId, product.Price.Currency,
+		domain.SameCategoryWeight, domain.SameSizeWeight, domain.PartsAmountWeight, domain.PriceBandWeight,
+		domain.PriceBand, domain.CoViewWeight, domain.SameShopWeight, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return products, nil
+}
+
+// RefreshCoViews counts users who viewed both products of pair within domain.CoViewWindowHours,
+// statistics are rebuilt from recent views, so that old interests fade out
+func (repo *ShopProductRepo) RefreshCoViews(ctx context.Context) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	deleteCoViewsQuery := `DELETE FROM product_coviews`
+
+	_, err = tx.Exec(ctx, deleteCoViewsQuery)
+	if err != nil {
+		return err
+	}
+
+	addCoViewsQuery := `INSERT INTO product_coviews (product_id, related_id, viewers_count)
+						SELECT views.product_id, other.product_id, count(DISTINCT views.user_id)
+						FROM product_views AS views
+						INNER JOIN product_views AS other
+							ON other.user_id = views.user_id AND other.product_id <> views.product_id
+						   AND other.viewed_at BETWEEN views.viewed_at - make_interval(hours => $1)
+												   AND views.viewed_at + make_interval(hours => $1)
+						WHERE views.user_id IS NOT NULL
+						  AND views.viewed_at > now() - make_interval(days => $2)
+						  AND other.viewed_at > now() - make_interval(days => $2)
+						GROUP BY views.product_id, other.product_id
+						HAVING count(DISTINCT views.user_id) >= $3`
+
+	_, err = tx.Exec(ctx, addCoViewsQuery, domain.CoViewWindowHours, domain.CoViewDays, domain.MinCoViewers)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
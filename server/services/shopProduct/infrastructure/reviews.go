@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// reviewColumns are selected by every query that returns reviews, in order expected by scanReview
+const reviewColumns = `product_reviews.id, product_reviews.product_id, product_reviews.user_id,
+					   product_reviews.title, product_reviews.text,
+					   product_reviews.rating, product_reviews.created_at, product_reviews.updated_at`
+
+func scanReview(row pgx.Row) (review domain.ProductReview, err error) {
+	err = row.Scan(&review.Id, &review.ProductId, &review.UserId, &review.Title, &review.Text,
+		&review.Rating, &review.CreatedAt, &review.UpdatedAt)
+	if err != nil {
+		return domain.ProductReview{}, err
+	}
+
+	return review, nil
+}
+
+// CreateReview adds review and its rating to product's aggregated rating in one transaction
+func (repo *ShopProductRepo) CreateReview(ctx context.Context, review domain.ProductReview) (reviewID uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	createReviewQuery := `INSERT INTO product_reviews (product_id, user_id, title, text, rating)
+						  VALUES ($1, $2, $3, $4, $5)
+						  ON CONFLICT (product_id, user_id) DO NOTHING
+						  RETURNING id`
+
+	row := tx.QueryRow(ctx, createReviewQuery, review.ProductId, review.UserId, review.Title, review.Text, int32(review.Rating))
+	err = row.Scan(&reviewID)
+	if err != nil {
+		switch {
+		case err == pgx.ErrNoRows:
+			return 0, domain.ReviewExistsError
+		case isForeignKeyViolation(err):
+			return 0, domain.ProductNotFoundError
+		}
+
+		return 0, err
+	}
+
+	addRatingQuery := `UPDATE products
+					   SET reviews_count = reviews_count + 1, rating_sum = rating_sum + $2::integer,
+						   rating_histogram[$2::integer] = rating_histogram[$2::integer] + 1,
+						   rating = (rating_sum + $2::integer)::real / (reviews_count + 1)
+					   WHERE id = $1`
+
+	_, err = tx.Exec(ctx, addRatingQuery, review.ProductId, int32(review.Rating))
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return reviewID, nil
+}
+
+func (repo *ShopProductRepo) GetReview(ctx context.Context, reviewID uint64) (review domain.ProductReview, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.ProductReview{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getReviewQuery := `SELECT ` + reviewColumns + `
+					   FROM product_reviews
+					   WHERE product_reviews.id = $1`
+
+	review, err = scanReview(tx.QueryRow(ctx, getReviewQuery, reviewID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ProductReview{}, domain.ReviewNotFoundError
+		}
+
+		return domain.ProductReview{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.ProductReview{}, domain.TransactionCommitError
+	}
+	return review, nil
+}
+
+// UpdateReview changes review's title, text and rating. If rating changes, product's aggregated rating
+// is moved from old rating to new one in the same transaction
+func (repo *ShopProductRepo) UpdateReview(ctx context.Context, review domain.ProductReview) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	lockReviewQuery := `SELECT product_id, rating
+						FROM product_reviews
+						WHERE id = $1
+						FOR UPDATE`
+
+	var productID uint64
+	var oldRating int32
+	err = tx.QueryRow(ctx, lockReviewQuery, review.Id).Scan(&productID, &oldRating)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ReviewNotFoundError
+		}
+
+		return err
+	}
+
+	updateReviewQuery := `UPDATE product_reviews
+						  SET title = $2, text = $3, rating = $4, updated_at = now()
+						  WHERE id = $1`
+
+	_, err = tx.Exec(ctx, updateReviewQuery, review.Id, review.Title, review.Text, int32(review.Rating))
+	if err != nil {
+		return err
+	}
+
+	if oldRating != int32(review.Rating) {
+		moveRatingQuery := `UPDATE products
+							SET rating_sum = rating_sum - $2::integer + $3::integer,
+								rating_histogram[$2::integer] = rating_histogram[$2::integer] - 1,
+								rating_histogram[$3::integer] = rating_histogram[$3::integer] + 1,
+								rating = (rating_sum - $2::integer + $3::integer)::real / reviews_count
+							WHERE id = $1`
+
+		_, err = tx.Exec(ctx, moveRatingQuery, productID, oldRating, int32(review.Rating))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// ListReviews returns page of product's reviews. Up to page.Limit + 1 reviews are returned
+func (repo *ShopProductRepo) ListReviews(ctx context.Context, productID uint64, page domain.ReviewsPage) (reviews []domain.ProductReview, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	keyset := keysetPage{
+		column:     page.Sorting.Column,
+		columnType: page.Sorting.ColumnType,
+		idColumn:   "product_reviews.id",
+		descending: page.Sorting.Descending,
+		limit:      page.Limit,
+		offset:     page.Offset,
+	}
+	if page.Cursor != nil {
+		keyset.cursor = []interface{}{page.Cursor.Value, page.Cursor.ReviewId}
+	}
+
+	condition, ordering, pageArgs := keyset.clauses(1)
+	listReviewsQuery := `SELECT ` + reviewColumns + `
+						 FROM product_reviews
+						 WHERE product_reviews.product_id = $1 AND ` + condition + `
+						 ` + ordering
+
+	rows, err := tx.Query(ctx, listReviewsQuery, append([]interface{}{productID}, pageArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews = make([]domain.ProductReview, 0)
+
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		reviews = append(reviews, review)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return reviews, nil
+}
@@ -0,0 +1,261 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"pinterest/services/shopProduct/domain"
+	"strings"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// searchFrom is used by every search query. Search query is always passed as the first argument,
+// relevance.rank is 0 if it is empty
+const searchFrom = `FROM products
+					CROSS JOIN websearch_to_tsquery('` + domain.SearchConfiguration + `', $1) AS search_query
+					CROSS JOIN LATERAL (SELECT ts_rank(products.search_vector, search_query) AS rank) AS relevance`
+
+// searchFilter is one condition of search. Condition contains %s for every argument
+type searchFilter struct {
+	// name is used to exclude filter when its own facet is counted
+	name      string
+	condition string
+	args      []interface{}
+}
+
+type searchFilters []searchFilter
+
+// searchPrice is product's price converted into currency of search, which is its only argument.
+// It is NULL for products in currencies without exchange rate, so that they do not match price filters
+const searchPrice = "convert_amount(products.price, products.currency, %s)"
+
+func newSearchFilters(filters domain.SearchFilters) (result searchFilters) {
+	if filters.Query != "" {
+		result = append(result, searchFilter{name: "query", condition: "products.search_vector @@ search_query"})
+	}
+	if filters.PriceMin != 0 {
+		result = append(result, searchFilter{"price", searchPrice + " >= %s", []interface{}{filters.Currency, filters.PriceMin}})
+	}
+	if filters.PriceMax != 0 {
+		result = append(result, searchFilter{"price", searchPrice + " <= %s", []interface{}{filters.Currency, filters.PriceMax}})
+	}
+	if len(filters.CategoryIds) != 0 {
+		result = append(result, searchFilter{"category", "products.category_id IN (" + categorySubtrees + ")", []interface{}{filters.CategoryIds}})
+	}
+	if len(filters.Sizes) != 0 {
+		result = append(result, searchFilter{"size", "products.size = ANY(%s)", []interface{}{filters.Sizes}})
+	}
+	if filters.OnlyAvailable {
+		result = append(result, searchFilter{name: "availability", condition: "products.availability"})
+	}
+	if filters.AssemblyTimeMin != 0 {
+		result = append(result, searchFilter{"assembly_time", "products.assembly_time >= %s", []interface{}{filters.AssemblyTimeMin}})
+	}
+	if filters.AssemblyTimeMax != 0 {
+		result = append(result, searchFilter{"assembly_time", "products.assembly_time <= %s", []interface{}{filters.AssemblyTimeMax}})
+	}
+	if filters.PartsAmountMin != 0 {
+		result = append(result, searchFilter{"parts_amount", "products.parts_amount >= %s", []interface{}{filters.PartsAmountMin}})
+	}
+	if filters.PartsAmountMax != 0 {
+		result = append(result, searchFilter{"parts_amount", "products.parts_amount <= %s", []interface{}{filters.PartsAmountMax}})
+	}
+	if filters.MinRating != 0 {
+		result = append(result, searchFilter{"rating", "products.rating >= %s::real", []interface{}{filters.MinRating}})
+	}
+
+	return result
+}
+
+// where joins all filters except ones named exclude. Arguments are numbered after argsCount ones,
+// as postgres does not accept arguments which query does not use
+func (filters searchFilters) where(exclude string, argsCount int) (condition string, args []interface{}) {
+	conditions := []string{"products.deleted_at IS NULL"}
+	for _, filter := range filters {
+		if filter.name == exclude {
+			continue
+		}
+
+		placeholders := make([]interface{}, 0, len(filter.args))
+		for range filter.args {
+			argsCount++
+			placeholders = append(placeholders, fmt.Sprintf("$%d", argsCount))
+		}
+
+		conditions = append(conditions, fmt.Sprintf(filter.condition, placeholders...))
+		args = append(args, filter.args...)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// SearchProducts returns page of found products, up to page.Limit + 1 of them, together with total amount
+// of found products and facets. All queries run in one snapshot, so that counts match found products
+func (repo *ShopProductRepo) SearchProducts(ctx context.Context, filters domain.SearchFilters, page domain.ProductsPage) (result domain.SearchResult, err error) {
+	tx, err := repo.postgresDB.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return domain.SearchResult{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	conditions := newSearchFilters(filters)
+
+	condition, args := conditions.where("", 1)
+	pageCondition, ordering, pageArgs := pageClauses(page, len(args)+1)
+	searchQuery := `SELECT ` + productColumns + `, relevance.rank
+					` + searchFrom + `
+					WHERE ` + condition + ` AND ` + pageCondition + `
+					` + ordering
+
+	rows, err := tx.Query(ctx, searchQuery, append(append([]interface{}{filters.Query}, args...), pageArgs...)...)
+	if err != nil {
+		return domain.SearchResult{}, err
+	}
+	defer rows.Close()
+
+	result.Products = make([]domain.Product, 0)
+
+	for rows.Next() {
+		var relevance float32
+		product, err := scanProduct(rows, &relevance)
+		if err != nil {
+			return domain.SearchResult{}, err
+		}
+
+		product.Relevance = relevance
+		result.Products = append(result.Products, product)
+	}
+	if rows.Err() != nil {
+		return domain.SearchResult{}, rows.Err()
+	}
+
+	countQuery := `SELECT count(*)
+				   ` + searchFrom + `
+				   WHERE ` + condition
+
+	err = tx.QueryRow(ctx, countQuery, append([]interface{}{filters.Query}, args...)...).Scan(&result.Total)
+	if err != nil {
+		return domain.SearchResult{}, err
+	}
+
+	result.Facets, err = searchFacets(ctx, tx, filters, conditions)
+	if err != nil {
+		return domain.SearchResult{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.SearchResult{}, domain.TransactionCommitError
+	}
+	return result, nil
+}
+
+// searchFacets counts facet of every filter with all other filters applied. Price facet is in currency of filters
+func searchFacets(ctx context.Context, tx pgx.Tx, filters domain.SearchFilters, conditions searchFilters) (facets domain.SearchFacets, err error) {
+	query := filters.Query
+	facets.Categories, err = valueFacet(ctx, tx, query, conditions, "category", "products.category_id::text")
+	if err != nil {
+		return domain.SearchFacets{}, err
+	}
+
+	facets.Sizes, err = valueFacet(ctx, tx, query, conditions, "size", "products.size")
+	if err != nil {
+		return domain.SearchFacets{}, err
+	}
+
+	facets.Availability, err = valueFacet(ctx, tx, query, conditions, "availability", "products.availability::text")
+	if err != nil {
+		return domain.SearchFacets{}, err
+	}
+
+	facets.Price, err = rangeFacet(ctx, tx, query, conditions, "price", searchPrice, filters.Currency)
+	if err != nil {
+		return domain.SearchFacets{}, err
+	}
+	facets.PriceCurrency = filters.Currency
+
+	facets.AssemblyTime, err = rangeFacet(ctx, tx, query, conditions, "assembly_time", "products.assembly_time")
+	if err != nil {
+		return domain.SearchFacets{}, err
+	}
+
+	facets.PartsAmount, err = rangeFacet(ctx, tx, query, conditions, "parts_amount", "products.parts_amount")
+	if err != nil {
+		return domain.SearchFacets{}, err
+	}
+
+	condition, args := conditions.where("rating", 1)
+	ratingFacetQuery := `SELECT count(*) FILTER (WHERE products.rating >= 1), count(*) FILTER (WHERE products.rating >= 2),
+								count(*) FILTER (WHERE products.rating >= 3), count(*) FILTER (WHERE products.rating >= 4)
+						 ` + searchFrom + `
+						 WHERE ` + condition
+
+	counts := make([]uint64, 4)
+	err = tx.QueryRow(ctx, ratingFacetQuery, append([]interface{}{query}, args...)...).Scan(&counts[0], &counts[1], &counts[2], &counts[3])
+	if err != nil {
+		return domain.SearchFacets{}, err
+	}
+
+	facets.MinRating = make([]domain.FacetValue, 0, len(counts))
+	for i, count := range counts {
+		facets.MinRating = append(facets.MinRating, domain.FacetValue{Value: fmt.Sprint(i + 1), Count: count})
+	}
+
+	return facets, nil
+}
+
+// valueFacet counts found products with every value of column, empty and NULL values are skipped
+func valueFacet(ctx context.Context, tx pgx.Tx, query string, conditions searchFilters, name string, column string) (values []domain.FacetValue, err error) {
+	condition, args := conditions.where(name, 1)
+	valueFacetQuery := `SELECT ` + column + ` AS value, count(*)
+						` + searchFrom + `
+						WHERE ` + condition + ` AND ` + column + ` <> ''
+						GROUP BY value
+						ORDER BY count(*) DESC, value`
+
+	rows, err := tx.Query(ctx, valueFacetQuery, append([]interface{}{query}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values = make([]domain.FacetValue, 0)
+
+	for rows.Next() {
+		var value domain.FacetValue
+		err = rows.Scan(&value.Value, &value.Count)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return values, nil
+}
+
+// rangeFacet returns the smallest and the biggest value of column among found products.
+// Column contains %s for every one of columnArgs
+func rangeFacet(ctx context.Context, tx pgx.Tx, query string, conditions searchFilters, name string, column string, columnArgs ...interface{}) (facet domain.RangeFacet, err error) {
+	placeholders := make([]interface{}, 0, len(columnArgs))
+	for i := range columnArgs {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", i+2))
+	}
+	column = fmt.Sprintf(column, placeholders...)
+
+	condition, args := conditions.where(name, 1+len(columnArgs))
+	rangeFacetQuery := `SELECT COALESCE(min(` + column + `), 0), COALESCE(max(` + column + `), 0)
+						` + searchFrom + `
+						WHERE ` + condition
+
+	args = append(append([]interface{}{query}, columnArgs...), args...)
+	err = tx.QueryRow(ctx, rangeFacetQuery, args...).Scan(&facet.Min, &facet.Max)
+	if err != nil {
+		return domain.RangeFacet{}, err
+	}
+
+	return facet, nil
+}
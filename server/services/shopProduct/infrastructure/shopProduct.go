@@ -0,0 +1,529 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ShopProductRepoInterface interface {
+	CreateShop(ctx context.Context, shop domain.Shop, ownerID uint64) (shopID uint64, err error)
+	UpdateShop(ctx context.Context, shop domain.Shop) (err error)
+	GetShop(ctx context.Context, shopID uint64) (shop domain.Shop, err error)
+	CreateProduct(ctx context.Context, product domain.Product, userID uint64) (productID uint64, err error)
+	UpdateProduct(ctx context.Context, product domain.Product, userID uint64) (err error)
+	GetProduct(ctx context.Context, productID uint64) (product domain.Product, err error)
+	GetProductsByIDs(ctx context.Context, productIDs []uint64) (products []domain.Product, err error)
+	DeleteProduct(ctx context.Context, productID uint64, deletedAt time.Time) (err error)
+	PurgeProduct(ctx context.Context, productID uint64) (images []domain.ProductImage, err error)
+	ListProductsByShop(ctx context.Context, shopID uint64, page domain.ProductsPage) (products []domain.Product, err error)
+	IsAdmin(ctx context.Context, userID uint64) (isAdmin bool, err error)
+	GetCategories(ctx context.Context) (categories []domain.Category, err error)
+	CreateCategory(ctx context.Context, category domain.Category) (categoryID uint64, err error)
+	UpdateCategory(ctx context.Context, category domain.Category) (err error)
+	DeleteCategory(ctx context.Context, categoryID uint64, replacementID uint64) (err error)
+	ListProductsByCategory(ctx context.Context, categoryID uint64, page domain.ProductsPage) (products []domain.Product, err error)
+	AdjustStock(ctx context.Context, adjustment domain.StockAdjustment) (movement domain.InventoryMovement, err error)
+	ReserveStock(ctx context.Context, items []domain.StockItem, expiresAt time.Time) (reservation domain.Reservation, err error)
+	GetReservation(ctx context.Context, reservationID uint64) (reservation domain.Reservation, err error)
+	CommitReservation(ctx context.Context, reservationID uint64, now time.Time) (err error)
+	ReleaseReservation(ctx context.Context, reservationID uint64, now time.Time) (err error)
+	ExpireReservations(ctx context.Context, now time.Time) (expired uint64, err error)
+	ListInventoryMovements(ctx context.Context, productID uint64, page domain.MovementsPage) (movements []domain.InventoryMovement, err error)
+	GetCartID(ctx context.Context, owner domain.CartOwner) (cartID uint64, err error)
+	CreateCart(ctx context.Context, owner domain.CartOwner) (cartID uint64, err error)
+	GetCart(ctx context.Context, owner domain.CartOwner, now time.Time) (cart domain.Cart, err error)
+	AddCartItem(ctx context.Context, cartID uint64, productID uint64, variantID uint64, quantity uint64) (err error)
+	UpdateCartItem(ctx context.Context, cartID uint64, productID uint64, variantID uint64, quantity uint64) (err error)
+	RemoveCartItem(ctx context.Context, cartID uint64, productID uint64, variantID uint64) (err error)
+	MergeCarts(ctx context.Context, token string, userID uint64) (err error)
+	DeleteAbandonedCarts(ctx context.Context, before time.Time) (err error)
+	SearchProducts(ctx context.Context, filters domain.SearchFilters, page domain.ProductsPage) (result domain.SearchResult, err error)
+	AddProductView(ctx context.Context, productID uint64, view domain.PageView) (err error)
+	FollowShop(ctx context.Context, shopID uint64, userID uint64) (err error)
+	UnfollowShop(ctx context.Context, shopID uint64, userID uint64) (err error)
+	CreateFeed(ctx context.Context, userID uint64) (feedID uint64, err error)
+	GetFeedPage(ctx context.Context, cursor domain.FeedCursor, userID uint64, limit uint64) (products []domain.Product, positions []uint64, err error)
+	DeleteExpiredFeeds(ctx context.Context) (err error)
+	CreateReview(ctx context.Context, review domain.ProductReview) (reviewID uint64, err error)
+	GetReview(ctx context.Context, reviewID uint64) (review domain.ProductReview, err error)
+	UpdateReview(ctx context.Context, review domain.ProductReview) (err error)
+	ListReviews(ctx context.Context, productID uint64, page domain.ReviewsPage) (reviews []domain.ProductReview, err error)
+	AddProductImage(ctx context.Context, image domain.ProductImage) (imageID uint64, err error)
+	GetProductImages(ctx context.Context, productID uint64) (images []domain.ProductImage, err error)
+	ReorderProductImages(ctx context.Context, productID uint64, imageIDs []uint64) (err error)
+	SetPrimaryProductImage(ctx context.Context, productID uint64, imageID uint64) (err error)
+	DeleteProductImage(ctx context.Context, productID uint64, imageID uint64) (image domain.ProductImage, err error)
+	GetManagerRole(ctx context.Context, shopID uint64, userID uint64) (role string, err error)
+	RemoveShopManager(ctx context.Context, shopID uint64, managerID uint64) (err error)
+	CreateInvitation(ctx context.Context, invitation domain.ShopInvitation) (invitationID uint64, err error)
+	GetUserInvitations(ctx context.Context, userID uint64) (invitations []domain.ShopInvitation, err error)
+	AcceptInvitation(ctx context.Context, invitationID uint64, userID uint64) (err error)
+	DeleteInvitation(ctx context.Context, invitationID uint64, userID uint64) (err error)
+	ListWishlists(ctx context.Context, userID uint64, publicOnly bool) (wishlists []domain.Wishlist, err error)
+	GetWishlist(ctx context.Context, wishlistID uint64) (wishlist domain.Wishlist, err error)
+	GetWishlistByToken(ctx context.Context, token string) (wishlist domain.Wishlist, err error)
+	GetWishlistProducts(ctx context.Context, wishlistID uint64) (products []domain.Product, err error)
+	CreateWishlist(ctx context.Context, wishlist domain.Wishlist) (wishlistID uint64, err error)
+	GetDefaultWishlistID(ctx context.Context, userID uint64, shareToken string) (wishlistID uint64, err error)
+	UpdateWishlist(ctx context.Context, wishlist domain.Wishlist) (err error)
+	DeleteWishlist(ctx context.Context, wishlistID uint64) (err error)
+	AddWishlistItem(ctx context.Context, wishlistID uint64, productID uint64) (err error)
+	RemoveWishlistItem(ctx context.Context, wishlistID uint64, productID uint64) (err error)
+	RemoveSavedProduct(ctx context.Context, userID uint64, productID uint64) (err error)
+	IsProductSaved(ctx context.Context, productID uint64, userID uint64) (isSaved bool, err error)
+	GetProductsVariants(ctx context.Context, productIDs []uint64) (options map[uint64][]domain.ProductOption, variants map[uint64][]domain.ProductVariant, err error)
+	SetProductOptions(ctx context.Context, productID uint64, options []domain.ProductOption) (err error)
+	CreateVariant(ctx context.Context, variant domain.ProductVariant, userID uint64) (variantID uint64, err error)
+	UpdateVariant(ctx context.Context, variant domain.ProductVariant, userID uint64) (err error)
+	DeleteVariant(ctx context.Context, productID uint64, variantID uint64, userID uint64) (err error)
+	GetActivePricing(ctx context.Context, productIDs []uint64, shopIDs []uint64, now time.Time) (pricing domain.Pricing, err error)
+	CreateSale(ctx context.Context, sale domain.ProductSale, userID uint64) (saleID uint64, err error)
+	ListSales(ctx context.Context, productID uint64, now time.Time) (sales []domain.ProductSale, err error)
+	DeleteSale(ctx context.Context, productID uint64, saleID uint64, userID uint64) (err error)
+	CreateShopDiscount(ctx context.Context, discount domain.ShopDiscount, userID uint64) (discountID uint64, err error)
+	ListShopDiscounts(ctx context.Context, shopID uint64, now time.Time) (discounts []domain.ShopDiscount, err error)
+	DeleteShopDiscount(ctx context.Context, shopID uint64, discountID uint64, userID uint64) (err error)
+	CreatePromoCode(ctx context.Context, promoCode domain.PromoCode) (promoCodeID uint64, err error)
+	ListPromoCodes(ctx context.Context, shopID uint64) (promoCodes []domain.PromoCode, err error)
+	DeletePromoCode(ctx context.Context, shopID uint64, promoCodeID uint64) (err error)
+	RedeemPromoCode(ctx context.Context, code string, shopID uint64, userID uint64, subtotal domain.Money, now time.Time) (redemption domain.PromoRedemption, err error)
+	ReleasePromoRedemption(ctx context.Context, redemptionID uint64, now time.Time) (err error)
+	ListPriceHistory(ctx context.Context, productID uint64, page domain.PriceHistoryPage) (changes []domain.PriceChange, err error)
+	GetExchangeRates(ctx context.Context) (rates []domain.ExchangeRate, err error)
+	ReplaceExchangeRates(ctx context.Context, rates []domain.ExchangeRate, now time.Time) (err error)
+	GetProductsBySKU(ctx context.Context, shopID uint64, skus []string) (products []domain.Product, err error)
+	ListShopCatalog(ctx context.Context, shopID uint64) (products []domain.Product, err error)
+	CreateImport(ctx context.Context, productImport domain.ProductImport) (createdImport domain.ProductImport, err error)
+	GetImport(ctx context.Context, importID uint64) (productImport domain.ProductImport, err error)
+	UpdateImport(ctx context.Context, productImport domain.ProductImport) (err error)
+	GetMarketplaceFeed(ctx context.Context, shopID uint64, format string) (feed domain.MarketplaceFeed, err error)
+	ListMarketplaceFeeds(ctx context.Context) (feeds []domain.MarketplaceFeed, err error)
+	SaveMarketplaceFeed(ctx context.Context, feed domain.MarketplaceFeed) (err error)
+	GetFeedScope(ctx context.Context, shopID uint64) (scope domain.FeedScope, err error)
+	GetStaleFeedProducts(ctx context.Context, shopID uint64, format string, now time.Time) (products []domain.Product, err error)
+	SaveFeedOffers(ctx context.Context, offers []domain.FeedOffer, renderedAt time.Time) (err error)
+	ListFeedOffers(ctx context.Context, shopID uint64, format string) (fragments []string, err error)
+	DeleteShop(ctx context.Context, shopID uint64, deletedAt time.Time) (err error)
+	GetDeletedShop(ctx context.Context, shopID uint64) (shop domain.Shop, err error)
+	RestoreShop(ctx context.Context, shopID uint64) (err error)
+	GetDeletedProduct(ctx context.Context, productID uint64) (product domain.Product, err error)
+	RestoreProduct(ctx context.Context, productID uint64) (err error)
+	ListDeletedProducts(ctx context.Context, shopID uint64, deletedAfter time.Time) (products []domain.Product, err error)
+	ListDeletedShops(ctx context.Context, before time.Time) (shopIDs []uint64, err error)
+	PurgeDeleted(ctx context.Context, before time.Time, shopIDs []uint64) (images []domain.ProductImage, err error)
+	GetRelatedProducts(ctx context.Context, product domain.Product, limit uint64) (products []domain.Product, err error)
+	RefreshCoViews(ctx context.Context) (err error)
+	AddShopView(ctx context.Context, shopID uint64, view domain.PageView) (err error)
+	GetLastRollupDay(ctx context.Context) (day time.Time, err error)
+	RollupViews(ctx context.Context, from time.Time) (err error)
+	GetShopAnalytics(ctx context.Context, shopID uint64, from time.Time, to time.Time) (analytics domain.ShopAnalytics, err error)
+	CreateQuestion(ctx context.Context, question domain.ProductQuestion) (questionID uint64, err error)
+	GetQuestion(ctx context.Context, questionID uint64) (question domain.ProductQuestion, err error)
+	CreateAnswer(ctx context.Context, answer domain.ProductAnswer) (answerID uint64, err error)
+	GetAnswer(ctx context.Context, answerID uint64) (answer domain.ProductAnswer, err error)
+	SetOfficialAnswer(ctx context.Context, answer domain.ProductAnswer, isOfficial bool) (err error)
+	VoteQuestion(ctx context.Context, questionID uint64, userID uint64) (err error)
+	UnvoteQuestion(ctx context.Context, questionID uint64, userID uint64) (err error)
+	ListQuestions(ctx context.Context, productID uint64, page domain.QuestionsPage, viewerID uint64) (questions []domain.ProductQuestion, err error)
+	CollectUserData(ctx context.Context, userID uint64) (sections []domain.ExportSection, err error)
+}
+
+type ShopProductRepo struct {
+	postgresDB *pgxpool.Pool
+}
+
+func NewShopProductRepo(postgresDB *pgxpool.Pool) *ShopProductRepo {
+	return &ShopProductRepo{postgresDB: postgresDB}
+}
+
+const (
+	foreignKeyViolationCode = "23503"
+	uniqueViolationCode     = "23505"
+	checkViolationCode      = "23514"
+	// productCategoryConstraint is foreign key which references category of product, other foreign keys
+	// of products reference shops
+	productCategoryConstraint = "products_category_fk"
+)
+
+// productColumns are selected by every query that returns full products, in order expected by scanProduct
+const productColumns = `products.id, products.title, products.description, products.sku, products.price, products.currency, products.availability,
+						products.stock, products.reserved, products.assembly_time, products.parts_amount, products.rating, products.size,
+						COALESCE(products.category_id, 0), products.shop_id, products.reviews_count, products.rating_histogram,
+						products.saves_count, ARRAY(SELECT large_link FROM product_images WHERE product_images.product_id = products.id
+							  ORDER BY is_primary DESC, position, id)`
+
+// scanProduct scans product, extra destinations are used for columns selected after productColumns
+func scanProduct(row pgx.Row, extra ...interface{}) (product domain.Product, err error) {
+	product.ImageLinks = make([]string, 0)
+	ratingHistogram := make([]int64, 0)
+	destinations := []interface{}{&product.Id, &product.Title, &product.Description, &product.SKU, &product.Price.Amount,
+		&product.Price.Currency, &product.Availability,
+		&product.Stock, &product.Reserved, &product.AssemblyTime, &product.PartsAmount, &product.Rating, &product.Size,
+		&product.CategoryId, &product.ShopId, &product.ReviewsCount, &ratingHistogram, &product.SavesCount, &product.ImageLinks}
+	err = row.Scan(append(destinations, extra...)...)
+	if err != nil {
+		return domain.Product{}, err
+	}
+
+	product.RatingHistogram = toUint64s(ratingHistogram)
+
+	return product, nil
+}
+
+// isForeignKeyViolation is used to recognize references to missing shops and categories
+func isForeignKeyViolation(err error) bool {
+	pgErr, ok := err.(*pgconn.PgError)
+	return ok && pgErr.Code == foreignKeyViolationCode
+}
+
+// productReferenceError converts foreign key violation of products table to error about missing shop or category
+func productReferenceError(err error) error {
+	pgErr, ok := err.(*pgconn.PgError)
+	if ok && pgErr.ConstraintName == productCategoryConstraint {
+		return domain.CategoryNotFoundError
+	}
+
+	return domain.ShopNotFoundError
+}
+
+// isCheckViolation is used to recognize stock which became less than reserved quantity
+func isCheckViolation(err error) bool {
+	pgErr, ok := err.(*pgconn.PgError)
+	return ok && pgErr.Code == checkViolationCode
+}
+
+// isUniqueViolation is used to recognize duplicate slugs, variants and product SKUs
+func isUniqueViolation(err error) bool {
+	pgErr, ok := err.(*pgconn.PgError)
+	return ok && pgErr.Code == uniqueViolationCode
+}
+
+// CreateShop creates shop and makes user with id ownerID its owner
+func (repo *ShopProductRepo) CreateShop(ctx context.Context, shop domain.Shop, ownerID uint64) (shopID uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	createShopQuery := `INSERT INTO shops (title, description, currency)
+						VALUES ($1, $2, $3)
+						RETURNING id`
+
+	row := tx.QueryRow(ctx, createShopQuery, shop.Title, shop.Description, shop.Currency)
+	err = row.Scan(&shopID)
+	if err != nil {
+		return 0, err
+	}
+
+	addOwnerQuery := `INSERT INTO shop_managers (shop_id, user_id, role)
+					  VALUES ($1, $2, $3)`
+
+	_, err = tx.Exec(ctx, addOwnerQuery, shopID, ownerID, domain.RoleOwner)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return shopID, nil
+}
+
+// UpdateShop updates shop, its currency can be changed only while it has no products and active promo codes,
+// as their prices are in shop's currency
+func (repo *ShopProductRepo) UpdateShop(ctx context.Context, shop domain.Shop) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getCurrencyQuery := `SELECT currency,
+								EXISTS(SELECT 1 FROM products WHERE shop_id = shops.id) OR
+								EXISTS(SELECT 1 FROM promo_codes WHERE shop_id = shops.id AND is_active)
+						 FROM shops
+						 WHERE id = $1
+						 FOR UPDATE`
+
+	var currency string
+	var hasPrices bool
+	err = tx.QueryRow(ctx, getCurrencyQuery, shop.Id).Scan(&currency, &hasPrices)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ShopNotFoundError
+		}
+
+		return err
+	}
+
+	if currency != shop.Currency && hasPrices {
+		return domain.CurrencyInUseError
+	}
+
+	updateShopQuery := `UPDATE shops
+						SET title = $2, description = $3, currency = $4
+						WHERE id = $1`
+
+	result, err := tx.Exec(ctx, updateShopQuery, shop.Id, shop.Title, shop.Description, shop.Currency)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.ShopNotFoundError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+func (repo *ShopProductRepo) GetShop(ctx context.Context, shopID uint64) (shop domain.Shop, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.Shop{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getShopQuery := `SELECT id, title, description, currency,
+							ARRAY(SELECT user_id FROM shop_managers WHERE shop_id = shops.id ORDER BY user_id),
+							ARRAY(SELECT user_id FROM shop_managers WHERE shop_id = shops.id AND role = 'owner' ORDER BY user_id)
+					 FROM shops
+					 WHERE id = $1 AND deleted_at IS NULL`
+
+	managerIDs := make([]int64, 0)
+	ownerIDs := make([]int64, 0)
+	row := tx.QueryRow(ctx, getShopQuery, shopID)
+	err = row.Scan(&shop.Id, &shop.Title, &shop.Description, &shop.Currency, &managerIDs, &ownerIDs)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Shop{}, domain.ShopNotFoundError
+		}
+
+		return domain.Shop{}, err
+	}
+
+	shop.ManagerIDs = toUint64s(managerIDs)
+	shop.OwnerIDs = toUint64s(ownerIDs)
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.Shop{}, domain.TransactionCommitError
+	}
+	return shop, nil
+}
+
+// CreateProduct creates product, its initial stock is recorded as adjustment made by user
+// and its price is recorded in price history
+func (repo *ShopProductRepo) CreateProduct(ctx context.Context, product domain.Product, userID uint64) (productID uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	createProductQuery := `INSERT INTO products (title, description, sku, price, currency, stock, assembly_time,
+												 parts_amount, size, category_id, shop_id)
+						   VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NULLIF($10, 0), $11)
+						   RETURNING id`
+
+	row := tx.QueryRow(ctx, createProductQuery, product.Title, product.Description, product.SKU, product.Price.Amount,
+		product.Price.Currency, product.Stock, product.AssemblyTime, product.PartsAmount, product.Size,
+		int64(product.CategoryId), product.ShopId)
+	err = row.Scan(&productID)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return 0, productReferenceError(err)
+		}
+		if isUniqueViolation(err) {
+			return 0, domain.DuplicateProductSKUError
+		}
+
+		return 0, err
+	}
+
+	if product.Stock != 0 {
+		_, err = addMovement(ctx, tx, domain.InventoryMovement{
+			ProductId:  productID,
+			Kind:       domain.MovementAdjustment,
+			StockDelta: int64(product.Stock),
+			StockAfter: product.Stock,
+			UserId:     userID,
+			Comment:    "Initial stock",
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = addPriceChange(ctx, tx, domain.PriceChange{
+		ProductId: productID,
+		ShopId:    product.ShopId,
+		Kind:      domain.PriceChangeList,
+		NewPrice:  product.Price,
+		UserId:    userID,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return productID, nil
+}
+
+// UpdateProduct updates product, change of its price is recorded in price history as made by user
+func (repo *ShopProductRepo) UpdateProduct(ctx context.Context, product domain.Product, userID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getPriceQuery := `SELECT price, currency
+					  FROM products
+					  WHERE id = $1
+					  FOR UPDATE`
+
+	var oldPrice domain.Money
+	err = tx.QueryRow(ctx, getPriceQuery, product.Id).Scan(&oldPrice.Amount, &oldPrice.Currency)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ProductNotFoundError
+		}
+
+		return err
+	}
+
+	// Rating and stock are not updated here, as they are maintained together with reviews and inventory movements
+	updateProductQuery := `UPDATE products
+						   SET title = $2, description = $3, price = $4, currency = $5, assembly_time = $6,
+							   parts_amount = $7, size = $8, category_id = NULLIF($9, 0), shop_id = $10, sku = $11
+						   WHERE id = $1`
+
+	result, err := tx.Exec(ctx, updateProductQuery, product.Id, product.Title, product.Description, product.Price.Amount,
+		product.Price.Currency, product.AssemblyTime, product.PartsAmount, product.Size, int64(product.CategoryId),
+		product.ShopId, product.SKU)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return productReferenceError(err)
+		}
+		if isUniqueViolation(err) {
+			return domain.DuplicateProductSKUError
+		}
+
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.ProductNotFoundError
+	}
+
+	if product.Price != oldPrice {
+		err = addPriceChange(ctx, tx, domain.PriceChange{
+			ProductId: product.Id,
+			ShopId:    product.ShopId,
+			Kind:      domain.PriceChangeList,
+			OldPrice:  oldPrice,
+			NewPrice:  product.Price,
+			UserId:    userID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+func (repo *ShopProductRepo) GetProduct(ctx context.Context, productID uint64) (product domain.Product, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.Product{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getProductQuery := `SELECT ` + productColumns + `
+						FROM products
+						WHERE id = $1 AND deleted_at IS NULL`
+
+	product, err = scanProduct(tx.QueryRow(ctx, getProductQuery, productID))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Product{}, domain.ProductNotFoundError
+		}
+
+		return domain.Product{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.Product{}, domain.TransactionCommitError
+	}
+	return product, nil
+}
+
+// GetProductsByIDs returns products in order of ids, unknown ids are skipped
+func (repo *ShopProductRepo) GetProductsByIDs(ctx context.Context, productIDs []uint64) (products []domain.Product, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getProductsQuery := `SELECT ` + productColumns + `
+						 FROM products
+						 JOIN unnest($1::bigint[]) WITH ORDINALITY AS ids(id, position) ON ids.id = products.id
+						 WHERE products.deleted_at IS NULL
+						 ORDER BY ids.position`
+
+	rows, err := tx.Query(ctx, getProductsQuery, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products = make([]domain.Product, 0, len(productIDs))
+
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		products = append(products, product)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return products, nil
+}
+
+// toUint64s converts ids scanned from postgres arrays
+func toUint64s(ids []int64) []uint64 {
+	result := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		result = append(result, uint64(id))
+	}
+	return result
+}
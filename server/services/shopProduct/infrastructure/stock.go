@@ -0,0 +1,468 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// stockTable returns table and id of row which holds stock of item, stock of products with variants is held by variants
+func stockTable(productID uint64, variantID uint64) (table string, rowID uint64) {
+	if variantID != 0 {
+		return "product_variants", variantID
+	}
+
+	return "products", productID
+}
+
+// lockStock locks product and checks that variant is selected as product requires
+func lockStock(ctx context.Context, tx pgx.Tx, productID uint64, variantID uint64) (err error) {
+	err = lockProduct(ctx, tx, productID)
+	if err != nil {
+		return err
+	}
+
+	return checkVariantSelection(ctx, tx, productID, variantID)
+}
+
+// AdjustStock changes stock of product or its variant by adjustment's delta. Stock can not become less than reserved quantity
+func (repo *ShopProductRepo) AdjustStock(ctx context.Context, adjustment domain.StockAdjustment) (movement domain.InventoryMovement, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.InventoryMovement{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	err = lockStock(ctx, tx, adjustment.ProductId, adjustment.VariantId)
+	if err != nil {
+		return domain.InventoryMovement{}, err
+	}
+
+	table, rowID := stockTable(adjustment.ProductId, adjustment.VariantId)
+	adjustStockQuery := `UPDATE ` + table + `
+						 SET stock = stock + $2
+						 WHERE id = $1
+						 RETURNING stock, reserved`
+
+	movement = domain.InventoryMovement{
+		ProductId:  adjustment.ProductId,
+		VariantId:  adjustment.VariantId,
+		Kind:       domain.MovementAdjustment,
+		StockDelta: adjustment.Delta,
+		UserId:     adjustment.UserId,
+		Comment:    adjustment.Comment,
+	}
+	row := tx.QueryRow(ctx, adjustStockQuery, rowID, adjustment.Delta)
+	err = row.Scan(&movement.StockAfter, &movement.ReservedAfter)
+	if err != nil {
+		if isCheckViolation(err) {
+			return domain.InventoryMovement{}, domain.StockBelowReservedError
+		}
+
+		return domain.InventoryMovement{}, err
+	}
+
+	movement, err = addMovement(ctx, tx, movement)
+	if err != nil {
+		return domain.InventoryMovement{}, err
+	}
+
+	if adjustment.VariantId != 0 {
+		err = syncVariantsStock(ctx, tx, adjustment.ProductId)
+		if err != nil {
+			return domain.InventoryMovement{}, err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.InventoryMovement{}, domain.TransactionCommitError
+	}
+	return movement, nil
+}
+
+// ReserveStock holds stock of all items until expiresAt, either every item is reserved or none.
+// OutOfStockError is returned if some product or variant does not have enough unreserved units
+func (repo *ShopProductRepo) ReserveStock(ctx context.Context, items []domain.StockItem, expiresAt time.Time) (reservation domain.Reservation, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.Reservation{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	createReservationQuery := `INSERT INTO stock_reservations (status, expires_at)
+							   VALUES ($1, $2)
+							   RETURNING id, status, expires_at, created_at`
+
+	row := tx.QueryRow(ctx, createReservationQuery, domain.ReservationActive, expiresAt)
+	err = row.Scan(&reservation.Id, &reservation.Status, &reservation.ExpiresAt, &reservation.CreatedAt)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+
+	// Products are locked in the same order by every reservation, so that concurrent reservations do not deadlock
+	reservation.Items = append([]domain.StockItem{}, items...)
+	sort.Slice(reservation.Items, func(i, j int) bool {
+		if reservation.Items[i].ProductId != reservation.Items[j].ProductId {
+			return reservation.Items[i].ProductId < reservation.Items[j].ProductId
+		}
+		return reservation.Items[i].VariantId < reservation.Items[j].VariantId
+	})
+
+	addItemQuery := `INSERT INTO reservation_items (reservation_id, product_id, variant_id, quantity)
+					 VALUES ($1, $2, NULLIF($3, 0), $4)`
+
+	for _, item := range reservation.Items {
+		err = lockStock(ctx, tx, item.ProductId, item.VariantId)
+		if err != nil {
+			return domain.Reservation{}, err
+		}
+
+		table, rowID := stockTable(item.ProductId, item.VariantId)
+		reserveQuery := `UPDATE ` + table + `
+						 SET reserved = reserved + $2
+						 WHERE id = $1 AND stock - reserved >= $2
+						 RETURNING stock, reserved`
+
+		movement := domain.InventoryMovement{
+			ProductId:     item.ProductId,
+			VariantId:     item.VariantId,
+			Kind:          domain.MovementReserve,
+			ReservedDelta: int64(item.Quantity),
+			ReservationId: reservation.Id,
+		}
+		err = tx.QueryRow(ctx, reserveQuery, rowID, item.Quantity).Scan(&movement.StockAfter, &movement.ReservedAfter)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				return domain.Reservation{}, domain.OutOfStockError
+			}
+
+			return domain.Reservation{}, err
+		}
+
+		_, err = tx.Exec(ctx, addItemQuery, reservation.Id, item.ProductId, int64(item.VariantId), item.Quantity)
+		if err != nil {
+			return domain.Reservation{}, err
+		}
+
+		_, err = addMovement(ctx, tx, movement)
+		if err != nil {
+			return domain.Reservation{}, err
+		}
+
+		if item.VariantId != 0 {
+			err = syncVariantsStock(ctx, tx, item.ProductId)
+			if err != nil {
+				return domain.Reservation{}, err
+			}
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.Reservation{}, domain.TransactionCommitError
+	}
+	return reservation, nil
+}
+
+func (repo *ShopProductRepo) GetReservation(ctx context.Context, reservationID uint64) (reservation domain.Reservation, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.Reservation{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	reservation, err = getReservation(ctx, tx, reservationID, false)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.Reservation{}, domain.TransactionCommitError
+	}
+	return reservation, nil
+}
+
+// getReservation returns reservation with its items, reservation is locked until the end of transaction if forUpdate is true
+func getReservation(ctx context.Context, tx pgx.Tx, reservationID uint64, forUpdate bool) (reservation domain.Reservation, err error) {
+	getReservationQuery := `SELECT id, status, expires_at, created_at
+							FROM stock_reservations
+							WHERE id = $1`
+	if forUpdate {
+		getReservationQuery += ` FOR UPDATE`
+	}
+
+	row := tx.QueryRow(ctx, getReservationQuery, reservationID)
+	err = row.Scan(&reservation.Id, &reservation.Status, &reservation.ExpiresAt, &reservation.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Reservation{}, domain.ReservationNotFoundError
+		}
+
+		return domain.Reservation{}, err
+	}
+
+	getItemsQuery := `SELECT product_id, COALESCE(variant_id, 0), quantity
+					  FROM reservation_items
+					  WHERE reservation_id = $1
+					  ORDER BY product_id, variant_id NULLS FIRST`
+
+	rows, err := tx.Query(ctx, getItemsQuery, reservationID)
+	if err != nil {
+		return domain.Reservation{}, err
+	}
+	defer rows.Close()
+
+	reservation.Items = make([]domain.StockItem, 0)
+
+	for rows.Next() {
+		var item domain.StockItem
+		err = rows.Scan(&item.ProductId, &item.VariantId, &item.Quantity)
+		if err != nil {
+			return domain.Reservation{}, err
+		}
+
+		reservation.Items = append(reservation.Items, item)
+	}
+	if rows.Err() != nil {
+		return domain.Reservation{}, rows.Err()
+	}
+
+	return reservation, nil
+}
+
+// CommitReservation removes reserved units from stock, as they are sold. Expired reservations can not be committed
+func (repo *ShopProductRepo) CommitReservation(ctx context.Context, reservationID uint64, now time.Time) (err error) {
+	return repo.finishReservation(ctx, reservationID, now, domain.ReservationCommitted)
+}
+
+// ReleaseReservation returns reserved units back to sale
+func (repo *ShopProductRepo) ReleaseReservation(ctx context.Context, reservationID uint64, now time.Time) (err error) {
+	return repo.finishReservation(ctx, reservationID, now, domain.ReservationReleased)
+}
+
+func (repo *ShopProductRepo) finishReservation(ctx context.Context, reservationID uint64, now time.Time, status string) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	reservation, err := getReservation(ctx, tx, reservationID, true)
+	if err != nil {
+		return err
+	}
+
+	// Expired reservation which was not processed yet still can be released
+	if reservation.Status != domain.ReservationActive ||
+		(status == domain.ReservationCommitted && !reservation.ExpiresAt.After(now)) {
+		return domain.ReservationNotActiveError
+	}
+
+	err = closeReservation(ctx, tx, reservation, status)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// closeReservation changes reserved quantities of reservation's products or variants and sets reservation's final status
+func closeReservation(ctx context.Context, tx pgx.Tx, reservation domain.Reservation, status string) (err error) {
+	kind := map[string]string{
+		domain.ReservationCommitted: domain.MovementCommit,
+		domain.ReservationReleased:  domain.MovementRelease,
+		domain.ReservationExpired:   domain.MovementExpire,
+	}[status]
+
+	// Committed units leave stock, released ones stay in it
+	stockFactor := int64(0)
+	if status == domain.ReservationCommitted {
+		stockFactor = 1
+	}
+
+	for _, item := range reservation.Items {
+		err = lockProduct(ctx, tx, item.ProductId)
+		if err != nil {
+			return err
+		}
+
+		table, rowID := stockTable(item.ProductId, item.VariantId)
+		unreserveQuery := `UPDATE ` + table + `
+						   SET stock = stock - $2, reserved = reserved - $3
+						   WHERE id = $1
+						   RETURNING stock, reserved`
+
+		movement := domain.InventoryMovement{
+			ProductId:     item.ProductId,
+			VariantId:     item.VariantId,
+			Kind:          kind,
+			StockDelta:    -stockFactor * int64(item.Quantity),
+			ReservedDelta: -int64(item.Quantity),
+			ReservationId: reservation.Id,
+		}
+		row := tx.QueryRow(ctx, unreserveQuery, rowID, stockFactor*int64(item.Quantity), item.Quantity)
+		err = row.Scan(&movement.StockAfter, &movement.ReservedAfter)
+		if err != nil {
+			return err
+		}
+
+		_, err = addMovement(ctx, tx, movement)
+		if err != nil {
+			return err
+		}
+
+		if item.VariantId != 0 {
+			err = syncVariantsStock(ctx, tx, item.ProductId)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	setStatusQuery := `UPDATE stock_reservations
+					   SET status = $2
+					   WHERE id = $1`
+
+	_, err = tx.Exec(ctx, setStatusQuery, reservation.Id, status)
+	return err
+}
+
+// ExpireReservations releases stock of active reservations which expired before now, returns amount of expired reservations.
+// Reservations which are being committed or released concurrently are skipped
+func (repo *ShopProductRepo) ExpireReservations(ctx context.Context, now time.Time) (expired uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getExpiredQuery := `SELECT id
+						FROM stock_reservations
+						WHERE status = $1 AND expires_at <= $2
+						ORDER BY id
+						FOR UPDATE SKIP LOCKED`
+
+	rows, err := tx.Query(ctx, getExpiredQuery, domain.ReservationActive, now)
+	if err != nil {
+		return 0, err
+	}
+
+	reservationIDs := make([]uint64, 0)
+	for rows.Next() {
+		var reservationID uint64
+		err = rows.Scan(&reservationID)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+
+		reservationIDs = append(reservationIDs, reservationID)
+	}
+	rows.Close()
+	if rows.Err() != nil {
+		return 0, rows.Err()
+	}
+
+	for _, reservationID := range reservationIDs {
+		reservation, err := getReservation(ctx, tx, reservationID, false)
+		if err != nil {
+			return 0, err
+		}
+
+		err = closeReservation(ctx, tx, reservation, domain.ReservationExpired)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return uint64(len(reservationIDs)), nil
+}
+
+// addMovement records movement in inventory ledger, returns it with id and creation time
+func addMovement(ctx context.Context, tx pgx.Tx, movement domain.InventoryMovement) (domain.InventoryMovement, error) {
+	addMovementQuery := `INSERT INTO inventory_movements (product_id, kind, stock_delta, reserved_delta, stock_after,
+														  reserved_after, reservation_id, user_id, comment, variant_id)
+						 VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, 0), NULLIF($8, 0), $9, NULLIF($10, 0))
+						 RETURNING id, created_at`
+
+	row := tx.QueryRow(ctx, addMovementQuery, movement.ProductId, movement.Kind, movement.StockDelta, movement.ReservedDelta,
+		movement.StockAfter, movement.ReservedAfter, int64(movement.ReservationId), int64(movement.UserId), movement.Comment,
+		int64(movement.VariantId))
+	err := row.Scan(&movement.Id, &movement.CreatedAt)
+	if err != nil {
+		return domain.InventoryMovement{}, err
+	}
+
+	return movement, nil
+}
+
+// ListInventoryMovements returns page of product's movements, newest movements go first.
+// Up to page.Limit + 1 movements are returned
+func (repo *ShopProductRepo) ListInventoryMovements(ctx context.Context, productID uint64, page domain.MovementsPage) (movements []domain.InventoryMovement, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	keyset := keysetPage{
+		column:     "inventory_movements.id",
+		columnType: "bigint",
+		idColumn:   "inventory_movements.id",
+		descending: true,
+		limit:      page.Limit,
+		offset:     page.Offset,
+	}
+	if page.Cursor != 0 {
+		keyset.cursor = []interface{}{page.Cursor, page.Cursor}
+	}
+
+	condition, ordering, pageArgs := keyset.clauses(1)
+	listMovementsQuery := `SELECT id, product_id, kind, stock_delta, reserved_delta, stock_after, reserved_after,
+								  COALESCE(reservation_id, 0), COALESCE(user_id, 0), comment, created_at, COALESCE(variant_id, 0)
+						   FROM inventory_movements
+						   WHERE product_id = $1 AND ` + condition + `
+						   ` + ordering
+
+	rows, err := tx.Query(ctx, listMovementsQuery, append([]interface{}{productID}, pageArgs...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	movements = make([]domain.InventoryMovement, 0)
+
+	for rows.Next() {
+		var movement domain.InventoryMovement
+		err = rows.Scan(&movement.Id, &movement.ProductId, &movement.Kind, &movement.StockDelta, &movement.ReservedDelta,
+			&movement.StockAfter, &movement.ReservedAfter, &movement.ReservationId, &movement.UserId,
+			&movement.Comment, &movement.CreatedAt, &movement.VariantId)
+		if err != nil {
+			return nil, err
+		}
+
+		movements = append(movements, movement)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return movements, nil
+}
@@ -0,0 +1,493 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// variantColumns are selected together with products joined to product_variants, in order expected by variantScan.
+// Columns are coalesced, so that products without variant can be left joined. Variants are priced in currency of product
+const variantColumns = `COALESCE(product_variants.id, 0), COALESCE(product_variants.product_id, 0), COALESCE(product_variants.sku, ''),
+						COALESCE(product_variants.options, '{}'), COALESCE(product_variants.price, products.price), products.currency,
+						COALESCE(product_variants.price, 0), COALESCE(product_variants.stock, 0), COALESCE(product_variants.reserved, 0),
+						COALESCE(product_variants.availability, false),
+						ARRAY(SELECT product_images.id FROM product_variant_images
+							  INNER JOIN product_images ON product_images.id = product_variant_images.image_id
+							  WHERE product_variant_images.variant_id = product_variants.id
+							  ORDER BY product_images.is_primary DESC, product_images.position, product_images.id),
+						ARRAY(SELECT product_images.large_link FROM product_variant_images
+							  INNER JOIN product_images ON product_images.id = product_variant_images.image_id
+							  WHERE product_variant_images.variant_id = product_variants.id
+							  ORDER BY product_images.is_primary DESC, product_images.position, product_images.id)`
+
+// variantScan holds destinations of variantColumns, so that they can be scanned together with other columns
+type variantScan struct {
+	variant  domain.ProductVariant
+	imageIDs []int64
+}
+
+func (scan *variantScan) destinations() []interface{} {
+	scan.variant.ImageLinks = make([]string, 0)
+	scan.imageIDs = make([]int64, 0)
+	return []interface{}{&scan.variant.Id, &scan.variant.ProductId, &scan.variant.SKU, &scan.variant.Options,
+		&scan.variant.Price.Amount, &scan.variant.Price.Currency, &scan.variant.PriceOverride.Amount, &scan.variant.Stock,
+		&scan.variant.Reserved,
+		&scan.variant.Availability, &scan.imageIDs, &scan.variant.ImageLinks}
+}
+
+func (scan *variantScan) result() domain.ProductVariant {
+	scan.variant.ImageIds = toUint64s(scan.imageIDs)
+	if scan.variant.PriceOverride.Amount != 0 {
+		scan.variant.PriceOverride.Currency = scan.variant.Price.Currency
+	}
+	return scan.variant
+}
+
+// GetProductsVariants returns options and variants of products keyed by product id, products without variants are skipped
+func (repo *ShopProductRepo) GetProductsVariants(ctx context.Context, productIDs []uint64) (options map[uint64][]domain.ProductOption, variants map[uint64][]domain.ProductVariant, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	options, err = getProductsOptions(ctx, tx, productIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variants, err = getProductsVariants(ctx, tx, productIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, nil, domain.TransactionCommitError
+	}
+	return options, variants, nil
+}
+
+func getProductsOptions(ctx context.Context, tx pgx.Tx, productIDs []uint64) (options map[uint64][]domain.ProductOption, err error) {
+	getOptionsQuery := `SELECT product_id, name, option_values
+						FROM product_options
+						WHERE product_id = ANY($1)
+						ORDER BY product_id, position`
+
+	rows, err := tx.Query(ctx, getOptionsQuery, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	options = make(map[uint64][]domain.ProductOption)
+
+	for rows.Next() {
+		var productID uint64
+		option := domain.ProductOption{Values: make([]string, 0)}
+		err = rows.Scan(&productID, &option.Name, &option.Values)
+		if err != nil {
+			return nil, err
+		}
+
+		options[productID] = append(options[productID], option)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return options, nil
+}
+
+// getProductsVariants returns variants of products in order of their creation
+func getProductsVariants(ctx context.Context, tx pgx.Tx, productIDs []uint64) (variants map[uint64][]domain.ProductVariant, err error) {
+	getVariantsQuery := `SELECT ` + variantColumns + `
+						 FROM product_variants
+						 INNER JOIN products ON products.id = product_variants.product_id
+						 WHERE product_variants.product_id = ANY($1)
+						 ORDER BY product_variants.product_id, product_variants.id`
+
+	rows, err := tx.Query(ctx, getVariantsQuery, productIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	variants = make(map[uint64][]domain.ProductVariant)
+
+	for rows.Next() {
+		var scan variantScan
+		err = rows.Scan(scan.destinations()...)
+		if err != nil {
+			return nil, err
+		}
+
+		variant := scan.result()
+		variants[variant.ProductId] = append(variants[variant.ProductId], variant)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	return variants, nil
+}
+
+// checkVariantSelection returns error if variantID does not select variant of product as domain.SelectVariant requires
+func checkVariantSelection(ctx context.Context, tx pgx.Tx, productID uint64, variantID uint64) (err error) {
+	variants, err := getProductsVariants(ctx, tx, []uint64{productID})
+	if err != nil {
+		return err
+	}
+
+	_, err = domain.SelectVariant(variants[productID], variantID)
+	return err
+}
+
+// lockProduct locks product until the end of transaction. Product is always locked before its variants,
+// so that stock changes of product and its variants do not deadlock
+func lockProduct(ctx context.Context, tx pgx.Tx, productID uint64) (err error) {
+	lockProductQuery := `SELECT id
+						 FROM products
+						 WHERE id = $1
+						 FOR UPDATE`
+
+	var lockedID uint64
+	err = tx.QueryRow(ctx, lockProductQuery, productID).Scan(&lockedID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.ProductNotFoundError
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// syncVariantsStock sets stock and reserved quantities of product to sums of its variants' quantities
+func syncVariantsStock(ctx context.Context, tx pgx.Tx, productID uint64) (err error) {
+	syncStockQuery := `UPDATE products
+					   SET stock = totals.stock, reserved = totals.reserved
+					   FROM (SELECT COALESCE(SUM(stock), 0)::bigint AS stock, COALESCE(SUM(reserved), 0)::bigint AS reserved
+							 FROM product_variants
+							 WHERE product_id = $1) AS totals
+					   WHERE products.id = $1`
+
+	_, err = tx.Exec(ctx, syncStockQuery, productID)
+	return err
+}
+
+// SetProductOptions replaces options of product, options go in order of slice
+func (repo *ShopProductRepo) SetProductOptions(ctx context.Context, productID uint64, options []domain.ProductOption) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	err = lockProduct(ctx, tx, productID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM product_options WHERE product_id = $1`, productID)
+	if err != nil {
+		return err
+	}
+
+	addOptionQuery := `INSERT INTO product_options (product_id, position, name, option_values)
+					   VALUES ($1, $2, $3, $4)`
+
+	for position, option := range options {
+		_, err = tx.Exec(ctx, addOptionQuery, productID, position, option.Name, option.Values)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// CreateVariant creates variant with initial stock, which is recorded as adjustment made by user.
+// Stock of product's first variant replaces product's own stock, which can not be reserved at that moment.
+// Price override of variant is recorded in price history
+func (repo *ShopProductRepo) CreateVariant(ctx context.Context, variant domain.ProductVariant, userID uint64) (variantID uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getProductStockQuery := `SELECT stock, reserved, EXISTS(SELECT 1 FROM product_variants WHERE product_id = products.id),
+									shop_id
+							 FROM products
+							 WHERE id = $1
+							 FOR UPDATE`
+
+	var stock, reserved, shopID uint64
+	var hasVariants bool
+	err = tx.QueryRow(ctx, getProductStockQuery, variant.ProductId).Scan(&stock, &reserved, &hasVariants, &shopID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, domain.ProductNotFoundError
+		}
+
+		return 0, err
+	}
+
+	if !hasVariants {
+		if reserved != 0 {
+			return 0, domain.StockBelowReservedError
+		}
+
+		if stock != 0 {
+			_, err = addMovement(ctx, tx, domain.InventoryMovement{
+				ProductId:  variant.ProductId,
+				Kind:       domain.MovementAdjustment,
+				StockDelta: -int64(stock),
+				UserId:     userID,
+				Comment:    "Stock moved to variants",
+			})
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	createVariantQuery := `INSERT INTO product_variants (product_id, sku, options, price, stock)
+						   VALUES ($1, $2, $3, NULLIF($4, 0), $5)
+						   RETURNING id`
+
+	row := tx.QueryRow(ctx, createVariantQuery, variant.ProductId, variant.SKU, variant.Options,
+		int64(variant.PriceOverride.Amount), variant.Stock)
+	err = row.Scan(&variantID)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return 0, domain.DuplicateVariantError
+		}
+
+		return 0, err
+	}
+
+	err = setVariantImages(ctx, tx, variant.ProductId, variantID, variant.ImageIds)
+	if err != nil {
+		return 0, err
+	}
+
+	if variant.Stock != 0 {
+		_, err = addMovement(ctx, tx, domain.InventoryMovement{
+			ProductId:  variant.ProductId,
+			VariantId:  variantID,
+			Kind:       domain.MovementAdjustment,
+			StockDelta: int64(variant.Stock),
+			StockAfter: variant.Stock,
+			UserId:     userID,
+			Comment:    "Initial stock",
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = syncVariantsStock(ctx, tx, variant.ProductId)
+	if err != nil {
+		return 0, err
+	}
+
+	if variant.PriceOverride.Amount != 0 {
+		err = addPriceChange(ctx, tx, domain.PriceChange{
+			ProductId: variant.ProductId,
+			VariantId: variantID,
+			ShopId:    shopID,
+			Kind:      domain.PriceChangeList,
+			NewPrice:  variant.PriceOverride,
+			UserId:    userID,
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return variantID, nil
+}
+
+// UpdateVariant changes SKU, options, price override and images of variant, stock is changed by AdjustStock.
+// Change of variant's price is recorded in price history as made by user
+func (repo *ShopProductRepo) UpdateVariant(ctx context.Context, variant domain.ProductVariant, userID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getVariantPriceQuery := `SELECT COALESCE(product_variants.price, products.price), products.price, products.currency,
+									products.shop_id
+							 FROM product_variants
+							 INNER JOIN products ON products.id = product_variants.product_id
+							 WHERE product_variants.id = $1 AND product_variants.product_id = $2
+							 FOR UPDATE OF product_variants`
+
+	var oldPrice, productPrice domain.Money
+	var shopID uint64
+	err = tx.QueryRow(ctx, getVariantPriceQuery, variant.Id, variant.ProductId).Scan(&oldPrice.Amount, &productPrice.Amount,
+		&productPrice.Currency, &shopID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.VariantNotFoundError
+		}
+
+		return err
+	}
+
+	updateVariantQuery := `UPDATE product_variants
+						   SET sku = $3, options = $4, price = NULLIF($5, 0)
+						   WHERE id = $1 AND product_id = $2`
+
+	result, err := tx.Exec(ctx, updateVariantQuery, variant.Id, variant.ProductId, variant.SKU, variant.Options,
+		int64(variant.PriceOverride.Amount))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.DuplicateVariantError
+		}
+
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.VariantNotFoundError
+	}
+
+	err = setVariantImages(ctx, tx, variant.ProductId, variant.Id, variant.ImageIds)
+	if err != nil {
+		return err
+	}
+
+	oldPrice.Currency = productPrice.Currency
+	newPrice := productPrice
+	if variant.PriceOverride.Amount != 0 {
+		newPrice = domain.Money{Amount: variant.PriceOverride.Amount, Currency: productPrice.Currency}
+	}
+
+	if newPrice != oldPrice {
+		err = addPriceChange(ctx, tx, domain.PriceChange{
+			ProductId: variant.ProductId,
+			VariantId: variant.Id,
+			ShopId:    shopID,
+			Kind:      domain.PriceChangeList,
+			OldPrice:  oldPrice,
+			NewPrice:  newPrice,
+			UserId:    userID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// setVariantImages replaces images of variant, every image must belong to variant's product
+func setVariantImages(ctx context.Context, tx pgx.Tx, productID uint64, variantID uint64, imageIDs []uint64) (err error) {
+	_, err = tx.Exec(ctx, `DELETE FROM product_variant_images WHERE variant_id = $1`, variantID)
+	if err != nil {
+		return err
+	}
+
+	if len(imageIDs) == 0 {
+		return nil
+	}
+
+	addImagesQuery := `INSERT INTO product_variant_images (variant_id, image_id)
+					   SELECT $1, id
+					   FROM product_images
+					   WHERE product_id = $2 AND id = ANY($3)`
+
+	result, err := tx.Exec(ctx, addImagesQuery, variantID, productID, imageIDs)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != int64(len(imageIDs)) {
+		return domain.ImageNotFoundError
+	}
+
+	return nil
+}
+
+// DeleteVariant deletes variant which has no reserved stock, its remaining stock is written off by user.
+// Product whose last variant is deleted is left without stock
+func (repo *ShopProductRepo) DeleteVariant(ctx context.Context, productID uint64, variantID uint64, userID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	err = lockProduct(ctx, tx, productID)
+	if err != nil {
+		return err
+	}
+
+	getVariantStockQuery := `SELECT stock, reserved
+							 FROM product_variants
+							 WHERE id = $1 AND product_id = $2
+							 FOR UPDATE`
+
+	var stock, reserved uint64
+	err = tx.QueryRow(ctx, getVariantStockQuery, variantID, productID).Scan(&stock, &reserved)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.VariantNotFoundError
+		}
+
+		return err
+	}
+
+	if reserved != 0 {
+		return domain.VariantReservedError
+	}
+
+	if stock != 0 {
+		_, err = addMovement(ctx, tx, domain.InventoryMovement{
+			ProductId:  productID,
+			VariantId:  variantID,
+			Kind:       domain.MovementAdjustment,
+			StockDelta: -int64(stock),
+			UserId:     userID,
+			Comment:    "Variant deleted",
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM product_variants WHERE id = $1`, variantID)
+	if err != nil {
+		return err
+	}
+
+	err = syncVariantsStock(ctx, tx, productID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
@@ -0,0 +1,420 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/shopProduct/domain"
+
+	"github.com/jackc/pgx/v4"
+)
+
+const wishlistColumns = `wishlists.id, wishlists.user_id, wishlists.title, wishlists.is_default, wishlists.is_public,
+						 wishlists.share_token, wishlists.created_at, wishlists.updated_at`
+
+func scanWishlist(row pgx.Row, extra ...interface{}) (wishlist domain.Wishlist, err error) {
+	destinations := []interface{}{&wishlist.Id, &wishlist.UserId, &wishlist.Title, &wishlist.IsDefault, &wishlist.IsPublic,
+		&wishlist.ShareToken, &wishlist.CreatedAt, &wishlist.UpdatedAt}
+	err = row.Scan(append(destinations, extra...)...)
+	return wishlist, err
+}
+
+// ListWishlists returns user's wishlists with amounts of their products, default wishlist goes first
+func (repo *ShopProductRepo) ListWishlists(ctx context.Context, userID uint64, publicOnly bool) (wishlists []domain.Wishlist, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	listWishlistsQuery := `SELECT ` + wishlistColumns + `,
+								  (SELECT count(*) FROM wishlist_items WHERE wishlist_items.wishlist_id = wishlists.id)
+						   FROM wishlists
+						   WHERE user_id = $1 AND (NOT $2 OR is_public)
+						   ORDER BY is_default DESC, id`
+
+	rows, err := tx.Query(ctx, listWishlistsQuery, userID, publicOnly)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	wishlists = make([]domain.Wishlist, 0)
+
+	for rows.Next() {
+		var productsCount uint64
+		wishlist, err := scanWishlist(rows, &productsCount)
+		if err != nil {
+			return nil, err
+		}
+
+		wishlist.ProductsCount = productsCount
+		wishlists = append(wishlists, wishlist)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return wishlists, nil
+}
+
+// GetWishlist returns wishlist without its products
+func (repo *ShopProductRepo) GetWishlist(ctx context.Context, wishlistID uint64) (wishlist domain.Wishlist, err error) {
+	return repo.getWishlist(ctx, `wishlists.id = $1`, wishlistID)
+}
+
+// GetWishlistByToken returns wishlist whose share link contains token, without its products
+func (repo *ShopProductRepo) GetWishlistByToken(ctx context.Context, token string) (wishlist domain.Wishlist, err error) {
+	return repo.getWishlist(ctx, `wishlists.share_token = $1`, token)
+}
+
+func (repo *ShopProductRepo) getWishlist(ctx context.Context, condition string, arg interface{}) (wishlist domain.Wishlist, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.Wishlist{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getWishlistQuery := `SELECT ` + wishlistColumns + `
+						 FROM wishlists
+						 WHERE ` + condition
+
+	wishlist, err = scanWishlist(tx.QueryRow(ctx, getWishlistQuery, arg))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.Wishlist{}, domain.WishlistNotFoundError
+		}
+
+		return domain.Wishlist{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.Wishlist{}, domain.TransactionCommitError
+	}
+	return wishlist, nil
+}
+
+// GetWishlistProducts returns products of wishlist, recently saved products go first
+func (repo *ShopProductRepo) GetWishlistProducts(ctx context.Context, wishlistID uint64) (products []domain.Product, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getProductsQuery := `SELECT ` + productColumns + `
+						 FROM wishlist_items
+						 INNER JOIN products ON products.id = wishlist_items.product_id
+						 WHERE wishlist_items.wishlist_id = $1 AND products.deleted_at IS NULL
+						 ORDER BY wishlist_items.added_at DESC, products.id DESC`
+
+	rows, err := tx.Query(ctx, getProductsQuery, wishlistID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products = make([]domain.Product, 0)
+
+	for rows.Next() {
+		product, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		products = append(products, product)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return products, nil
+}
+
+// CreateWishlist creates user's wishlist, TooManyWishlistsError is returned if user already has domain.MaxWishlists
+func (repo *ShopProductRepo) CreateWishlist(ctx context.Context, wishlist domain.Wishlist) (wishlistID uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	createWishlistQuery := `INSERT INTO wishlists (user_id, title, is_public, share_token)
+							SELECT $1, $2, $3, $4
+							WHERE (SELECT count(*) FROM wishlists WHERE user_id = $1) < $5
+							RETURNING id`
+
+	err = tx.QueryRow(ctx, createWishlistQuery, wishlist.UserId, wishlist.Title, wishlist.IsPublic, wishlist.ShareToken,
+		domain.MaxWishlists).Scan(&wishlistID)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return 0, domain.TooManyWishlistsError
+		}
+
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return wishlistID, nil
+}
+
+// GetDefaultWishlistID returns id of user's default wishlist, creating it with shareToken if user has none
+func (repo *ShopProductRepo) GetDefaultWishlistID(ctx context.Context, userID uint64, shareToken string) (wishlistID uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return 0, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	createDefaultQuery := `INSERT INTO wishlists (user_id, title, is_default, share_token)
+						   VALUES ($1, $2, true, $3)
+						   ON CONFLICT (user_id) WHERE is_default DO UPDATE SET user_id = EXCLUDED.user_id
+						   RETURNING id`
+
+	err = tx.QueryRow(ctx, createDefaultQuery, userID, domain.DefaultWishlistTitle, shareToken).Scan(&wishlistID)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, domain.TransactionCommitError
+	}
+	return wishlistID, nil
+}
+
+func (repo *ShopProductRepo) UpdateWishlist(ctx context.Context, wishlist domain.Wishlist) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	updateWishlistQuery := `UPDATE wishlists
+							SET title = $2, is_public = $3, updated_at = now()
+							WHERE id = $1`
+
+	result, err := tx.Exec(ctx, updateWishlistQuery, wishlist.Id, wishlist.Title, wishlist.IsPublic)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.WishlistNotFoundError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// DeleteWishlist deletes wishlist, saves of its products are recounted
+func (repo *ShopProductRepo) DeleteWishlist(ctx context.Context, wishlistID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	productIDs, err := lockSavedProducts(ctx, tx, `SELECT product_id FROM wishlist_items WHERE wishlist_id = $1`, wishlistID)
+	if err != nil {
+		return err
+	}
+
+	deleteWishlistQuery := `DELETE FROM wishlists
+							WHERE id = $1`
+
+	result, err := tx.Exec(ctx, deleteWishlistQuery, wishlistID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.WishlistNotFoundError
+	}
+
+	err = recountSaves(ctx, tx, productIDs)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// AddWishlistItem saves product to wishlist, saving product which is already there changes nothing
+func (repo *ShopProductRepo) AddWishlistItem(ctx context.Context, wishlistID uint64, productID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	// Deleted products can be removed from wishlists, but can not be saved
+	productIDs, err := lockSavedProducts(ctx, tx, `SELECT id FROM products WHERE id = $1::bigint AND deleted_at IS NULL`, productID)
+	if err != nil {
+		return err
+	}
+	if len(productIDs) == 0 {
+		return domain.ProductNotFoundError
+	}
+
+	addItemQuery := `INSERT INTO wishlist_items (wishlist_id, product_id)
+					 VALUES ($1, $2)
+					 ON CONFLICT DO NOTHING`
+
+	_, err = tx.Exec(ctx, addItemQuery, wishlistID, productID)
+	if err != nil {
+		if isForeignKeyViolation(err) {
+			return domain.WishlistNotFoundError
+		}
+
+		return err
+	}
+
+	err = recountSaves(ctx, tx, productIDs)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// RemoveWishlistItem removes product from wishlist
+func (repo *ShopProductRepo) RemoveWishlistItem(ctx context.Context, wishlistID uint64, productID uint64) (err error) {
+	return repo.removeSavedProduct(ctx, `wishlist_id = $2`, productID, wishlistID)
+}
+
+// RemoveSavedProduct removes product from all wishlists of user
+func (repo *ShopProductRepo) RemoveSavedProduct(ctx context.Context, userID uint64, productID uint64) (err error) {
+	return repo.removeSavedProduct(ctx, `wishlist_id IN (SELECT id FROM wishlists WHERE user_id = $2)`, productID, userID)
+}
+
+// removeSavedProduct deletes items of product $1 from wishlists which match condition,
+// WishlistItemNotFoundError is returned if there were no such items
+func (repo *ShopProductRepo) removeSavedProduct(ctx context.Context, condition string, productID uint64, arg interface{}) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	productIDs, err := lockSavedProducts(ctx, tx, `SELECT $1::bigint`, productID)
+	if err != nil {
+		return err
+	}
+
+	removeItemsQuery := `DELETE FROM wishlist_items
+						 WHERE product_id = $1 AND ` + condition
+
+	result, err := tx.Exec(ctx, removeItemsQuery, productID, arg)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return domain.WishlistItemNotFoundError
+	}
+
+	err = recountSaves(ctx, tx, productIDs)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// IsProductSaved reports whether user saved product to any of their wishlists
+func (repo *ShopProductRepo) IsProductSaved(ctx context.Context, productID uint64, userID uint64) (isSaved bool, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return false, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	isSavedQuery := `SELECT EXISTS(SELECT 1
+								   FROM wishlist_items
+								   INNER JOIN wishlists ON wishlists.id = wishlist_items.wishlist_id
+								   WHERE wishlist_items.product_id = $1 AND wishlists.user_id = $2)`
+
+	err = tx.QueryRow(ctx, isSavedQuery, productID, userID).Scan(&isSaved)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return false, domain.TransactionCommitError
+	}
+	return isSaved, nil
+}
+
+// lockSavedProducts locks products whose ids are selected by query, so that their saves are recounted one
+// transaction at a time. Ids of existing products are returned
+func lockSavedProducts(ctx context.Context, tx pgx.Tx, productIDsQuery string, arg interface{}) (productIDs []uint64, err error) {
+	lockProductsQuery := `SELECT id
+						  FROM products
+						  WHERE id IN (` + productIDsQuery + `)
+						  ORDER BY id
+						  FOR UPDATE`
+
+	rows, err := tx.Query(ctx, lockProductsQuery, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	productIDs = make([]uint64, 0)
+
+	for rows.Next() {
+		var productID uint64
+		err = rows.Scan(&productID)
+		if err != nil {
+			return nil, err
+		}
+
+		productIDs = append(productIDs, productID)
+	}
+
+	return productIDs, rows.Err()
+}
+
+// recountSaves sets saves count of products to amount of users who have them in wishlists
+func recountSaves(ctx context.Context, tx pgx.Tx, productIDs []uint64) (err error) {
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	recountSavesQuery := `UPDATE products
+						  SET saves_count = (SELECT count(DISTINCT wishlists.user_id)
+											 FROM wishlist_items
+											 INNER JOIN wishlists ON wishlists.id = wishlist_items.wishlist_id
+											 WHERE wishlist_items.product_id = products.id)
+						  WHERE id = ANY($1)`
+
+	_, err = tx.Exec(ctx, recountSavesQuery, productIDs)
+	return err
+}
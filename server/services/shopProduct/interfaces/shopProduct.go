@@ -1,39 +1,938 @@
 package facade
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"pinterest/services/shopProduct/application"
 	"pinterest/services/shopProduct/domain"
 	pb "pinterest/services/shopProduct/proto"
+	"time"
 
-	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/pkg/errors"
-	"google.golang.org/grpc"
 	_ "google.golang.org/grpc"
 )
 
 type ShopProductFacade struct {
-	postgresDB *pgxpool.Pool
-	app        application.ShopProductAppInterface
+	pb.UnimplementedShopProductServer
+	app application.ShopProductAppInterface
 }
 
-func NewService(postgresDB *pgxpool.Pool, app application.ShopProductAppInterface) *ShopProductFacade {
+func NewShopProductFacade(app application.ShopProductAppInterface) *ShopProductFacade {
 	return &ShopProductFacade{
-		postgresDB: postgresDB,
-		app:        app,
+		app: app,
 	}
 }
 
-func (facade *ShopProductFacade) CreateShop(ctx context.Context, in *pb.CreateShopRequest, opts ...grpc.CallOption) (*pb.StatusResponse, error) {
-	shopInput := domain.Shop{
-		Title:       in.Title,
-		Description: in.Description,
-		ManagerIDs:  in.ManagerIds,
+func (facade *ShopProductFacade) CreateShop(ctx context.Context, in *pb.CreateShopRequest) (*pb.CreateShopResponse, error) {
+	id, err := facade.app.CreateShop(ctx, domain.CreateShopRequestToShop(in), in.GetUserId())
+	if err != nil {
+		return &pb.CreateShopResponse{}, errors.Wrap(err, "Could not create shop:")
+	}
+
+	return &pb.CreateShopResponse{Id: id}, nil
+}
+
+func (facade *ShopProductFacade) EditShop(ctx context.Context, in *pb.EditShopRequest) (*pb.StatusResponse, error) {
+	err := facade.app.EditShop(ctx, domain.EditShopRequestToShop(in), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not edit shop:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) GetShop(ctx context.Context, in *pb.GetShopRequest) (*pb.Shop, error) {
+	var shop domain.Shop
+	var err error
+	if in.GetIncludeDeleted() {
+		shop, err = facade.app.GetShopIncludingDeleted(ctx, in.GetId())
+	} else {
+		view := domain.PageView{UserID: in.GetUserId(), Session: in.GetSession(), Referrer: in.GetReferrer()}
+		shop, err = facade.app.GetShop(ctx, in.GetId(), view)
+	}
+	if err != nil {
+		return &pb.Shop{}, errors.Wrap(err, "Could not get shop:")
+	}
+
+	return domain.ToPbShop(shop), nil
+}
+
+func (facade *ShopProductFacade) CreateProduct(ctx context.Context, in *pb.CreateProductRequest) (*pb.CreateProductResponse, error) {
+	id, err := facade.app.CreateProduct(ctx, domain.CreateProductRequestToProduct(in), in.GetUserId())
+	if err != nil {
+		return &pb.CreateProductResponse{}, errors.Wrap(err, "Could not create product:")
+	}
+
+	return &pb.CreateProductResponse{Id: id}, nil
+}
+
+func (facade *ShopProductFacade) EditProduct(ctx context.Context, in *pb.EditProductRequest) (*pb.StatusResponse, error) {
+	err := facade.app.EditProduct(ctx, domain.EditProductRequestToProduct(in), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not edit product:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) GetProduct(ctx context.Context, in *pb.GetProductRequest) (*pb.Product, error) {
+	view := domain.PageView{UserID: in.GetUserId(), Session: in.GetSession(), Referrer: in.GetReferrer()}
+	product, err := facade.app.GetProduct(ctx, in.GetId(), in.GetVariantId(), view, in.GetCurrency())
+	if err != nil {
+		return &pb.Product{}, errors.Wrap(err, "Could not get product:")
+	}
+
+	return domain.ToPbProduct(product), nil
+}
+
+func (facade *ShopProductFacade) GetProductsByIds(ctx context.Context, in *pb.ProductIdsRequest) (*pb.ProductsList, error) {
+	products, err := facade.app.GetProductsByIDs(ctx, in.GetIds(), in.GetCurrency())
+	if err != nil {
+		return &pb.ProductsList{}, errors.Wrap(err, "Could not get products:")
+	}
+
+	return domain.ToPbProductsList(products, ""), nil
+}
+
+func (facade *ShopProductFacade) ListProductsByShop(ctx context.Context, in *pb.ListProductsRequest) (*pb.ProductsList, error) {
+	page, err := domain.NewProductsPage(in.GetSorting(), in.GetLimit(), in.GetCursor(), in.GetPage())
+	if err != nil {
+		return &pb.ProductsList{}, errors.Wrap(err, "Could not list shop's products:")
+	}
+
+	products, nextCursor, err := facade.app.ListProductsByShop(ctx, in.GetShopId(), page, in.GetCurrency())
+	if err != nil {
+		return &pb.ProductsList{}, errors.Wrap(err, "Could not list shop's products:")
+	}
+
+	return domain.ToPbProductsList(products, nextCursor), nil
+}
+
+func (facade *ShopProductFacade) GetCategories(ctx context.Context, in *pb.CategoriesRequest) (*pb.CategoryTree, error) {
+	categories, err := facade.app.GetCategories(ctx)
+	if err != nil {
+		return &pb.CategoryTree{}, errors.Wrap(err, "Could not get categories:")
+	}
+
+	return &pb.CategoryTree{Categories: domain.ToPbCategories(categories)}, nil
+}
+
+func (facade *ShopProductFacade) CreateCategory(ctx context.Context, in *pb.CategoryRequest) (*pb.CategoryResponse, error) {
+	id, err := facade.app.CreateCategory(ctx, domain.ToCategory(in.GetCategory()), in.GetUserId())
+	if err != nil {
+		return &pb.CategoryResponse{}, errors.Wrap(err, "Could not create category:")
+	}
+
+	return &pb.CategoryResponse{Id: id}, nil
+}
+
+func (facade *ShopProductFacade) EditCategory(ctx context.Context, in *pb.CategoryRequest) (*pb.StatusResponse, error) {
+	err := facade.app.EditCategory(ctx, domain.ToCategory(in.GetCategory()), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not edit category:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) DeleteCategory(ctx context.Context, in *pb.DeleteCategoryRequest) (*pb.StatusResponse, error) {
+	err := facade.app.DeleteCategory(ctx, in.GetId(), in.GetReplacementId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not delete category:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) ListProductsByCategory(ctx context.Context, in *pb.ListCategoryProductsRequest) (*pb.ProductsList, error) {
+	page, err := domain.NewProductsPage(in.GetSorting(), in.GetLimit(), in.GetCursor(), in.GetPage())
+	if err != nil {
+		return &pb.ProductsList{}, errors.Wrap(err, "Could not list category's products:")
+	}
+
+	products, nextCursor, err := facade.app.ListProductsByCategory(ctx, in.GetCategoryId(), page, in.GetCurrency())
+	if err != nil {
+		return &pb.ProductsList{}, errors.Wrap(err, "Could not list category's products:")
+	}
+
+	return domain.ToPbProductsList(products, nextCursor), nil
+}
+
+func (facade *ShopProductFacade) AdjustStock(ctx context.Context, in *pb.AdjustStockRequest) (*pb.InventoryMovement, error) {
+	movement, err := facade.app.AdjustStock(ctx, domain.AdjustStockRequestToAdjustment(in))
+	if err != nil {
+		return &pb.InventoryMovement{}, errors.Wrap(err, "Could not adjust stock:")
+	}
+
+	return domain.ToPbInventoryMovement(movement), nil
+}
+
+func (facade *ShopProductFacade) ReserveStock(ctx context.Context, in *pb.ReserveStockRequest) (*pb.Reservation, error) {
+	reservation, err := facade.app.ReserveStock(ctx, domain.ToStockItems(in.GetItems()), time.Duration(in.GetTtlSeconds())*time.Second)
+	if err != nil {
+		return &pb.Reservation{}, errors.Wrap(err, "Could not reserve stock:")
+	}
+
+	return domain.ToPbReservation(reservation), nil
+}
+
+func (facade *ShopProductFacade) GetReservation(ctx context.Context, in *pb.ReservationRequest) (*pb.Reservation, error) {
+	reservation, err := facade.app.GetReservation(ctx, in.GetId())
+	if err != nil {
+		return &pb.Reservation{}, errors.Wrap(err, "Could not get reservation:")
+	}
+
+	return domain.ToPbReservation(reservation), nil
+}
+
+func (facade *ShopProductFacade) CommitReservation(ctx context.Context, in *pb.ReservationRequest) (*pb.StatusResponse, error) {
+	err := facade.app.CommitReservation(ctx, in.GetId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not commit reservation:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) ReleaseReservation(ctx context.Context, in *pb.ReservationRequest) (*pb.StatusResponse, error) {
+	err := facade.app.ReleaseReservation(ctx, in.GetId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not release reservation:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) ListInventoryMovements(ctx context.Context, in *pb.ListMovementsRequest) (*pb.InventoryMovements, error) {
+	page, err := domain.NewMovementsPage(in.GetLimit(), in.GetCursor(), in.GetPage())
+	if err != nil {
+		return &pb.InventoryMovements{}, errors.Wrap(err, "Could not list inventory movements:")
+	}
+
+	movements, nextCursor, err := facade.app.ListInventoryMovements(ctx, in.GetProductId(), in.GetUserId(), page)
+	if err != nil {
+		return &pb.InventoryMovements{}, errors.Wrap(err, "Could not list inventory movements:")
+	}
+
+	return domain.ToPbInventoryMovements(movements, nextCursor), nil
+}
+
+func (facade *ShopProductFacade) GetCart(ctx context.Context, in *pb.CartRequest) (*pb.Cart, error) {
+	cart, err := facade.app.GetCart(ctx, domain.ToCartOwner(in.GetOwner()), in.GetCurrency())
+	if err != nil {
+		return &pb.Cart{}, errors.Wrap(err, "Could not get cart:")
+	}
+
+	return domain.ToPbCart(cart), nil
+}
+
+func (facade *ShopProductFacade) AddToCart(ctx context.Context, in *pb.CartItemRequest) (*pb.Cart, error) {
+	cart, err := facade.app.AddToCart(ctx, domain.ToCartOwner(in.GetOwner()), in.GetProductId(), in.GetVariantId(), in.GetQuantity(), in.GetCurrency())
+	if err != nil {
+		return &pb.Cart{}, errors.Wrap(err, "Could not add product to cart:")
+	}
+
+	return domain.ToPbCart(cart), nil
+}
+
+func (facade *ShopProductFacade) UpdateCartItem(ctx context.Context, in *pb.CartItemRequest) (*pb.Cart, error) {
+	cart, err := facade.app.UpdateCartItem(ctx, domain.ToCartOwner(in.GetOwner()), in.GetProductId(), in.GetVariantId(), in.GetQuantity(), in.GetCurrency())
+	if err != nil {
+		return &pb.Cart{}, errors.Wrap(err, "Could not update cart item:")
+	}
+
+	return domain.ToPbCart(cart), nil
+}
+
+func (facade *ShopProductFacade) RemoveFromCart(ctx context.Context, in *pb.CartItemRequest) (*pb.Cart, error) {
+	cart, err := facade.app.RemoveFromCart(ctx, domain.ToCartOwner(in.GetOwner()), in.GetProductId(), in.GetVariantId(), in.GetCurrency())
+	if err != nil {
+		return &pb.Cart{}, errors.Wrap(err, "Could not remove product from cart:")
+	}
+
+	return domain.ToPbCart(cart), nil
+}
+
+func (facade *ShopProductFacade) MergeCarts(ctx context.Context, in *pb.MergeCartsRequest) (*pb.StatusResponse, error) {
+	err := facade.app.MergeCarts(ctx, in.GetToken(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not merge carts:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) SearchProducts(ctx context.Context, in *pb.SearchProductsRequest) (*pb.SearchProductsResponse, error) {
+	page, err := domain.NewSearchPage(in.GetSorting(), in.GetQuery(), in.GetLimit(), in.GetCursor(), in.GetPage())
+	if err != nil {
+		return &pb.SearchProductsResponse{}, errors.Wrap(err, "Could not search products:")
+	}
+
+	result, err := facade.app.SearchProducts(ctx, domain.SearchProductsRequestToFilters(in), page)
+	if err != nil {
+		return &pb.SearchProductsResponse{}, errors.Wrap(err, "Could not search products:")
+	}
+
+	return domain.ToPbSearchProductsResponse(result), nil
+}
+
+func (facade *ShopProductFacade) GetFeed(ctx context.Context, in *pb.FeedRequest) (*pb.ProductsList, error) {
+	products, nextCursor, err := facade.app.GetFeed(ctx, in.GetUserId(), in.GetLimit(), in.GetCursor(), in.GetCurrency())
+	if err != nil {
+		return &pb.ProductsList{}, errors.Wrap(err, "Could not get feed:")
+	}
+
+	return domain.ToPbProductsList(products, nextCursor), nil
+}
+
+func (facade *ShopProductFacade) FollowShop(ctx context.Context, in *pb.ShopFollowRequest) (*pb.StatusResponse, error) {
+	err := facade.app.FollowShop(ctx, in.GetShopId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not follow shop:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) UnfollowShop(ctx context.Context, in *pb.ShopFollowRequest) (*pb.StatusResponse, error) {
+	err := facade.app.UnfollowShop(ctx, in.GetShopId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not unfollow shop:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) CreateReview(ctx context.Context, in *pb.CreateReviewRequest) (*pb.ReviewResponse, error) {
+	id, err := facade.app.CreateReview(ctx, domain.CreateReviewRequestToReview(in))
+	if err != nil {
+		return &pb.ReviewResponse{}, errors.Wrap(err, "Could not create review:")
+	}
+
+	return &pb.ReviewResponse{Id: id}, nil
+}
+
+func (facade *ShopProductFacade) EditReview(ctx context.Context, in *pb.EditReviewRequest) (*pb.StatusResponse, error) {
+	err := facade.app.EditReview(ctx, domain.EditReviewRequestToReview(in))
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not edit review:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) ListReviews(ctx context.Context, in *pb.ListReviewsRequest) (*pb.ReviewsList, error) {
+	page, err := domain.NewReviewsPage(in.GetSorting(), in.GetLimit(), in.GetCursor(), in.GetPage())
+	if err != nil {
+		return &pb.ReviewsList{}, errors.Wrap(err, "Could not list product's reviews:")
+	}
+
+	reviews, nextCursor, err := facade.app.ListReviews(ctx, in.GetProductId(), page)
+	if err != nil {
+		return &pb.ReviewsList{}, errors.Wrap(err, "Could not list product's reviews:")
+	}
+
+	return domain.ToPbReviewsList(reviews, nextCursor), nil
+}
+
+func (facade *ShopProductFacade) DeleteProduct(ctx context.Context, in *pb.DeleteProductRequest) (*pb.StatusResponse, error) {
+	err := facade.app.DeleteProduct(ctx, in.GetId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not delete product:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) PurgeProduct(ctx context.Context, in *pb.DeleteProductRequest) (*pb.StatusResponse, error) {
+	err := facade.app.PurgeProduct(ctx, in.GetId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not purge product:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) RestoreProduct(ctx context.Context, in *pb.RestoreProductRequest) (*pb.StatusResponse, error) {
+	err := facade.app.RestoreProduct(ctx, in.GetId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not restore product:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) ListDeletedProducts(ctx context.Context, in *pb.ShopRequest) (*pb.ProductsList, error) {
+	products, err := facade.app.ListDeletedProducts(ctx, in.GetShopId(), in.GetUserId())
+	if err != nil {
+		return &pb.ProductsList{}, errors.Wrap(err, "Could not list deleted products:")
+	}
+
+	return domain.ToPbProductsList(products, ""), nil
+}
+
+func (facade *ShopProductFacade) DeleteShop(ctx context.Context, in *pb.ShopRequest) (*pb.StatusResponse, error) {
+	err := facade.app.DeleteShop(ctx, in.GetShopId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not delete shop:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) RestoreShop(ctx context.Context, in *pb.ShopRequest) (*pb.StatusResponse, error) {
+	err := facade.app.RestoreShop(ctx, in.GetShopId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not restore shop:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) GetRelatedProducts(ctx context.Context, in *pb.RelatedProductsRequest) (*pb.ProductsList, error) {
+	products, err := facade.app.GetRelatedProducts(ctx, in.GetProductId(), in.GetLimit(), in.GetCurrency())
+	if err != nil {
+		return &pb.ProductsList{}, errors.Wrap(err, "Could not get related products:")
+	}
+
+	return domain.ToPbProductsList(products, ""), nil
+}
+
+func (facade *ShopProductFacade) GetShopAnalytics(ctx context.Context, in *pb.ShopAnalyticsRequest) (*pb.ShopAnalytics, error) {
+	analytics, err := facade.app.GetShopAnalytics(ctx, in.GetShopId(), in.GetUserId(), in.GetFrom(), in.GetTo())
+	if err != nil {
+		return &pb.ShopAnalytics{}, errors.Wrap(err, "Could not get shop analytics:")
+	}
+
+	return domain.ToPbShopAnalytics(analytics), nil
+}
+
+func (facade *ShopProductFacade) AskQuestion(ctx context.Context, in *pb.AskQuestionRequest) (*pb.QuestionResponse, error) {
+	question := domain.ProductQuestion{ProductId: in.GetProductId(), UserId: in.GetUserId(), Text: in.GetText()}
+	id, err := facade.app.AskQuestion(ctx, question)
+	if err != nil {
+		return &pb.QuestionResponse{}, errors.Wrap(err, "Could not ask question:")
+	}
+
+	return &pb.QuestionResponse{Id: id}, nil
+}
+
+func (facade *ShopProductFacade) AnswerQuestion(ctx context.Context, in *pb.AnswerQuestionRequest) (*pb.QuestionResponse, error) {
+	answer := domain.ProductAnswer{QuestionId: in.GetQuestionId(), UserId: in.GetUserId(), Text: in.GetText()}
+	id, err := facade.app.AnswerQuestion(ctx, answer)
+	if err != nil {
+		return &pb.QuestionResponse{}, errors.Wrap(err, "Could not answer question:")
+	}
+
+	return &pb.QuestionResponse{Id: id}, nil
+}
+
+func (facade *ShopProductFacade) SetOfficialAnswer(ctx context.Context, in *pb.OfficialAnswerRequest) (*pb.StatusResponse, error) {
+	err := facade.app.SetOfficialAnswer(ctx, in.GetAnswerId(), in.GetUserId(), in.GetIsOfficial())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not set official answer:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) VoteQuestion(ctx context.Context, in *pb.QuestionVoteRequest) (*pb.StatusResponse, error) {
+	err := facade.app.VoteQuestion(ctx, in.GetQuestionId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not vote for question:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) UnvoteQuestion(ctx context.Context, in *pb.QuestionVoteRequest) (*pb.StatusResponse, error) {
+	err := facade.app.UnvoteQuestion(ctx, in.GetQuestionId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not remove vote for question:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) ListQuestions(ctx context.Context, in *pb.ListQuestionsRequest) (*pb.QuestionsList, error) {
+	page, err := domain.NewQuestionsPage(in.GetSorting(), in.GetLimit(), in.GetCursor(), in.GetPage(), in.GetQuery())
+	if err != nil {
+		return &pb.QuestionsList{}, errors.Wrap(err, "Could not list product's questions:")
+	}
+
+	questions, nextCursor, err := facade.app.ListQuestions(ctx, in.GetProductId(), page, in.GetUserId())
+	if err != nil {
+		return &pb.QuestionsList{}, errors.Wrap(err, "Could not list product's questions:")
+	}
+
+	return domain.ToPbQuestionsList(questions, nextCursor), nil
+}
+
+func (facade *ShopProductFacade) ExportUserData(ctx context.Context, in *pb.UserRequest) (*pb.ExportSections, error) {
+	sections, err := facade.app.ExportUserData(ctx, in.GetUserId())
+	if err != nil {
+		return &pb.ExportSections{}, errors.Wrap(err, "Could not export user data:")
+	}
+
+	pbSections, err := domain.ToPbExportSections(sections)
+	if err != nil {
+		return &pb.ExportSections{}, errors.Wrap(err, "Could not export user data:")
+	}
+	return pbSections, nil
+}
+
+func (facade *ShopProductFacade) InviteShopManager(ctx context.Context, in *pb.InviteShopManagerRequest) (*pb.InvitationResponse, error) {
+	id, err := facade.app.InviteShopManager(ctx, domain.InviteShopManagerRequestToInvitation(in))
+	if err != nil {
+		return &pb.InvitationResponse{}, errors.Wrap(err, "Could not invite shop manager:")
+	}
+
+	return &pb.InvitationResponse{Id: id}, nil
+}
+
+func (facade *ShopProductFacade) RemoveShopManager(ctx context.Context, in *pb.RemoveShopManagerRequest) (*pb.StatusResponse, error) {
+	err := facade.app.RemoveShopManager(ctx, in.GetShopId(), in.GetUserId(), in.GetManagerId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not remove shop manager:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) GetShopInvitations(ctx context.Context, in *pb.UserRequest) (*pb.ShopInvitations, error) {
+	invitations, err := facade.app.GetShopInvitations(ctx, in.GetUserId())
+	if err != nil {
+		return &pb.ShopInvitations{}, errors.Wrap(err, "Could not get shop invitations:")
+	}
+
+	return domain.ToPbShopInvitations(invitations), nil
+}
+
+func (facade *ShopProductFacade) AcceptShopInvitation(ctx context.Context, in *pb.InvitationRequest) (*pb.StatusResponse, error) {
+	err := facade.app.AcceptShopInvitation(ctx, in.GetId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not accept shop invitation:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) DeclineShopInvitation(ctx context.Context, in *pb.InvitationRequest) (*pb.StatusResponse, error) {
+	err := facade.app.DeclineShopInvitation(ctx, in.GetId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not decline shop invitation:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+// UploadProductImage receives image info followed by image's bytes, image is processed when stream is closed
+func (facade *ShopProductFacade) UploadProductImage(stream pb.ShopProduct_UploadProductImageServer) error {
+	request, err := stream.Recv()
+	if err != nil {
+		return errors.Wrap(err, "Could not receive image info:")
+	}
+
+	info := request.GetInfo()
+	if info == nil {
+		return errors.Wrap(domain.ImageInfoMissingError, "Could not upload product image:")
+	}
+
+	imageData := new(bytes.Buffer)
+	for {
+		request, err = stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "Could not receive image chunk:")
+		}
+
+		imageData.Write(request.GetChunkData())
+		if imageData.Len() > domain.MaxImageSize {
+			return errors.Wrap(domain.ImageTooLargeError, "Could not upload product image:")
+		}
+	}
+
+	image, err := facade.app.UploadProductImage(stream.Context(), info.GetProductId(), info.GetUserId(), imageData.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "Could not upload product image:")
+	}
+
+	return stream.SendAndClose(domain.ToPbProductImage(image))
+}
+
+func (facade *ShopProductFacade) GetProductImages(ctx context.Context, in *pb.GetProductRequest) (*pb.ProductImages, error) {
+	images, err := facade.app.GetProductImages(ctx, in.GetId())
+	if err != nil {
+		return &pb.ProductImages{}, errors.Wrap(err, "Could not get product images:")
+	}
+
+	return domain.ToPbProductImages(images), nil
+}
+
+func (facade *ShopProductFacade) ReorderProductImages(ctx context.Context, in *pb.ReorderImagesRequest) (*pb.StatusResponse, error) {
+	err := facade.app.ReorderProductImages(ctx, in.GetProductId(), in.GetUserId(), in.GetImageIds())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not reorder product images:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) SetPrimaryProductImage(ctx context.Context, in *pb.ProductImageRequest) (*pb.StatusResponse, error) {
+	err := facade.app.SetPrimaryProductImage(ctx, in.GetProductId(), in.GetImageId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not set primary product image:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) DeleteProductImage(ctx context.Context, in *pb.ProductImageRequest) (*pb.StatusResponse, error) {
+	err := facade.app.DeleteProductImage(ctx, in.GetProductId(), in.GetImageId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not delete product image:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) SetProductOptions(ctx context.Context, in *pb.SetProductOptionsRequest) (*pb.StatusResponse, error) {
+	err := facade.app.SetProductOptions(ctx, in.GetProductId(), in.GetUserId(), domain.ToOptions(in.GetOptions()))
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not set product options:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) CreateVariant(ctx context.Context, in *pb.EditVariantRequest) (*pb.VariantResponse, error) {
+	id, err := facade.app.CreateVariant(ctx, domain.EditVariantRequestToVariant(in), in.GetUserId())
+	if err != nil {
+		return &pb.VariantResponse{}, errors.Wrap(err, "Could not create variant:")
+	}
+
+	return &pb.VariantResponse{Id: id}, nil
+}
+
+func (facade *ShopProductFacade) EditVariant(ctx context.Context, in *pb.EditVariantRequest) (*pb.StatusResponse, error) {
+	err := facade.app.EditVariant(ctx, domain.EditVariantRequestToVariant(in), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not edit variant:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) DeleteVariant(ctx context.Context, in *pb.VariantRequest) (*pb.StatusResponse, error) {
+	err := facade.app.DeleteVariant(ctx, in.GetProductId(), in.GetVariantId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not delete variant:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) CreateProductSale(ctx context.Context, in *pb.CreateSaleRequest) (*pb.PricingRuleResponse, error) {
+	id, err := facade.app.CreateProductSale(ctx, domain.CreateSaleRequestToSale(in), in.GetUserId())
+	if err != nil {
+		return &pb.PricingRuleResponse{}, errors.Wrap(err, "Could not create sale:")
+	}
+
+	return &pb.PricingRuleResponse{Id: id}, nil
+}
+
+func (facade *ShopProductFacade) ListProductSales(ctx context.Context, in *pb.SaleRequest) (*pb.ProductSales, error) {
+	sales, err := facade.app.ListProductSales(ctx, in.GetProductId(), in.GetUserId())
+	if err != nil {
+		return &pb.ProductSales{}, errors.Wrap(err, "Could not list sales:")
+	}
+
+	return domain.ToPbSales(sales), nil
+}
+
+func (facade *ShopProductFacade) DeleteProductSale(ctx context.Context, in *pb.SaleRequest) (*pb.StatusResponse, error) {
+	err := facade.app.DeleteProductSale(ctx, in.GetProductId(), in.GetSaleId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not delete sale:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) CreateShopDiscount(ctx context.Context, in *pb.CreateShopDiscountRequest) (*pb.PricingRuleResponse, error) {
+	id, err := facade.app.CreateShopDiscount(ctx, domain.CreateShopDiscountRequestToDiscount(in), in.GetUserId())
+	if err != nil {
+		return &pb.PricingRuleResponse{}, errors.Wrap(err, "Could not create shop discount:")
+	}
+
+	return &pb.PricingRuleResponse{Id: id}, nil
+}
+
+func (facade *ShopProductFacade) ListShopDiscounts(ctx context.Context, in *pb.ShopPricingRequest) (*pb.ShopDiscounts, error) {
+	discounts, err := facade.app.ListShopDiscounts(ctx, in.GetShopId(), in.GetUserId())
+	if err != nil {
+		return &pb.ShopDiscounts{}, errors.Wrap(err, "Could not list shop discounts:")
+	}
+
+	return domain.ToPbShopDiscounts(discounts), nil
+}
+
+func (facade *ShopProductFacade) DeleteShopDiscount(ctx context.Context, in *pb.ShopDiscountRequest) (*pb.StatusResponse, error) {
+	err := facade.app.DeleteShopDiscount(ctx, in.GetShopId(), in.GetDiscountId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not delete shop discount:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) CreatePromoCode(ctx context.Context, in *pb.CreatePromoCodeRequest) (*pb.PricingRuleResponse, error) {
+	id, err := facade.app.CreatePromoCode(ctx, domain.CreatePromoCodeRequestToPromoCode(in), in.GetUserId())
+	if err != nil {
+		return &pb.PricingRuleResponse{}, errors.Wrap(err, "Could not create promo code:")
+	}
+
+	return &pb.PricingRuleResponse{Id: id}, nil
+}
+
+func (facade *ShopProductFacade) ListPromoCodes(ctx context.Context, in *pb.ShopPricingRequest) (*pb.PromoCodes, error) {
+	promoCodes, err := facade.app.ListPromoCodes(ctx, in.GetShopId(), in.GetUserId())
+	if err != nil {
+		return &pb.PromoCodes{}, errors.Wrap(err, "Could not list promo codes:")
+	}
+
+	return domain.ToPbPromoCodes(promoCodes), nil
+}
+
+func (facade *ShopProductFacade) DeletePromoCode(ctx context.Context, in *pb.PromoCodeRequest) (*pb.StatusResponse, error) {
+	err := facade.app.DeletePromoCode(ctx, in.GetShopId(), in.GetPromoCodeId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not delete promo code:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) RedeemPromoCode(ctx context.Context, in *pb.RedeemPromoCodeRequest) (*pb.PromoRedemption, error) {
+	redemption, err := facade.app.RedeemPromoCode(ctx, in.GetCode(), in.GetShopId(), in.GetUserId(), domain.ToMoney(in.GetSubtotal()))
+	if err != nil {
+		return &pb.PromoRedemption{}, errors.Wrap(err, "Could not redeem promo code:")
+	}
+
+	return domain.ToPbPromoRedemption(redemption), nil
+}
+
+func (facade *ShopProductFacade) ReleasePromoRedemption(ctx context.Context, in *pb.PromoRedemptionRequest) (*pb.StatusResponse, error) {
+	err := facade.app.ReleasePromoRedemption(ctx, in.GetId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not release promo code redemption:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) ListPriceHistory(ctx context.Context, in *pb.ListPriceHistoryRequest) (*pb.PriceHistory, error) {
+	page, err := domain.NewPriceHistoryPage(in.GetLimit(), in.GetCursor(), in.GetPage())
+	if err != nil {
+		return &pb.PriceHistory{}, errors.Wrap(err, "Could not list price history:")
+	}
+
+	changes, nextCursor, err := facade.app.ListPriceHistory(ctx, in.GetProductId(), page)
+	if err != nil {
+		return &pb.PriceHistory{}, errors.Wrap(err, "Could not list price history:")
+	}
+
+	return domain.ToPbPriceHistory(changes, nextCursor), nil
+}
+
+func (facade *ShopProductFacade) ListWishlists(ctx context.Context, in *pb.ListWishlistsRequest) (*pb.Wishlists, error) {
+	wishlists, err := facade.app.ListWishlists(ctx, in.GetOwnerId(), in.GetUserId())
+	if err != nil {
+		return &pb.Wishlists{}, errors.Wrap(err, "Could not list wishlists:")
+	}
+
+	return domain.ToPbWishlists(wishlists), nil
+}
+
+func (facade *ShopProductFacade) GetWishlist(ctx context.Context, in *pb.WishlistRequest) (*pb.Wishlist, error) {
+	var wishlist domain.Wishlist
+	var err error
+	if in.GetToken() != "" {
+		wishlist, err = facade.app.GetSharedWishlist(ctx, in.GetToken(), in.GetUserId(), in.GetCurrency())
+	} else {
+		wishlist, err = facade.app.GetWishlist(ctx, in.GetId(), in.GetUserId(), in.GetCurrency())
+	}
+	if err != nil {
+		return &pb.Wishlist{}, errors.Wrap(err, "Could not get wishlist:")
+	}
+
+	return domain.ToPbWishlist(wishlist), nil
+}
+
+func (facade *ShopProductFacade) CreateWishlist(ctx context.Context, in *pb.EditWishlistRequest) (*pb.WishlistResponse, error) {
+	id, err := facade.app.CreateWishlist(ctx, domain.EditWishlistRequestToWishlist(in))
+	if err != nil {
+		return &pb.WishlistResponse{}, errors.Wrap(err, "Could not create wishlist:")
+	}
+
+	return &pb.WishlistResponse{Id: id}, nil
+}
+
+func (facade *ShopProductFacade) EditWishlist(ctx context.Context, in *pb.EditWishlistRequest) (*pb.StatusResponse, error) {
+	err := facade.app.EditWishlist(ctx, domain.EditWishlistRequestToWishlist(in), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not edit wishlist:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) DeleteWishlist(ctx context.Context, in *pb.WishlistRequest) (*pb.StatusResponse, error) {
+	err := facade.app.DeleteWishlist(ctx, in.GetId(), in.GetUserId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not delete wishlist:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) SaveProduct(ctx context.Context, in *pb.SaveProductRequest) (*pb.WishlistResponse, error) {
+	wishlistID, err := facade.app.SaveProduct(ctx, in.GetProductId(), in.GetUserId(), in.GetWishlistId())
+	if err != nil {
+		return &pb.WishlistResponse{}, errors.Wrap(err, "Could not save product:")
+	}
+
+	return &pb.WishlistResponse{Id: wishlistID}, nil
+}
+
+func (facade *ShopProductFacade) UnsaveProduct(ctx context.Context, in *pb.SaveProductRequest) (*pb.StatusResponse, error) {
+	err := facade.app.UnsaveProduct(ctx, in.GetProductId(), in.GetUserId(), in.GetWishlistId())
+	if err != nil {
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not unsave product:")
+	}
+
+	return &pb.StatusResponse{
+		Code:   200,
+		Status: "success",
+	}, nil
+}
+
+func (facade *ShopProductFacade) GetExchangeRates(ctx context.Context, in *pb.ExchangeRatesRequest) (*pb.ExchangeRates, error) {
+	rates, err := facade.app.GetExchangeRates(ctx)
+	if err != nil {
+		return &pb.ExchangeRates{}, errors.Wrap(err, "Could not get exchange rates:")
 	}
 
-	_, err := facade.app.CreateShop(ctx, shopInput)
+	return domain.ToPbExchangeRates(rates), nil
+}
+
+func (facade *ShopProductFacade) UpdateExchangeRates(ctx context.Context, in *pb.UpdateExchangeRatesRequest) (*pb.StatusResponse, error) {
+	base, rates := domain.ToExchangeRates(in.GetRates())
+	err := facade.app.UpdateExchangeRates(ctx, base, rates, in.GetUserId())
 	if err != nil {
-		return nil, errors.Wrap(err, "Could not create shop:")
+		return &pb.StatusResponse{}, errors.Wrap(err, "Could not update exchange rates:")
 	}
 
 	return &pb.StatusResponse{
@@ -41,3 +940,94 @@ func (facade *ShopProductFacade) CreateShop(ctx context.Context, in *pb.CreateSh
 		Status: "success",
 	}, nil
 }
+
+// ImportProducts receives import info followed by catalog file's bytes, import starts when stream is closed
+func (facade *ShopProductFacade) ImportProducts(stream pb.ShopProduct_ImportProductsServer) error {
+	request, err := stream.Recv()
+	if err != nil {
+		return errors.Wrap(err, "Could not receive import info:")
+	}
+
+	info := request.GetInfo()
+	if info == nil {
+		return errors.Wrap(domain.ImportInfoMissingError, "Could not import products:")
+	}
+
+	catalogData := new(bytes.Buffer)
+	for {
+		request, err = stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "Could not receive catalog chunk:")
+		}
+
+		catalogData.Write(request.GetChunkData())
+		if catalogData.Len() > domain.MaxImportFileSize {
+			return errors.Wrap(domain.ImportFileTooLargeError, "Could not import products:")
+		}
+	}
+
+	productImport, err := facade.app.ImportProducts(stream.Context(), info.GetShopId(), info.GetUserId(), info.GetFormat(),
+		info.GetDryRun(), catalogData.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "Could not import products:")
+	}
+
+	return stream.SendAndClose(domain.ToPbProductImport(productImport))
+}
+
+func (facade *ShopProductFacade) GetProductImport(ctx context.Context, in *pb.ProductImportRequest) (*pb.ProductImport, error) {
+	productImport, err := facade.app.GetProductImport(ctx, in.GetShopId(), in.GetImportId(), in.GetUserId())
+	if err != nil {
+		return &pb.ProductImport{}, errors.Wrap(err, "Could not get product import:")
+	}
+
+	return domain.ToPbProductImport(productImport), nil
+}
+
+// ExportProducts sends shop's catalog file in chunks of domain.CatalogChunkSize
+func (facade *ShopProductFacade) ExportProducts(in *pb.ExportProductsRequest, stream pb.ShopProduct_ExportProductsServer) error {
+	data, err := facade.app.ExportProducts(stream.Context(), in.GetShopId(), in.GetUserId(), in.GetFormat())
+	if err != nil {
+		return errors.Wrap(err, "Could not export products:")
+	}
+
+	for len(data) > 0 {
+		chunkSize := domain.CatalogChunkSize
+		if len(data) < chunkSize {
+			chunkSize = len(data)
+		}
+
+		err = stream.Send(&pb.CatalogChunk{ChunkData: data[:chunkSize]})
+		if err != nil {
+			return errors.Wrap(err, "Could not send catalog chunk:")
+		}
+		data = data[chunkSize:]
+	}
+
+	return nil
+}
+
+func (facade *ShopProductFacade) GetMarketplaceFeed(in *pb.MarketplaceFeedRequest, stream pb.ShopProduct_GetMarketplaceFeedServer) error {
+	document, err := facade.app.GetMarketplaceFeed(stream.Context(), in.GetShopId(), in.GetFormat())
+	if err != nil {
+		return errors.Wrap(err, "Could not get marketplace feed:")
+	}
+
+	for len(document) > 0 {
+		chunkSize := domain.CatalogChunkSize
+		if len(document) < chunkSize {
+			chunkSize = len(document)
+		}
+
+		err = stream.Send(&pb.CatalogChunk{ChunkData: document[:chunkSize]})
+		if err != nil {
+			return errors.Wrap(err, "Could not send feed chunk:")
+		}
+		document = document[chunkSize:]
+	}
+
+	return nil
+}
@@ -18,12 +18,91 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ShopProductClient interface {
-	CreateShop(ctx context.Context, in *CreateShopRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	CreateShop(ctx context.Context, in *CreateShopRequest, opts ...grpc.CallOption) (*CreateShopResponse, error)
 	EditShop(ctx context.Context, in *EditShopRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 	GetShop(ctx context.Context, in *GetShopRequest, opts ...grpc.CallOption) (*Shop, error)
-	CreateProduct(ctx context.Context, in *CreateProductRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	CreateProduct(ctx context.Context, in *CreateProductRequest, opts ...grpc.CallOption) (*CreateProductResponse, error)
 	EditProduct(ctx context.Context, in *EditProductRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 	GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*Product, error)
+	GetProductsByIds(ctx context.Context, in *ProductIdsRequest, opts ...grpc.CallOption) (*ProductsList, error)
+	ListProductsByShop(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ProductsList, error)
+	GetCategories(ctx context.Context, in *CategoriesRequest, opts ...grpc.CallOption) (*CategoryTree, error)
+	CreateCategory(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (*CategoryResponse, error)
+	EditCategory(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ListProductsByCategory(ctx context.Context, in *ListCategoryProductsRequest, opts ...grpc.CallOption) (*ProductsList, error)
+	AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*InventoryMovement, error)
+	ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*Reservation, error)
+	GetReservation(ctx context.Context, in *ReservationRequest, opts ...grpc.CallOption) (*Reservation, error)
+	CommitReservation(ctx context.Context, in *ReservationRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ReleaseReservation(ctx context.Context, in *ReservationRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ListInventoryMovements(ctx context.Context, in *ListMovementsRequest, opts ...grpc.CallOption) (*InventoryMovements, error)
+	GetCart(ctx context.Context, in *CartRequest, opts ...grpc.CallOption) (*Cart, error)
+	AddToCart(ctx context.Context, in *CartItemRequest, opts ...grpc.CallOption) (*Cart, error)
+	UpdateCartItem(ctx context.Context, in *CartItemRequest, opts ...grpc.CallOption) (*Cart, error)
+	RemoveFromCart(ctx context.Context, in *CartItemRequest, opts ...grpc.CallOption) (*Cart, error)
+	MergeCarts(ctx context.Context, in *MergeCartsRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	SearchProducts(ctx context.Context, in *SearchProductsRequest, opts ...grpc.CallOption) (*SearchProductsResponse, error)
+	GetFeed(ctx context.Context, in *FeedRequest, opts ...grpc.CallOption) (*ProductsList, error)
+	FollowShop(ctx context.Context, in *ShopFollowRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	UnfollowShop(ctx context.Context, in *ShopFollowRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	CreateReview(ctx context.Context, in *CreateReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error)
+	EditReview(ctx context.Context, in *EditReviewRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ReviewsList, error)
+	DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	PurgeProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	RestoreProduct(ctx context.Context, in *RestoreProductRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ListDeletedProducts(ctx context.Context, in *ShopRequest, opts ...grpc.CallOption) (*ProductsList, error)
+	DeleteShop(ctx context.Context, in *ShopRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	GetRelatedProducts(ctx context.Context, in *RelatedProductsRequest, opts ...grpc.CallOption) (*ProductsList, error)
+	RestoreShop(ctx context.Context, in *ShopRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	GetShopAnalytics(ctx context.Context, in *ShopAnalyticsRequest, opts ...grpc.CallOption) (*ShopAnalytics, error)
+	AskQuestion(ctx context.Context, in *AskQuestionRequest, opts ...grpc.CallOption) (*QuestionResponse, error)
+	AnswerQuestion(ctx context.Context, in *AnswerQuestionRequest, opts ...grpc.CallOption) (*QuestionResponse, error)
+	SetOfficialAnswer(ctx context.Context, in *OfficialAnswerRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	VoteQuestion(ctx context.Context, in *QuestionVoteRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	UnvoteQuestion(ctx context.Context, in *QuestionVoteRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ListQuestions(ctx context.Context, in *ListQuestionsRequest, opts ...grpc.CallOption) (*QuestionsList, error)
+	ExportUserData(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*ExportSections, error)
+	InviteShopManager(ctx context.Context, in *InviteShopManagerRequest, opts ...grpc.CallOption) (*InvitationResponse, error)
+	RemoveShopManager(ctx context.Context, in *RemoveShopManagerRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	GetShopInvitations(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*ShopInvitations, error)
+	AcceptShopInvitation(ctx context.Context, in *InvitationRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	DeclineShopInvitation(ctx context.Context, in *InvitationRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	UploadProductImage(ctx context.Context, opts ...grpc.CallOption) (ShopProduct_UploadProductImageClient, error)
+	GetProductImages(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*ProductImages, error)
+	ReorderProductImages(ctx context.Context, in *ReorderImagesRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	SetPrimaryProductImage(ctx context.Context, in *ProductImageRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	DeleteProductImage(ctx context.Context, in *ProductImageRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	SetProductOptions(ctx context.Context, in *SetProductOptionsRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	CreateVariant(ctx context.Context, in *EditVariantRequest, opts ...grpc.CallOption) (*VariantResponse, error)
+	EditVariant(ctx context.Context, in *EditVariantRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	DeleteVariant(ctx context.Context, in *VariantRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	CreateProductSale(ctx context.Context, in *CreateSaleRequest, opts ...grpc.CallOption) (*PricingRuleResponse, error)
+	ListProductSales(ctx context.Context, in *SaleRequest, opts ...grpc.CallOption) (*ProductSales, error)
+	DeleteProductSale(ctx context.Context, in *SaleRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	CreateShopDiscount(ctx context.Context, in *CreateShopDiscountRequest, opts ...grpc.CallOption) (*PricingRuleResponse, error)
+	ListShopDiscounts(ctx context.Context, in *ShopPricingRequest, opts ...grpc.CallOption) (*ShopDiscounts, error)
+	DeleteShopDiscount(ctx context.Context, in *ShopDiscountRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	CreatePromoCode(ctx context.Context, in *CreatePromoCodeRequest, opts ...grpc.CallOption) (*PricingRuleResponse, error)
+	ListPromoCodes(ctx context.Context, in *ShopPricingRequest, opts ...grpc.CallOption) (*PromoCodes, error)
+	DeletePromoCode(ctx context.Context, in *PromoCodeRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	RedeemPromoCode(ctx context.Context, in *RedeemPromoCodeRequest, opts ...grpc.CallOption) (*PromoRedemption, error)
+	ReleasePromoRedemption(ctx context.Context, in *PromoRedemptionRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ListPriceHistory(ctx context.Context, in *ListPriceHistoryRequest, opts ...grpc.CallOption) (*PriceHistory, error)
+	GetExchangeRates(ctx context.Context, in *ExchangeRatesRequest, opts ...grpc.CallOption) (*ExchangeRates, error)
+	UpdateExchangeRates(ctx context.Context, in *UpdateExchangeRatesRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	ImportProducts(ctx context.Context, opts ...grpc.CallOption) (ShopProduct_ImportProductsClient, error)
+	GetProductImport(ctx context.Context, in *ProductImportRequest, opts ...grpc.CallOption) (*ProductImport, error)
+	ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (ShopProduct_ExportProductsClient, error)
+	GetMarketplaceFeed(ctx context.Context, in *MarketplaceFeedRequest, opts ...grpc.CallOption) (ShopProduct_GetMarketplaceFeedClient, error)
+	ListWishlists(ctx context.Context, in *ListWishlistsRequest, opts ...grpc.CallOption) (*Wishlists, error)
+	GetWishlist(ctx context.Context, in *WishlistRequest, opts ...grpc.CallOption) (*Wishlist, error)
+	CreateWishlist(ctx context.Context, in *EditWishlistRequest, opts ...grpc.CallOption) (*WishlistResponse, error)
+	EditWishlist(ctx context.Context, in *EditWishlistRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	DeleteWishlist(ctx context.Context, in *WishlistRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	SaveProduct(ctx context.Context, in *SaveProductRequest, opts ...grpc.CallOption) (*WishlistResponse, error)
+	UnsaveProduct(ctx context.Context, in *SaveProductRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 }
 
 type shopProductClient struct {
@@ -34,8 +113,8 @@ func NewShopProductClient(cc grpc.ClientConnInterface) ShopProductClient {
 	return &shopProductClient{cc}
 }
 
-func (c *shopProductClient) CreateShop(ctx context.Context, in *CreateShopRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
-	out := new(StatusResponse)
+func (c *shopProductClient) CreateShop(ctx context.Context, in *CreateShopRequest, opts ...grpc.CallOption) (*CreateShopResponse, error) {
+	out := new(CreateShopResponse)
 	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/CreateShop", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -61,8 +140,8 @@ func (c *shopProductClient) GetShop(ctx context.Context, in *GetShopRequest, opt
 	return out, nil
 }
 
-func (c *shopProductClient) CreateProduct(ctx context.Context, in *CreateProductRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
-	out := new(StatusResponse)
+func (c *shopProductClient) CreateProduct(ctx context.Context, in *CreateProductRequest, opts ...grpc.CallOption) (*CreateProductResponse, error) {
+	out := new(CreateProductResponse)
 	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/CreateProduct", in, out, opts...)
 	if err != nil {
 		return nil, err
@@ -70,176 +149,2743 @@ func (c *shopProductClient) CreateProduct(ctx context.Context, in *CreateProduct
 	return out, nil
 }
 
-func (c *shopProductClient) EditProduct(ctx context.Context, in *EditProductRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
-	out := new(StatusResponse)
-	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/EditProduct", in, out, opts...)
-	if err != nil {
+func (c *shopProductClient) EditProduct(ctx context.Context, in *EditProductRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/EditProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*Product, error) {
+	out := new(Product)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/GetProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) GetProductsByIds(ctx context.Context, in *ProductIdsRequest, opts ...grpc.CallOption) (*ProductsList, error) {
+	out := new(ProductsList)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/GetProductsByIds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) ListProductsByShop(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ProductsList, error) {
+	out := new(ProductsList)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/ListProductsByShop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) GetCategories(ctx context.Context, in *CategoriesRequest, opts ...grpc.CallOption) (*CategoryTree, error) {
+	out := new(CategoryTree)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/GetCategories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) CreateCategory(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (*CategoryResponse, error) {
+	out := new(CategoryResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/CreateCategory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) EditCategory(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/EditCategory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) DeleteCategory(ctx context.Context, in *DeleteCategoryRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/DeleteCategory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) ListProductsByCategory(ctx context.Context, in *ListCategoryProductsRequest, opts ...grpc.CallOption) (*ProductsList, error) {
+	out := new(ProductsList)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/ListProductsByCategory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) AdjustStock(ctx context.Context, in *AdjustStockRequest, opts ...grpc.CallOption) (*InventoryMovement, error) {
+	out := new(InventoryMovement)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/AdjustStock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) ReserveStock(ctx context.Context, in *ReserveStockRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/ReserveStock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) GetReservation(ctx context.Context, in *ReservationRequest, opts ...grpc.CallOption) (*Reservation, error) {
+	out := new(Reservation)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/GetReservation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) CommitReservation(ctx context.Context, in *ReservationRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/CommitReservation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) ReleaseReservation(ctx context.Context, in *ReservationRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/ReleaseReservation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) ListInventoryMovements(ctx context.Context, in *ListMovementsRequest, opts ...grpc.CallOption) (*InventoryMovements, error) {
+	out := new(InventoryMovements)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/ListInventoryMovements", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) GetCart(ctx context.Context, in *CartRequest, opts ...grpc.CallOption) (*Cart, error) {
+	out := new(Cart)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/GetCart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) AddToCart(ctx context.Context, in *CartItemRequest, opts ...grpc.CallOption) (*Cart, error) {
+	out := new(Cart)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/AddToCart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) UpdateCartItem(ctx context.Context, in *CartItemRequest, opts ...grpc.CallOption) (*Cart, error) {
+	out := new(Cart)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/UpdateCartItem", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) RemoveFromCart(ctx context.Context, in *CartItemRequest, opts ...grpc.CallOption) (*Cart, error) {
+	out := new(Cart)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/RemoveFromCart", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) MergeCarts(ctx context.Context, in *MergeCartsRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/MergeCarts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) SearchProducts(ctx context.Context, in *SearchProductsRequest, opts ...grpc.CallOption) (*SearchProductsResponse, error) {
+	out := new(SearchProductsResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/SearchProducts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) GetFeed(ctx context.Context, in *FeedRequest, opts ...grpc.CallOption) (*ProductsList, error) {
+	out := new(ProductsList)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/GetFeed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) FollowShop(ctx context.Context, in *ShopFollowRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/FollowShop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) UnfollowShop(ctx context.Context, in *ShopFollowRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/UnfollowShop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) CreateReview(ctx context.Context, in *CreateReviewRequest, opts ...grpc.CallOption) (*ReviewResponse, error) {
+	out := new(ReviewResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/CreateReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) EditReview(ctx context.Context, in *EditReviewRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/EditReview", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) ListReviews(ctx context.Context, in *ListReviewsRequest, opts ...grpc.CallOption) (*ReviewsList, error) {
+	out := new(ReviewsList)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/ListReviews", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) DeleteProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/DeleteProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) PurgeProduct(ctx context.Context, in *DeleteProductRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/PurgeProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) RestoreProduct(ctx context.Context, in *RestoreProductRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/RestoreProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) ListDeletedProducts(ctx context.Context, in *ShopRequest, opts ...grpc.CallOption) (*ProductsList, error) {
+	out := new(ProductsList)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/ListDeletedProducts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) DeleteShop(ctx context.Context, in *ShopRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/DeleteShop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) GetRelatedProducts(ctx context.Context, in *RelatedProductsRequest, opts ...grpc.CallOption) (*ProductsList, error) {
+	out := new(ProductsList)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/GetRelatedProducts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) RestoreShop(ctx context.Context, in *ShopRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/RestoreShop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) GetShopAnalytics(ctx context.Context, in *ShopAnalyticsRequest, opts ...grpc.CallOption) (*ShopAnalytics, error) {
+	out := new(ShopAnalytics)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/GetShopAnalytics", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) AskQuestion(ctx context.Context, in *AskQuestionRequest, opts ...grpc.CallOption) (*QuestionResponse, error) {
+	out := new(QuestionResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/AskQuestion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) AnswerQuestion(ctx context.Context, in *AnswerQuestionRequest, opts ...grpc.CallOption) (*QuestionResponse, error) {
+	out := new(QuestionResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/AnswerQuestion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) SetOfficialAnswer(ctx context.Context, in *OfficialAnswerRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/SetOfficialAnswer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) VoteQuestion(ctx context.Context, in *QuestionVoteRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/VoteQuestion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) UnvoteQuestion(ctx context.Context, in *QuestionVoteRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/UnvoteQuestion", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) ListQuestions(ctx context.Context, in *ListQuestionsRequest, opts ...grpc.CallOption) (*QuestionsList, error) {
+	out := new(QuestionsList)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/ListQuestions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) ExportUserData(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*ExportSections, error) {
+	out := new(ExportSections)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/ExportUserData", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) InviteShopManager(ctx context.Context, in *InviteShopManagerRequest, opts ...grpc.CallOption) (*InvitationResponse, error) {
+	out := new(InvitationResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/InviteShopManager", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) RemoveShopManager(ctx context.Context, in *RemoveShopManagerRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/RemoveShopManager", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) GetShopInvitations(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*ShopInvitations, error) {
+	out := new(ShopInvitations)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/GetShopInvitations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) AcceptShopInvitation(ctx context.Context, in *InvitationRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/AcceptShopInvitation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) DeclineShopInvitation(ctx context.Context, in *InvitationRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/DeclineShopInvitation", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) UploadProductImage(ctx context.Context, opts ...grpc.CallOption) (ShopProduct_UploadProductImageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ShopProduct_ServiceDesc.Streams[0], "/shopProduct.ShopProduct/UploadProductImage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &shopProductUploadProductImageClient{stream}
+	return x, nil
+}
+
+type ShopProduct_UploadProductImageClient interface {
+	Send(*UploadImageRequest) error
+	CloseAndRecv() (*ProductImage, error)
+	grpc.ClientStream
+}
+
+type shopProductUploadProductImageClient struct {
+	grpc.ClientStream
+}
+
+func (x *shopProductUploadProductImageClient) Send(m *UploadImageRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *shopProductUploadProductImageClient) CloseAndRecv() (*ProductImage, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ProductImage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *shopProductClient) GetProductImages(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*ProductImages, error) {
+	out := new(ProductImages)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/GetProductImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) ReorderProductImages(ctx context.Context, in *ReorderImagesRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/ReorderProductImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) SetPrimaryProductImage(ctx context.Context, in *ProductImageRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/SetPrimaryProductImage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) DeleteProductImage(ctx context.Context, in *ProductImageRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/DeleteProductImage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) SetProductOptions(ctx context.Context, in *SetProductOptionsRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/SetProductOptions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) CreateVariant(ctx context.Context, in *EditVariantRequest, opts ...grpc.CallOption) (*VariantResponse, error) {
+	out := new(VariantResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/CreateVariant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) EditVariant(ctx context.Context, in *EditVariantRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/EditVariant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) DeleteVariant(ctx context.Context, in *VariantRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/DeleteVariant", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) CreateProductSale(ctx context.Context, in *CreateSaleRequest, opts ...grpc.CallOption) (*PricingRuleResponse, error) {
+	out := new(PricingRuleResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/CreateProductSale", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) ListProductSales(ctx context.Context, in *SaleRequest, opts ...grpc.CallOption) (*ProductSales, error) {
+	out := new(ProductSales)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/ListProductSales", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) DeleteProductSale(ctx context.Context, in *SaleRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/DeleteProductSale", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) CreateShopDiscount(ctx context.Context, in *CreateShopDiscountRequest, opts ...grpc.CallOption) (*PricingRuleResponse, error) {
+	out := new(PricingRuleResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/CreateShopDiscount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) ListShopDiscounts(ctx context.Context, in *ShopPricingRequest, opts ...grpc.CallOption) (*ShopDiscounts, error) {
+	out := new(ShopDiscounts)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/ListShopDiscounts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) DeleteShopDiscount(ctx context.Context, in *ShopDiscountRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/DeleteShopDiscount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) CreatePromoCode(ctx context.Context, in *CreatePromoCodeRequest, opts ...grpc.CallOption) (*PricingRuleResponse, error) {
+	out := new(PricingRuleResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/CreatePromoCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) ListPromoCodes(ctx context.Context, in *ShopPricingRequest, opts ...grpc.CallOption) (*PromoCodes, error) {
+	out := new(PromoCodes)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/ListPromoCodes", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) DeletePromoCode(ctx context.Context, in *PromoCodeRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/DeletePromoCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) RedeemPromoCode(ctx context.Context, in *RedeemPromoCodeRequest, opts ...grpc.CallOption) (*PromoRedemption, error) {
+	out := new(PromoRedemption)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/RedeemPromoCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) ReleasePromoRedemption(ctx context.Context, in *PromoRedemptionRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/ReleasePromoRedemption", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) ListPriceHistory(ctx context.Context, in *ListPriceHistoryRequest, opts ...grpc.CallOption) (*PriceHistory, error) {
+	out := new(PriceHistory)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/ListPriceHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) GetExchangeRates(ctx context.Context, in *ExchangeRatesRequest, opts ...grpc.CallOption) (*ExchangeRates, error) {
+	out := new(ExchangeRates)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/GetExchangeRates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) UpdateExchangeRates(ctx context.Context, in *UpdateExchangeRatesRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/UpdateExchangeRates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) ImportProducts(ctx context.Context, opts ...grpc.CallOption) (ShopProduct_ImportProductsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ShopProduct_ServiceDesc.Streams[1], "/shopProduct.ShopProduct/ImportProducts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &shopProductImportProductsClient{stream}
+	return x, nil
+}
+
+type ShopProduct_ImportProductsClient interface {
+	Send(*ImportProductsRequest) error
+	CloseAndRecv() (*ProductImport, error)
+	grpc.ClientStream
+}
+
+type shopProductImportProductsClient struct {
+	grpc.ClientStream
+}
+
+func (x *shopProductImportProductsClient) Send(m *ImportProductsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *shopProductImportProductsClient) CloseAndRecv() (*ProductImport, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(ProductImport)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *shopProductClient) GetProductImport(ctx context.Context, in *ProductImportRequest, opts ...grpc.CallOption) (*ProductImport, error) {
+	out := new(ProductImport)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/GetProductImport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) ExportProducts(ctx context.Context, in *ExportProductsRequest, opts ...grpc.CallOption) (ShopProduct_ExportProductsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ShopProduct_ServiceDesc.Streams[2], "/shopProduct.ShopProduct/ExportProducts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &shopProductExportProductsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ShopProduct_ExportProductsClient interface {
+	Recv() (*CatalogChunk, error)
+	grpc.ClientStream
+}
+
+type shopProductExportProductsClient struct {
+	grpc.ClientStream
+}
+
+func (x *shopProductExportProductsClient) Recv() (*CatalogChunk, error) {
+	m := new(CatalogChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *shopProductClient) GetMarketplaceFeed(ctx context.Context, in *MarketplaceFeedRequest, opts ...grpc.CallOption) (ShopProduct_GetMarketplaceFeedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ShopProduct_ServiceDesc.Streams[3], "/shopProduct.ShopProduct/GetMarketplaceFeed", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &shopProductGetMarketplaceFeedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ShopProduct_GetMarketplaceFeedClient interface {
+	Recv() (*CatalogChunk, error)
+	grpc.ClientStream
+}
+
+type shopProductGetMarketplaceFeedClient struct {
+	grpc.ClientStream
+}
+
+func (x *shopProductGetMarketplaceFeedClient) Recv() (*CatalogChunk, error) {
+	m := new(CatalogChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *shopProductClient) ListWishlists(ctx context.Context, in *ListWishlistsRequest, opts ...grpc.CallOption) (*Wishlists, error) {
+	out := new(Wishlists)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/ListWishlists", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) GetWishlist(ctx context.Context, in *WishlistRequest, opts ...grpc.CallOption) (*Wishlist, error) {
+	out := new(Wishlist)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/GetWishlist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) CreateWishlist(ctx context.Context, in *EditWishlistRequest, opts ...grpc.CallOption) (*WishlistResponse, error) {
+	out := new(WishlistResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/CreateWishlist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) EditWishlist(ctx context.Context, in *EditWishlistRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/EditWishlist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) DeleteWishlist(ctx context.Context, in *WishlistRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/DeleteWishlist", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) SaveProduct(ctx context.Context, in *SaveProductRequest, opts ...grpc.CallOption) (*WishlistResponse, error) {
+	out := new(WishlistResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/SaveProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *shopProductClient) UnsaveProduct(ctx context.Context, in *SaveProductRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/UnsaveProduct", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ShopProductServer is the server API for ShopProduct service.
+// All implementations must embed UnimplementedShopProductServer
+// for forward compatibility
+type ShopProductServer interface {
+	CreateShop(context.Context, *CreateShopRequest) (*CreateShopResponse, error)
+	EditShop(context.Context, *EditShopRequest) (*StatusResponse, error)
+	GetShop(context.Context, *GetShopRequest) (*Shop, error)
+	CreateProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error)
+	EditProduct(context.Context, *EditProductRequest) (*StatusResponse, error)
+	GetProduct(context.Context, *GetProductRequest) (*Product, error)
+	GetProductsByIds(context.Context, *ProductIdsRequest) (*ProductsList, error)
+	ListProductsByShop(context.Context, *ListProductsRequest) (*ProductsList, error)
+	GetCategories(context.Context, *CategoriesRequest) (*CategoryTree, error)
+	CreateCategory(context.Context, *CategoryRequest) (*CategoryResponse, error)
+	EditCategory(context.Context, *CategoryRequest) (*StatusResponse, error)
+	DeleteCategory(context.Context, *DeleteCategoryRequest) (*StatusResponse, error)
+	ListProductsByCategory(context.Context, *ListCategoryProductsRequest) (*ProductsList, error)
+	AdjustStock(context.Context, *AdjustStockRequest) (*InventoryMovement, error)
+	ReserveStock(context.Context, *ReserveStockRequest) (*Reservation, error)
+	GetReservation(context.Context, *ReservationRequest) (*Reservation, error)
+	CommitReservation(context.Context, *ReservationRequest) (*StatusResponse, error)
+	ReleaseReservation(context.Context, *ReservationRequest) (*StatusResponse, error)
+	ListInventoryMovements(context.Context, *ListMovementsRequest) (*InventoryMovements, error)
+	GetCart(context.Context, *CartRequest) (*Cart, error)
+	AddToCart(context.Context, *CartItemRequest) (*Cart, error)
+	UpdateCartItem(context.Context, *CartItemRequest) (*Cart, error)
+	RemoveFromCart(context.Context, *CartItemRequest) (*Cart, error)
+	MergeCarts(context.Context, *MergeCartsRequest) (*StatusResponse, error)
+	SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error)
+	GetFeed(context.Context, *FeedRequest) (*ProductsList, error)
+	FollowShop(context.Context, *ShopFollowRequest) (*StatusResponse, error)
+	UnfollowShop(context.Context, *ShopFollowRequest) (*StatusResponse, error)
+	CreateReview(context.Context, *CreateReviewRequest) (*ReviewResponse, error)
+	EditReview(context.Context, *EditReviewRequest) (*StatusResponse, error)
+	ListReviews(context.Context, *ListReviewsRequest) (*ReviewsList, error)
+	DeleteProduct(context.Context, *DeleteProductRequest) (*StatusResponse, error)
+	PurgeProduct(context.Context, *DeleteProductRequest) (*StatusResponse, error)
+	RestoreProduct(context.Context, *RestoreProductRequest) (*StatusResponse, error)
+	ListDeletedProducts(context.Context, *ShopRequest) (*ProductsList, error)
+	DeleteShop(context.Context, *ShopRequest) (*StatusResponse, error)
+	GetRelatedProducts(context.Context, *RelatedProductsRequest) (*ProductsList, error)
+	RestoreShop(context.Context, *ShopRequest) (*StatusResponse, error)
+	GetShopAnalytics(context.Context, *ShopAnalyticsRequest) (*ShopAnalytics, error)
+	AskQuestion(context.Context, *AskQuestionRequest) (*QuestionResponse, error)
+	AnswerQuestion(context.Context, *AnswerQuestionRequest) (*QuestionResponse, error)
+	SetOfficialAnswer(context.Context, *OfficialAnswerRequest) (*StatusResponse, error)
+	VoteQuestion(context.Context, *QuestionVoteRequest) (*StatusResponse, error)
+	UnvoteQuestion(context.Context, *QuestionVoteRequest) (*StatusResponse, error)
+	ListQuestions(context.Context, *ListQuestionsRequest) (*QuestionsList, error)
+	ExportUserData(context.Context, *UserRequest) (*ExportSections, error)
+	InviteShopManager(context.Context, *InviteShopManagerRequest) (*InvitationResponse, error)
+	RemoveShopManager(context.Context, *RemoveShopManagerRequest) (*StatusResponse, error)
+	GetShopInvitations(context.Context, *UserRequest) (*ShopInvitations, error)
+	AcceptShopInvitation(context.Context, *InvitationRequest) (*StatusResponse, error)
+	DeclineShopInvitation(context.Context, *InvitationRequest) (*StatusResponse, error)
+	UploadProductImage(ShopProduct_UploadProductImageServer) error
+	GetProductImages(context.Context, *GetProductRequest) (*ProductImages, error)
+	ReorderProductImages(context.Context, *ReorderImagesRequest) (*StatusResponse, error)
+	SetPrimaryProductImage(context.Context, *ProductImageRequest) (*StatusResponse, error)
+	DeleteProductImage(context.Context, *ProductImageRequest) (*StatusResponse, error)
+	SetProductOptions(context.Context, *SetProductOptionsRequest) (*StatusResponse, error)
+	CreateVariant(context.Context, *EditVariantRequest) (*VariantResponse, error)
+	EditVariant(context.Context, *EditVariantRequest) (*StatusResponse, error)
+	DeleteVariant(context.Context, *VariantRequest) (*StatusResponse, error)
+	CreateProductSale(context.Context, *CreateSaleRequest) (*PricingRuleResponse, error)
+	ListProductSales(context.Context, *SaleRequest) (*ProductSales, error)
+	DeleteProductSale(context.Context, *SaleRequest) (*StatusResponse, error)
+	CreateShopDiscount(context.Context, *CreateShopDiscountRequest) (*PricingRuleResponse, error)
+	ListShopDiscounts(context.Context, *ShopPricingRequest) (*ShopDiscounts, error)
+	DeleteShopDiscount(context.Context, *ShopDiscountRequest) (*StatusResponse, error)
+	CreatePromoCode(context.Context, *CreatePromoCodeRequest) (*PricingRuleResponse, error)
+	ListPromoCodes(context.Context, *ShopPricingRequest) (*PromoCodes, error)
+	DeletePromoCode(context.Context, *PromoCodeRequest) (*StatusResponse, error)
+	RedeemPromoCode(context.Context, *RedeemPromoCodeRequest) (*PromoRedemption, error)
+	ReleasePromoRedemption(context.Context, *PromoRedemptionRequest) (*StatusResponse, error)
+	ListPriceHistory(context.Context, *ListPriceHistoryRequest) (*PriceHistory, error)
+	GetExchangeRates(context.Context, *ExchangeRatesRequest) (*ExchangeRates, error)
+	UpdateExchangeRates(context.Context, *UpdateExchangeRatesRequest) (*StatusResponse, error)
+	ImportProducts(ShopProduct_ImportProductsServer) error
+	GetProductImport(context.Context, *ProductImportRequest) (*ProductImport, error)
+	ExportProducts(*ExportProductsRequest, ShopProduct_ExportProductsServer) error
+	GetMarketplaceFeed(*MarketplaceFeedRequest, ShopProduct_GetMarketplaceFeedServer) error
+	ListWishlists(context.Context, *ListWishlistsRequest) (*Wishlists, error)
+	GetWishlist(context.Context, *WishlistRequest) (*Wishlist, error)
+	CreateWishlist(context.Context, *EditWishlistRequest) (*WishlistResponse, error)
+	EditWishlist(context.Context, *EditWishlistRequest) (*StatusResponse, error)
+	DeleteWishlist(context.Context, *WishlistRequest) (*StatusResponse, error)
+	SaveProduct(context.Context, *SaveProductRequest) (*WishlistResponse, error)
+	UnsaveProduct(context.Context, *SaveProductRequest) (*StatusResponse, error)
+	mustEmbedUnimplementedShopProductServer()
+}
+
+// UnimplementedShopProductServer must be embedded to have forward compatible implementations.
+type UnimplementedShopProductServer struct {
+}
+
+func (UnimplementedShopProductServer) CreateShop(context.Context, *CreateShopRequest) (*CreateShopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShop not implemented")
+}
+func (UnimplementedShopProductServer) EditShop(context.Context, *EditShopRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditShop not implemented")
+}
+func (UnimplementedShopProductServer) GetShop(context.Context, *GetShopRequest) (*Shop, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShop not implemented")
+}
+func (UnimplementedShopProductServer) CreateProduct(context.Context, *CreateProductRequest) (*CreateProductResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProduct not implemented")
+}
+func (UnimplementedShopProductServer) EditProduct(context.Context, *EditProductRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditProduct not implemented")
+}
+func (UnimplementedShopProductServer) GetProduct(context.Context, *GetProductRequest) (*Product, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProduct not implemented")
+}
+func (UnimplementedShopProductServer) GetProductsByIds(context.Context, *ProductIdsRequest) (*ProductsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductsByIds not implemented")
+}
+func (UnimplementedShopProductServer) ListProductsByShop(context.Context, *ListProductsRequest) (*ProductsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProductsByShop not implemented")
+}
+func (UnimplementedShopProductServer) GetCategories(context.Context, *CategoriesRequest) (*CategoryTree, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCategories not implemented")
+}
+func (UnimplementedShopProductServer) CreateCategory(context.Context, *CategoryRequest) (*CategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCategory not implemented")
+}
+func (UnimplementedShopProductServer) EditCategory(context.Context, *CategoryRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditCategory not implemented")
+}
+func (UnimplementedShopProductServer) DeleteCategory(context.Context, *DeleteCategoryRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCategory not implemented")
+}
+func (UnimplementedShopProductServer) ListProductsByCategory(context.Context, *ListCategoryProductsRequest) (*ProductsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProductsByCategory not implemented")
+}
+func (UnimplementedShopProductServer) AdjustStock(context.Context, *AdjustStockRequest) (*InventoryMovement, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjustStock not implemented")
+}
+func (UnimplementedShopProductServer) ReserveStock(context.Context, *ReserveStockRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveStock not implemented")
+}
+func (UnimplementedShopProductServer) GetReservation(context.Context, *ReservationRequest) (*Reservation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReservation not implemented")
+}
+func (UnimplementedShopProductServer) CommitReservation(context.Context, *ReservationRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitReservation not implemented")
+}
+func (UnimplementedShopProductServer) ReleaseReservation(context.Context, *ReservationRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseReservation not implemented")
+}
+func (UnimplementedShopProductServer) ListInventoryMovements(context.Context, *ListMovementsRequest) (*InventoryMovements, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInventoryMovements not implemented")
+}
+func (UnimplementedShopProductServer) GetCart(context.Context, *CartRequest) (*Cart, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCart not implemented")
+}
+func (UnimplementedShopProductServer) AddToCart(context.Context, *CartItemRequest) (*Cart, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddToCart not implemented")
+}
+func (UnimplementedShopProductServer) UpdateCartItem(context.Context, *CartItemRequest) (*Cart, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCartItem not implemented")
+}
+func (UnimplementedShopProductServer) RemoveFromCart(context.Context, *CartItemRequest) (*Cart, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromCart not implemented")
+}
+func (UnimplementedShopProductServer) MergeCarts(context.Context, *MergeCartsRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MergeCarts not implemented")
+}
+func (UnimplementedShopProductServer) SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchProducts not implemented")
+}
+func (UnimplementedShopProductServer) GetFeed(context.Context, *FeedRequest) (*ProductsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFeed not implemented")
+}
+func (UnimplementedShopProductServer) FollowShop(context.Context, *ShopFollowRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FollowShop not implemented")
+}
+func (UnimplementedShopProductServer) UnfollowShop(context.Context, *ShopFollowRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnfollowShop not implemented")
+}
+func (UnimplementedShopProductServer) CreateReview(context.Context, *CreateReviewRequest) (*ReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReview not implemented")
+}
+func (UnimplementedShopProductServer) EditReview(context.Context, *EditReviewRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditReview not implemented")
+}
+func (UnimplementedShopProductServer) ListReviews(context.Context, *ListReviewsRequest) (*ReviewsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReviews not implemented")
+}
+func (UnimplementedShopProductServer) DeleteProduct(context.Context, *DeleteProductRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProduct not implemented")
+}
+func (UnimplementedShopProductServer) PurgeProduct(context.Context, *DeleteProductRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeProduct not implemented")
+}
+func (UnimplementedShopProductServer) RestoreProduct(context.Context, *RestoreProductRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreProduct not implemented")
+}
+func (UnimplementedShopProductServer) ListDeletedProducts(context.Context, *ShopRequest) (*ProductsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedProducts not implemented")
+}
+func (UnimplementedShopProductServer) DeleteShop(context.Context, *ShopRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteShop not implemented")
+}
+func (UnimplementedShopProductServer) GetRelatedProducts(context.Context, *RelatedProductsRequest) (*ProductsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRelatedProducts not implemented")
+}
+func (UnimplementedShopProductServer) RestoreShop(context.Context, *ShopRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreShop not implemented")
+}
+func (UnimplementedShopProductServer) GetShopAnalytics(context.Context, *ShopAnalyticsRequest) (*ShopAnalytics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShopAnalytics not implemented")
+}
+func (UnimplementedShopProductServer) AskQuestion(context.Context, *AskQuestionRequest) (*QuestionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AskQuestion not implemented")
+}
+func (UnimplementedShopProductServer) AnswerQuestion(context.Context, *AnswerQuestionRequest) (*QuestionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnswerQuestion not implemented")
+}
+func (UnimplementedShopProductServer) SetOfficialAnswer(context.Context, *OfficialAnswerRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetOfficialAnswer not implemented")
+}
+func (UnimplementedShopProductServer) VoteQuestion(context.Context, *QuestionVoteRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VoteQuestion not implemented")
+}
+func (UnimplementedShopProductServer) UnvoteQuestion(context.Context, *QuestionVoteRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnvoteQuestion not implemented")
+}
+func (UnimplementedShopProductServer) ListQuestions(context.Context, *ListQuestionsRequest) (*QuestionsList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListQuestions not implemented")
+}
+func (UnimplementedShopProductServer) ExportUserData(context.Context, *UserRequest) (*ExportSections, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedShopProductServer) InviteShopManager(context.Context, *InviteShopManagerRequest) (*InvitationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InviteShopManager not implemented")
+}
+func (UnimplementedShopProductServer) RemoveShopManager(context.Context, *RemoveShopManagerRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveShopManager not implemented")
+}
+func (UnimplementedShopProductServer) GetShopInvitations(context.Context, *UserRequest) (*ShopInvitations, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetShopInvitations not implemented")
+}
+func (UnimplementedShopProductServer) AcceptShopInvitation(context.Context, *InvitationRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptShopInvitation not implemented")
+}
+func (UnimplementedShopProductServer) DeclineShopInvitation(context.Context, *InvitationRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclineShopInvitation not implemented")
+}
+func (UnimplementedShopProductServer) UploadProductImage(ShopProduct_UploadProductImageServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadProductImage not implemented")
+}
+func (UnimplementedShopProductServer) GetProductImages(context.Context, *GetProductRequest) (*ProductImages, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductImages not implemented")
+}
+func (UnimplementedShopProductServer) ReorderProductImages(context.Context, *ReorderImagesRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReorderProductImages not implemented")
+}
+func (UnimplementedShopProductServer) SetPrimaryProductImage(context.Context, *ProductImageRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetPrimaryProductImage not implemented")
+}
+func (UnimplementedShopProductServer) DeleteProductImage(context.Context, *ProductImageRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProductImage not implemented")
+}
+func (UnimplementedShopProductServer) SetProductOptions(context.Context, *SetProductOptionsRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetProductOptions not implemented")
+}
+func (UnimplementedShopProductServer) CreateVariant(context.Context, *EditVariantRequest) (*VariantResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateVariant not implemented")
+}
+func (UnimplementedShopProductServer) EditVariant(context.Context, *EditVariantRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditVariant not implemented")
+}
+func (UnimplementedShopProductServer) DeleteVariant(context.Context, *VariantRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteVariant not implemented")
+}
+func (UnimplementedShopProductServer) CreateProductSale(context.Context, *CreateSaleRequest) (*PricingRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateProductSale not implemented")
+}
+func (UnimplementedShopProductServer) ListProductSales(context.Context, *SaleRequest) (*ProductSales, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListProductSales not implemented")
+}
+func (UnimplementedShopProductServer) DeleteProductSale(context.Context, *SaleRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteProductSale not implemented")
+}
+func (UnimplementedShopProductServer) CreateShopDiscount(context.Context, *CreateShopDiscountRequest) (*PricingRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateShopDiscount not implemented")
+}
+func (UnimplementedShopProductServer) ListShopDiscounts(context.Context, *ShopPricingRequest) (*ShopDiscounts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListShopDiscounts not implemented")
+}
+func (UnimplementedShopProductServer) DeleteShopDiscount(context.Context, *ShopDiscountRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteShopDiscount not implemented")
+}
+func (UnimplementedShopProductServer) CreatePromoCode(context.Context, *CreatePromoCodeRequest) (*PricingRuleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePromoCode not implemented")
+}
+func (UnimplementedShopProductServer) ListPromoCodes(context.Context, *ShopPricingRequest) (*PromoCodes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPromoCodes not implemented")
+}
+func (UnimplementedShopProductServer) DeletePromoCode(context.Context, *PromoCodeRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePromoCode not implemented")
+}
+func (UnimplementedShopProductServer) RedeemPromoCode(context.Context, *RedeemPromoCodeRequest) (*PromoRedemption, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemPromoCode not implemented")
+}
+func (UnimplementedShopProductServer) ReleasePromoRedemption(context.Context, *PromoRedemptionRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleasePromoRedemption not implemented")
+}
+func (UnimplementedShopProductServer) ListPriceHistory(context.Context, *ListPriceHistoryRequest) (*PriceHistory, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPriceHistory not implemented")
+}
+func (UnimplementedShopProductServer) GetExchangeRates(context.Context, *ExchangeRatesRequest) (*ExchangeRates, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExchangeRates not implemented")
+}
+func (UnimplementedShopProductServer) UpdateExchangeRates(context.Context, *UpdateExchangeRatesRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateExchangeRates not implemented")
+}
+func (UnimplementedShopProductServer) ImportProducts(ShopProduct_ImportProductsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ImportProducts not implemented")
+}
+func (UnimplementedShopProductServer) GetProductImport(context.Context, *ProductImportRequest) (*ProductImport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProductImport not implemented")
+}
+func (UnimplementedShopProductServer) ExportProducts(*ExportProductsRequest, ShopProduct_ExportProductsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportProducts not implemented")
+}
+func (UnimplementedShopProductServer) GetMarketplaceFeed(*MarketplaceFeedRequest, ShopProduct_GetMarketplaceFeedServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetMarketplaceFeed not implemented")
+}
+func (UnimplementedShopProductServer) ListWishlists(context.Context, *ListWishlistsRequest) (*Wishlists, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWishlists not implemented")
+}
+func (UnimplementedShopProductServer) GetWishlist(context.Context, *WishlistRequest) (*Wishlist, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWishlist not implemented")
+}
+func (UnimplementedShopProductServer) CreateWishlist(context.Context, *EditWishlistRequest) (*WishlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWishlist not implemented")
+}
+func (UnimplementedShopProductServer) EditWishlist(context.Context, *EditWishlistRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditWishlist not implemented")
+}
+func (UnimplementedShopProductServer) DeleteWishlist(context.Context, *WishlistRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWishlist not implemented")
+}
+func (UnimplementedShopProductServer) SaveProduct(context.Context, *SaveProductRequest) (*WishlistResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SaveProduct not implemented")
+}
+func (UnimplementedShopProductServer) UnsaveProduct(context.Context, *SaveProductRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnsaveProduct not implemented")
+}
+func (UnimplementedShopProductServer) mustEmbedUnimplementedShopProductServer() {}
+
+// UnsafeShopProductServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ShopProductServer will
+// result in compilation errors.
+type UnsafeShopProductServer interface {
+	mustEmbedUnimplementedShopProductServer()
+}
+
+func RegisterShopProductServer(s grpc.ServiceRegistrar, srv ShopProductServer) {
+	s.RegisterService(&ShopProduct_ServiceDesc, srv)
+}
+
+func _ShopProduct_CreateShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).CreateShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/CreateShop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).CreateShop(ctx, req.(*CreateShopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_EditShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditShopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).EditShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/EditShop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).EditShop(ctx, req.(*EditShopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_GetShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetShopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).GetShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/GetShop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).GetShop(ctx, req.(*GetShopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_CreateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).CreateProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/CreateProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).CreateProduct(ctx, req.(*CreateProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_EditProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).EditProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/EditProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).EditProduct(ctx, req.(*EditProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_GetProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).GetProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/GetProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).GetProduct(ctx, req.(*GetProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_GetProductsByIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).GetProductsByIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/GetProductsByIds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).GetProductsByIds(ctx, req.(*ProductIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_ListProductsByShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).ListProductsByShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/ListProductsByShop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).ListProductsByShop(ctx, req.(*ListProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_GetCategories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).GetCategories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/GetCategories",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).GetCategories(ctx, req.(*CategoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_CreateCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).CreateCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/CreateCategory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).CreateCategory(ctx, req.(*CategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_EditCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).EditCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/EditCategory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).EditCategory(ctx, req.(*CategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_DeleteCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).DeleteCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/DeleteCategory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).DeleteCategory(ctx, req.(*DeleteCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_ListProductsByCategory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCategoryProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).ListProductsByCategory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/ListProductsByCategory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).ListProductsByCategory(ctx, req.(*ListCategoryProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_AdjustStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).AdjustStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/AdjustStock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).AdjustStock(ctx, req.(*AdjustStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_ReserveStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).ReserveStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/ReserveStock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).ReserveStock(ctx, req.(*ReserveStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_GetReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).GetReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/GetReservation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).GetReservation(ctx, req.(*ReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_CommitReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).CommitReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/CommitReservation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).CommitReservation(ctx, req.(*ReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_ReleaseReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).ReleaseReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/ReleaseReservation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).ReleaseReservation(ctx, req.(*ReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_ListInventoryMovements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMovementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).ListInventoryMovements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/ListInventoryMovements",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).ListInventoryMovements(ctx, req.(*ListMovementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_GetCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).GetCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/GetCart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).GetCart(ctx, req.(*CartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_AddToCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CartItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).AddToCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/AddToCart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).AddToCart(ctx, req.(*CartItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_UpdateCartItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CartItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).UpdateCartItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/UpdateCartItem",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).UpdateCartItem(ctx, req.(*CartItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_RemoveFromCart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CartItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).RemoveFromCart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/RemoveFromCart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).RemoveFromCart(ctx, req.(*CartItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_MergeCarts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MergeCartsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).MergeCarts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/MergeCarts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).MergeCarts(ctx, req.(*MergeCartsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_SearchProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).SearchProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/SearchProducts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).SearchProducts(ctx, req.(*SearchProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_GetFeed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).GetFeed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/GetFeed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).GetFeed(ctx, req.(*FeedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_FollowShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShopFollowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).FollowShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/FollowShop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).FollowShop(ctx, req.(*ShopFollowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_UnfollowShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShopFollowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).UnfollowShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/UnfollowShop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).UnfollowShop(ctx, req.(*ShopFollowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_CreateReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).CreateReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/CreateReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).CreateReview(ctx, req.(*CreateReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_EditReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).EditReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/EditReview",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).EditReview(ctx, req.(*EditReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_ListReviews_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).ListReviews(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/ListReviews",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).ListReviews(ctx, req.(*ListReviewsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_DeleteProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).DeleteProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/DeleteProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).DeleteProduct(ctx, req.(*DeleteProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_PurgeProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).PurgeProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/PurgeProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).PurgeProduct(ctx, req.(*DeleteProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_RestoreProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).RestoreProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/RestoreProduct",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).RestoreProduct(ctx, req.(*RestoreProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_ListDeletedProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).ListDeletedProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/ListDeletedProducts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).ListDeletedProducts(ctx, req.(*ShopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_DeleteShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).DeleteShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/DeleteShop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).DeleteShop(ctx, req.(*ShopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_GetRelatedProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RelatedProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).GetRelatedProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/GetRelatedProducts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).GetRelatedProducts(ctx, req.(*RelatedProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_RestoreShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).RestoreShop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/RestoreShop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).RestoreShop(ctx, req.(*ShopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_GetShopAnalytics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShopAnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).GetShopAnalytics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/GetShopAnalytics",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).GetShopAnalytics(ctx, req.(*ShopAnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_AskQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AskQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).AskQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/AskQuestion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).AskQuestion(ctx, req.(*AskQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_AnswerQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnswerQuestionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).AnswerQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/AnswerQuestion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).AnswerQuestion(ctx, req.(*AnswerQuestionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_SetOfficialAnswer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OfficialAnswerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).SetOfficialAnswer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/SetOfficialAnswer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).SetOfficialAnswer(ctx, req.(*OfficialAnswerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_VoteQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuestionVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).VoteQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/VoteQuestion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).VoteQuestion(ctx, req.(*QuestionVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_UnvoteQuestion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuestionVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).UnvoteQuestion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/UnvoteQuestion",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).UnvoteQuestion(ctx, req.(*QuestionVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_ListQuestions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListQuestionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).ListQuestions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/ListQuestions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).ListQuestions(ctx, req.(*ListQuestionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_ExportUserData_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).ExportUserData(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/ExportUserData",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).ExportUserData(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_InviteShopManager_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InviteShopManagerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).InviteShopManager(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/InviteShopManager",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).InviteShopManager(ctx, req.(*InviteShopManagerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_RemoveShopManager_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveShopManagerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).RemoveShopManager(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/RemoveShopManager",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).RemoveShopManager(ctx, req.(*RemoveShopManagerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_GetShopInvitations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).GetShopInvitations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/GetShopInvitations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).GetShopInvitations(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_AcceptShopInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).AcceptShopInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/AcceptShopInvitation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).AcceptShopInvitation(ctx, req.(*InvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_DeclineShopInvitation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvitationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).DeclineShopInvitation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/DeclineShopInvitation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).DeclineShopInvitation(ctx, req.(*InvitationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_UploadProductImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ShopProductServer).UploadProductImage(&shopProductUploadProductImageServer{stream})
+}
+
+type ShopProduct_UploadProductImageServer interface {
+	SendAndClose(*ProductImage) error
+	Recv() (*UploadImageRequest, error)
+	grpc.ServerStream
+}
+
+type shopProductUploadProductImageServer struct {
+	grpc.ServerStream
+}
+
+func (x *shopProductUploadProductImageServer) SendAndClose(m *ProductImage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *shopProductUploadProductImageServer) Recv() (*UploadImageRequest, error) {
+	m := new(UploadImageRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _ShopProduct_GetProductImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).GetProductImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/GetProductImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).GetProductImages(ctx, req.(*GetProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_ReorderProductImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).ReorderProductImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/ReorderProductImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).ReorderProductImages(ctx, req.(*ReorderImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_SetPrimaryProductImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).SetPrimaryProductImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/SetPrimaryProductImage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).SetPrimaryProductImage(ctx, req.(*ProductImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_DeleteProductImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).DeleteProductImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/DeleteProductImage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).DeleteProductImage(ctx, req.(*ProductImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_SetProductOptions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetProductOptionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).SetProductOptions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/SetProductOptions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).SetProductOptions(ctx, req.(*SetProductOptionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_CreateVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditVariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).CreateVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/CreateVariant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).CreateVariant(ctx, req.(*EditVariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_EditVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditVariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).EditVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/EditVariant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).EditVariant(ctx, req.(*EditVariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_DeleteVariant_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VariantRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).DeleteVariant(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/DeleteVariant",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).DeleteVariant(ctx, req.(*VariantRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_CreateProductSale_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSaleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).CreateProductSale(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/CreateProductSale",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).CreateProductSale(ctx, req.(*CreateSaleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_ListProductSales_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).ListProductSales(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/ListProductSales",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).ListProductSales(ctx, req.(*SaleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_DeleteProductSale_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).DeleteProductSale(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/DeleteProductSale",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).DeleteProductSale(ctx, req.(*SaleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_CreateShopDiscount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateShopDiscountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).CreateShopDiscount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/CreateShopDiscount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).CreateShopDiscount(ctx, req.(*CreateShopDiscountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_ListShopDiscounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShopPricingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).ListShopDiscounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/ListShopDiscounts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).ListShopDiscounts(ctx, req.(*ShopPricingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_DeleteShopDiscount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShopDiscountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).DeleteShopDiscount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/DeleteShopDiscount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).DeleteShopDiscount(ctx, req.(*ShopDiscountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_CreatePromoCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePromoCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).CreatePromoCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/CreatePromoCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).CreatePromoCode(ctx, req.(*CreatePromoCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_ListPromoCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShopPricingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).ListPromoCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/ListPromoCodes",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).ListPromoCodes(ctx, req.(*ShopPricingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_DeletePromoCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).DeletePromoCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/DeletePromoCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).DeletePromoCode(ctx, req.(*PromoCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_RedeemPromoCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemPromoCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).RedeemPromoCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/RedeemPromoCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).RedeemPromoCode(ctx, req.(*RedeemPromoCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_ReleasePromoRedemption_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoRedemptionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).ReleasePromoRedemption(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/ReleasePromoRedemption",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).ReleasePromoRedemption(ctx, req.(*PromoRedemptionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_ListPriceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPriceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).ListPriceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/ListPriceHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).ListPriceHistory(ctx, req.(*ListPriceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_GetExchangeRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExchangeRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).GetExchangeRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/GetExchangeRates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).GetExchangeRates(ctx, req.(*ExchangeRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_UpdateExchangeRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateExchangeRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).UpdateExchangeRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/UpdateExchangeRates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).UpdateExchangeRates(ctx, req.(*UpdateExchangeRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ShopProduct_ImportProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ShopProductServer).ImportProducts(&shopProductImportProductsServer{stream})
+}
+
+type ShopProduct_ImportProductsServer interface {
+	SendAndClose(*ProductImport) error
+	Recv() (*ImportProductsRequest, error)
+	grpc.ServerStream
+}
+
+type shopProductImportProductsServer struct {
+	grpc.ServerStream
+}
+
+func (x *shopProductImportProductsServer) SendAndClose(m *ProductImport) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *shopProductImportProductsServer) Recv() (*ImportProductsRequest, error) {
+	m := new(ImportProductsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *shopProductClient) GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*Product, error) {
-	out := new(Product)
-	err := c.cc.Invoke(ctx, "/shopProduct.ShopProduct/GetProduct", in, out, opts...)
-	if err != nil {
+func _ShopProduct_GetProductImport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProductImportRequest)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(ShopProductServer).GetProductImport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/GetProductImport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).GetProductImport(ctx, req.(*ProductImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// ShopProductServer is the server API for ShopProduct service.
-// All implementations must embed UnimplementedShopProductServer
-// for forward compatibility
-type ShopProductServer interface {
-	CreateShop(context.Context, *CreateShopRequest) (*StatusResponse, error)
-	EditShop(context.Context, *EditShopRequest) (*StatusResponse, error)
-	GetShop(context.Context, *GetShopRequest) (*Shop, error)
-	CreateProduct(context.Context, *CreateProductRequest) (*StatusResponse, error)
-	EditProduct(context.Context, *EditProductRequest) (*StatusResponse, error)
-	GetProduct(context.Context, *GetProductRequest) (*Product, error)
-	mustEmbedUnimplementedShopProductServer()
+func _ShopProduct_ExportProducts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportProductsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShopProductServer).ExportProducts(m, &shopProductExportProductsServer{stream})
 }
 
-// UnimplementedShopProductServer must be embedded to have forward compatible implementations.
-type UnimplementedShopProductServer struct {
+type ShopProduct_ExportProductsServer interface {
+	Send(*CatalogChunk) error
+	grpc.ServerStream
 }
 
-func (UnimplementedShopProductServer) CreateShop(context.Context, *CreateShopRequest) (*StatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateShop not implemented")
-}
-func (UnimplementedShopProductServer) EditShop(context.Context, *EditShopRequest) (*StatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method EditShop not implemented")
+type shopProductExportProductsServer struct {
+	grpc.ServerStream
 }
-func (UnimplementedShopProductServer) GetShop(context.Context, *GetShopRequest) (*Shop, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetShop not implemented")
+
+func (x *shopProductExportProductsServer) Send(m *CatalogChunk) error {
+	return x.ServerStream.SendMsg(m)
 }
-func (UnimplementedShopProductServer) CreateProduct(context.Context, *CreateProductRequest) (*StatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateProduct not implemented")
+
+func _ShopProduct_GetMarketplaceFeed_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MarketplaceFeedRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ShopProductServer).GetMarketplaceFeed(m, &shopProductGetMarketplaceFeedServer{stream})
 }
-func (UnimplementedShopProductServer) EditProduct(context.Context, *EditProductRequest) (*StatusResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method EditProduct not implemented")
+
+type ShopProduct_GetMarketplaceFeedServer interface {
+	Send(*CatalogChunk) error
+	grpc.ServerStream
 }
-func (UnimplementedShopProductServer) GetProduct(context.Context, *GetProductRequest) (*Product, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetProduct not implemented")
+
+type shopProductGetMarketplaceFeedServer struct {
+	grpc.ServerStream
 }
-func (UnimplementedShopProductServer) mustEmbedUnimplementedShopProductServer() {}
 
-// UnsafeShopProductServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to ShopProductServer will
-// result in compilation errors.
-type UnsafeShopProductServer interface {
-	mustEmbedUnimplementedShopProductServer()
+func (x *shopProductGetMarketplaceFeedServer) Send(m *CatalogChunk) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func RegisterShopProductServer(s grpc.ServiceRegistrar, srv ShopProductServer) {
-	s.RegisterService(&ShopProduct_ServiceDesc, srv)
+func _ShopProduct_ListWishlists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWishlistsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ShopProductServer).ListWishlists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/shopProduct.ShopProduct/ListWishlists",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ShopProductServer).ListWishlists(ctx, req.(*ListWishlistsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _ShopProduct_CreateShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateShopRequest)
+func _ShopProduct_GetWishlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WishlistRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShopProductServer).CreateShop(ctx, in)
+		return srv.(ShopProductServer).GetWishlist(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/shopProduct.ShopProduct/CreateShop",
+		FullMethod: "/shopProduct.ShopProduct/GetWishlist",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShopProductServer).CreateShop(ctx, req.(*CreateShopRequest))
+		return srv.(ShopProductServer).GetWishlist(ctx, req.(*WishlistRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ShopProduct_EditShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EditShopRequest)
+func _ShopProduct_CreateWishlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditWishlistRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShopProductServer).EditShop(ctx, in)
+		return srv.(ShopProductServer).CreateWishlist(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/shopProduct.ShopProduct/EditShop",
+		FullMethod: "/shopProduct.ShopProduct/CreateWishlist",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShopProductServer).EditShop(ctx, req.(*EditShopRequest))
+		return srv.(ShopProductServer).CreateWishlist(ctx, req.(*EditWishlistRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ShopProduct_GetShop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetShopRequest)
+func _ShopProduct_EditWishlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditWishlistRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShopProductServer).GetShop(ctx, in)
+		return srv.(ShopProductServer).EditWishlist(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/shopProduct.ShopProduct/GetShop",
+		FullMethod: "/shopProduct.ShopProduct/EditWishlist",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShopProductServer).GetShop(ctx, req.(*GetShopRequest))
+		return srv.(ShopProductServer).EditWishlist(ctx, req.(*EditWishlistRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ShopProduct_CreateProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateProductRequest)
+func _ShopProduct_DeleteWishlist_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WishlistRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShopProductServer).CreateProduct(ctx, in)
+		return srv.(ShopProductServer).DeleteWishlist(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/shopProduct.ShopProduct/CreateProduct",
+		FullMethod: "/shopProduct.ShopProduct/DeleteWishlist",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShopProductServer).CreateProduct(ctx, req.(*CreateProductRequest))
+		return srv.(ShopProductServer).DeleteWishlist(ctx, req.(*WishlistRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ShopProduct_EditProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EditProductRequest)
+func _ShopProduct_SaveProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveProductRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShopProductServer).EditProduct(ctx, in)
+		return srv.(ShopProductServer).SaveProduct(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/shopProduct.ShopProduct/EditProduct",
+		FullMethod: "/shopProduct.ShopProduct/SaveProduct",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShopProductServer).EditProduct(ctx, req.(*EditProductRequest))
+		return srv.(ShopProductServer).SaveProduct(ctx, req.(*SaveProductRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ShopProduct_GetProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetProductRequest)
+func _ShopProduct_UnsaveProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveProductRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ShopProductServer).GetProduct(ctx, in)
+		return srv.(ShopProductServer).UnsaveProduct(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/shopProduct.ShopProduct/GetProduct",
+		FullMethod: "/shopProduct.ShopProduct/UnsaveProduct",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ShopProductServer).GetProduct(ctx, req.(*GetProductRequest))
+		return srv.(ShopProductServer).UnsaveProduct(ctx, req.(*SaveProductRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -275,7 +2921,328 @@ var ShopProduct_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProduct",
 			Handler:    _ShopProduct_GetProduct_Handler,
 		},
+		{
+			MethodName: "GetProductsByIds",
+			Handler:    _ShopProduct_GetProductsByIds_Handler,
+		},
+		{
+			MethodName: "ListProductsByShop",
+			Handler:    _ShopProduct_ListProductsByShop_Handler,
+		},
+		{
+			MethodName: "GetCategories",
+			Handler:    _ShopProduct_GetCategories_Handler,
+		},
+		{
+			MethodName: "CreateCategory",
+			Handler:    _ShopProduct_CreateCategory_Handler,
+		},
+		{
+			MethodName: "EditCategory",
+			Handler:    _ShopProduct_EditCategory_Handler,
+		},
+		{
+			MethodName: "DeleteCategory",
+			Handler:    _ShopProduct_DeleteCategory_Handler,
+		},
+		{
+			MethodName: "ListProductsByCategory",
+			Handler:    _ShopProduct_ListProductsByCategory_Handler,
+		},
+		{
+			MethodName: "AdjustStock",
+			Handler:    _ShopProduct_AdjustStock_Handler,
+		},
+		{
+			MethodName: "ReserveStock",
+			Handler:    _ShopProduct_ReserveStock_Handler,
+		},
+		{
+			MethodName: "GetReservation",
+			Handler:    _ShopProduct_GetReservation_Handler,
+		},
+		{
+			MethodName: "CommitReservation",
+			Handler:    _ShopProduct_CommitReservation_Handler,
+		},
+		{
+			MethodName: "ReleaseReservation",
+			Handler:    _ShopProduct_ReleaseReservation_Handler,
+		},
+		{
+			MethodName: "ListInventoryMovements",
+			Handler:    _ShopProduct_ListInventoryMovements_Handler,
+		},
+		{
+			MethodName: "GetCart",
+			Handler:    _ShopProduct_GetCart_Handler,
+		},
+		{
+			MethodName: "AddToCart",
+			Handler:    _ShopProduct_AddToCart_Handler,
+		},
+		{
+			MethodName: "UpdateCartItem",
+			Handler:    _ShopProduct_UpdateCartItem_Handler,
+		},
+		{
+			MethodName: "RemoveFromCart",
+			Handler:    _ShopProduct_RemoveFromCart_Handler,
+		},
+		{
+			MethodName: "MergeCarts",
+			Handler:    _ShopProduct_MergeCarts_Handler,
+		},
+		{
+			MethodName: "SearchProducts",
+			Handler:    _ShopProduct_SearchProducts_Handler,
+		},
+		{
+			MethodName: "GetFeed",
+			Handler:    _ShopProduct_GetFeed_Handler,
+		},
+		{
+			MethodName: "FollowShop",
+			Handler:    _ShopProduct_FollowShop_Handler,
+		},
+		{
+			MethodName: "UnfollowShop",
+			Handler:    _ShopProduct_UnfollowShop_Handler,
+		},
+		{
+			MethodName: "CreateReview",
+			Handler:    _ShopProduct_CreateReview_Handler,
+		},
+		{
+			MethodName: "EditReview",
+			Handler:    _ShopProduct_EditReview_Handler,
+		},
+		{
+			MethodName: "ListReviews",
+			Handler:    _ShopProduct_ListReviews_Handler,
+		},
+		{
+			MethodName: "DeleteProduct",
+			Handler:    _ShopProduct_DeleteProduct_Handler,
+		},
+		{
+			MethodName: "PurgeProduct",
+			Handler:    _ShopProduct_PurgeProduct_Handler,
+		},
+		{
+			MethodName: "RestoreProduct",
+			Handler:    _ShopProduct_RestoreProduct_Handler,
+		},
+		{
+			MethodName: "ListDeletedProducts",
+			Handler:    _ShopProduct_ListDeletedProducts_Handler,
+		},
+		{
+			MethodName: "DeleteShop",
+			Handler:    _ShopProduct_DeleteShop_Handler,
+		},
+		{
+			MethodName: "GetRelatedProducts",
+			Handler:    _ShopProduct_GetRelatedProducts_Handler,
+		},
+		{
+			MethodName: "RestoreShop",
+			Handler:    _ShopProduct_RestoreShop_Handler,
+		},
+		{
+			MethodName: "GetShopAnalytics",
+			Handler:    _ShopProduct_GetShopAnalytics_Handler,
+		},
+		{
+			MethodName: "AskQuestion",
+			Handler:    _ShopProduct_AskQuestion_Handler,
+		},
+		{
+			MethodName: "AnswerQuestion",
+			Handler:    _ShopProduct_AnswerQuestion_Handler,
+		},
+		{
+			MethodName: "SetOfficialAnswer",
+			Handler:    _ShopProduct_SetOfficialAnswer_Handler,
+		},
+		{
+			MethodName: "VoteQuestion",
+			Handler:    _ShopProduct_VoteQuestion_Handler,
+		},
+		{
+			MethodName: "UnvoteQuestion",
+			Handler:    _ShopProduct_UnvoteQuestion_Handler,
+		},
+		{
+			MethodName: "ListQuestions",
+			Handler:    _ShopProduct_ListQuestions_Handler,
+		},
+		{
+			MethodName: "ExportUserData",
+			Handler:    _ShopProduct_ExportUserData_Handler,
+		},
+		{
+			MethodName: "InviteShopManager",
+			Handler:    _ShopProduct_InviteShopManager_Handler,
+		},
+		{
+			MethodName: "RemoveShopManager",
+			Handler:    _ShopProduct_RemoveShopManager_Handler,
+		},
+		{
+			MethodName: "GetShopInvitations",
+			Handler:    _ShopProduct_GetShopInvitations_Handler,
+		},
+		{
+			MethodName: "AcceptShopInvitation",
+			Handler:    _ShopProduct_AcceptShopInvitation_Handler,
+		},
+		{
+			MethodName: "DeclineShopInvitation",
+			Handler:    _ShopProduct_DeclineShopInvitation_Handler,
+		},
+		{
+			MethodName: "GetProductImages",
+			Handler:    _ShopProduct_GetProductImages_Handler,
+		},
+		{
+			MethodName: "ReorderProductImages",
+			Handler:    _ShopProduct_ReorderProductImages_Handler,
+		},
+		{
+			MethodName: "SetPrimaryProductImage",
+			Handler:    _ShopProduct_SetPrimaryProductImage_Handler,
+		},
+		{
+			MethodName: "DeleteProductImage",
+			Handler:    _ShopProduct_DeleteProductImage_Handler,
+		},
+		{
+			MethodName: "SetProductOptions",
+			Handler:    _ShopProduct_SetProductOptions_Handler,
+		},
+		{
+			MethodName: "CreateVariant",
+			Handler:    _ShopProduct_CreateVariant_Handler,
+		},
+		{
+			MethodName: "EditVariant",
+			Handler:    _ShopProduct_EditVariant_Handler,
+		},
+		{
+			MethodName: "DeleteVariant",
+			Handler:    _ShopProduct_DeleteVariant_Handler,
+		},
+		{
+			MethodName: "CreateProductSale",
+			Handler:    _ShopProduct_CreateProductSale_Handler,
+		},
+		{
+			MethodName: "ListProductSales",
+			Handler:    _ShopProduct_ListProductSales_Handler,
+		},
+		{
+			MethodName: "DeleteProductSale",
+			Handler:    _ShopProduct_DeleteProductSale_Handler,
+		},
+		{
+			MethodName: "CreateShopDiscount",
+			Handler:    _ShopProduct_CreateShopDiscount_Handler,
+		},
+		{
+			MethodName: "ListShopDiscounts",
+			Handler:    _ShopProduct_ListShopDiscounts_Handler,
+		},
+		{
+			MethodName: "DeleteShopDiscount",
+			Handler:    _ShopProduct_DeleteShopDiscount_Handler,
+		},
+		{
+			MethodName: "CreatePromoCode",
+			Handler:    _ShopProduct_CreatePromoCode_Handler,
+		},
+		{
+			MethodName: "ListPromoCodes",
+			Handler:    _ShopProduct_ListPromoCodes_Handler,
+		},
+		{
+			MethodName: "DeletePromoCode",
+			Handler:    _ShopProduct_DeletePromoCode_Handler,
+		},
+		{
+			MethodName: "RedeemPromoCode",
+			Handler:    _ShopProduct_RedeemPromoCode_Handler,
+		},
+		{
+			MethodName: "ReleasePromoRedemption",
+			Handler:    _ShopProduct_ReleasePromoRedemption_Handler,
+		},
+		{
+			MethodName: "ListPriceHistory",
+			Handler:    _ShopProduct_ListPriceHistory_Handler,
+		},
+		{
+			MethodName: "GetExchangeRates",
+			Handler:    _ShopProduct_GetExchangeRates_Handler,
+		},
+		{
+			MethodName: "UpdateExchangeRates",
+			Handler:    _ShopProduct_UpdateExchangeRates_Handler,
+		},
+		{
+			MethodName: "GetProductImport",
+			Handler:    _ShopProduct_GetProductImport_Handler,
+		},
+		{
+			MethodName: "ListWishlists",
+			Handler:    _ShopProduct_ListWishlists_Handler,
+		},
+		{
+			MethodName: "GetWishlist",
+			Handler:    _ShopProduct_GetWishlist_Handler,
+		},
+		{
+			MethodName: "CreateWishlist",
+			Handler:    _ShopProduct_CreateWishlist_Handler,
+		},
+		{
+			MethodName: "EditWishlist",
+			Handler:    _ShopProduct_EditWishlist_Handler,
+		},
+		{
+			MethodName: "DeleteWishlist",
+			Handler:    _ShopProduct_DeleteWishlist_Handler,
+		},
+		{
+			MethodName: "SaveProduct",
+			Handler:    _ShopProduct_SaveProduct_Handler,
+		},
+		{
+			MethodName: "UnsaveProduct",
+			Handler:    _ShopProduct_UnsaveProduct_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadProductImage",
+			Handler:       _ShopProduct_UploadProductImage_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ImportProducts",
+			Handler:       _ShopProduct_ImportProducts_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ExportProducts",
+			Handler:       _ShopProduct_ExportProducts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetMarketplaceFeed",
+			Handler:       _ShopProduct_GetMarketplaceFeed_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "shopProduct.proto",
 }
@@ -0,0 +1,339 @@
+package application
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	orderpb "pinterest/services/order/proto"
+	shopproductpb "pinterest/services/shopProduct/proto"
+	"pinterest/services/user/domain"
+	repository "pinterest/services/user/infrastructure"
+	"strings"
+	"time"
+)
+
+type ExportAppInterface interface {
+	RequestDataExport(ctx context.Context, userID uint64) (export domain.DataExport, err error)
+	GetDataExport(ctx context.Context, userID uint64, exportID uint64) (export domain.DataExport, err error)
+	OpenDataExport(ctx context.Context, token string) (archive *os.File, err error)
+	PurgeExpiredExports(ctx context.Context) (err error)
+	FailStaleExports(ctx context.Context) (err error)
+}
+
+// ExportApp assembles archives of user's data. Data of shops, products and orders is collected
+// by shopProduct and order services, which own it
+type ExportApp struct {
+	repo              repository.ExportRepoInterface
+	shopProductClient shopproductpb.ShopProductClient
+	orderClient       orderpb.OrderServiceClient
+	// exportsDir is where finished archives are kept until they expire
+	exportsDir string
+	// mediaDir is the root which media links stored in database are relative to
+	mediaDir string
+}
+
+func NewExportApp(repo repository.ExportRepoInterface, shopProductClient shopproductpb.ShopProductClient,
+	orderClient orderpb.OrderServiceClient, exportsDir string, mediaDir string) *ExportApp {
+	return &ExportApp{
+		repo:              repo,
+		shopProductClient: shopProductClient,
+		orderClient:       orderClient,
+		exportsDir:        exportsDir,
+		mediaDir:          mediaDir,
+	}
+}
+
+// RequestDataExport starts collecting user's data in background.
+// If user already has unfinished export, it is returned instead of starting a new one
+func (app *ExportApp) RequestDataExport(ctx context.Context, userID uint64) (export domain.DataExport, err error) {
+	export, err = app.repo.GetActiveExport(ctx, userID)
+	switch err {
+	case nil:
+		return export, nil
+	case domain.ExportNotFoundError:
+		break
+	default:
+		return domain.DataExport{}, err
+	}
+
+	export, err = app.repo.CreateExport(ctx, userID)
+	if err != nil {
+		return domain.DataExport{}, err
+	}
+
+	go app.processExport(export)
+
+	return export, nil
+}
+
+// GetDataExport returns status of user's export. Users can not see each other's exports
+func (app *ExportApp) GetDataExport(ctx context.Context, userID uint64, exportID uint64) (export domain.DataExport, err error) {
+	export, err = app.repo.GetExport(ctx, exportID)
+	if err != nil {
+		return domain.DataExport{}, err
+	}
+
+	if export.UserID != userID {
+		return domain.DataExport{}, domain.ExportNotFoundError
+	}
+
+	return export, nil
+}
+
+// OpenDataExport opens archive by its download token, if it has not expired yet
+func (app *ExportApp) OpenDataExport(ctx context.Context, token string) (archive *os.File, err error) {
+	if token == "" {
+		return nil, domain.ExportNotFoundError
+	}
+
+	export, err := app.repo.GetExportByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case export.Status == domain.ExportStatusExpired || time.Now().After(export.ExpiresAt):
+		return nil, domain.ExportExpiredError
+	case export.Status != domain.ExportStatusReady:
+		return nil, domain.ExportNotReadyError
+	}
+
+	return os.Open(export.ArchivePath)
+}
+
+// PurgeExpiredExports deletes archives whose download links have expired
+func (app *ExportApp) PurgeExpiredExports(ctx context.Context) (err error) {
+	exports, err := app.repo.GetExpiredExports(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for _, export := range exports {
+		err = os.Remove(export.ArchivePath)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+
+		export.Status = domain.ExportStatusExpired
+		export.ArchivePath = ""
+		export.DownloadToken = ""
+		err = app.repo.UpdateExport(ctx, export)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FailStaleExports fails exports which have not finished in domain.ExportTimeoutHours, for example because
+// service was restarted or their status could not be saved. Otherwise their users could not request new exports
+func (app *ExportApp) FailStaleExports(ctx context.Context) (err error) {
+	now := time.Now()
+	return app.repo.FailStaleExports(ctx, now.Add(-time.Hour*domain.ExportTimeoutHours), domain.ExportTimeoutError.Error(), now)
+}
+
+// processExport collects user's data and writes archive. Errors are saved in export itself,
+// exports whose status could not be saved are failed later by FailStaleExports
+func (app *ExportApp) processExport(export domain.DataExport) {
+	ctx := context.Background()
+
+	export.Status = domain.ExportStatusProcessing
+	err := app.repo.UpdateExport(ctx, export)
+	if err != nil {
+		return
+	}
+
+	archivePath, err := app.writeArchive(ctx, export)
+	if err != nil {
+		app.failExport(ctx, export, err)
+		return
+	}
+
+	token, err := newDownloadToken()
+	if err != nil {
+		os.Remove(archivePath)
+		app.failExport(ctx, export, err)
+		return
+	}
+
+	export.Status = domain.ExportStatusReady
+	export.ArchivePath = archivePath
+	export.DownloadToken = token
+	export.FinishedAt = time.Now()
+	export.ExpiresAt = export.FinishedAt.Add(time.Hour * domain.ExportExpiryHours)
+	err = app.repo.UpdateExport(ctx, export)
+	if err != nil {
+		os.Remove(archivePath) // Archive without ready export could never be downloaded or purged
+		export.ArchivePath = ""
+		export.DownloadToken = ""
+		export.ExpiresAt = time.Time{}
+		app.failExport(ctx, export, err)
+	}
+}
+
+// failExport saves error of export. If it can not be saved either, export is failed later by FailStaleExports
+func (app *ExportApp) failExport(ctx context.Context, export domain.DataExport, exportErr error) {
+	export.Status = domain.ExportStatusFailed
+	export.ErrorMessage = exportErr.Error()
+	export.FinishedAt = time.Now()
+	app.repo.UpdateExport(ctx, export)
+}
+
+// exportManifest describes archive contents
+type exportManifest struct {
+	UserID      uint64    `json:"userID"`
+	ExportID    uint64    `json:"exportID"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	Sections    []string  `json:"sections"`
+	// MissingMedia lists media files that are referenced in data but could not be found
+	MissingMedia []string `json:"missingMedia"`
+}
+
+// writeArchive creates zip with one JSON file per data section, original media files and manifest.
+// Partially written archive is removed if anything fails
+func (app *ExportApp) writeArchive(ctx context.Context, export domain.DataExport) (archivePath string, err error) {
+	sections, err := app.collectUserData(ctx, export.UserID)
+	if err != nil {
+		return "", err
+	}
+
+	err = os.MkdirAll(app.exportsDir, 0750)
+	if err != nil {
+		return "", err
+	}
+
+	archivePath = filepath.Join(app.exportsDir, fmt.Sprintf("export_%d_%d.zip", export.UserID, export.ExportID))
+	file, err := os.Create(archivePath)
+	if err != nil {
+		return "", err
+	}
+
+	err = app.writeZip(file, export, sections)
+	closeErr := file.Close() // Data may be flushed only on close, so archive is not complete until it succeeds
+	if err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(archivePath)
+		return "", err
+	}
+	return archivePath, nil
+}
+
+// collectUserData gathers sections kept by user service itself and by shopProduct and order services
+func (app *ExportApp) collectUserData(ctx context.Context, userID uint64) (sections []domain.ExportSection, err error) {
+	sections, err = app.repo.CollectUserData(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	shopProductSections, err := app.shopProductClient.ExportUserData(ctx, &shopproductpb.UserRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	sections = append(sections, domain.PbShopProductSectionsToSections(shopProductSections)...)
+
+	orderSections, err := app.orderClient.ExportUserData(ctx, &orderpb.UserRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	sections = append(sections, domain.PbOrderSectionsToSections(orderSections)...)
+
+	return sections, nil
+}
+
+// writeZip writes sections, their media files and manifest into archive file
+func (app *ExportApp) writeZip(file io.Writer, export domain.DataExport, sections []domain.ExportSection) (err error) {
+	archive := zip.NewWriter(file)
+	manifest := exportManifest{
+		UserID:       export.UserID,
+		ExportID:     export.ExportID,
+		GeneratedAt:  time.Now(),
+		Sections:     make([]string, 0, len(sections)),
+		MissingMedia: make([]string, 0),
+	}
+
+	addedMedia := make(map[string]bool)
+	for _, section := range sections {
+		err = writeJSONFile(archive, section.Name+".json", section.Data)
+		if err != nil {
+			return err
+		}
+		manifest.Sections = append(manifest.Sections, section.Name)
+
+		for _, link := range section.MediaLinks {
+			if addedMedia[link] {
+				continue
+			}
+			addedMedia[link] = true
+
+			found, err := app.addMediaFile(archive, link)
+			if err != nil {
+				return err
+			}
+			if !found {
+				manifest.MissingMedia = append(manifest.MissingMedia, link)
+			}
+		}
+	}
+
+	err = writeJSONFile(archive, "manifest.json", manifest)
+	if err != nil {
+		return err
+	}
+
+	return archive.Close()
+}
+
+func writeJSONFile(archive *zip.Writer, name string, data interface{}) error {
+	writer, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(data)
+}
+
+// addMediaFile copies media file into archive's media directory. Returns false if there is no such file
+func (app *ExportApp) addMediaFile(archive *zip.Writer, link string) (found bool, err error) {
+	cleanLink := filepath.Clean("/" + link) // Links must not point outside of media directory
+	media, err := os.Open(filepath.Join(app.mediaDir, cleanLink))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer media.Close()
+
+	writer, err := archive.Create("media/" + strings.TrimPrefix(filepath.ToSlash(cleanLink), "/"))
+	if err != nil {
+		return false, err
+	}
+
+	_, err = io.Copy(writer, media)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// newDownloadToken returns hex encoded random bytes, so that every token is equally likely
+func newDownloadToken() (token string, err error) {
+	bytes := make([]byte, domain.ExportDownloadTokenBytes)
+	_, err = rand.Read(bytes)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
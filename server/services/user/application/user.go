@@ -10,10 +10,23 @@ import (
 
 type UserAppInterface interface {
 	CreateUser(ctx context.Context, user domain.User) (userID uint64, err error)
-	GetUserByID(ctx context.Context, userID uint64) (user domain.User, err error)
-	GetUserByUsername(ctx context.Context, username string) (user domain.User, err error)
-	GetUsers(ctx context.Context) (users []domain.User, err error)
+	GetUserByID(ctx context.Context, userID uint64, viewerID uint64) (user domain.User, err error)
+	GetUserByUsername(ctx context.Context, username string, viewerID uint64) (user domain.User, err error)
+	GetUsers(ctx context.Context, viewerID uint64) (users []domain.User, err error)
 	EditUser(ctx context.Context, user domain.User) (err error)
+	SearchUsers(ctx context.Context, keyWords string, viewerID uint64) (users []domain.User, err error)
+	GetFollowers(ctx context.Context, userID uint64, viewerID uint64) (users []domain.User, err error)
+	GetFollowed(ctx context.Context, userID uint64, viewerID uint64) (users []domain.User, err error)
+	GetPrivacySettings(ctx context.Context, userID uint64) (settings domain.PrivacySettings, err error)
+	EditPrivacySettings(ctx context.Context, userID uint64, settings domain.PrivacySettings) (err error)
+	BlockUser(ctx context.Context, pair domain.UserPair) (err error)
+	UnblockUser(ctx context.Context, pair domain.UserPair) (err error)
+	MuteUser(ctx context.Context, pair domain.UserPair) (err error)
+	UnmuteUser(ctx context.Context, pair domain.UserPair) (err error)
+	GetBlockedUsers(ctx context.Context, userID uint64) (users []domain.User, err error)
+	GetMutedUsers(ctx context.Context, userID uint64) (users []domain.User, err error)
+	IsBlocked(ctx context.Context, pairs []domain.UserPair) (blocked []bool, muted []bool, err error)
+	GetUsernames(ctx context.Context, userIDs []uint64) (usernames []string, err error)
 }
 
 type UserApp struct {
@@ -35,16 +48,44 @@ func (app *UserApp) CreateUser(ctx context.Context, user domain.User) (userID ui
 	return app.repo.CreateUser(ctx, user, passwordHash)
 }
 
-func (app *UserApp) GetUserByID(ctx context.Context, userID uint64) (user domain.User, err error) {
-	return app.repo.GetUserByID(ctx, userID)
+// GetUserByID returns user data with fields that viewer is not allowed to see stripped (see applyPrivacy)
+func (app *UserApp) GetUserByID(ctx context.Context, userID uint64, viewerID uint64) (user domain.User, err error) {
+	user, err = app.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	err = app.checkNotBlocked(ctx, user.UserID, viewerID)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	return app.applyPrivacy(ctx, user, viewerID)
 }
 
-func (app *UserApp) GetUserByUsername(ctx context.Context, username string) (user domain.User, err error) {
-	return app.repo.GetUserByUsername(ctx, username)
+// GetUserByUsername returns user data with fields that viewer is not allowed to see stripped (see applyPrivacy)
+func (app *UserApp) GetUserByUsername(ctx context.Context, username string, viewerID uint64) (user domain.User, err error) {
+	user, err = app.repo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	err = app.checkNotBlocked(ctx, user.UserID, viewerID)
+	if err != nil {
+		return domain.User{}, err
+	}
+
+	return app.applyPrivacy(ctx, user, viewerID)
 }
 
-func (app *UserApp) GetUsers(ctx context.Context) (users []domain.User, err error) {
-	return app.repo.GetUsers(ctx)
+// GetUsers returns all users with their data stripped the same way as in search results
+func (app *UserApp) GetUsers(ctx context.Context, viewerID uint64) (users []domain.User, err error) {
+	users, err = app.repo.GetUsers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.applyPrivacyToList(ctx, users, viewerID)
 }
 
 func (app *UserApp) EditUser(ctx context.Context, user domain.User) (err error) {
@@ -66,3 +107,253 @@ func (app *UserApp) EditUser(ctx context.Context, user domain.User) (err error)
 
 	return app.repo.UpdateUser(ctx, dbUser)
 }
+
+// SearchUsers returns users who allowed themselves to be found and whose username or visible name matches key words
+func (app *UserApp) SearchUsers(ctx context.Context, keyWords string, viewerID uint64) (users []domain.User, err error) {
+	users, err = app.repo.SearchUsers(ctx, keyWords)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.applyPrivacyToList(ctx, users, viewerID)
+}
+
+func (app *UserApp) GetFollowers(ctx context.Context, userID uint64, viewerID uint64) (users []domain.User, err error) {
+	err = app.checkFollowersVisible(ctx, userID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err = app.repo.GetFollowers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.applyPrivacyToList(ctx, users, viewerID)
+}
+
+func (app *UserApp) GetFollowed(ctx context.Context, userID uint64, viewerID uint64) (users []domain.User, err error) {
+	err = app.checkFollowersVisible(ctx, userID, viewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	users, err = app.repo.GetFollowed(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return app.applyPrivacyToList(ctx, users, viewerID)
+}
+
+func (app *UserApp) GetPrivacySettings(ctx context.Context, userID uint64) (settings domain.PrivacySettings, err error) {
+	user, err := app.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return domain.PrivacySettings{}, err
+	}
+
+	return user.Privacy, nil
+}
+
+func (app *UserApp) EditPrivacySettings(ctx context.Context, userID uint64, settings domain.PrivacySettings) (err error) {
+	return app.repo.UpdatePrivacySettings(ctx, userID, settings)
+}
+
+// applyPrivacy strips user's data according to their privacy settings.
+// Viewer with id 0 is anonymous. Users always see their own data in full
+func (app *UserApp) applyPrivacy(ctx context.Context, user domain.User, viewerID uint64) (domain.User, error) {
+	if viewerID != 0 && viewerID == user.UserID {
+		return user, nil
+	}
+
+	settings := user.Privacy
+	user.Privacy = domain.PrivacySettings{} // Settings themselves are only shown to their owner
+	user.Email = ""
+
+	if settings.HideRealName {
+		user.FirstName = ""
+		user.LastName = ""
+	}
+
+	if settings.PrivateProfile {
+		isFollower := false
+		if viewerID != 0 {
+			var err error
+			isFollower, err = app.repo.IsFollowing(ctx, viewerID, user.UserID)
+			if err != nil {
+				return domain.User{}, err
+			}
+		}
+
+		if !isFollower {
+			return domain.User{
+				UserID:    user.UserID,
+				Username:  user.Username,
+				IsPrivate: true,
+			}, nil
+		}
+	}
+
+	return user, nil
+}
+
+// applyPrivacyToList strips every user's data and removes users who have blocked viewer from list
+func (app *UserApp) applyPrivacyToList(ctx context.Context, users []domain.User, viewerID uint64) ([]domain.User, error) {
+	blockers := make(map[uint64]bool)
+	if viewerID != 0 {
+		blockerIDs, err := app.repo.GetBlockerIDs(ctx, viewerID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, blockerID := range blockerIDs {
+			blockers[blockerID] = true
+		}
+	}
+
+	visibleUsers := make([]domain.User, 0, len(users))
+	for _, user := range users {
+		if blockers[user.UserID] {
+			continue
+		}
+
+		strippedUser, err := app.applyPrivacy(ctx, user, viewerID)
+		if err != nil {
+			return nil, err
+		}
+
+		visibleUsers = append(visibleUsers, strippedUser)
+	}
+
+	return visibleUsers, nil
+}
+
+// checkNotBlocked returns UserNotFoundError if user has blocked viewer, so that blocked users can not see blocker at all
+func (app *UserApp) checkNotBlocked(ctx context.Context, userID uint64, viewerID uint64) error {
+	if viewerID == 0 || viewerID == userID {
+		return nil
+	}
+
+	blockerIDs, err := app.repo.GetBlockerIDs(ctx, viewerID)
+	if err != nil {
+		return err
+	}
+
+	for _, blockerID := range blockerIDs {
+		if blockerID == userID {
+			return domain.UserNotFoundError
+		}
+	}
+	return nil
+}
+
+// checkFollowersVisible returns FollowersHiddenError if viewer can not see whom user follows or is followed by
+func (app *UserApp) checkFollowersVisible(ctx context.Context, userID uint64, viewerID uint64) error {
+	if viewerID != 0 && viewerID == userID {
+		return nil
+	}
+
+	user, err := app.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	err = app.checkNotBlocked(ctx, userID, viewerID)
+	if err != nil {
+		return err
+	}
+
+	if user.Privacy.HideFollowers {
+		return domain.FollowersHiddenError
+	}
+
+	strippedUser, err := app.applyPrivacy(ctx, user, viewerID)
+	if err != nil {
+		return err
+	}
+
+	if strippedUser.IsPrivate {
+		return domain.FollowersHiddenError
+	}
+
+	return nil
+}
+
+// BlockUser blocks target user and removes follows between users in both directions.
+// Blocked user can not see blocker's profile, other services check blocks using IsBlocked
+func (app *UserApp) BlockUser(ctx context.Context, pair domain.UserPair) (err error) {
+	if pair.UserID == pair.TargetID {
+		return domain.SelfRelationError
+	}
+
+	return app.repo.BlockUser(ctx, pair.UserID, pair.TargetID)
+}
+
+func (app *UserApp) UnblockUser(ctx context.Context, pair domain.UserPair) (err error) {
+	return app.repo.UnblockUser(ctx, pair.UserID, pair.TargetID)
+}
+
+// MuteUser hides target user's content from user without notifying target
+func (app *UserApp) MuteUser(ctx context.Context, pair domain.UserPair) (err error) {
+	if pair.UserID == pair.TargetID {
+		return domain.SelfRelationError
+	}
+
+	return app.repo.MuteUser(ctx, pair.UserID, pair.TargetID)
+}
+
+func (app *UserApp) UnmuteUser(ctx context.Context, pair domain.UserPair) (err error) {
+	return app.repo.UnmuteUser(ctx, pair.UserID, pair.TargetID)
+}
+
+func (app *UserApp) GetBlockedUsers(ctx context.Context, userID uint64) (users []domain.User, err error) {
+	users, err = app.repo.GetBlockedUsers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Users who have blocked viewer back are not filtered out, so that they still can be unblocked
+	for i, user := range users {
+		users[i], err = app.applyPrivacy(ctx, user, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return users, nil
+}
+
+func (app *UserApp) GetMutedUsers(ctx context.Context, userID uint64) (users []domain.User, err error) {
+	users, err = app.repo.GetMutedUsers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Muted users who have blocked viewer are listed too, so that they still can be unmuted
+	for i, user := range users {
+		users[i], err = app.applyPrivacy(ctx, user, userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return users, nil
+}
+
+// IsBlocked checks whether users of each pair have blocked each other (in any direction)
+// and whether first user of each pair has muted second one
+func (app *UserApp) IsBlocked(ctx context.Context, pairs []domain.UserPair) (blocked []bool, muted []bool, err error) {
+	if len(pairs) == 0 {
+		return []bool{}, []bool{}, nil
+	}
+
+	return app.repo.CheckBlocks(ctx, pairs)
+}
+
+// GetUsernames returns usernames of users in the same order as ids, other services use them to show authors.
+// Usernames of deleted users are empty
+func (app *UserApp) GetUsernames(ctx context.Context, userIDs []uint64) (usernames []string, err error) {
+	if len(userIDs) == 0 {
+		return []string{}, nil
+	}
+
+	return app.repo.GetUsernames(ctx, userIDs)
+}
@@ -0,0 +1,23 @@
+package domain
+
+const (
+	ExportStatusPending    = "pending"
+	ExportStatusProcessing = "processing"
+	ExportStatusReady      = "ready"
+	ExportStatusFailed     = "failed"
+	ExportStatusExpired    = "expired"
+
+	// ExportDownloadTokenBytes is number of random bytes in download token, token is hex encoded
+	ExportDownloadTokenBytes = 20
+	ExportExpiryHours        = 48
+	// ExportTimeoutHours is time after which unfinished export is considered interrupted
+	ExportTimeoutHours = 6
+	ExportChunkSize    = 64 * 1024
+)
+
+// Audit events which are recorded by user service, logins and credential changes are recorded by auth service
+const (
+	AuditEventProfileEdited   = "profile_edited"
+	AuditEventPrivacyChanged  = "privacy_changed"
+	AuditEventExportRequested = "data_export_requested"
+)
@@ -6,4 +6,12 @@ var (
 	TransactionBeginError  = errors.New("Could not begin transaction")
 	TransactionCommitError = errors.New("Could not commit transaction")
 	UserNotFoundError      = errors.New("Could not find user")
+	FollowersHiddenError   = errors.New("User's followers are hidden")
+	ExportNotFoundError    = errors.New("Could not find data export")
+	ExportNotReadyError    = errors.New("Data export is not ready yet")
+	ExportExpiredError     = errors.New("Data export has expired")
+	ExportTimeoutError     = errors.New("Data export was interrupted")
+	SelfRelationError      = errors.New("User can not block or mute themselves")
+	BlockNotFoundError     = errors.New("Could not find block")
+	MuteNotFoundError      = errors.New("Could not find mute")
 )
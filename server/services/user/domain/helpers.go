@@ -1,7 +1,12 @@
 package domain
 
 import (
+	"encoding/json"
+	orderpb "pinterest/services/order/proto"
+	shopproductpb "pinterest/services/shopProduct/proto"
 	pb "pinterest/services/user/proto"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func PbUserRegToUser(pbUser *pb.UserReg) User {
@@ -31,6 +36,7 @@ func UserToPbUserOutput(user User) *pb.UserOutput {
 		Email:     user.Email,
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
+		IsPrivate: user.IsPrivate,
 	}
 }
 
@@ -43,3 +49,73 @@ func UsersToPbUserListOutput(users []User) *pb.UsersListOutput {
 		Users: result,
 	}
 }
+
+func ToPrivacySettings(pbSettings *pb.PrivacySettings) PrivacySettings {
+	return PrivacySettings{
+		PrivateProfile: pbSettings.GetPrivateProfile(),
+		HideRealName:   pbSettings.GetHideRealName(),
+		HideFollowers:  pbSettings.GetHideFollowers(),
+		Searchable:     pbSettings.GetSearchable(),
+	}
+}
+
+func ToPbPrivacySettings(settings PrivacySettings) *pb.PrivacySettings {
+	return &pb.PrivacySettings{
+		PrivateProfile: settings.PrivateProfile,
+		HideRealName:   settings.HideRealName,
+		HideFollowers:  settings.HideFollowers,
+		Searchable:     settings.Searchable,
+	}
+}
+
+// ToPbDataExport converts export to protobuf. Download token is only passed if export can be downloaded
+func ToPbDataExport(export DataExport) *pb.DataExport {
+	pbExport := &pb.DataExport{
+		ExportID:  export.ExportID,
+		Status:    export.Status,
+		CreatedAt: timestamppb.New(export.CreatedAt),
+	}
+
+	if export.Status == ExportStatusReady {
+		pbExport.DownloadToken = export.DownloadToken
+		pbExport.ExpiresAt = timestamppb.New(export.ExpiresAt)
+	}
+	return pbExport
+}
+
+func ToUserPairs(pbPairs []*pb.UserPair) []UserPair {
+	pairs := make([]UserPair, 0, len(pbPairs))
+	for _, pbPair := range pbPairs {
+		pairs = append(pairs, UserPair{
+			UserID:   pbPair.GetUserID(),
+			TargetID: pbPair.GetTargetID(),
+		})
+	}
+	return pairs
+}
+
+// PbShopProductSectionsToSections converts export sections of shopProduct service, their data is already JSON
+func PbShopProductSectionsToSections(pbSections *shopproductpb.ExportSections) []ExportSection {
+	sections := make([]ExportSection, 0, len(pbSections.GetSections()))
+	for _, pbSection := range pbSections.GetSections() {
+		sections = append(sections, ExportSection{
+			Name:       pbSection.GetName(),
+			Data:       json.RawMessage(pbSection.GetData()),
+			MediaLinks: pbSection.GetMediaLinks(),
+		})
+	}
+	return sections
+}
+
+// PbOrderSectionsToSections converts export sections of order service, their data is already JSON
+func PbOrderSectionsToSections(pbSections *orderpb.ExportSections) []ExportSection {
+	sections := make([]ExportSection, 0, len(pbSections.GetSections()))
+	for _, pbSection := range pbSections.GetSections() {
+		sections = append(sections, ExportSection{
+			Name:       pbSection.GetName(),
+			Data:       json.RawMessage(pbSection.GetData()),
+			MediaLinks: pbSection.GetMediaLinks(),
+		})
+	}
+	return sections
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type User struct {
 	UserID    uint64
 	Username  string
@@ -7,4 +9,43 @@ type User struct {
 	FirstName string
 	LastName  string
 	Email     string
+	Privacy   PrivacySettings
+	// IsPrivate is true if profile details were hidden from viewer
+	IsPrivate bool
+}
+
+type PrivacySettings struct {
+	// PrivateProfile means only followers can see profile details
+	PrivateProfile bool
+	HideRealName   bool
+	// HideFollowers means only user themselves can see their followers and followed users
+	HideFollowers bool
+	Searchable    bool
+}
+
+// DataExport is a job which collects all data tied to user into one zip archive
+type DataExport struct {
+	ExportID      uint64
+	UserID        uint64
+	Status        string
+	ArchivePath   string
+	DownloadToken string
+	ErrorMessage  string
+	CreatedAt     time.Time
+	FinishedAt    time.Time
+	ExpiresAt     time.Time
+}
+
+// ExportSection is one JSON file of data export archive.
+// MediaLinks are paths of original media files (relative to media directory) which are added to archive as-is
+type ExportSection struct {
+	Name       string
+	Data       interface{}
+	MediaLinks []string
+}
+
+// UserPair describes relation of user with id UserID to user with id TargetID, for example block
+type UserPair struct {
+	UserID   uint64
+	TargetID uint64
 }
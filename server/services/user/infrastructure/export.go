@@ -0,0 +1,386 @@
+package repository
+
+import (
+	"context"
+	"pinterest/services/user/domain"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ExportRepoInterface interface {
+	CreateExport(ctx context.Context, userID uint64) (export domain.DataExport, err error)
+	GetExport(ctx context.Context, exportID uint64) (export domain.DataExport, err error)
+	GetActiveExport(ctx context.Context, userID uint64) (export domain.DataExport, err error)
+	GetExportByToken(ctx context.Context, token string) (export domain.DataExport, err error)
+	GetExpiredExports(ctx context.Context, now time.Time) (exports []domain.DataExport, err error)
+	UpdateExport(ctx context.Context, export domain.DataExport) (err error)
+	FailStaleExports(ctx context.Context, createdBefore time.Time, message string, now time.Time) (err error)
+	CollectUserData(ctx context.Context, userID uint64) (sections []domain.ExportSection, err error)
+}
+
+type ExportRepo struct {
+	postgresDB *pgxpool.Pool
+}
+
+func NewExportRepo(postgresDB *pgxpool.Pool) *ExportRepo {
+	return &ExportRepo{postgresDB: postgresDB}
+}
+
+// exportQuery selects rows of one export section. Query takes user id as its only parameter
+type exportQuery struct {
+	section string
+	query   string
+	// mediaColumns contain links to media files which should be added to archive
+	mediaColumns []string
+}
+
+// exportQueries describe everything tied to user in user service's tables, one archive file per query.
+// Sections of shopProduct and order services are collected by their own ExportUserData
+var exportQueries = []exportQuery{
+	{
+		section: "profile",
+		query: `SELECT id, username, email, first_name, last_name, avatar,
+					   private_profile, hide_real_name, hide_followers, searchable
+				FROM users
+				WHERE id = $1`,
+		mediaColumns: []string{"avatar"},
+	},
+	{
+		section: "sessions",
+		query: `SELECT cookie_expiry AS expires
+				FROM users
+				WHERE id = $1 AND cookie_value <> ''`,
+	},
+	{
+		section: "audit_events",
+		query: `SELECT event, created_at
+				FROM user_audit_events
+				WHERE user_id = $1
+				ORDER BY created_at, id`,
+	},
+	{
+		section: "data_exports",
+		query: `SELECT id, status, created_at, finished_at, expires_at
+				FROM data_exports
+				WHERE user_id = $1`,
+	},
+	{
+		section: "followers",
+		query: `SELECT users.id, users.username
+				FROM followers
+				INNER JOIN users ON users.id = followers.followerid
+				WHERE followers.followedid = $1`,
+	},
+	{
+		section: "followed",
+		query: `SELECT users.id, users.username
+				FROM followers
+				INNER JOIN users ON users.id = followers.followedid
+				WHERE followers.followerid = $1`,
+	},
+	{
+		section: "blocked_users",
+		query: `SELECT users.id, users.username, user_blocks.created_at
+				FROM user_blocks
+				INNER JOIN users ON users.id = user_blocks.blocked_id
+				WHERE user_blocks.blocker_id = $1`,
+	},
+	{
+		section: "muted_users",
+		query: `SELECT users.id, users.username, user_mutes.created_at
+				FROM user_mutes
+				INNER JOIN users ON users.id = user_mutes.muted_id
+				WHERE user_mutes.muter_id = $1`,
+	},
+	{
+		section: "boards",
+		query: `SELECT boardid, title, description, imagelink, imageheight, imagewidth, imageavgcolor
+				FROM boards
+				WHERE userid = $1`,
+		mediaColumns: []string{"imagelink"},
+	},
+	{
+		section: "pins",
+		query: `SELECT pinid, title, description, imagelink, imageheight, imagewidth, imageavgcolor, creationdate,
+					   ARRAY(SELECT boardid FROM pairs WHERE pairs.pinid = pins.pinid) AS boards
+				FROM pins
+				WHERE userid = $1`,
+		mediaColumns: []string{"imagelink"},
+	},
+	{
+		section: "comments",
+		query: `SELECT id, pinid, text
+				FROM comments
+				WHERE userid = $1`,
+	},
+}
+
+func (repo *ExportRepo) CreateExport(ctx context.Context, userID uint64) (export domain.DataExport, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.DataExport{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	createExportQuery := `INSERT INTO data_exports (user_id, status)
+						  VALUES ($1, $2)
+						  RETURNING id, created_at`
+
+	export.UserID = userID
+	export.Status = domain.ExportStatusPending
+	row := tx.QueryRow(ctx, createExportQuery, userID, export.Status)
+	err = row.Scan(&export.ExportID, &export.CreatedAt)
+	if err != nil {
+		return domain.DataExport{}, err
+	}
+
+	err = addAuditEvent(ctx, tx, userID, domain.AuditEventExportRequested)
+	if err != nil {
+		return domain.DataExport{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.DataExport{}, domain.TransactionCommitError
+	}
+	return export, nil
+}
+
+func (repo *ExportRepo) GetExport(ctx context.Context, exportID uint64) (export domain.DataExport, err error) {
+	getExportQuery := `SELECT id, user_id, status, archive_path, download_token, error_message,
+							  created_at, finished_at, expires_at
+					   FROM data_exports
+					   WHERE id = $1`
+
+	return repo.getExport(ctx, getExportQuery, exportID)
+}
+
+// GetActiveExport returns user's export which is not finished yet
+func (repo *ExportRepo) GetActiveExport(ctx context.Context, userID uint64) (export domain.DataExport, err error) {
+	getActiveExportQuery := `SELECT id, user_id, status, archive_path, download_token, error_message,
+									created_at, finished_at, expires_at
+							 FROM data_exports
+							 WHERE user_id = $1 AND status IN ('pending', 'processing')
+							 ORDER BY id DESC
+							 LIMIT 1`
+
+	return repo.getExport(ctx, getActiveExportQuery, userID)
+}
+
+func (repo *ExportRepo) GetExportByToken(ctx context.Context, token string) (export domain.DataExport, err error) {
+	getExportByTokenQuery := `SELECT id, user_id, status, archive_path, download_token, error_message,
+									 created_at, finished_at, expires_at
+							  FROM data_exports
+							  WHERE download_token = $1`
+
+	return repo.getExport(ctx, getExportByTokenQuery, token)
+}
+
+func (repo *ExportRepo) getExport(ctx context.Context, query string, args ...interface{}) (export domain.DataExport, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.DataExport{}, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	export, err = scanExport(tx.QueryRow(ctx, query, args...))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return domain.DataExport{}, domain.ExportNotFoundError
+		}
+
+		return domain.DataExport{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.DataExport{}, domain.TransactionCommitError
+	}
+	return export, nil
+}
+
+// scanExport scans export row, finish and expiry times are zero if export is not finished yet
+func scanExport(row pgx.Row) (export domain.DataExport, err error) {
+	var finishedAt, expiresAt *time.Time
+	err = row.Scan(&export.ExportID, &export.UserID, &export.Status, &export.ArchivePath, &export.DownloadToken,
+		&export.ErrorMessage, &export.CreatedAt, &finishedAt, &expiresAt)
+	if err != nil {
+		return domain.DataExport{}, err
+	}
+
+	if finishedAt != nil {
+		export.FinishedAt = *finishedAt
+	}
+	if expiresAt != nil {
+		export.ExpiresAt = *expiresAt
+	}
+	return export, nil
+}
+
+// GetExpiredExports returns ready exports whose archives should be deleted
+func (repo *ExportRepo) GetExpiredExports(ctx context.Context, now time.Time) (exports []domain.DataExport, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getExpiredExportsQuery := `SELECT id, user_id, status, archive_path, download_token, error_message,
+									  created_at, finished_at, expires_at
+							   FROM data_exports
+							   WHERE status = 'ready' AND expires_at < $1`
+
+	rows, err := tx.Query(ctx, getExpiredExportsQuery, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	exports = make([]domain.DataExport, 0)
+
+	for rows.Next() {
+		export, err := scanExport(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		exports = append(exports, export)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return exports, nil
+}
+
+func (repo *ExportRepo) UpdateExport(ctx context.Context, export domain.DataExport) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	updateExportQuery := `UPDATE data_exports
+						  SET status = $2, archive_path = $3, download_token = $4, error_message = $5,
+							  finished_at = $6, expires_at = $7
+						  WHERE id = $1`
+
+	result, err := tx.Exec(ctx, updateExportQuery, export.ExportID, export.Status, export.ArchivePath,
+		export.DownloadToken, export.ErrorMessage, nullTime(export.FinishedAt), nullTime(export.ExpiresAt))
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.ExportNotFoundError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// FailStaleExports marks exports which are still pending or processing although they were created before given time
+// as failed, so that their users can request new ones
+func (repo *ExportRepo) FailStaleExports(ctx context.Context, createdBefore time.Time, message string, now time.Time) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	failStaleExportsQuery := `UPDATE data_exports
+							  SET status = $1, error_message = $2, finished_at = $3
+							  WHERE status IN ('pending', 'processing') AND created_at < $4`
+
+	_, err = tx.Exec(ctx, failStaleExportsQuery, domain.ExportStatusFailed, message, now, createdBefore)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// nullTime converts zero time to NULL
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+// CollectUserData runs all export queries in one transaction, so that archive is consistent
+func (repo *ExportRepo) CollectUserData(ctx context.Context, userID uint64) (sections []domain.ExportSection, err error) {
+	tx, err := repo.postgresDB.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	sections = make([]domain.ExportSection, 0, len(exportQueries))
+	for _, query := range exportQueries {
+		section, err := collectSection(ctx, tx, query, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		sections = append(sections, section)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return sections, nil
+}
+
+func collectSection(ctx context.Context, tx pgx.Tx, query exportQuery, userID uint64) (section domain.ExportSection, err error) {
+	rows, err := tx.Query(ctx, query.query, userID)
+	if err != nil {
+		return domain.ExportSection{}, err
+	}
+	defer rows.Close()
+
+	section.Name = query.section
+	records := make([]map[string]interface{}, 0)
+	section.MediaLinks = make([]string, 0)
+
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return domain.ExportSection{}, err
+		}
+
+		record := make(map[string]interface{}, len(values))
+		for i, field := range rows.FieldDescriptions() {
+			record[string(field.Name)] = values[i]
+		}
+
+		for _, column := range query.mediaColumns {
+			link, ok := record[column].(string)
+			if ok && link != "" {
+				section.MediaLinks = append(section.MediaLinks, link)
+			}
+		}
+
+		records = append(records, record)
+	}
+	if rows.Err() != nil {
+		return domain.ExportSection{}, rows.Err()
+	}
+
+	section.Data = records
+	return section, nil
+}
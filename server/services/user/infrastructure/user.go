@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"pinterest/services/user/domain"
+	"strings"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
@@ -14,6 +15,20 @@ type UserRepoInterface interface {
 	GetUserByUsername(ctx context.Context, username string) (user domain.User, err error)
 	GetUsers(ctx context.Context) (users []domain.User, err error)
 	UpdateUser(ctx context.Context, user domain.User) (err error)
+	SearchUsers(ctx context.Context, keyWords string) (users []domain.User, err error)
+	GetFollowers(ctx context.Context, userID uint64) (users []domain.User, err error)
+	GetFollowed(ctx context.Context, userID uint64) (users []domain.User, err error)
+	IsFollowing(ctx context.Context, followerID uint64, followedID uint64) (isFollowing bool, err error)
+	UpdatePrivacySettings(ctx context.Context, userID uint64, settings domain.PrivacySettings) (err error)
+	BlockUser(ctx context.Context, blockerID uint64, blockedID uint64) (err error)
+	UnblockUser(ctx context.Context, blockerID uint64, blockedID uint64) (err error)
+	MuteUser(ctx context.Context, muterID uint64, mutedID uint64) (err error)
+	UnmuteUser(ctx context.Context, muterID uint64, mutedID uint64) (err error)
+	GetBlockedUsers(ctx context.Context, userID uint64) (users []domain.User, err error)
+	GetMutedUsers(ctx context.Context, userID uint64) (users []domain.User, err error)
+	CheckBlocks(ctx context.Context, pairs []domain.UserPair) (blocked []bool, muted []bool, err error)
+	GetBlockerIDs(ctx context.Context, userID uint64) (blockerIDs []uint64, err error)
+	GetUsernames(ctx context.Context, userIDs []uint64) (usernames []string, err error)
 }
 
 type UserRepo struct {
@@ -68,6 +83,11 @@ func (repo *UserRepo) UpdateUser(ctx context.Context, user domain.User) (err err
 		return domain.UserNotFoundError
 	}
 
+	err = addAuditEvent(ctx, tx, user.UserID, domain.AuditEventProfileEdited)
+	if err != nil {
+		return err
+	}
+
 	err = tx.Commit(ctx)
 	if err != nil {
 		return domain.TransactionCommitError
@@ -82,12 +102,14 @@ func (repo *UserRepo) GetUserByID(ctx context.Context, userID uint64) (user doma
 	}
 	defer tx.Rollback(ctx)
 
-	getUserByIDQuery := `SELECT id, username, email, first_name, last_name
+	getUserByIDQuery := `SELECT id, username, email, first_name, last_name,
+						        private_profile, hide_real_name, hide_followers, searchable
 						 FROM users
 						 WHERE id = $1`
 
 	row := tx.QueryRow(ctx, getUserByIDQuery, userID)
-	err = row.Scan(&user.UserID, &user.Username, &user.Email, &user.FirstName, &user.LastName)
+	err = row.Scan(&user.UserID, &user.Username, &user.Email, &user.FirstName, &user.LastName,
+		&user.Privacy.PrivateProfile, &user.Privacy.HideRealName, &user.Privacy.HideFollowers, &user.Privacy.Searchable)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return domain.User{}, domain.UserNotFoundError
@@ -110,12 +132,14 @@ func (repo *UserRepo) GetUserByUsername(ctx context.Context, username string) (u
 	}
 	defer tx.Rollback(ctx)
 
-	getUserByUsernameQuery := `SELECT id, username, email, first_name, last_name
+	getUserByUsernameQuery := `SELECT id, username, email, first_name, last_name,
+							          private_profile, hide_real_name, hide_followers, searchable
 							   FROM users
 							   WHERE username = $1`
 
 	row := tx.QueryRow(ctx, getUserByUsernameQuery, username)
-	err = row.Scan(&user.UserID, &user.Username, &user.Email, &user.FirstName, &user.LastName)
+	err = row.Scan(&user.UserID, &user.Username, &user.Email, &user.FirstName, &user.LastName,
+		&user.Privacy.PrivateProfile, &user.Privacy.HideRealName, &user.Privacy.HideFollowers, &user.Privacy.Searchable)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return domain.User{}, domain.UserNotFoundError
@@ -165,3 +189,438 @@ func (repo *UserRepo) GetUsers(ctx context.Context) (users []domain.User, err er
 	}
 	return users, nil
 }
+
+func (repo *UserRepo) SearchUsers(ctx context.Context, keyWords string) (users []domain.User, err error) {
+	// Real names are not searchable if user has hidden them
+	searchUsersQuery := `SELECT id, username, email, first_name, last_name,
+						        private_profile, hide_real_name, hide_followers, searchable
+						 FROM users
+						 WHERE searchable = true
+						   AND (username ILIKE '%' || $1 || '%' ESCAPE '\'
+						        OR (NOT hide_real_name AND (first_name || ' ' || last_name) ILIKE '%' || $1 || '%' ESCAPE '\'))
+						 ORDER BY username`
+
+	return repo.queryUsers(ctx, searchUsersQuery, likeEscaper.Replace(keyWords))
+}
+
+func (repo *UserRepo) GetFollowers(ctx context.Context, userID uint64) (users []domain.User, err error) {
+	getFollowersQuery := `SELECT users.id, users.username, users.email, users.first_name, users.last_name,
+							     users.private_profile, users.hide_real_name, users.hide_followers, users.searchable
+						  FROM followers
+						  INNER JOIN users ON users.id = followers.followerid
+						  WHERE followers.followedid = $1`
+
+	return repo.queryUsers(ctx, getFollowersQuery, userID)
+}
+
+func (repo *UserRepo) GetFollowed(ctx context.Context, userID uint64) (users []domain.User, err error) {
+	getFollowedQuery := `SELECT users.id, users.username, users.email, users.first_name, users.last_name,
+							    users.private_profile, users.hide_real_name, users.hide_followers, users.searchable
+						 FROM followers
+						 INNER JOIN users ON users.id = followers.followedid
+						 WHERE followers.followerid = $1`
+
+	return repo.queryUsers(ctx, getFollowedQuery, userID)
+}
+
+// queryUsers runs query which selects full user rows (with privacy settings) and scans them
+func (repo *UserRepo) queryUsers(ctx context.Context, query string, args ...interface{}) (users []domain.User, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users = make([]domain.User, 0)
+
+	for rows.Next() {
+		user := domain.User{}
+		err = rows.Scan(&user.UserID, &user.Username, &user.Email, &user.FirstName, &user.LastName,
+			&user.Privacy.PrivateProfile, &user.Privacy.HideRealName, &user.Privacy.HideFollowers, &user.Privacy.Searchable)
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return users, nil
+}
+
+func (repo *UserRepo) IsFollowing(ctx context.Context, followerID uint64, followedID uint64) (isFollowing bool, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return false, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	isFollowingQuery := `SELECT EXISTS(SELECT 1
+									   FROM followers
+									   WHERE followerid = $1 AND followedid = $2)`
+
+	row := tx.QueryRow(ctx, isFollowingQuery, followerID, followedID)
+	err = row.Scan(&isFollowing)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return false, domain.TransactionCommitError
+	}
+	return isFollowing, nil
+}
+
+func (repo *UserRepo) UpdatePrivacySettings(ctx context.Context, userID uint64, settings domain.PrivacySettings) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	updatePrivacySettingsQuery := `UPDATE users
+								   SET private_profile = $2, hide_real_name = $3, hide_followers = $4, searchable = $5
+								   WHERE id = $1`
+
+	result, err := tx.Exec(ctx, updatePrivacySettingsQuery, userID,
+		settings.PrivateProfile, settings.HideRealName, settings.HideFollowers, settings.Searchable)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.UserNotFoundError
+	}
+
+	err = addAuditEvent(ctx, tx, userID, domain.AuditEventPrivacyChanged)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+// BlockUser saves block and removes follows between users in both directions
+func (repo *UserRepo) BlockUser(ctx context.Context, blockerID uint64, blockedID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	err = checkUserExists(ctx, tx, blockedID)
+	if err != nil {
+		return err
+	}
+
+	blockUserQuery := `INSERT INTO user_blocks (blocker_id, blocked_id)
+					   VALUES ($1, $2)
+					   ON CONFLICT DO NOTHING`
+
+	_, err = tx.Exec(ctx, blockUserQuery, blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+
+	deleteFollowsQuery := `DELETE FROM followers
+						   WHERE (followerid = $1 AND followedid = $2)
+							  OR (followerid = $2 AND followedid = $1)`
+
+	_, err = tx.Exec(ctx, deleteFollowsQuery, blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+func (repo *UserRepo) UnblockUser(ctx context.Context, blockerID uint64, blockedID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	unblockUserQuery := `DELETE FROM user_blocks
+						 WHERE blocker_id = $1 AND blocked_id = $2`
+
+	result, err := tx.Exec(ctx, unblockUserQuery, blockerID, blockedID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.BlockNotFoundError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+func (repo *UserRepo) MuteUser(ctx context.Context, muterID uint64, mutedID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	err = checkUserExists(ctx, tx, mutedID)
+	if err != nil {
+		return err
+	}
+
+	muteUserQuery := `INSERT INTO user_mutes (muter_id, muted_id)
+					  VALUES ($1, $2)
+					  ON CONFLICT DO NOTHING`
+
+	_, err = tx.Exec(ctx, muteUserQuery, muterID, mutedID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+func (repo *UserRepo) UnmuteUser(ctx context.Context, muterID uint64, mutedID uint64) (err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	unmuteUserQuery := `DELETE FROM user_mutes
+						WHERE muter_id = $1 AND muted_id = $2`
+
+	result, err := tx.Exec(ctx, unmuteUserQuery, muterID, mutedID)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() != 1 {
+		return domain.MuteNotFoundError
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return domain.TransactionCommitError
+	}
+	return nil
+}
+
+func checkUserExists(ctx context.Context, tx pgx.Tx, userID uint64) error {
+	userExistsQuery := `SELECT EXISTS(SELECT 1 FROM users WHERE id = $1)`
+
+	userExists := false
+	err := tx.QueryRow(ctx, userExistsQuery, userID).Scan(&userExists)
+	if err != nil {
+		return err
+	}
+
+	if !userExists {
+		return domain.UserNotFoundError
+	}
+	return nil
+}
+
+func (repo *UserRepo) GetBlockedUsers(ctx context.Context, userID uint64) (users []domain.User, err error) {
+	getBlockedUsersQuery := `SELECT users.id, users.username, users.email, users.first_name, users.last_name,
+									users.private_profile, users.hide_real_name, users.hide_followers, users.searchable
+							 FROM user_blocks
+							 INNER JOIN users ON users.id = user_blocks.blocked_id
+							 WHERE user_blocks.blocker_id = $1
+							 ORDER BY user_blocks.created_at DESC`
+
+	return repo.queryUsers(ctx, getBlockedUsersQuery, userID)
+}
+
+func (repo *UserRepo) GetMutedUsers(ctx context.Context, userID uint64) (users []domain.User, err error) {
+	getMutedUsersQuery := `SELECT users.id, users.username, users.email, users.first_name, users.last_name,
+								  users.private_profile, users.hide_real_name, users.hide_followers, users.searchable
+						   FROM user_mutes
+						   INNER JOIN users ON users.id = user_mutes.muted_id
+						   WHERE user_mutes.muter_id = $1
+						   ORDER BY user_mutes.created_at DESC`
+
+	return repo.queryUsers(ctx, getMutedUsersQuery, userID)
+}
+
+// CheckBlocks checks all pairs in one query. Results are in the same order as pairs
+func (repo *UserRepo) CheckBlocks(ctx context.Context, pairs []domain.UserPair) (blocked []bool, muted []bool, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	userIDs := make([]int64, 0, len(pairs))
+	targetIDs := make([]int64, 0, len(pairs))
+	for _, pair := range pairs {
+		userIDs = append(userIDs, int64(pair.UserID))
+		targetIDs = append(targetIDs, int64(pair.TargetID))
+	}
+
+	checkBlocksQuery := `SELECT EXISTS(SELECT 1
+									   FROM user_blocks
+									   WHERE (blocker_id = pairs.user_id AND blocked_id = pairs.target_id)
+										  OR (blocker_id = pairs.target_id AND blocked_id = pairs.user_id)),
+								EXISTS(SELECT 1
+									   FROM user_mutes
+									   WHERE muter_id = pairs.user_id AND muted_id = pairs.target_id)
+						 FROM unnest($1::bigint[], $2::bigint[]) WITH ORDINALITY AS pairs(user_id, target_id, position)
+						 ORDER BY pairs.position`
+
+	rows, err := tx.Query(ctx, checkBlocksQuery, userIDs, targetIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	blocked = make([]bool, 0, len(pairs))
+	muted = make([]bool, 0, len(pairs))
+
+	for rows.Next() {
+		isBlocked, isMuted := false, false
+		err = rows.Scan(&isBlocked, &isMuted)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		blocked = append(blocked, isBlocked)
+		muted = append(muted, isMuted)
+	}
+	if rows.Err() != nil {
+		return nil, nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, nil, domain.TransactionCommitError
+	}
+	return blocked, muted, nil
+}
+
+// GetUsernames returns usernames in the same order as ids, usernames of missing users are empty
+func (repo *UserRepo) GetUsernames(ctx context.Context, userIDs []uint64) (usernames []string, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	ids := make([]int64, 0, len(userIDs))
+	for _, userID := range userIDs {
+		ids = append(ids, int64(userID))
+	}
+
+	getUsernamesQuery := `SELECT COALESCE(users.username, '')
+						  FROM unnest($1::bigint[]) WITH ORDINALITY AS ids(id, position)
+						  LEFT JOIN users ON users.id = ids.id
+						  ORDER BY ids.position`
+
+	rows, err := tx.Query(ctx, getUsernamesQuery, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	usernames = make([]string, 0, len(userIDs))
+
+	for rows.Next() {
+		username := ""
+		err = rows.Scan(&username)
+		if err != nil {
+			return nil, err
+		}
+
+		usernames = append(usernames, username)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return usernames, nil
+}
+
+// GetBlockerIDs returns ids of users who have blocked specified user
+func (repo *UserRepo) GetBlockerIDs(ctx context.Context, userID uint64) (blockerIDs []uint64, err error) {
+	tx, err := repo.postgresDB.Begin(ctx)
+	if err != nil {
+		return nil, domain.TransactionBeginError
+	}
+	defer tx.Rollback(ctx)
+
+	getBlockerIDsQuery := `SELECT blocker_id
+						   FROM user_blocks
+						   WHERE blocked_id = $1`
+
+	rows, err := tx.Query(ctx, getBlockerIDsQuery, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	blockerIDs = make([]uint64, 0)
+
+	for rows.Next() {
+		var blockerID uint64
+		err = rows.Scan(&blockerID)
+		if err != nil {
+			return nil, err
+		}
+
+		blockerIDs = append(blockerIDs, blockerID)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, domain.TransactionCommitError
+	}
+	return blockerIDs, nil
+}
+
+// likeEscaper escapes wildcards of LIKE patterns, so that users can search for them literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// addAuditEvent records event of user's account in transaction which makes the change
+func addAuditEvent(ctx context.Context, tx pgx.Tx, userID uint64, event string) (err error) {
+	addEventQuery := `INSERT INTO user_audit_events (user_id, event)
+					  VALUES ($1, $2)`
+
+	_, err = tx.Exec(ctx, addEventQuery, userID, event)
+	return err
+}
@@ -2,6 +2,7 @@ package facade
 
 import (
 	"context"
+	"io"
 	"pinterest/services/user/application"
 	"pinterest/services/user/domain"
 	pb "pinterest/services/user/proto"
@@ -12,12 +13,14 @@ import (
 
 type UserFacade struct {
 	pb.UnimplementedUserServer
-	app application.UserAppInterface
+	app       application.UserAppInterface
+	exportApp application.ExportAppInterface
 }
 
-func NewUserFacade(app application.UserAppInterface) *UserFacade {
+func NewUserFacade(app application.UserAppInterface, exportApp application.ExportAppInterface) *UserFacade {
 	return &UserFacade{
-		app: app,
+		app:       app,
+		exportApp: exportApp,
 	}
 }
 
@@ -35,26 +38,172 @@ func (facade *UserFacade) EditUser(ctx context.Context, in *pb.UserEditInput) (*
 	return &pb.Empty{}, err
 }
 
-func (facade *UserFacade) GetUserByID(ctx context.Context, in *pb.UserID) (*pb.UserOutput, error) {
-	user, err := facade.app.GetUserByID(ctx, in.GetUid())
+func (facade *UserFacade) GetUserByID(ctx context.Context, in *pb.UserViewInput) (*pb.UserOutput, error) {
+	user, err := facade.app.GetUserByID(ctx, in.GetUserID(), in.GetViewerID())
 	if err != nil {
 		return &pb.UserOutput{}, errors.Wrap(err, "Could not get user by id:")
 	}
 	return domain.UserToPbUserOutput(user), nil
 }
 
-func (facade *UserFacade) GetUserByUsername(ctx context.Context, in *pb.Username) (*pb.UserOutput, error) {
-	user, err := facade.app.GetUserByUsername(ctx, in.GetUsername())
+func (facade *UserFacade) GetUserByUsername(ctx context.Context, in *pb.UsernameViewInput) (*pb.UserOutput, error) {
+	user, err := facade.app.GetUserByUsername(ctx, in.GetUsername(), in.GetViewerID())
 	if err != nil {
 		return &pb.UserOutput{}, errors.Wrap(err, "Could not get user by username:")
 	}
 	return domain.UserToPbUserOutput(user), nil
 }
 
-func (facade *UserFacade) GetUsers(ctx context.Context, in *pb.Empty) (*pb.UsersListOutput, error) {
-	users, err := facade.app.GetUsers(ctx)
+func (facade *UserFacade) GetUsers(ctx context.Context, in *pb.ViewerInput) (*pb.UsersListOutput, error) {
+	users, err := facade.app.GetUsers(ctx, in.GetViewerID())
 	if err != nil {
 		return &pb.UsersListOutput{}, errors.Wrap(err, "Could not get users:")
 	}
 	return domain.UsersToPbUserListOutput(users), nil
 }
+
+func (facade *UserFacade) SearchUsers(ctx context.Context, in *pb.SearchInput) (*pb.UsersListOutput, error) {
+	users, err := facade.app.SearchUsers(ctx, in.GetKeyWords(), in.GetViewerID())
+	if err != nil {
+		return &pb.UsersListOutput{}, errors.Wrap(err, "Could not search users:")
+	}
+	return domain.UsersToPbUserListOutput(users), nil
+}
+
+func (facade *UserFacade) GetFollowers(ctx context.Context, in *pb.UserViewInput) (*pb.UsersListOutput, error) {
+	users, err := facade.app.GetFollowers(ctx, in.GetUserID(), in.GetViewerID())
+	if err != nil {
+		return &pb.UsersListOutput{}, errors.Wrap(err, "Could not get followers:")
+	}
+	return domain.UsersToPbUserListOutput(users), nil
+}
+
+func (facade *UserFacade) GetFollowed(ctx context.Context, in *pb.UserViewInput) (*pb.UsersListOutput, error) {
+	users, err := facade.app.GetFollowed(ctx, in.GetUserID(), in.GetViewerID())
+	if err != nil {
+		return &pb.UsersListOutput{}, errors.Wrap(err, "Could not get followed users:")
+	}
+	return domain.UsersToPbUserListOutput(users), nil
+}
+
+func (facade *UserFacade) GetPrivacySettings(ctx context.Context, in *pb.UserID) (*pb.PrivacySettings, error) {
+	settings, err := facade.app.GetPrivacySettings(ctx, in.GetUid())
+	if err != nil {
+		return &pb.PrivacySettings{}, errors.Wrap(err, "Could not get privacy settings:")
+	}
+	return domain.ToPbPrivacySettings(settings), nil
+}
+
+func (facade *UserFacade) EditPrivacySettings(ctx context.Context, in *pb.PrivacySettingsInput) (*pb.Empty, error) {
+	err := facade.app.EditPrivacySettings(ctx, in.GetUserID(), domain.ToPrivacySettings(in.GetSettings()))
+	if err != nil {
+		return &pb.Empty{}, errors.Wrap(err, "Could not edit privacy settings:")
+	}
+	return &pb.Empty{}, nil
+}
+
+func (facade *UserFacade) RequestDataExport(ctx context.Context, in *pb.UserID) (*pb.DataExport, error) {
+	export, err := facade.exportApp.RequestDataExport(ctx, in.GetUid())
+	if err != nil {
+		return &pb.DataExport{}, errors.Wrap(err, "Could not request data export:")
+	}
+	return domain.ToPbDataExport(export), nil
+}
+
+func (facade *UserFacade) GetDataExport(ctx context.Context, in *pb.DataExportInput) (*pb.DataExport, error) {
+	export, err := facade.exportApp.GetDataExport(ctx, in.GetUserID(), in.GetExportID())
+	if err != nil {
+		return &pb.DataExport{}, errors.Wrap(err, "Could not get data export:")
+	}
+	return domain.ToPbDataExport(export), nil
+}
+
+func (facade *UserFacade) DownloadDataExport(in *pb.DownloadToken, stream pb.User_DownloadDataExportServer) error {
+	archive, err := facade.exportApp.OpenDataExport(stream.Context(), in.GetToken())
+	if err != nil {
+		return errors.Wrap(err, "Could not open data export:")
+	}
+	defer archive.Close()
+
+	buffer := make([]byte, domain.ExportChunkSize)
+	for {
+		n, err := archive.Read(buffer)
+		if n > 0 {
+			sendErr := stream.Send(&pb.DataExportChunk{ChunkData: buffer[:n]})
+			if sendErr != nil {
+				return errors.Wrap(sendErr, "Could not send data export chunk:")
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "Could not read data export:")
+		}
+	}
+}
+
+func (facade *UserFacade) BlockUser(ctx context.Context, in *pb.UserPair) (*pb.Empty, error) {
+	err := facade.app.BlockUser(ctx, domain.UserPair{UserID: in.GetUserID(), TargetID: in.GetTargetID()})
+	if err != nil {
+		return &pb.Empty{}, errors.Wrap(err, "Could not block user:")
+	}
+	return &pb.Empty{}, nil
+}
+
+func (facade *UserFacade) UnblockUser(ctx context.Context, in *pb.UserPair) (*pb.Empty, error) {
+	err := facade.app.UnblockUser(ctx, domain.UserPair{UserID: in.GetUserID(), TargetID: in.GetTargetID()})
+	if err != nil {
+		return &pb.Empty{}, errors.Wrap(err, "Could not unblock user:")
+	}
+	return &pb.Empty{}, nil
+}
+
+func (facade *UserFacade) MuteUser(ctx context.Context, in *pb.UserPair) (*pb.Empty, error) {
+	err := facade.app.MuteUser(ctx, domain.UserPair{UserID: in.GetUserID(), TargetID: in.GetTargetID()})
+	if err != nil {
+		return &pb.Empty{}, errors.Wrap(err, "Could not mute user:")
+	}
+	return &pb.Empty{}, nil
+}
+
+func (facade *UserFacade) UnmuteUser(ctx context.Context, in *pb.UserPair) (*pb.Empty, error) {
+	err := facade.app.UnmuteUser(ctx, domain.UserPair{UserID: in.GetUserID(), TargetID: in.GetTargetID()})
+	if err != nil {
+		return &pb.Empty{}, errors.Wrap(err, "Could not unmute user:")
+	}
+	return &pb.Empty{}, nil
+}
+
+func (facade *UserFacade) GetBlockedUsers(ctx context.Context, in *pb.UserID) (*pb.UsersListOutput, error) {
+	users, err := facade.app.GetBlockedUsers(ctx, in.GetUid())
+	if err != nil {
+		return &pb.UsersListOutput{}, errors.Wrap(err, "Could not get blocked users:")
+	}
+	return domain.UsersToPbUserListOutput(users), nil
+}
+
+func (facade *UserFacade) GetMutedUsers(ctx context.Context, in *pb.UserID) (*pb.UsersListOutput, error) {
+	users, err := facade.app.GetMutedUsers(ctx, in.GetUid())
+	if err != nil {
+		return &pb.UsersListOutput{}, errors.Wrap(err, "Could not get muted users:")
+	}
+	return domain.UsersToPbUserListOutput(users), nil
+}
+
+func (facade *UserFacade) IsBlocked(ctx context.Context, in *pb.BlockCheckInput) (*pb.BlockCheckOutput, error) {
+	blocked, muted, err := facade.app.IsBlocked(ctx, domain.ToUserPairs(in.GetPairs()))
+	if err != nil {
+		return &pb.BlockCheckOutput{}, errors.Wrap(err, "Could not check blocks:")
+	}
+	return &pb.BlockCheckOutput{Blocked: blocked, Muted: muted}, nil
+}
+
+func (facade *UserFacade) GetUsernames(ctx context.Context, in *pb.UserIDs) (*pb.Usernames, error) {
+	usernames, err := facade.app.GetUsernames(ctx, in.GetUserIDs())
+	if err != nil {
+		return &pb.Usernames{}, errors.Wrap(err, "Could not get usernames:")
+	}
+	return &pb.Usernames{Usernames: usernames}, nil
+}
@@ -22,9 +22,25 @@ type UserClient interface {
 	EditUser(ctx context.Context, in *UserEditInput, opts ...grpc.CallOption) (*Empty, error)
 	// rpc   UpdateAvatar(stream UploadAvatar) returns (UploadAvatarResponse) {}
 	// rpc   DeleteUser(UserID) returns (Empty) {}
-	GetUserByID(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*UserOutput, error)
-	GetUserByUsername(ctx context.Context, in *Username, opts ...grpc.CallOption) (*UserOutput, error)
-	GetUsers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UsersListOutput, error)
+	GetUserByID(ctx context.Context, in *UserViewInput, opts ...grpc.CallOption) (*UserOutput, error)
+	GetUserByUsername(ctx context.Context, in *UsernameViewInput, opts ...grpc.CallOption) (*UserOutput, error)
+	GetUsers(ctx context.Context, in *ViewerInput, opts ...grpc.CallOption) (*UsersListOutput, error)
+	SearchUsers(ctx context.Context, in *SearchInput, opts ...grpc.CallOption) (*UsersListOutput, error)
+	GetFollowers(ctx context.Context, in *UserViewInput, opts ...grpc.CallOption) (*UsersListOutput, error)
+	GetFollowed(ctx context.Context, in *UserViewInput, opts ...grpc.CallOption) (*UsersListOutput, error)
+	GetPrivacySettings(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*PrivacySettings, error)
+	EditPrivacySettings(ctx context.Context, in *PrivacySettingsInput, opts ...grpc.CallOption) (*Empty, error)
+	RequestDataExport(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*DataExport, error)
+	GetDataExport(ctx context.Context, in *DataExportInput, opts ...grpc.CallOption) (*DataExport, error)
+	DownloadDataExport(ctx context.Context, in *DownloadToken, opts ...grpc.CallOption) (User_DownloadDataExportClient, error)
+	BlockUser(ctx context.Context, in *UserPair, opts ...grpc.CallOption) (*Empty, error)
+	UnblockUser(ctx context.Context, in *UserPair, opts ...grpc.CallOption) (*Empty, error)
+	MuteUser(ctx context.Context, in *UserPair, opts ...grpc.CallOption) (*Empty, error)
+	UnmuteUser(ctx context.Context, in *UserPair, opts ...grpc.CallOption) (*Empty, error)
+	GetBlockedUsers(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*UsersListOutput, error)
+	GetMutedUsers(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*UsersListOutput, error)
+	IsBlocked(ctx context.Context, in *BlockCheckInput, opts ...grpc.CallOption) (*BlockCheckOutput, error)
+	GetUsernames(ctx context.Context, in *UserIDs, opts ...grpc.CallOption) (*Usernames, error)
 }
 
 type userClient struct {
@@ -53,7 +69,7 @@ func (c *userClient) EditUser(ctx context.Context, in *UserEditInput, opts ...gr
 	return out, nil
 }
 
-func (c *userClient) GetUserByID(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*UserOutput, error) {
+func (c *userClient) GetUserByID(ctx context.Context, in *UserViewInput, opts ...grpc.CallOption) (*UserOutput, error) {
 	out := new(UserOutput)
 	err := c.cc.Invoke(ctx, "/user.User/GetUserByID", in, out, opts...)
 	if err != nil {
@@ -62,7 +78,7 @@ func (c *userClient) GetUserByID(ctx context.Context, in *UserID, opts ...grpc.C
 	return out, nil
 }
 
-func (c *userClient) GetUserByUsername(ctx context.Context, in *Username, opts ...grpc.CallOption) (*UserOutput, error) {
+func (c *userClient) GetUserByUsername(ctx context.Context, in *UsernameViewInput, opts ...grpc.CallOption) (*UserOutput, error) {
 	out := new(UserOutput)
 	err := c.cc.Invoke(ctx, "/user.User/GetUserByUsername", in, out, opts...)
 	if err != nil {
@@ -71,7 +87,7 @@ func (c *userClient) GetUserByUsername(ctx context.Context, in *Username, opts .
 	return out, nil
 }
 
-func (c *userClient) GetUsers(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*UsersListOutput, error) {
+func (c *userClient) GetUsers(ctx context.Context, in *ViewerInput, opts ...grpc.CallOption) (*UsersListOutput, error) {
 	out := new(UsersListOutput)
 	err := c.cc.Invoke(ctx, "/user.User/GetUsers", in, out, opts...)
 	if err != nil {
@@ -80,6 +96,173 @@ func (c *userClient) GetUsers(ctx context.Context, in *Empty, opts ...grpc.CallO
 	return out, nil
 }
 
+func (c *userClient) SearchUsers(ctx context.Context, in *SearchInput, opts ...grpc.CallOption) (*UsersListOutput, error) {
+	out := new(UsersListOutput)
+	err := c.cc.Invoke(ctx, "/user.User/SearchUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) GetFollowers(ctx context.Context, in *UserViewInput, opts ...grpc.CallOption) (*UsersListOutput, error) {
+	out := new(UsersListOutput)
+	err := c.cc.Invoke(ctx, "/user.User/GetFollowers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) GetFollowed(ctx context.Context, in *UserViewInput, opts ...grpc.CallOption) (*UsersListOutput, error) {
+	out := new(UsersListOutput)
+	err := c.cc.Invoke(ctx, "/user.User/GetFollowed", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) GetPrivacySettings(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*PrivacySettings, error) {
+	out := new(PrivacySettings)
+	err := c.cc.Invoke(ctx, "/user.User/GetPrivacySettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) EditPrivacySettings(ctx context.Context, in *PrivacySettingsInput, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/user.User/EditPrivacySettings", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) RequestDataExport(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*DataExport, error) {
+	out := new(DataExport)
+	err := c.cc.Invoke(ctx, "/user.User/RequestDataExport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) GetDataExport(ctx context.Context, in *DataExportInput, opts ...grpc.CallOption) (*DataExport, error) {
+	out := new(DataExport)
+	err := c.cc.Invoke(ctx, "/user.User/GetDataExport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) DownloadDataExport(ctx context.Context, in *DownloadToken, opts ...grpc.CallOption) (User_DownloadDataExportClient, error) {
+	stream, err := c.cc.NewStream(ctx, &User_ServiceDesc.Streams[0], "/user.User/DownloadDataExport", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &userDownloadDataExportClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type User_DownloadDataExportClient interface {
+	Recv() (*DataExportChunk, error)
+	grpc.ClientStream
+}
+
+type userDownloadDataExportClient struct {
+	grpc.ClientStream
+}
+
+func (x *userDownloadDataExportClient) Recv() (*DataExportChunk, error) {
+	m := new(DataExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *userClient) BlockUser(ctx context.Context, in *UserPair, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/user.User/BlockUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UnblockUser(ctx context.Context, in *UserPair, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/user.User/UnblockUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) MuteUser(ctx context.Context, in *UserPair, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/user.User/MuteUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) UnmuteUser(ctx context.Context, in *UserPair, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/user.User/UnmuteUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) GetBlockedUsers(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*UsersListOutput, error) {
+	out := new(UsersListOutput)
+	err := c.cc.Invoke(ctx, "/user.User/GetBlockedUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) GetMutedUsers(ctx context.Context, in *UserID, opts ...grpc.CallOption) (*UsersListOutput, error) {
+	out := new(UsersListOutput)
+	err := c.cc.Invoke(ctx, "/user.User/GetMutedUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) IsBlocked(ctx context.Context, in *BlockCheckInput, opts ...grpc.CallOption) (*BlockCheckOutput, error) {
+	out := new(BlockCheckOutput)
+	err := c.cc.Invoke(ctx, "/user.User/IsBlocked", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userClient) GetUsernames(ctx context.Context, in *UserIDs, opts ...grpc.CallOption) (*Usernames, error) {
+	out := new(Usernames)
+	err := c.cc.Invoke(ctx, "/user.User/GetUsernames", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // UserServer is the server API for User service.
 // All implementations must embed UnimplementedUserServer
 // for forward compatibility
@@ -88,9 +271,25 @@ type UserServer interface {
 	EditUser(context.Context, *UserEditInput) (*Empty, error)
 	// rpc   UpdateAvatar(stream UploadAvatar) returns (UploadAvatarResponse) {}
 	// rpc   DeleteUser(UserID) returns (Empty) {}
-	GetUserByID(context.Context, *UserID) (*UserOutput, error)
-	GetUserByUsername(context.Context, *Username) (*UserOutput, error)
-	GetUsers(context.Context, *Empty) (*UsersListOutput, error)
+	GetUserByID(context.Context, *UserViewInput) (*UserOutput, error)
+	GetUserByUsername(context.Context, *UsernameViewInput) (*UserOutput, error)
+	GetUsers(context.Context, *ViewerInput) (*UsersListOutput, error)
+	SearchUsers(context.Context, *SearchInput) (*UsersListOutput, error)
+	GetFollowers(context.Context, *UserViewInput) (*UsersListOutput, error)
+	GetFollowed(context.Context, *UserViewInput) (*UsersListOutput, error)
+	GetPrivacySettings(context.Context, *UserID) (*PrivacySettings, error)
+	EditPrivacySettings(context.Context, *PrivacySettingsInput) (*Empty, error)
+	RequestDataExport(context.Context, *UserID) (*DataExport, error)
+	GetDataExport(context.Context, *DataExportInput) (*DataExport, error)
+	DownloadDataExport(*DownloadToken, User_DownloadDataExportServer) error
+	BlockUser(context.Context, *UserPair) (*Empty, error)
+	UnblockUser(context.Context, *UserPair) (*Empty, error)
+	MuteUser(context.Context, *UserPair) (*Empty, error)
+	UnmuteUser(context.Context, *UserPair) (*Empty, error)
+	GetBlockedUsers(context.Context, *UserID) (*UsersListOutput, error)
+	GetMutedUsers(context.Context, *UserID) (*UsersListOutput, error)
+	IsBlocked(context.Context, *BlockCheckInput) (*BlockCheckOutput, error)
+	GetUsernames(context.Context, *UserIDs) (*Usernames, error)
 	mustEmbedUnimplementedUserServer()
 }
 
@@ -104,15 +303,63 @@ func (UnimplementedUserServer) CreateUser(context.Context, *UserReg) (*UserID, e
 func (UnimplementedUserServer) EditUser(context.Context, *UserEditInput) (*Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EditUser not implemented")
 }
-func (UnimplementedUserServer) GetUserByID(context.Context, *UserID) (*UserOutput, error) {
+func (UnimplementedUserServer) GetUserByID(context.Context, *UserViewInput) (*UserOutput, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserByID not implemented")
 }
-func (UnimplementedUserServer) GetUserByUsername(context.Context, *Username) (*UserOutput, error) {
+func (UnimplementedUserServer) GetUserByUsername(context.Context, *UsernameViewInput) (*UserOutput, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUserByUsername not implemented")
 }
-func (UnimplementedUserServer) GetUsers(context.Context, *Empty) (*UsersListOutput, error) {
+func (UnimplementedUserServer) GetUsers(context.Context, *ViewerInput) (*UsersListOutput, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetUsers not implemented")
 }
+func (UnimplementedUserServer) SearchUsers(context.Context, *SearchInput) (*UsersListOutput, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchUsers not implemented")
+}
+func (UnimplementedUserServer) GetFollowers(context.Context, *UserViewInput) (*UsersListOutput, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFollowers not implemented")
+}
+func (UnimplementedUserServer) GetFollowed(context.Context, *UserViewInput) (*UsersListOutput, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFollowed not implemented")
+}
+func (UnimplementedUserServer) GetPrivacySettings(context.Context, *UserID) (*PrivacySettings, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPrivacySettings not implemented")
+}
+func (UnimplementedUserServer) EditPrivacySettings(context.Context, *PrivacySettingsInput) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditPrivacySettings not implemented")
+}
+func (UnimplementedUserServer) RequestDataExport(context.Context, *UserID) (*DataExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestDataExport not implemented")
+}
+func (UnimplementedUserServer) GetDataExport(context.Context, *DataExportInput) (*DataExport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDataExport not implemented")
+}
+func (UnimplementedUserServer) DownloadDataExport(*DownloadToken, User_DownloadDataExportServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadDataExport not implemented")
+}
+func (UnimplementedUserServer) BlockUser(context.Context, *UserPair) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BlockUser not implemented")
+}
+func (UnimplementedUserServer) UnblockUser(context.Context, *UserPair) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnblockUser not implemented")
+}
+func (UnimplementedUserServer) MuteUser(context.Context, *UserPair) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuteUser not implemented")
+}
+func (UnimplementedUserServer) UnmuteUser(context.Context, *UserPair) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnmuteUser not implemented")
+}
+func (UnimplementedUserServer) GetBlockedUsers(context.Context, *UserID) (*UsersListOutput, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlockedUsers not implemented")
+}
+func (UnimplementedUserServer) GetMutedUsers(context.Context, *UserID) (*UsersListOutput, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMutedUsers not implemented")
+}
+func (UnimplementedUserServer) IsBlocked(context.Context, *BlockCheckInput) (*BlockCheckOutput, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IsBlocked not implemented")
+}
+func (UnimplementedUserServer) GetUsernames(context.Context, *UserIDs) (*Usernames, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsernames not implemented")
+}
 func (UnimplementedUserServer) mustEmbedUnimplementedUserServer() {}
 
 // UnsafeUserServer may be embedded to opt out of forward compatibility for this service.
@@ -163,7 +410,7 @@ func _User_EditUser_Handler(srv interface{}, ctx context.Context, dec func(inter
 }
 
 func _User_GetUserByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserID)
+	in := new(UserViewInput)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -175,13 +422,13 @@ func _User_GetUserByID_Handler(srv interface{}, ctx context.Context, dec func(in
 		FullMethod: "/user.User/GetUserByID",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServer).GetUserByID(ctx, req.(*UserID))
+		return srv.(UserServer).GetUserByID(ctx, req.(*UserViewInput))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _User_GetUserByUsername_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Username)
+	in := new(UsernameViewInput)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -193,13 +440,13 @@ func _User_GetUserByUsername_Handler(srv interface{}, ctx context.Context, dec f
 		FullMethod: "/user.User/GetUserByUsername",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServer).GetUserByUsername(ctx, req.(*Username))
+		return srv.(UserServer).GetUserByUsername(ctx, req.(*UsernameViewInput))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _User_GetUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Empty)
+	in := new(ViewerInput)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -211,7 +458,298 @@ func _User_GetUsers_Handler(srv interface{}, ctx context.Context, dec func(inter
 		FullMethod: "/user.User/GetUsers",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(UserServer).GetUsers(ctx, req.(*Empty))
+		return srv.(UserServer).GetUsers(ctx, req.(*ViewerInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_SearchUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).SearchUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/SearchUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).SearchUsers(ctx, req.(*SearchInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_GetFollowers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserViewInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).GetFollowers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/GetFollowers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).GetFollowers(ctx, req.(*UserViewInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_GetFollowed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserViewInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).GetFollowed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/GetFollowed",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).GetFollowed(ctx, req.(*UserViewInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_GetPrivacySettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).GetPrivacySettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/GetPrivacySettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).GetPrivacySettings(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_EditPrivacySettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PrivacySettingsInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).EditPrivacySettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/EditPrivacySettings",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).EditPrivacySettings(ctx, req.(*PrivacySettingsInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_RequestDataExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).RequestDataExport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/RequestDataExport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).RequestDataExport(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_GetDataExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DataExportInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).GetDataExport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/GetDataExport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).GetDataExport(ctx, req.(*DataExportInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_DownloadDataExport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadToken)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(UserServer).DownloadDataExport(m, &userDownloadDataExportServer{stream})
+}
+
+type User_DownloadDataExportServer interface {
+	Send(*DataExportChunk) error
+	grpc.ServerStream
+}
+
+type userDownloadDataExportServer struct {
+	grpc.ServerStream
+}
+
+func (x *userDownloadDataExportServer) Send(m *DataExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _User_BlockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPair)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).BlockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/BlockUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).BlockUser(ctx, req.(*UserPair))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UnblockUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPair)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UnblockUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/UnblockUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UnblockUser(ctx, req.(*UserPair))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_MuteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPair)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).MuteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/MuteUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).MuteUser(ctx, req.(*UserPair))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_UnmuteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserPair)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).UnmuteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/UnmuteUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).UnmuteUser(ctx, req.(*UserPair))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_GetBlockedUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).GetBlockedUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/GetBlockedUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).GetBlockedUsers(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_GetMutedUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).GetMutedUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/GetMutedUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).GetMutedUsers(ctx, req.(*UserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_IsBlocked_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlockCheckInput)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).IsBlocked(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/IsBlocked",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).IsBlocked(ctx, req.(*BlockCheckInput))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _User_GetUsernames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserIDs)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServer).GetUsernames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/user.User/GetUsernames",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServer).GetUsernames(ctx, req.(*UserIDs))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -243,7 +781,73 @@ var User_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetUsers",
 			Handler:    _User_GetUsers_Handler,
 		},
+		{
+			MethodName: "SearchUsers",
+			Handler:    _User_SearchUsers_Handler,
+		},
+		{
+			MethodName: "GetFollowers",
+			Handler:    _User_GetFollowers_Handler,
+		},
+		{
+			MethodName: "GetFollowed",
+			Handler:    _User_GetFollowed_Handler,
+		},
+		{
+			MethodName: "GetPrivacySettings",
+			Handler:    _User_GetPrivacySettings_Handler,
+		},
+		{
+			MethodName: "EditPrivacySettings",
+			Handler:    _User_EditPrivacySettings_Handler,
+		},
+		{
+			MethodName: "RequestDataExport",
+			Handler:    _User_RequestDataExport_Handler,
+		},
+		{
+			MethodName: "GetDataExport",
+			Handler:    _User_GetDataExport_Handler,
+		},
+		{
+			MethodName: "BlockUser",
+			Handler:    _User_BlockUser_Handler,
+		},
+		{
+			MethodName: "UnblockUser",
+			Handler:    _User_UnblockUser_Handler,
+		},
+		{
+			MethodName: "MuteUser",
+			Handler:    _User_MuteUser_Handler,
+		},
+		{
+			MethodName: "UnmuteUser",
+			Handler:    _User_UnmuteUser_Handler,
+		},
+		{
+			MethodName: "GetBlockedUsers",
+			Handler:    _User_GetBlockedUsers_Handler,
+		},
+		{
+			MethodName: "GetMutedUsers",
+			Handler:    _User_GetMutedUsers_Handler,
+		},
+		{
+			MethodName: "IsBlocked",
+			Handler:    _User_IsBlocked_Handler,
+		},
+		{
+			MethodName: "GetUsernames",
+			Handler:    _User_GetUsernames_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DownloadDataExport",
+			Handler:       _User_DownloadDataExport_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "user.proto",
 }